@@ -0,0 +1,150 @@
+package db
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/2451965602/LMS/pkg/constants"
+	"github.com/2451965602/LMS/pkg/errno"
+)
+
+// AddAttachment 添加一个附件记录，附件内容需已写入附件存储
+// 1. 检查书籍类型是否存在。
+// 2. 添加封面时锁定并删除该 ISBN 原有的封面记录，每个 ISBN 只保留一张封面。
+// 3. 插入附件记录，返回被替换的封面记录，由调用方删除其存储内容。
+func AddAttachment(ctx context.Context, att *Attachment) ([]*Attachment, error) {
+	var replaced []*Attachment
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		err := tx.Table(BookType{}.TableName()).
+			Where("ISBN = ?", att.ISBN).
+			Count(&count).
+			Error
+		if err != nil {
+			return errno.Errorf(errno.InternalDatabaseErrorCode, "check book type existence failed: %v", err)
+		}
+		if count == 0 {
+			return errno.Errorf(errno.ServiceBookTypeNotExist, "book type with ISBN %s not exist", att.ISBN)
+		}
+
+		if att.Kind == constants.AttachmentKindCover {
+			err = tx.Table(Attachment{}.TableName()).
+				Clauses(clause.Locking{Strength: "UPDATE"}).
+				Where("ISBN = ? AND kind = ?", att.ISBN, constants.AttachmentKindCover).
+				Find(&replaced).
+				Error
+			if err != nil {
+				return errno.Errorf(errno.InternalDatabaseErrorCode, "get covers of book type %s failed: %v", att.ISBN, err)
+			}
+			if len(replaced) > 0 {
+				err = tx.Table(Attachment{}.TableName()).
+					Where("ISBN = ? AND kind = ?", att.ISBN, constants.AttachmentKindCover).
+					Delete(&Attachment{}).
+					Error
+				if err != nil {
+					return errno.Errorf(errno.InternalDatabaseErrorCode, "delete covers of book type %s failed: %v", att.ISBN, err)
+				}
+			}
+		}
+
+		if err = tx.Table(Attachment{}.TableName()).Create(att).Error; err != nil {
+			return errno.Errorf(errno.InternalDatabaseErrorCode, "create attachment failed: %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return replaced, nil
+}
+
+// GetAttachment 根据 ID 获取附件记录
+func GetAttachment(ctx context.Context, id int64) (*Attachment, error) {
+	var att Attachment
+	err := db.WithContext(ctx).
+		Table(Attachment{}.TableName()).
+		Where("id = ?", id).
+		First(&att).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.Errorf(errno.ServiceAttachmentNotExist, "attachment (id: %d) not exist", id)
+		}
+		return nil, errno.Errorf(errno.InternalDatabaseErrorCode, "get attachment (id: %d) failed: %v", id, err)
+	}
+	return &att, nil
+}
+
+// GetAttachmentsByISBN 获取书籍类型的附件，kind 不为空时只返回该类附件，封面在前
+func GetAttachmentsByISBN(ctx context.Context, isbn string, kind *string) ([]*Attachment, error) {
+	var results []*Attachment
+	query := db.WithContext(ctx).
+		Table(Attachment{}.TableName()).
+		Where("ISBN = ?", isbn)
+	if kind != nil && *kind != "" {
+		query = query.Where("kind = ?", *kind)
+	}
+	err := query.Order("kind ASC, id ASC").
+		Find(&results).
+		Error
+	if err != nil {
+		return nil, errno.Errorf(errno.InternalDatabaseErrorCode, "get attachments of book type %s failed: %v", isbn, err)
+	}
+	return results, nil
+}
+
+// DeleteAttachment 删除附件记录，返回被删除的记录，由调用方删除其存储内容
+func DeleteAttachment(ctx context.Context, id int64) (*Attachment, error) {
+	var att Attachment
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Table(Attachment{}.TableName()).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", id).
+			First(&att).
+			Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errno.Errorf(errno.ServiceAttachmentNotExist, "attachment (id: %d) not exist", id)
+			}
+			return errno.Errorf(errno.InternalDatabaseErrorCode, "get attachment (id: %d) failed: %v", id, err)
+		}
+		if err = tx.Table(Attachment{}.TableName()).Where("id = ?", id).Delete(&Attachment{}).Error; err != nil {
+			return errno.Errorf(errno.InternalDatabaseErrorCode, "delete attachment (id: %d) failed: %v", id, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &att, nil
+}
+
+// loadBookTypeCovers 一次查询一页书籍类型的封面并填入，没有封面的书籍类型保持为 nil
+func loadBookTypeCovers(ctx context.Context, bookTypes []*BookType) error {
+	if len(bookTypes) == 0 {
+		return nil
+	}
+	isbns := make([]string, 0, len(bookTypes))
+	byISBN := make(map[string]*BookType, len(bookTypes))
+	for _, bt := range bookTypes {
+		isbns = append(isbns, bt.ISBN)
+		byISBN[bt.ISBN] = bt
+	}
+
+	var covers []*Attachment
+	err := db.WithContext(ctx).
+		Table(Attachment{}.TableName()).
+		Where("ISBN IN ? AND kind = ?", isbns, constants.AttachmentKindCover).
+		Find(&covers).
+		Error
+	if err != nil {
+		return errno.Errorf(errno.InternalDatabaseErrorCode, "load covers of book types failed: %v", err)
+	}
+	for _, c := range covers {
+		byISBN[c.ISBN].Cover = c
+	}
+	return nil
+}
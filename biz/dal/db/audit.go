@@ -0,0 +1,20 @@
+package db
+
+import (
+	"context"
+
+	"github.com/2451965602/LMS/pkg/errno"
+)
+
+// CreateAuditLog 写入一条审计日志
+// 1. 创建时间由数据库填充。
+func CreateAuditLog(ctx context.Context, action, subject, ip, detail string) error {
+	err := db.WithContext(ctx).
+		Table(AuditLog{}.TableName()).
+		Create(&AuditLog{Action: action, Subject: subject, IP: ip, Detail: detail}).
+		Error
+	if err != nil {
+		return errno.Errorf(errno.InternalDatabaseErrorCode, "create audit log (action: %s) failed: %v", action, err)
+	}
+	return nil
+}
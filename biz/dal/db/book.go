@@ -3,19 +3,23 @@ package db
 import (
 	"context"
 	"errors"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/2451965602/LMS/biz/model/book"
+	"github.com/2451965602/LMS/pkg/constants"
 	"github.com/2451965602/LMS/pkg/errno"
+	"github.com/2451965602/LMS/pkg/pagination"
 )
 
 // AddBook 添加一本新书到数据库中
-//  1. 创建一个新的 Book 实例并填充请求参数。
+//  1. 创建一个新的 Book 实例并填充请求参数，初始状态由服务层检查。
 //  2. 使用事务确保操作的原子性：
 //     a. 将新书插入到 Book 表中。
-//     b. 更新 BookType 表中的总副本数和可用副本数。
+//     b. 更新 BookType 表中的总副本数，在架可借的副本同时增加可用副本数。
 //  3. 如果事务成功，返回新书的 ID，否则返回错误。
 func AddBook(ctx context.Context, req book.AddBookRequest) (int64, error) {
 	bk := Book{
@@ -33,11 +37,15 @@ func AddBook(ctx context.Context, req book.AddBookRequest) (int64, error) {
 		}
 
 		// 更新 BookType 表中的总副本数和可用副本数
+		availableExpr := "available_copies"
+		if bk.Status == constants.BookStatusAvailable {
+			availableExpr = "available_copies + 1"
+		}
 		result := tx.Table(BookType{}.TableName()).
 			Where("ISBN = ?", bk.ISBN).
 			Updates(map[string]interface{}{
 				"total_copies":     gorm.Expr("total_copies + 1"),
-				"available_copies": gorm.Expr("available_copies + 1"),
+				"available_copies": gorm.Expr(availableExpr),
 			})
 
 		if result.Error != nil {
@@ -56,53 +64,84 @@ func AddBook(ctx context.Context, req book.AddBookRequest) (int64, error) {
 }
 
 // UpdateBook 更新指定 ID 的书籍信息
-// 1. 根据 BookID 查询书籍是否存在。
-// 2. 根据请求参数构建更新字段。
-// 3. 使用 gorm 的 Updates 方法更新书籍信息。
-// 4. 如果更新成功，返回更新后的书籍信息，否则返回错误。
-func UpdateBook(ctx context.Context, req book.UpdateBookRequest) (*Book, error) {
-	var bk Book
-	err := db.WithContext(ctx).
-		Table(Book{}.TableName()).
-		Where("id = ?", req.BookID).
-		First(&bk).
-		Error
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errno.NewErrNo(errno.ServiceBookNotExist, "book not exist")
-		}
-		return nil, errno.Errorf(errno.InternalDatabaseErrorCode, "failed to fetch book for update: %v", err)
-	}
-
+//  1. 使用事务确保操作的原子性：
+//     a. 锁定并查询书籍，书籍不存在时返回错误。
+//     b. 传入期望的版本时检查版本，不一致时返回当前的书籍信息和 ServiceVersionConflict。
+//     c. 请求变更状态时，按副本状态变更规则检查并更新状态，同时调整书籍类型的副本数；不允许的变更返回 ServiceIllegalStatusTransition。
+//     d. 更新馆藏位置、购买日期和购买价格，并增加版本。
+//  2. 如果事务成功，返回更新后的书籍信息，否则返回错误。
+func UpdateBook(ctx context.Context, req book.UpdateBookRequest, expectedVersion *int64) (*Book, error) {
 	updates := make(map[string]interface{})
 	if req.Location != nil {
 		updates["location"] = *req.Location
-		bk.Location = *req.Location
-	}
-	if req.Status != nil {
-		updates["status"] = *req.Status
-		bk.Status = *req.Status
 	}
 	if req.PurchaseDate != nil {
 		updates["purchase_date"] = time.Unix(*req.PurchaseDate, 0)
-		bk.PurchaseDate = time.Unix(*req.PurchaseDate, 0)
 	}
 	if req.PurchasePrice != nil {
 		updates["purchase_price"] = *req.PurchasePrice
-		bk.PurchasePrice = *req.PurchasePrice
 	}
 
-	if len(updates) == 0 {
+	if len(updates) == 0 && req.Status == nil {
 		return nil, errno.Errorf(errno.ParamMissingErrorCode, "no fields to update")
 	}
 
-	err = db.WithContext(ctx).
-		Table(Book{}.TableName()).
-		Where("id = ?", req.BookID).
-		Updates(updates).
-		Error
+	var bk Book
+	conflict := false
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Table(Book{}.TableName()).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", req.BookID).
+			First(&bk).
+			Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errno.NewErrNo(errno.ServiceBookNotExist, "book not exist")
+			}
+			return errno.Errorf(errno.InternalDatabaseErrorCode, "failed to fetch book for update: %v", err)
+		}
+		if err = checkVersion("book", req.BookID, expectedVersion, bk.Version); err != nil {
+			conflict = true
+			return err
+		}
+
+		// 状态未变化时不视为状态变更，状态变更时已增加版本
+		if req.Status != nil && *req.Status != bk.Status {
+			if err = transitBookStatus(tx, &bk, *req.Status); err != nil {
+				return err
+			}
+		} else if len(updates) > 0 {
+			updates["version"] = gorm.Expr("version + 1")
+			bk.Version++
+		}
+
+		if len(updates) == 0 {
+			return nil
+		}
+		err = tx.Table(Book{}.TableName()).
+			Where("id = ?", req.BookID).
+			Updates(updates).
+			Error
+		if err != nil {
+			return errno.Errorf(errno.InternalDatabaseErrorCode, "update book failed: %v", err)
+		}
+		return nil
+	})
+	if conflict {
+		return &bk, err
+	}
 	if err != nil {
-		return nil, errno.Errorf(errno.InternalDatabaseErrorCode, "update book failed: %v", err)
+		return nil, err
+	}
+
+	if req.Location != nil {
+		bk.Location = *req.Location
+	}
+	if req.PurchaseDate != nil {
+		bk.PurchaseDate = time.Unix(*req.PurchaseDate, 0)
+	}
+	if req.PurchasePrice != nil {
+		bk.PurchasePrice = *req.PurchasePrice
 	}
 	return &bk, nil
 }
@@ -111,7 +150,7 @@ func UpdateBook(ctx context.Context, req book.UpdateBookRequest) (*Book, error)
 //  1. 根据 BookID 查询书籍是否存在。
 //  2. 使用事务确保操作的原子性：
 //     a. 从 Book 表中删除书籍。
-//     b. 更新 BookType 表中的总副本数和可用副本数。
+//     b. 更新 BookType 表中的总副本数和可用副本数，已剔除的副本不再重复扣减。
 //  3. 如果事务成功，返回 nil，否则返回错误。
 func DeleteBook(ctx context.Context, bookId int64) error {
 	var bk Book
@@ -140,9 +179,14 @@ func DeleteBook(ctx context.Context, bookId int64) error {
 			return errno.NewErrNo(errno.ServiceBookNotExist, "book not found during delete operation")
 		}
 
+		// 已剔除的副本在剔除时已从副本数中减去
+		if bk.Status == constants.BookStatusWithdrawn {
+			return nil
+		}
+
 		// 更新 BookType 表中的总副本数和可用副本数
 		availableExpr := "available_copies - 1"
-		if bk.Status != "available" {
+		if bk.Status != constants.BookStatusAvailable {
 			availableExpr = "available_copies"
 		}
 
@@ -162,62 +206,98 @@ func DeleteBook(ctx context.Context, bookId int64) error {
 	return err
 }
 
-// SearchBook 搜索书籍
-// 1. 根据请求参数构建查询条件。
-// 2. 查询总记录数。
-// 3. 根据分页参数查询书籍列表。
-// 4. 返回书籍列表和总记录数。
-func SearchBook(ctx context.Context, req book.GetBookRequest) ([]*Book, int64, error) {
-	var results []Book
-	var total int64
-
-	query := db.WithContext(ctx).Table(Book{}.TableName())
-	countQuery := db.WithContext(ctx).Table(Book{}.TableName())
-
-	// 构建查询条件
-	if req.ISBN != nil && *req.ISBN != "" {
-		query = query.Where("ISBN = ?", *req.ISBN)
-		countQuery = countQuery.Where("ISBN = ?", *req.ISBN)
-	}
-	if req.BookID != nil {
-		query = query.Where("id = ?", *req.BookID)
-		countQuery = countQuery.Where("id = ?", *req.BookID)
-	}
+// BookPageSpec 图书列表的分页规则，默认按 ID 倒序，即最新入库的图书在前
+var BookPageSpec = pagination.Spec{
+	Fields: map[string]pagination.Field{
+		"id":            {Column: "id", Kind: pagination.KindInt},
+		"purchase_date": {Column: "purchase_date", Kind: pagination.KindTime},
+		"location":      {Column: "location", Kind: pagination.KindString},
+	},
+	DefaultSort: "-id",
+	Key:         pagination.Field{Column: "id", Kind: pagination.KindInt},
+}
 
-	// 查询总记录数
-	err := countQuery.Count(&total).Error
-	if err != nil {
-		return nil, 0, errno.Errorf(errno.InternalDatabaseErrorCode, "count books failed: %v", err)
-	}
+// SearchBook 搜索书籍
+// 1. 根据请求参数构建查询条件，状态、馆藏位置前缀、购买日期、价格和最后借出时间均可组合。
+// 2. 请求需要时关联书籍类型，一并返回书名和作者。
+// 3. 请求需要时查询总记录数。
+// 4. 根据分页参数查询一页书籍列表。
+// 5. 返回书籍列表和分页信息。
+func SearchBook(ctx context.Context, req book.GetBookRequest, page *pagination.Page) ([]*BookDetail, *pagination.Result, error) {
+	var results []BookDetail
+	books := Book{}.TableName()
 
-	// 如果没有记录，直接返回空列表
-	if total == 0 {
-		return []*Book{}, 0, nil
+	// 构建查询条件，关联书籍类型后 ISBN 列重名，条件中的列都带上表名
+	filter := func(query *gorm.DB) *gorm.DB {
+		if req.ISBN != nil && *req.ISBN != "" {
+			query = query.Where(books+".ISBN = ?", *req.ISBN)
+		}
+		if req.BookID != nil {
+			query = query.Where(books+".id = ?", *req.BookID)
+		}
+		if len(req.Status) > 0 {
+			query = query.Where(books+".status IN ?", req.Status)
+		}
+		if req.LocationPrefix != nil && *req.LocationPrefix != "" {
+			query = query.Where(books+".location LIKE ?", escapeLike(*req.LocationPrefix)+"%")
+		}
+		if req.PurchasedAfter != nil {
+			query = query.Where(books+".purchase_date >= ?", time.Unix(*req.PurchasedAfter, 0))
+		}
+		if req.PurchasedBefore != nil {
+			query = query.Where(books+".purchase_date < ?", time.Unix(*req.PurchasedBefore, 0))
+		}
+		if req.MinPrice != nil {
+			query = query.Where(books+".purchase_price >= ?", *req.MinPrice)
+		}
+		if req.MaxPrice != nil {
+			query = query.Where(books+".purchase_price <= ?", *req.MaxPrice)
+		}
+		if req.LastCheckoutBefore != nil {
+			// 从未借出的书籍以购买日期作为起点，购买时间晚于该时间的新书不算作长期未借出
+			before := time.Unix(*req.LastCheckoutBefore, 0)
+			query = query.Where("("+books+".last_checkout < ? OR ("+books+".last_checkout IS NULL AND "+books+".purchase_date < ?))", before, before)
+		}
+		return query
 	}
 
-	// 计算分页的偏移量
-	offset := int((req.PageNum - 1) * req.PageSize)
-	if offset < 0 {
-		offset = 0
+	total, err := countPage(ctx, books, filter, page)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	// 查询书籍列表
-	err = query.Order("id desc").
-		Offset(offset).
-		Limit(int(req.PageSize)).
+	query := db.WithContext(ctx).Table(books)
+	if req.WithBookType != nil && *req.WithBookType {
+		bookTypes := BookType{}.TableName()
+		query = query.
+			Select(books + ".*, " + bookTypes + ".title, " + bookTypes + ".author").
+			Joins("LEFT JOIN " + bookTypes + " ON " + bookTypes + ".ISBN = " + books + ".ISBN")
+	}
+	err = paginate(query.Scopes(filter), page).
 		Find(&results).
 		Error
 	if err != nil {
-		return nil, 0, errno.Errorf(errno.InternalDatabaseErrorCode, "search book failed: %v", err)
+		return nil, nil, errno.Errorf(errno.InternalDatabaseErrorCode, "search book failed: %v", err)
+	}
+
+	results, result, err := pageResult(page, results, total)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	// 将结果转换为指针切片
-	var resultBooks []*Book
+	resultBooks := make([]*BookDetail, 0, len(results))
 	for i := range results {
 		resultBooks = append(resultBooks, &results[i])
 	}
 
-	return resultBooks, total, nil
+	return resultBooks, result, nil
+}
+
+// escapeLike 转义 LIKE 模式中的通配符，使用户输入按字面匹配
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
 }
 
 // GetBookById 根据 ID 获取书籍信息
@@ -0,0 +1,83 @@
+package db
+
+import (
+	"slices"
+
+	"gorm.io/gorm"
+
+	"github.com/2451965602/LMS/pkg/constants"
+	"github.com/2451965602/LMS/pkg/errno"
+)
+
+// bookTransitions 副本状态允许的人工变更
+// 借出和归还只能通过借还流程完成，因此任何状态都不能人工变更为已借出，已借出的副本也不能人工变更为其他状态。
+var bookTransitions = map[string][]string{
+	constants.BookStatusAvailable: {constants.BookStatusInRepair, constants.BookStatusDamaged, constants.BookStatusLost, constants.BookStatusWithdrawn},
+	constants.BookStatusInRepair:  {constants.BookStatusAvailable, constants.BookStatusWithdrawn},
+	constants.BookStatusDamaged:   {constants.BookStatusInRepair, constants.BookStatusWithdrawn},
+	constants.BookStatusLost:      {constants.BookStatusAvailable, constants.BookStatusWithdrawn}, // 遗失的副本找回后重新上架
+	constants.BookStatusWithdrawn: {constants.BookStatusInRepair, constants.BookStatusAvailable},
+}
+
+// CanTransitBookStatus 检查副本状态能否从 from 人工变更为 to
+func CanTransitBookStatus(from, to string) bool {
+	return slices.Contains(bookTransitions[from], to)
+}
+
+// bookCountDelta 副本状态变更引起的书籍类型总副本数和可用副本数的变化
+// 已剔除的副本不计入总副本数，只有在架可借的副本计入可用副本数。
+func bookCountDelta(from, to string) (total, available int) {
+	count := func(status string) (int, int) {
+		t, a := 1, 0
+		if status == constants.BookStatusWithdrawn {
+			t = 0
+		}
+		if status == constants.BookStatusAvailable {
+			a = 1
+		}
+		return t, a
+	}
+	fromTotal, fromAvailable := count(from)
+	toTotal, toAvailable := count(to)
+	return toTotal - fromTotal, toAvailable - fromAvailable
+}
+
+// transitBookStatus 在事务中变更副本状态
+// 1. 检查状态变更是否允许，不允许时返回 ServiceIllegalStatusTransition。
+// 2. 更新副本状态，并增加副本的版本。
+// 3. 按状态变更调整书籍类型的总副本数和可用副本数。
+// 调用方需已在同一事务中锁定副本所在行，bk 的状态为锁定后读取的当前状态。
+func transitBookStatus(tx *gorm.DB, bk *Book, to string) error {
+	if !CanTransitBookStatus(bk.Status, to) {
+		return errno.Errorf(errno.ServiceIllegalStatusTransition, "cannot change book (id: %d) status from %s to %s", bk.ID, bk.Status, to)
+	}
+
+	err := tx.Table(Book{}.TableName()).
+		Where("id = ?", bk.ID).
+		Updates(map[string]interface{}{
+			"status":  to,
+			"version": gorm.Expr("version + 1"),
+		}).
+		Error
+	if err != nil {
+		return errno.Errorf(errno.InternalDatabaseErrorCode, "update book (id: %d) status to %s failed: %v", bk.ID, to, err)
+	}
+
+	total, available := bookCountDelta(bk.Status, to)
+	if total != 0 || available != 0 {
+		err = tx.Table(BookType{}.TableName()).
+			Where("ISBN = ?", bk.ISBN).
+			Updates(map[string]interface{}{
+				"total_copies":     gorm.Expr("total_copies + ?", total),
+				"available_copies": gorm.Expr("available_copies + ?", available),
+			}).
+			Error
+		if err != nil {
+			return errno.Errorf(errno.InternalDatabaseErrorCode, "update book count (ISBN: %s) failed: %v", bk.ISBN, err)
+		}
+	}
+
+	bk.Status = to
+	bk.Version++
+	return nil
+}
@@ -8,38 +8,70 @@ import (
 
 	"github.com/2451965602/LMS/biz/model/booktype"
 	"github.com/2451965602/LMS/pkg/errno"
+	"github.com/2451965602/LMS/pkg/isbn"
+	"github.com/2451965602/LMS/pkg/pagination"
 )
 
 // AddBookType 添加一个新的书籍类型到数据库
-// 1. 根据请求参数创建一个新的 BookType 实例。
-// 2. 使用 gorm 的 Create 方法插入到数据库。
-// 3. 如果插入成功，返回新创建的 BookType 实例，否则返回错误。
-func AddBookType(ctx context.Context, req booktype.AddBookTypeRequest) (*BookType, error) {
+// 1. 根据请求参数创建一个新的 BookType 实例，ISBN 已由调用方统一为 ISBN-13，978 开头的同时记录 ISBN-10。
+// 2. 指定分类节点时检查其是否存在，分类名称以节点名称为准。
+// 3. 未指定著者说明时由关联的责任者生成。
+// 4. 在同一事务中插入书籍类型，并关联责任者、主题和丛书。
+// 5. 如果插入成功，返回新创建的 BookType 实例，否则返回错误。
+func AddBookType(ctx context.Context, req booktype.AddBookTypeRequest, links BookTypeLinks) (*BookType, error) {
 	bt := BookType{
 		Title:       req.Title,
-		Author:      req.Author,
 		Category:    req.Category,
 		ISBN:        req.ISBN,
 		Publisher:   req.Publisher,
 		PublishYear: req.PublishYear,
 		Description: req.Description,
 	}
-	err := db.WithContext(ctx).
-		Table(BookType{}.TableName()).
-		Create(&bt).
-		Error
+	if req.Author != nil {
+		bt.Author = *req.Author
+	}
+	if isbn10, ok := isbn.To10(req.ISBN); ok {
+		bt.ISBN10 = &isbn10
+	}
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if req.CategoryID != nil {
+			node, err := getCategory(tx, *req.CategoryID)
+			if err != nil {
+				return err
+			}
+			bt.CategoryID = &node.ID
+			bt.Category = node.Name
+		}
+		if bt.Author == "" && len(links.Contributors) > 0 {
+			author, err := contributorAuthor(tx, links.Contributors)
+			if err != nil {
+				return err
+			}
+			bt.Author = author
+		}
+		if err := tx.Table(BookType{}.TableName()).Create(&bt).Error; err != nil {
+			return errno.Errorf(errno.InternalDatabaseErrorCode, "create book type failed: %v", err)
+		}
+		return setBookTypeLinks(tx, bt.ISBN, links)
+	})
 	if err != nil {
-		return nil, errno.Errorf(errno.InternalDatabaseErrorCode, "create book type failed: %v", err)
+		return nil, err
+	}
+	if err = loadBookTypeLinks(ctx, []*BookType{&bt}); err != nil {
+		return nil, err
 	}
 	return &bt, nil
 }
 
 // UpdateBookType 更新指定 ISBN 的书籍类型信息
 // 1. 根据 ISBN 查询书籍类型是否存在。
-// 2. 根据请求参数构建更新字段。
-// 3. 使用 gorm 的 Updates 方法更新书籍类型信息。
-// 4. 如果更新成功，返回更新后的书籍类型信息，否则返回错误。
-func UpdateBookType(ctx context.Context, req booktype.UpdateBookTypeRequest) (*BookType, error) {
+// 2. 传入期望的版本时检查版本，不一致时返回当前的书籍类型信息和 ServiceVersionConflict。
+// 3. 根据请求参数构建更新字段，并增加版本。指定分类节点时分类名称以节点名称为准，分类节点为 0 表示解除关联；
+// 已关联分类节点时分类名称随节点，只修改分类名称且与节点名称不同时返回错误，需修改或解除分类节点。
+// 4. 在同一事务中以读取时的版本为条件更新书籍类型信息，期间被他人修改时同样返回当前的书籍类型信息和 ServiceVersionConflict；
+// 请求修改关联时替换对应的责任者、主题或丛书。修改责任者而未同时指定著者说明时，著者说明随责任者重新生成。
+// 5. 如果更新成功，返回更新后的书籍类型信息，否则返回错误。
+func UpdateBookType(ctx context.Context, req booktype.UpdateBookTypeRequest, links BookTypeLinks, expectedVersion *int64) (*BookType, error) {
 	var bt BookType
 
 	err := db.WithContext(ctx).
@@ -53,6 +85,12 @@ func UpdateBookType(ctx context.Context, req booktype.UpdateBookTypeRequest) (*B
 		}
 		return nil, errno.Errorf(errno.InternalDatabaseErrorCode, "failed to fetch book type for update: %v", err)
 	}
+	if err = checkVersion("book type", req.ISBN, expectedVersion, bt.Version); err != nil {
+		if err := loadBookTypeLinks(ctx, []*BookType{&bt}); err != nil {
+			return nil, err
+		}
+		return &bt, err
+	}
 
 	updates := make(map[string]interface{})
 	if req.Title != nil {
@@ -64,9 +102,28 @@ func UpdateBookType(ctx context.Context, req booktype.UpdateBookTypeRequest) (*B
 		bt.Author = *req.Author
 	}
 	if req.Category != nil {
+		if req.CategoryID == nil && bt.CategoryID != nil && *req.Category != bt.Category {
+			return nil, errno.Errorf(errno.ParamVerifyErrorCode,
+				"category of book type %s follows category_id %d, change category_id or set it to 0 first", req.ISBN, *bt.CategoryID)
+		}
 		updates["category"] = *req.Category
 		bt.Category = *req.Category
 	}
+	if req.CategoryID != nil {
+		if *req.CategoryID == 0 {
+			updates["category_id"] = nil
+			bt.CategoryID = nil
+		} else {
+			node, err := GetCategory(ctx, *req.CategoryID)
+			if err != nil {
+				return nil, err
+			}
+			updates["category_id"] = node.ID
+			updates["category"] = node.Name
+			bt.CategoryID = &node.ID
+			bt.Category = node.Name
+		}
+	}
 	if req.Publisher != nil {
 		updates["publisher"] = *req.Publisher
 		bt.Publisher = *req.Publisher
@@ -80,105 +137,186 @@ func UpdateBookType(ctx context.Context, req booktype.UpdateBookTypeRequest) (*B
 		bt.Description = *req.Description
 	}
 
-	if len(updates) == 0 {
+	if len(updates) == 0 && !links.changed() {
 		return nil, errno.Errorf(errno.ParamMissingErrorCode, "no fields to update")
 	}
+	updates["version"] = gorm.Expr("version + 1")
 
-	err = db.WithContext(ctx).
-		Table(BookType{}.TableName()).
-		Where("ISBN = ?", req.ISBN).
-		Updates(updates).
-		Error
+	conflict := false
+	err = db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if req.Author == nil && links.Contributors != nil {
+			author, err := contributorAuthor(tx, links.Contributors)
+			if err != nil {
+				return err
+			}
+			updates["author"] = author
+			bt.Author = author
+		}
+		result := tx.Table(BookType{}.TableName()).
+			Where("ISBN = ? AND version = ?", req.ISBN, bt.Version).
+			Updates(updates)
+		if result.Error != nil {
+			return errno.Errorf(errno.InternalDatabaseErrorCode, "update book type failed: %v", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			conflict = true
+			return nil
+		}
+		return setBookTypeLinks(tx, req.ISBN, links)
+	})
 	if err != nil {
-		return nil, errno.Errorf(errno.InternalDatabaseErrorCode, "update book type failed: %v", err)
+		return nil, err
+	}
+	if conflict {
+		current, err := GetBookTypeByISBN(ctx, req.ISBN)
+		if err != nil {
+			return nil, err
+		}
+		return current, versionConflict("book type", req.ISBN, current.Version)
 	}
 
+	bt.Version++
+	if err = loadBookTypeLinks(ctx, []*BookType{&bt}); err != nil {
+		return nil, err
+	}
 	return &bt, nil
 }
 
 // DeleteBookType 删除指定 ISBN 的书籍类型
 // 1. 根据 ISBN 查询书籍类型是否存在。
-// 2. 如果存在，从数据库中删除该书籍类型。
+// 2. 如果存在，在同一事务中解除与责任者、主题和丛书的关联，删除附件记录，并从数据库中删除该书籍类型。
+// 附件的存储内容由调用方删除。
 // 3. 如果删除成功，返回 nil，否则返回错误。
 func DeleteBookType(ctx context.Context, isbn string) error {
-	result := db.WithContext(ctx).
-		Table(BookType{}.TableName()).
-		Where("ISBN = ?", isbn).
-		Delete(&BookType{})
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 先解除与责任者、主题和丛书的关联
+		if err := setBookTypeLinks(tx, isbn, BookTypeLinks{Contributors: []BookTypeContributor{}, SubjectIDs: []int64{}, Series: []BookTypeSeries{}}); err != nil {
+			return err
+		}
+		if err := tx.Table(Attachment{}.TableName()).Where("ISBN = ?", isbn).Delete(&Attachment{}).Error; err != nil {
+			return errno.Errorf(errno.InternalDatabaseErrorCode, "delete attachments of book type %s failed: %v", isbn, err)
+		}
 
-	if result.Error != nil {
-		return errno.Errorf(errno.InternalDatabaseErrorCode, "delete book type failed: %v", result.Error)
-	}
-	if result.RowsAffected == 0 {
-		return errno.Errorf(errno.ServiceBookTypeNotFound, "book type not found with ISBN: %s, no rows deleted", isbn)
-	}
-	return nil
+		result := tx.Table(BookType{}.TableName()).
+			Where("ISBN = ?", isbn).
+			Delete(&BookType{})
+
+		if result.Error != nil {
+			return errno.Errorf(errno.InternalDatabaseErrorCode, "delete book type failed: %v", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return errno.Errorf(errno.ServiceBookTypeNotFound, "book type not found with ISBN: %s, no rows deleted", isbn)
+		}
+		return nil
+	})
+}
+
+// BookTypePageSpec 书籍类型列表的分页规则，默认按 ISBN 倒序
+var BookTypePageSpec = pagination.Spec{
+	Fields: map[string]pagination.Field{
+		"isbn":         {Column: "isbn", Kind: pagination.KindString},
+		"title":        {Column: "title", Kind: pagination.KindString},
+		"author":       {Column: "author", Kind: pagination.KindString},
+		"category":     {Column: "category", Kind: pagination.KindString},
+		"publish_year": {Column: "publish_year", Kind: pagination.KindInt},
+	},
+	DefaultSort: "-isbn",
+	Key:         pagination.Field{Column: "isbn", Kind: pagination.KindString},
 }
 
 // SearchBookType 搜索书籍类型
-// 1. 根据请求参数构建查询条件。
-// 2. 查询总记录数。
-// 3. 根据分页参数查询书籍类型列表。
-// 4. 返回书籍类型列表和总记录数。
-func SearchBookType(ctx context.Context, title, author, isbn, category *string, pageNum, pageSize int64) ([]*BookType, int64, error) {
+// 1. 根据请求参数构建查询条件，可以按责任者（及其角色）、主题和丛书浏览，按作者搜索时匹配责任者的名称；
+// 按分类节点浏览时包括其全部下级分类。
+// 2. 请求需要时查询总记录数。
+// 3. 根据分页参数查询一页书籍类型列表，并查询其责任者、主题和丛书。
+// 4. 返回书籍类型列表和分页信息。
+func SearchBookType(ctx context.Context, req booktype.GetBookTypeRequest, page *pagination.Page) ([]*BookType, *pagination.Result, error) {
 	var results []BookType
-	var total int64
+	bookTypes := BookType{}.TableName()
 
-	query := db.WithContext(ctx).Table(BookType{}.TableName())
-	countQuery := db.WithContext(ctx).Table(BookType{}.TableName())
+	var category *CategoryNode
+	if req.CategoryID != nil {
+		var err error
+		if category, err = GetCategory(ctx, *req.CategoryID); err != nil {
+			return nil, nil, err
+		}
+	}
 
 	// 构建查询条件
-	if title != nil && *title != "" {
-		query = query.Where("title LIKE ?", "%"+*title+"%")
-		countQuery = countQuery.Where("title LIKE ?", "%"+*title+"%")
-	}
-	if isbn != nil && *isbn != "" {
-		query = query.Where("ISBN = ?", *isbn)
-		countQuery = countQuery.Where("ISBN = ?", *isbn)
-	}
-	if author != nil && *author != "" {
-		query = query.Where("author LIKE ?", "%"+*author+"%")
-		countQuery = countQuery.Where("author LIKE ?", "%"+*author+"%")
-	}
-	if category != nil && *category != "" {
-		query = query.Where("category LIKE ?", "%"+*category+"%")
-		countQuery = countQuery.Where("category LIKE ?", "%"+*category+"%")
+	filter := func(query *gorm.DB) *gorm.DB {
+		if req.Title != nil && *req.Title != "" {
+			query = query.Where("title LIKE ?", "%"+*req.Title+"%")
+		}
+		if req.ISBN != nil && *req.ISBN != "" {
+			query = query.Where("ISBN = ?", *req.ISBN)
+		}
+		if req.Author != nil && *req.Author != "" {
+			sub := db.Table(ContributorKind.LinkTable+" AS l").
+				Select("1").
+				Joins("JOIN "+ContributorKind.Table+" AS c ON c.id = l.contributor_id").
+				Where("l.ISBN = "+bookTypes+".ISBN AND c.name LIKE ?", "%"+escapeLike(*req.Author)+"%")
+			if req.Role != nil && *req.Role != "" {
+				sub = sub.Where("l.role = ?", *req.Role)
+			}
+			query = query.Where("EXISTS (?)", sub)
+		}
+		if req.Category != nil && *req.Category != "" {
+			query = query.Where("category LIKE ?", "%"+*req.Category+"%")
+		}
+		if category != nil {
+			query = query.Where("category_id IN (?)", categorySubtree(category))
+		}
+		// 关联条件使用 EXISTS，同一责任者在一本书中承担多个角色时不会重复返回
+		if req.ContributorID != nil {
+			sub := db.Table(ContributorKind.LinkTable).
+				Select("1").
+				Where(ContributorKind.LinkTable+".ISBN = "+bookTypes+".ISBN AND contributor_id = ?", *req.ContributorID)
+			if req.Role != nil && *req.Role != "" {
+				sub = sub.Where("role = ?", *req.Role)
+			}
+			query = query.Where("EXISTS (?)", sub)
+		}
+		if req.SubjectID != nil {
+			query = query.Where("EXISTS (?)", db.Table(SubjectKind.LinkTable).
+				Select("1").
+				Where(SubjectKind.LinkTable+".ISBN = "+bookTypes+".ISBN AND subject_id = ?", *req.SubjectID))
+		}
+		if req.SeriesID != nil {
+			query = query.Where("EXISTS (?)", db.Table(SeriesKind.LinkTable).
+				Select("1").
+				Where(SeriesKind.LinkTable+".ISBN = "+bookTypes+".ISBN AND series_id = ?", *req.SeriesID))
+		}
+		return query
 	}
 
-	// 查询总记录数
-	err := countQuery.Count(&total).Error
+	total, err := countPage(ctx, bookTypes, filter, page)
 	if err != nil {
-		return nil, 0, errno.Errorf(errno.InternalDatabaseErrorCode, "count book type failed: %v", err)
-	}
-
-	// 如果没有记录，直接返回空列表
-	if total == 0 {
-		return []*BookType{}, 0, nil
-	}
-
-	// 计算分页的偏移量
-	offset := int((pageNum - 1) * pageSize)
-	if offset < 0 {
-		offset = 0
+		return nil, nil, err
 	}
 
 	// 查询书籍类型列表
-	err = query.Order("ISBN desc").
-		Offset(offset).
-		Limit(int(pageSize)).
+	err = paginate(db.WithContext(ctx).Table(bookTypes).Scopes(filter), page).
 		Find(&results).
 		Error
 	if err != nil {
-		return nil, 0, errno.Errorf(errno.InternalDatabaseErrorCode, "search book type failed: %v", err)
+		return nil, nil, errno.Errorf(errno.InternalDatabaseErrorCode, "search book type failed: %v", err)
+	}
+
+	results, result, err := pageResult(page, results, total)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	// 将结果转换为指针切片
-	var resultBookTypes []*BookType
+	resultBookTypes := make([]*BookType, 0, len(results))
 	for i := range results {
 		resultBookTypes = append(resultBookTypes, &results[i])
 	}
+	if err = loadBookTypeLinks(ctx, resultBookTypes); err != nil {
+		return nil, nil, err
+	}
 
-	return resultBookTypes, total, nil
+	return resultBookTypes, result, nil
 }
 
 // IsBookTypeExist 检查指定 ISBN 的书籍类型是否存在
@@ -199,7 +337,7 @@ func IsBookTypeExist(ctx context.Context, isbn string) (bool, error) {
 
 // GetBookTypeByISBN 根据 ISBN 获取书籍类型信息
 // 1. 根据 ISBN 查询书籍类型。
-// 2. 如果书籍类型存在，返回书籍类型信息，否则返回错误。
+// 2. 如果书籍类型存在，查询其责任者、主题和丛书，返回书籍类型信息，否则返回错误。
 func GetBookTypeByISBN(ctx context.Context, isbn string) (*BookType, error) {
 	var bt BookType
 	err := db.WithContext(ctx).
@@ -213,5 +351,8 @@ func GetBookTypeByISBN(ctx context.Context, isbn string) (*BookType, error) {
 		}
 		return nil, errno.Errorf(errno.InternalDatabaseErrorCode, "failed to fetch book type: %v", err)
 	}
+	if err = loadBookTypeLinks(ctx, []*BookType{&bt}); err != nil {
+		return nil, err
+	}
 	return &bt, nil
 }
@@ -0,0 +1,231 @@
+package db
+
+import (
+	"context"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"github.com/2451965602/LMS/pkg/constants"
+	"github.com/2451965602/LMS/pkg/errno"
+)
+
+// BookTypeLinks 书籍类型关联的责任者、主题和丛书
+// 为 nil 的列表表示不修改该类关联，空列表表示清除该类关联。
+type BookTypeLinks struct {
+	Contributors []BookTypeContributor // 责任者及其角色，按列表顺序记录位置
+	SubjectIDs   []int64               // 主题 ID
+	Series       []BookTypeSeries      // 丛书及卷次
+}
+
+// changed 是否需要修改任一类关联
+func (l BookTypeLinks) changed() bool {
+	return l.Contributors != nil || l.SubjectIDs != nil || l.Series != nil
+}
+
+// setBookTypeLinks 在事务中替换书籍类型的关联
+// 1. 检查引用的责任者、主题和丛书都存在，不存在时返回对应的错误码。
+// 2. 删除该类原有的关联，再按列表插入新的关联。
+func setBookTypeLinks(tx *gorm.DB, isbn string, links BookTypeLinks) error {
+	if links.Contributors != nil {
+		ids := make([]int64, 0, len(links.Contributors))
+		for i := range links.Contributors {
+			links.Contributors[i].ISBN = isbn
+			links.Contributors[i].Position = int64(i)
+			ids = append(ids, links.Contributors[i].ContributorID)
+		}
+		if err := replaceLinks(tx, ContributorKind, isbn, ids, links.Contributors); err != nil {
+			return err
+		}
+	}
+	if links.SubjectIDs != nil {
+		rows := make([]BookTypeSubject, 0, len(links.SubjectIDs))
+		for _, id := range links.SubjectIDs {
+			rows = append(rows, BookTypeSubject{ISBN: isbn, SubjectID: id})
+		}
+		if err := replaceLinks(tx, SubjectKind, isbn, links.SubjectIDs, rows); err != nil {
+			return err
+		}
+	}
+	if links.Series != nil {
+		ids := make([]int64, 0, len(links.Series))
+		for i := range links.Series {
+			links.Series[i].ISBN = isbn
+			ids = append(ids, links.Series[i].SeriesID)
+		}
+		if err := replaceLinks(tx, SeriesKind, isbn, ids, links.Series); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// contributorAuthor 由责任者生成书籍类型的著者说明
+// 1. 按列表顺序取著者的名称，以“、”连接；没有著者时取全部责任者，同一责任者只取一次。
+// 2. 超过著者说明的最大长度时截断，不存在的责任者由 setBookTypeLinks 报错。
+func contributorAuthor(tx *gorm.DB, contributors []BookTypeContributor) (string, error) {
+	ids := make([]int64, 0, len(contributors))
+	for _, c := range contributors {
+		ids = append(ids, c.ContributorID)
+	}
+	var entries []CatalogEntry
+	err := tx.Table(ContributorKind.Table).
+		Where("id IN ?", ids).
+		Find(&entries).
+		Error
+	if err != nil {
+		return "", errno.Errorf(errno.InternalDatabaseErrorCode, "get contributor names failed: %v", err)
+	}
+	names := make(map[int64]string, len(entries))
+	for _, e := range entries {
+		names[e.ID] = e.Name
+	}
+
+	pick := func(role string) []string {
+		var result []string
+		seen := make(map[int64]bool)
+		for _, c := range contributors {
+			if name, ok := names[c.ContributorID]; ok && !seen[c.ContributorID] && (role == "" || c.Role == role) {
+				seen[c.ContributorID] = true
+				result = append(result, name)
+			}
+		}
+		return result
+	}
+	authors := pick(constants.ContributorRoleAuthor)
+	if len(authors) == 0 {
+		authors = pick("")
+	}
+
+	author := []rune(strings.Join(authors, "、"))
+	if len(author) > constants.BookTypeAuthorMaxLength {
+		author = author[:constants.BookTypeAuthorMaxLength]
+	}
+	return string(author), nil
+}
+
+// replaceLinks 检查条目存在后替换书籍类型的一类关联，rows 为要插入的关联行
+func replaceLinks[T any](tx *gorm.DB, kind CatalogKind, isbn string, ids []int64, rows []T) error {
+	if len(ids) > 0 {
+		var found []int64
+		err := tx.Table(kind.Table).
+			Where("id IN ?", ids).
+			Pluck("id", &found).
+			Error
+		if err != nil {
+			return errno.Errorf(errno.InternalDatabaseErrorCode, "check %s exist failed: %v", kind.Name, err)
+		}
+		exist := make(map[int64]bool, len(found))
+		for _, id := range found {
+			exist[id] = true
+		}
+		for _, id := range ids {
+			if !exist[id] {
+				return errno.Errorf(kind.NotExist, "%s (id: %d) not exist", kind.Name, id)
+			}
+		}
+	}
+
+	if err := tx.Table(kind.LinkTable).Where("ISBN = ?", isbn).Delete(new(T)).Error; err != nil {
+		return errno.Errorf(errno.InternalDatabaseErrorCode, "clear %s links of book type %s failed: %v", kind.Name, isbn, err)
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+	if err := tx.Table(kind.LinkTable).Create(&rows).Error; err != nil {
+		return errno.Errorf(errno.InternalDatabaseErrorCode, "link %s to book type %s failed: %v", kind.Name, isbn, err)
+	}
+	return nil
+}
+
+// loadBookTypeLinks 一次查询一页书籍类型的责任者、主题、丛书和封面并填入
+func loadBookTypeLinks(ctx context.Context, bookTypes []*BookType) error {
+	if len(bookTypes) == 0 {
+		return nil
+	}
+	isbns := make([]string, 0, len(bookTypes))
+	byISBN := make(map[string]*BookType, len(bookTypes))
+	for _, bt := range bookTypes {
+		isbns = append(isbns, bt.ISBN)
+		byISBN[bt.ISBN] = bt
+		bt.Contributors = []BookTypeContributorDetail{}
+		bt.Subjects = []CatalogEntry{}
+		bt.Series = []BookTypeSeriesDetail{}
+	}
+
+	var contributors []BookTypeContributorDetail
+	err := db.WithContext(ctx).
+		Table(ContributorKind.LinkTable+" AS l").
+		Select("l.ISBN, l.contributor_id, c.name, l.role").
+		Joins("JOIN "+ContributorKind.Table+" AS c ON c.id = l.contributor_id").
+		Where("l.ISBN IN ?", isbns).
+		Order("l.ISBN, l.position, l.role").
+		Scan(&contributors).
+		Error
+	if err != nil {
+		return errno.Errorf(errno.InternalDatabaseErrorCode, "load contributors of book types failed: %v", err)
+	}
+	for _, c := range contributors {
+		byISBN[c.ISBN].Contributors = append(byISBN[c.ISBN].Contributors, c)
+	}
+
+	var subjects []struct {
+		ISBN string
+		CatalogEntry
+	}
+	err = db.WithContext(ctx).
+		Table(SubjectKind.LinkTable+" AS l").
+		Select("l.ISBN, s.id, s.name").
+		Joins("JOIN "+SubjectKind.Table+" AS s ON s.id = l.subject_id").
+		Where("l.ISBN IN ?", isbns).
+		Order("l.ISBN, s.name").
+		Scan(&subjects).
+		Error
+	if err != nil {
+		return errno.Errorf(errno.InternalDatabaseErrorCode, "load subjects of book types failed: %v", err)
+	}
+	for _, s := range subjects {
+		byISBN[s.ISBN].Subjects = append(byISBN[s.ISBN].Subjects, s.CatalogEntry)
+	}
+
+	var series []BookTypeSeriesDetail
+	err = db.WithContext(ctx).
+		Table(SeriesKind.LinkTable+" AS l").
+		Select("l.ISBN, l.series_id, s.name, l.volume").
+		Joins("JOIN "+SeriesKind.Table+" AS s ON s.id = l.series_id").
+		Where("l.ISBN IN ?", isbns).
+		Order("l.ISBN, s.name").
+		Scan(&series).
+		Error
+	if err != nil {
+		return errno.Errorf(errno.InternalDatabaseErrorCode, "load series of book types failed: %v", err)
+	}
+	for _, s := range series {
+		byISBN[s.ISBN].Series = append(byISBN[s.ISBN].Series, s)
+	}
+	return loadBookTypeCovers(ctx, bookTypes)
+}
+
+// GetBookTypesWithoutContributors 获取作者字段不为空、但还没有关联责任者的书籍类型
+// 用于把旧的作者字符串拆分为责任者，已关联责任者的书籍类型不再处理，因此拆分可以重复执行。
+func GetBookTypesWithoutContributors(ctx context.Context) ([]*BookType, error) {
+	var results []*BookType
+	err := db.WithContext(ctx).
+		Table(BookType{}.TableName() + " AS bt").
+		Where("bt.author <> ''").
+		Where("NOT EXISTS (SELECT 1 FROM " + ContributorKind.LinkTable + " AS l WHERE l.ISBN = bt.ISBN)").
+		Order("bt.ISBN").
+		Find(&results).
+		Error
+	if err != nil {
+		return nil, errno.Errorf(errno.InternalDatabaseErrorCode, "get book types without contributors failed: %v", err)
+	}
+	return results, nil
+}
+
+// SetBookTypeContributors 替换书籍类型的责任者，不修改主题和丛书
+func SetBookTypeContributors(ctx context.Context, isbn string, contributors []BookTypeContributor) error {
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return setBookTypeLinks(tx, isbn, BookTypeLinks{Contributors: contributors})
+	})
+}
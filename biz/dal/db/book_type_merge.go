@@ -0,0 +1,206 @@
+package db
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/2451965602/LMS/pkg/constants"
+	"github.com/2451965602/LMS/pkg/errno"
+)
+
+// BookTypeMerge 把书籍类型合并到规范 ISBN 的结果
+type BookTypeMerge struct {
+	Renamed       bool          // 规范 ISBN 的书籍类型原本不存在，只是改用规范 ISBN
+	Books         int64         // 转移的副本数
+	Reservations  int64         // 转移的预约数
+	DroppedCovers []*Attachment // 规范 ISBN 已有封面时被删除的封面记录，由调用方删除其存储内容
+}
+
+// GetBookTypeISBNs 获取全部书籍类型的 ISBN 和 ISBN-10，按 ISBN 排序
+func GetBookTypeISBNs(ctx context.Context) ([]*BookType, error) {
+	var results []*BookType
+	err := db.WithContext(ctx).
+		Table(BookType{}.TableName()).
+		Select("ISBN, isbn10").
+		Order("ISBN ASC").
+		Find(&results).
+		Error
+	if err != nil {
+		return nil, errno.Errorf(errno.InternalDatabaseErrorCode, "get book type ISBNs failed: %v", err)
+	}
+	return results, nil
+}
+
+// SetBookTypeISBN10 记录书籍类型的 ISBN-10，并递增版本
+func SetBookTypeISBN10(ctx context.Context, isbn13, isbn10 string) error {
+	err := db.WithContext(ctx).
+		Table(BookType{}.TableName()).
+		Where("ISBN = ?", isbn13).
+		Updates(map[string]interface{}{
+			"isbn10":  isbn10,
+			"version": gorm.Expr("version + 1"),
+		}).
+		Error
+	if err != nil {
+		return errno.Errorf(errno.InternalDatabaseErrorCode, "set ISBN-10 of book type %s failed: %v", isbn13, err)
+	}
+	return nil
+}
+
+// MergeBookType 把以非规范 ISBN 保存的书籍类型合并到规范的 ISBN-13
+// 1. 锁定两个书籍类型，规范 ISBN 的书籍类型不存在时复制一份，存在时累加副本数，并补齐其空缺的简介和分类。
+// 2. 把副本、预约、责任者、主题、丛书和附件转移到规范 ISBN，两边都有的关联只保留一份。
+// 3. 规范 ISBN 已有封面时删除被合并一方的封面，每个 ISBN 只保留一张封面。
+// 4. 删除被合并的书籍类型。
+func MergeBookType(ctx context.Context, from, to string, isbn10 *string) (*BookTypeMerge, error) {
+	result := &BookTypeMerge{}
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var rows []*BookType
+		err := tx.Table(BookType{}.TableName()).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("ISBN IN ?", []string{from, to}).
+			Find(&rows).
+			Error
+		if err != nil {
+			return errno.Errorf(errno.InternalDatabaseErrorCode, "lock book types %s and %s failed: %v", from, to, err)
+		}
+		var src, dst *BookType
+		for _, row := range rows {
+			if row.ISBN == from {
+				src = row
+			} else {
+				dst = row
+			}
+		}
+		if src == nil {
+			return errno.Errorf(errno.ServiceBookTypeNotExist, "book type with ISBN %s not exist", from)
+		}
+
+		hasCover := false
+		if dst == nil {
+			result.Renamed = true
+			renamed := *src
+			renamed.ISBN = to
+			renamed.ISBN10 = isbn10
+			renamed.Version = src.Version + 1
+			if err = tx.Table(BookType{}.TableName()).Create(&renamed).Error; err != nil {
+				return errno.Errorf(errno.InternalDatabaseErrorCode, "create book type %s failed: %v", to, err)
+			}
+		} else {
+			updates := map[string]interface{}{
+				"total_copies":     gorm.Expr("total_copies + ?", src.TotalCopies),
+				"available_copies": gorm.Expr("available_copies + ?", src.AvailableCopies),
+				"version":          gorm.Expr("version + 1"),
+			}
+			if dst.Description == "" && src.Description != "" {
+				updates["description"] = src.Description
+			}
+			if dst.CategoryID == nil && src.CategoryID != nil {
+				updates["category_id"] = *src.CategoryID
+				updates["category"] = src.Category
+			}
+			if dst.ISBN10 == nil && isbn10 != nil {
+				updates["isbn10"] = *isbn10
+			}
+			if err = tx.Table(BookType{}.TableName()).Where("ISBN = ?", to).Updates(updates).Error; err != nil {
+				return errno.Errorf(errno.InternalDatabaseErrorCode, "update book type %s failed: %v", to, err)
+			}
+
+			var covers int64
+			err = tx.Table(Attachment{}.TableName()).
+				Where("ISBN = ? AND kind = ?", to, constants.AttachmentKindCover).
+				Count(&covers).
+				Error
+			if err != nil {
+				return errno.Errorf(errno.InternalDatabaseErrorCode, "count covers of book type %s failed: %v", to, err)
+			}
+			hasCover = covers > 0
+		}
+
+		books := tx.Table(Book{}.TableName()).
+			Where("ISBN = ?", from).
+			Updates(map[string]interface{}{
+				"ISBN":    to,
+				"version": gorm.Expr("version + 1"),
+			})
+		if books.Error != nil {
+			return errno.Errorf(errno.InternalDatabaseErrorCode, "move books of book type %s failed: %v", from, books.Error)
+		}
+		result.Books = books.RowsAffected
+
+		reservations := tx.Table(Reservation{}.TableName()).Where("ISBN = ?", from).Update("ISBN", to)
+		if reservations.Error != nil {
+			return errno.Errorf(errno.InternalDatabaseErrorCode, "move reservations of book type %s failed: %v", from, reservations.Error)
+		}
+		result.Reservations = reservations.RowsAffected
+
+		if err = moveBookTypeLinks(tx, from, to, func(l *BookTypeContributor) { l.ISBN = to }); err != nil {
+			return err
+		}
+		if err = moveBookTypeLinks(tx, from, to, func(l *BookTypeSubject) { l.ISBN = to }); err != nil {
+			return err
+		}
+		if err = moveBookTypeLinks(tx, from, to, func(l *BookTypeSeries) { l.ISBN = to }); err != nil {
+			return err
+		}
+
+		if hasCover {
+			err = tx.Table(Attachment{}.TableName()).
+				Where("ISBN = ? AND kind = ?", from, constants.AttachmentKindCover).
+				Find(&result.DroppedCovers).
+				Error
+			if err != nil {
+				return errno.Errorf(errno.InternalDatabaseErrorCode, "get covers of book type %s failed: %v", from, err)
+			}
+			err = tx.Table(Attachment{}.TableName()).
+				Where("ISBN = ? AND kind = ?", from, constants.AttachmentKindCover).
+				Delete(&Attachment{}).
+				Error
+			if err != nil {
+				return errno.Errorf(errno.InternalDatabaseErrorCode, "delete covers of book type %s failed: %v", from, err)
+			}
+		}
+		if err = tx.Table(Attachment{}.TableName()).Where("ISBN = ?", from).Update("ISBN", to).Error; err != nil {
+			return errno.Errorf(errno.InternalDatabaseErrorCode, "move attachments of book type %s failed: %v", from, err)
+		}
+
+		if err = tx.Table(BookType{}.TableName()).Where("ISBN = ?", from).Delete(&BookType{}).Error; err != nil {
+			return errno.Errorf(errno.InternalDatabaseErrorCode, "delete book type %s failed: %v", from, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// bookTypeLink 书籍类型与责任者、主题或丛书的关联
+type bookTypeLink interface {
+	BookTypeContributor | BookTypeSubject | BookTypeSeries
+	TableName() string
+}
+
+// moveBookTypeLinks 把一种关联从 from 转移到 to，to 已有的关联保持不变
+func moveBookTypeLinks[T bookTypeLink](tx *gorm.DB, from, to string, setISBN func(*T)) error {
+	var zero T
+	var links []T
+	if err := tx.Table(zero.TableName()).Where("ISBN = ?", from).Find(&links).Error; err != nil {
+		return errno.Errorf(errno.InternalDatabaseErrorCode, "get %s of book type %s failed: %v", zero.TableName(), from, err)
+	}
+	if len(links) == 0 {
+		return nil
+	}
+	for i := range links {
+		setISBN(&links[i])
+	}
+	if err := tx.Table(zero.TableName()).Clauses(clause.OnConflict{DoNothing: true}).Create(&links).Error; err != nil {
+		return errno.Errorf(errno.InternalDatabaseErrorCode, "move %s of book type %s failed: %v", zero.TableName(), from, err)
+	}
+	if err := tx.Table(zero.TableName()).Where("ISBN = ?", from).Delete(&zero).Error; err != nil {
+		return errno.Errorf(errno.InternalDatabaseErrorCode, "delete %s of book type %s failed: %v", zero.TableName(), from, err)
+	}
+	return nil
+}
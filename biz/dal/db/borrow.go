@@ -6,66 +6,125 @@ import (
 	"time"
 
 	"github.com/2451965602/LMS/pkg/constants"
+	"github.com/2451965602/LMS/pkg/pagination"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/2451965602/LMS/pkg/errno"
 )
 
 // BookBorrow 处理书籍借阅操作
-// 1. 检查书籍是否存在且状态为可借阅。
-// 2. 检查书籍类型的可用副本数是否大于 0。
-// 3. 创建借阅记录。
-// 4. 更新书籍类型表中的可用副本数。
-// 5. 更新书籍表中的状态为 "checked_out"。
-// 6. 如果所有操作成功，返回借阅记录的 ID。
-func BookBorrow(ctx context.Context, userId, bookId int64) (int64, error) {
+// 1. 锁定用户所在行，同一用户的借书请求在此排队，借阅数量检查和幂等键查询不会与并发请求交错。
+// 2. 带有幂等键时查询该用户已使用此键的借阅记录：借的是同一本书则直接返回原借阅记录的 ID，否则返回 ServiceIdempotencyKeyReused。
+// 3. 检查用户当前借出中的记录数未达到 maxBorrow。
+// 4. 锁定书籍所在行，检查书籍存在且状态为可借阅。
+// 5. 以可用副本数大于 0 为条件扣减书籍类型的可用副本数，扣减失败说明已无可借副本。
+// 6. 创建借阅记录，并将书籍状态更新为 "checked_out"，记录最后借出时间。
+// 7. 如果所有操作成功，返回借阅记录的 ID。
+func BookBorrow(ctx context.Context, userId, bookId, maxBorrow int64, idempotencyKey string) (int64, error) {
 	var br BorrowRecord
 	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var u User
+		err := tx.Table(User{}.TableName()).
+			Select("id").
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", userId).
+			First(&u).
+			Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errno.NewErrNo(errno.ServiceUserNotExist, "user not exist")
+			}
+			return errno.Errorf(errno.InternalDatabaseErrorCode, "lock user (id: %d) for borrow failed: %v", userId, err)
+		}
+
+		if idempotencyKey != "" {
+			err = tx.Table(BorrowRecord{}.TableName()).
+				Where("user_id = ? AND idempotency_key = ?", userId, idempotencyKey).
+				First(&br).
+				Error
+			if err == nil {
+				if br.BookID != bookId {
+					return errno.Errorf(errno.ServiceIdempotencyKeyReused, "idempotency key already used to borrow book %d", br.BookID)
+				}
+				return nil
+			}
+			if !errors.Is(err, gorm.ErrRecordNotFound) {
+				return errno.Errorf(errno.InternalDatabaseErrorCode, "get borrow record by idempotency key failed: %v", err)
+			}
+		}
+
+		var count int64
+		err = tx.Table(BorrowRecord{}.TableName()).
+			Where("user_id = ? AND status = ?", userId, "checked_out").
+			Count(&count).
+			Error
+		if err != nil {
+			return errno.Errorf(errno.InternalDatabaseErrorCode, "count borrow records failed: %v", err)
+		}
+		if count >= maxBorrow {
+			return errno.Errorf(errno.ServiceBorrowNumOver, "can't borrow more")
+		}
+
 		var bookInfo Book
-		if err := tx.Table(Book{}.TableName()).Where("id = ?", bookId).First(&bookInfo).Error; err != nil {
+		err = tx.Table(Book{}.TableName()).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", bookId).
+			First(&bookInfo).
+			Error
+		if err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
 				return errno.NewErrNo(errno.ServiceBookNotExist, "book not exist")
 			}
 			return errno.Errorf(errno.InternalDatabaseErrorCode, "get book info failed for borrow: %v", err)
 		}
 
-		if bookInfo.Status != "available" {
+		if bookInfo.Status != constants.BookStatusAvailable {
 			return errno.Errorf(errno.ServiceBookNotAvailable, "book with id %d is not available (status: %s)", bookId, bookInfo.Status)
 		}
 
 		var bt BookType
-		if err := tx.Table(BookType{}.TableName()).Where("ISBN = ?", bookInfo.ISBN).First(&bt).Error; err != nil {
+		if err = tx.Table(BookType{}.TableName()).Where("ISBN = ?", bookInfo.ISBN).First(&bt).Error; err != nil {
 			return errno.Errorf(errno.InternalDatabaseErrorCode, "failed to fetch book type %s: %v", bookInfo.ISBN, err)
 		}
-		if bt.AvailableCopies <= 0 {
+
+		// 以可用副本数为条件扣减，并发借阅同一书籍类型的其他副本时不会扣成负数
+		result := tx.Table(BookType{}.TableName()).
+			Where("ISBN = ? AND available_copies > 0", bookInfo.ISBN).
+			Update("available_copies", gorm.Expr("available_copies - 1"))
+		if result.Error != nil {
+			return errno.Errorf(errno.InternalDatabaseErrorCode, "sub book available count failed: %v", result.Error)
+		}
+		if result.RowsAffected == 0 {
 			return errno.Errorf(errno.ServiceActionNotAllowed, "no available copies for book type %s (ISBN: %s)", bt.Title, bt.ISBN)
 		}
 
+		now := time.Now()
 		br = BorrowRecord{
-			UserID:       userId,
+			UserID:       &userId,
 			BookID:       bookId,
 			Title:        bt.Title,
-			CheckoutDate: time.Now(),
-			DueDate:      time.Now().AddDate(0, 0, constants.DefauteRenewTime),
+			CheckoutDate: now,
+			DueDate:      now.AddDate(0, 0, constants.DefauteRenewTime),
 			Status:       "checked_out",
 			RenewalCount: 0,
 		}
-		if err := tx.Table(BorrowRecord{}.TableName()).Create(&br).Error; err != nil {
-			return errno.Errorf(errno.InternalDatabaseErrorCode, "create borrow record failed: %v", err)
-		}
-
-		result := tx.Table(BookType{}.TableName()).
-			Where("ISBN = ?", bookInfo.ISBN).
-			Update("available_copies", gorm.Expr("available_copies - 1"))
-		if result.Error != nil {
-			return errno.Errorf(errno.InternalDatabaseErrorCode, "sub book available count failed: %v", result.Error)
+		if idempotencyKey != "" {
+			br.IdempotencyKey = &idempotencyKey
 		}
-		if result.RowsAffected == 0 {
-			return errno.Errorf(errno.InternalDatabaseErrorCode, "failed to update available copies for book type %s (ISBN not found or no change)", bookInfo.ISBN)
+		if err = tx.Table(BorrowRecord{}.TableName()).Create(&br).Error; err != nil {
+			return errno.Errorf(errno.InternalDatabaseErrorCode, "create borrow record failed: %v", err)
 		}
 
-		if err := tx.Table(Book{}.TableName()).Where("id = ?", bookId).Update("status", "checked_out").Error; err != nil {
+		err = tx.Table(Book{}.TableName()).
+			Where("id = ?", bookId).
+			Updates(map[string]interface{}{
+				"status":        constants.BookStatusCheckedOut,
+				"last_checkout": now,
+			}).
+			Error
+		if err != nil {
 			return errno.Errorf(errno.InternalDatabaseErrorCode, "update book status to checked_out failed: %v", err)
 		}
 
@@ -212,54 +271,134 @@ func BookRenew(ctx context.Context, userId, borrowId int64, daysToExtend int) (*
 	return &record, nil
 }
 
+// SettleFine 结清借阅记录的逾期费用，登记为已缴纳或已减免
+// 1. 锁定借阅记录，检查记录存在、有逾期费用且尚未结清，并发结清同一笔费用时只有一次成功。
+// 2. 记录结清方式和结清时间。
+// 3. 在同一事务中写入审计日志，费用状态和审计记录同时生效。
+// 4. 返回更新后的借阅记录。
+func SettleFine(ctx context.Context, borrowId int64, waive bool, audit *AuditLog) (*BorrowRecord, error) {
+	var record BorrowRecord
+
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Table(BorrowRecord{}.TableName()).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", borrowId).
+			First(&record).
+			Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errno.Errorf(errno.ServiceBorrowRecordNotExist, "borrow record (id: %d) not exist", borrowId)
+			}
+			return errno.Errorf(errno.InternalDatabaseErrorCode, "lock borrow record (id: %d) for fine settlement failed: %v", borrowId, err)
+		}
+		if record.LateFee <= 0 {
+			return errno.Errorf(errno.ServiceActionNotAllowed, "borrow record (id: %d) has no late fee", borrowId)
+		}
+		if record.FeePaid || record.FeeWaived {
+			return errno.Errorf(errno.ServiceActionNotAllowed, "late fee of borrow record (id: %d) already settled", borrowId)
+		}
+
+		now := time.Now()
+		column := "fee_paid"
+		if waive {
+			column = "fee_waived"
+		}
+		err = tx.Table(BorrowRecord{}.TableName()).
+			Where("id = ?", borrowId).
+			Updates(map[string]interface{}{
+				column:           true,
+				"fee_settled_at": now,
+			}).
+			Error
+		if err != nil {
+			return errno.Errorf(errno.InternalDatabaseErrorCode, "settle late fee of borrow record (id: %d) failed: %v", borrowId, err)
+		}
+
+		if err = tx.Table(AuditLog{}.TableName()).Create(audit).Error; err != nil {
+			return errno.Errorf(errno.InternalDatabaseErrorCode, "create audit log (action: %s) failed: %v", audit.Action, err)
+		}
+
+		if err = tx.Table(BorrowRecord{}.TableName()).Where("id = ?", borrowId).First(&record).Error; err != nil {
+			return errno.Errorf(errno.InternalDatabaseErrorCode, "failed to fetch settled borrow record: %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// CountCheckedOutRecord 统计用户当前借出中的借阅记录数量
+// 1. 根据用户 ID 和借出状态统计借阅记录。
+// 2. 返回记录数量。
+func CountCheckedOutRecord(ctx context.Context, userId int64) (int64, error) {
+	var count int64
+	err := db.WithContext(ctx).
+		Table(BorrowRecord{}.TableName()).
+		Where("user_id = ? AND status = ?", userId, "checked_out").
+		Count(&count).
+		Error
+	if err != nil {
+		return 0, errno.Errorf(errno.InternalDatabaseErrorCode, "count borrow records failed: %v", err)
+	}
+	return count, nil
+}
+
+// BorrowRecordPageSpec 借阅记录列表的分页规则，默认按借出时间倒序
+var BorrowRecordPageSpec = pagination.Spec{
+	Fields: map[string]pagination.Field{
+		"id":            {Column: "id", Kind: pagination.KindInt},
+		"checkout_date": {Column: "checkout_date", Kind: pagination.KindTime},
+		"due_date":      {Column: "due_date", Kind: pagination.KindTime},
+	},
+	DefaultSort: "-checkout_date",
+	Key:         pagination.Field{Column: "id", Kind: pagination.KindInt},
+}
+
 // GetCurrentBorrowRecord 获取当前用户的借阅记录
 // 1. 根据用户 ID 查询借阅记录。
 // 2. 根据状态参数过滤借阅记录。
-// 3. 查询总记录数。
-// 4. 根据分页参数查询借阅记录列表。
-// 5. 返回借阅记录列表和总记录数。
-func GetCurrentBorrowRecord(ctx context.Context, userId, pageNum, pageSize, status int64) ([]BorrowRecord, int64, error) {
+// 3. 请求需要时查询总记录数。
+// 4. 根据分页参数查询一页借阅记录。
+// 5. 返回借阅记录列表和分页信息。
+func GetCurrentBorrowRecord(ctx context.Context, userId, status int64, page *pagination.Page) ([]BorrowRecord, *pagination.Result, error) {
 	var results []BorrowRecord
-	var total int64
-
-	baseQuery := db.WithContext(ctx).Table(BorrowRecord{}.TableName()).Where("user_id = ?", userId)
 
+	var statusName string
 	switch status {
 	case constants.CheckedOut:
-		baseQuery = baseQuery.Where("status = ?", "checked_out")
+		statusName = "checked_out"
 	case constants.Returned:
-		baseQuery = baseQuery.Where("status = ?", "returned")
+		statusName = "returned"
 	case constants.Overdue:
-		baseQuery = baseQuery.Where("status = ?", "overdue")
+		statusName = "overdue"
 	case constants.Lost:
-		baseQuery = baseQuery.Where("status = ?", "lost")
+		statusName = "lost"
 	case constants.All:
 		// 不添加额外的过滤条件，查询所有状态的记录
 	default:
-		return nil, 0, errno.Errorf(errno.IllegalOperatorCode, "invalid status parameter: %d", status)
+		return nil, nil, errno.Errorf(errno.IllegalOperatorCode, "invalid status parameter: %d", status)
 	}
 
-	err := baseQuery.Count(&total).Error
-	if err != nil {
-		return nil, 0, errno.Errorf(errno.InternalDatabaseErrorCode, "count borrow records failed: %v", err)
-	}
-
-	if total == 0 {
-		return []BorrowRecord{}, 0, nil
+	filter := func(query *gorm.DB) *gorm.DB {
+		query = query.Where("user_id = ?", userId)
+		if statusName != "" {
+			query = query.Where("status = ?", statusName)
+		}
+		return query
 	}
 
-	offset := int((pageNum - 1) * pageSize)
-	if offset < 0 {
-		offset = 0
+	total, err := countPage(ctx, BorrowRecord{}.TableName(), filter, page)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	err = baseQuery.Order("checkout_date DESC").
-		Offset(offset).
-		Limit(int(pageSize)).
+	err = paginate(db.WithContext(ctx).Table(BorrowRecord{}.TableName()).Scopes(filter), page).
 		Find(&results).
 		Error
 	if err != nil {
-		return nil, 0, errno.Errorf(errno.InternalDatabaseErrorCode, "search borrow record failed: %v", err)
+		return nil, nil, errno.Errorf(errno.InternalDatabaseErrorCode, "search borrow record failed: %v", err)
 	}
-	return results, total, nil
+	return pageResult(page, results, total)
 }
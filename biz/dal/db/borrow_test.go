@@ -0,0 +1,200 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/2451965602/LMS/config"
+	"github.com/2451965602/LMS/pkg/constants"
+	"github.com/2451965602/LMS/pkg/errno"
+)
+
+// createTestCopies 创建一个书籍类型及其 n 个在架副本，返回副本ID
+func createTestCopies(t *testing.T, isbn string, n int) []int64 {
+	t.Helper()
+	bt := BookType{ISBN: isbn, Title: "Concurrency", Author: "Tester", Category: "Test", Publisher: "Test",
+		PublishYear: 2024, TotalCopies: int64(n), AvailableCopies: int64(n)}
+	if err := db.Table(BookType{}.TableName()).Create(&bt).Error; err != nil {
+		t.Fatalf("create book type %s: %v", isbn, err)
+	}
+	ids := make([]int64, n)
+	for i := range ids {
+		b := Book{ISBN: isbn, Location: "A-1", Status: constants.BookStatusAvailable, PurchaseDate: time.Now(), PurchasePrice: 10}
+		if err := db.Table(Book{}.TableName()).Create(&b).Error; err != nil {
+			t.Fatalf("create book of %s: %v", isbn, err)
+		}
+		ids[i] = b.ID
+	}
+	return ids
+}
+
+// createTestUser 创建一个读者，返回用户ID
+func createTestUser(t *testing.T, name string) int64 {
+	t.Helper()
+	u := User{Name: name, Password: "-", Permission: "member", Status: "active"}
+	if err := db.Table(User{}.TableName()).Create(&u).Error; err != nil {
+		t.Fatalf("create user %s: %v", name, err)
+	}
+	return u.ID
+}
+
+// borrowConcurrently 同时发起 n 次借阅，bookIds 和 userIds 按下标取第 i 次借阅的副本和用户，长度为 1 时所有借阅共用
+// 返回成功的次数和各错误码出现的次数
+func borrowConcurrently(t *testing.T, n int, userIds, bookIds []int64, maxBorrow int64) (int, map[int64]int) {
+	t.Helper()
+	pick := func(ids []int64, i int) int64 {
+		if len(ids) == 1 {
+			return ids[0]
+		}
+		return ids[i]
+	}
+
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		successes int
+		failures  = make(map[int64]int)
+	)
+	start := make(chan struct{})
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(userId, bookId int64) {
+			defer wg.Done()
+			<-start
+			_, err := BookBorrow(context.Background(), userId, bookId, maxBorrow, "")
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				failures[errno.ConvertErr(err).ErrorCode]++
+				return
+			}
+			successes++
+		}(pick(userIds, i), pick(bookIds, i))
+	}
+	close(start)
+	wg.Wait()
+	return successes, failures
+}
+
+// checkNoDrift 检查 ISBN 的借阅数据与副本状态、可用副本数一致
+func checkNoDrift(t *testing.T, isbn string) {
+	t.Helper()
+	problems, err := CheckCirculation(context.Background(), isbn)
+	if err != nil {
+		t.Fatalf("CheckCirculation(%s): %v", isbn, err)
+	}
+	for _, p := range problems {
+		t.Errorf("CheckCirculation(%s): %s", isbn, p)
+	}
+}
+
+// checkedOutCount 返回用户借出中的借阅记录数
+func checkedOutCount(t *testing.T, userId int64) int64 {
+	t.Helper()
+	var count int64
+	err := db.Table(BorrowRecord{}.TableName()).
+		Where("user_id = ? AND status = ?", userId, "checked_out").
+		Count(&count).
+		Error
+	if err != nil {
+		t.Fatalf("count borrow records of user %d: %v", userId, err)
+	}
+	return count
+}
+
+// TestBookBorrowConcurrentSameCopy 同一用户并发借同一副本时只有一次成功
+func TestBookBorrowConcurrentSameCopy(t *testing.T) {
+	initTestDB(t)
+	const n = 10
+	bookIds := createTestCopies(t, "9780000000011", 1)
+	userId := createTestUser(t, "borrow-same-copy")
+
+	successes, failures := borrowConcurrently(t, n, []int64{userId}, bookIds, config.MaxBorrowNum.Num)
+	if successes != 1 {
+		t.Errorf("%d of %d concurrent borrows succeeded, want 1 (failures: %v)", successes, n, failures)
+	}
+	if failures[errno.ServiceBookNotAvailable] != n-1 {
+		t.Errorf("failures = %v, want %d ServiceBookNotAvailable", failures, n-1)
+	}
+	if count := checkedOutCount(t, userId); count != 1 {
+		t.Errorf("user has %d checked out records, want 1", count)
+	}
+	checkNoDrift(t, "9780000000011")
+}
+
+// TestBookBorrowConcurrentUsersSameCopy 不同用户并发借同一副本时只有一次成功
+func TestBookBorrowConcurrentUsersSameCopy(t *testing.T) {
+	initTestDB(t)
+	const n = 10
+	bookIds := createTestCopies(t, "9780000000028", 1)
+	userIds := make([]int64, n)
+	for i := range userIds {
+		userIds[i] = createTestUser(t, fmt.Sprintf("borrow-users-%d", i))
+	}
+
+	successes, failures := borrowConcurrently(t, n, userIds, bookIds, config.MaxBorrowNum.Num)
+	if successes != 1 {
+		t.Errorf("%d of %d concurrent borrows succeeded, want 1 (failures: %v)", successes, n, failures)
+	}
+	if failures[errno.ServiceBookNotAvailable] != n-1 {
+		t.Errorf("failures = %v, want %d ServiceBookNotAvailable", failures, n-1)
+	}
+	checkNoDrift(t, "9780000000028")
+}
+
+// TestBookBorrowConcurrentLimit 同一用户并发借不同副本时借出数量不超过 MaxBorrowNum
+func TestBookBorrowConcurrentLimit(t *testing.T) {
+	initTestDB(t)
+	maxBorrow := config.MaxBorrowNum.Num
+	n := int(maxBorrow) * 3
+	bookIds := createTestCopies(t, "9780000000035", n)
+	userId := createTestUser(t, "borrow-limit")
+
+	successes, failures := borrowConcurrently(t, n, []int64{userId}, bookIds, maxBorrow)
+	if int64(successes) != maxBorrow {
+		t.Errorf("%d of %d concurrent borrows succeeded, want %d (failures: %v)", successes, n, maxBorrow, failures)
+	}
+	if failures[errno.ServiceBorrowNumOver] != n-int(maxBorrow) {
+		t.Errorf("failures = %v, want %d ServiceBorrowNumOver", failures, n-int(maxBorrow))
+	}
+	if count := checkedOutCount(t, userId); count != maxBorrow {
+		t.Errorf("user has %d checked out records, want %d", count, maxBorrow)
+	}
+	checkNoDrift(t, "9780000000035")
+}
+
+// TestBookBorrowIdempotencyKeyReplay 以相同幂等键重试借同一副本时返回原借阅记录，借其他副本时拒绝
+func TestBookBorrowIdempotencyKeyReplay(t *testing.T) {
+	initTestDB(t)
+	ctx := context.Background()
+	bookIds := createTestCopies(t, "9780000000042", 2)
+	userId := createTestUser(t, "borrow-idempotency")
+	const key = "5f0c6f7e-2d1b-4c8a-9e44-idempotency"
+
+	first, err := BookBorrow(ctx, userId, bookIds[0], config.MaxBorrowNum.Num, key)
+	if err != nil {
+		t.Fatalf("first borrow: %v", err)
+	}
+	replay, err := BookBorrow(ctx, userId, bookIds[0], config.MaxBorrowNum.Num, key)
+	if err != nil {
+		t.Fatalf("replayed borrow: %v", err)
+	}
+	if replay != first {
+		t.Errorf("replayed borrow returned record %d, want %d", replay, first)
+	}
+	if count := checkedOutCount(t, userId); count != 1 {
+		t.Errorf("user has %d checked out records after replay, want 1", count)
+	}
+
+	_, err = BookBorrow(ctx, userId, bookIds[1], config.MaxBorrowNum.Num, key)
+	if code := errno.ConvertErr(err).ErrorCode; err == nil || code != errno.ServiceIdempotencyKeyReused {
+		t.Errorf("borrow of another copy with the same key: err = %v, want ServiceIdempotencyKeyReused", err)
+	}
+	if count := checkedOutCount(t, userId); count != 1 {
+		t.Errorf("user has %d checked out records after key reuse, want 1", count)
+	}
+	checkNoDrift(t, "9780000000042")
+}
@@ -0,0 +1,170 @@
+package db
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"github.com/2451965602/LMS/pkg/constants"
+	"github.com/2451965602/LMS/pkg/errno"
+	"github.com/2451965602/LMS/pkg/pagination"
+)
+
+// CatalogKind 一类规范化的编目条目：责任者、主题或丛书
+// 三者都只有 ID 和名称，以多对多的方式关联书籍类型，增删改查的逻辑相同，只是所在的表不同。
+type CatalogKind struct {
+	Name       string // 条目类型名称，用于错误信息
+	Table      string // 条目所在的表
+	LinkTable  string // 与书籍类型的关联表
+	LinkColumn string // 关联表中引用条目 ID 的列
+	NotExist   int64  // 条目不存在时的错误码
+}
+
+var (
+	ContributorKind = CatalogKind{
+		Name:       "contributor",
+		Table:      constants.ContributorTableName,
+		LinkTable:  constants.BookTypeContributorTableName,
+		LinkColumn: "contributor_id",
+		NotExist:   errno.ServiceContributorNotExist,
+	}
+	SubjectKind = CatalogKind{
+		Name:       "subject",
+		Table:      constants.SubjectTableName,
+		LinkTable:  constants.BookTypeSubjectTableName,
+		LinkColumn: "subject_id",
+		NotExist:   errno.ServiceSubjectNotExist,
+	}
+	SeriesKind = CatalogKind{
+		Name:       "series",
+		Table:      constants.SeriesTableName,
+		LinkTable:  constants.BookTypeSeriesTableName,
+		LinkColumn: "series_id",
+		NotExist:   errno.ServiceSeriesNotExist,
+	}
+)
+
+// CatalogEntryPageSpec 责任者、主题和丛书列表的分页规则，默认按名称排序
+var CatalogEntryPageSpec = pagination.Spec{
+	Fields: map[string]pagination.Field{
+		"id":   {Column: "id", Kind: pagination.KindInt},
+		"name": {Column: "name", Kind: pagination.KindString},
+	},
+	DefaultSort: "name",
+	Key:         pagination.Field{Column: "id", Kind: pagination.KindInt},
+}
+
+// AddCatalogEntry 添加一个责任者、主题或丛书
+// 同名的条目可以是不同的人或主题，名称不要求唯一。
+func AddCatalogEntry(ctx context.Context, kind CatalogKind, name string) (*CatalogEntry, error) {
+	entry := CatalogEntry{Name: name}
+	if err := db.WithContext(ctx).Table(kind.Table).Create(&entry).Error; err != nil {
+		return nil, errno.Errorf(errno.InternalDatabaseErrorCode, "create %s failed: %v", kind.Name, err)
+	}
+	return &entry, nil
+}
+
+// UpdateCatalogEntry 修改责任者、主题或丛书的名称
+// 1. 查询条目是否存在。
+// 2. 更新名称，关联的书籍类型随之显示新名称。
+func UpdateCatalogEntry(ctx context.Context, kind CatalogKind, id int64, name string) (*CatalogEntry, error) {
+	entry, err := GetCatalogEntry(ctx, kind, id)
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.WithContext(ctx).
+		Table(kind.Table).
+		Where("id = ?", id).
+		Update("name", name).
+		Error
+	if err != nil {
+		return nil, errno.Errorf(errno.InternalDatabaseErrorCode, "update %s (id: %d) failed: %v", kind.Name, id, err)
+	}
+	entry.Name = name
+	return entry, nil
+}
+
+// DeleteCatalogEntry 删除责任者、主题或丛书
+// 1. 仍有书籍类型关联该条目时返回 ServiceCatalogEntryInUse，需先解除关联。
+// 2. 删除条目，条目不存在时返回对应的错误码。
+func DeleteCatalogEntry(ctx context.Context, kind CatalogKind, id int64) error {
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		err := tx.Table(kind.LinkTable).
+			Where(kind.LinkColumn+" = ?", id).
+			Count(&count).
+			Error
+		if err != nil {
+			return errno.Errorf(errno.InternalDatabaseErrorCode, "count book types of %s (id: %d) failed: %v", kind.Name, id, err)
+		}
+		if count > 0 {
+			return errno.Errorf(errno.ServiceCatalogEntryInUse, "%s (id: %d) still linked to %d book types", kind.Name, id, count)
+		}
+
+		result := tx.Table(kind.Table).Where("id = ?", id).Delete(&CatalogEntry{})
+		if result.Error != nil {
+			return errno.Errorf(errno.InternalDatabaseErrorCode, "delete %s (id: %d) failed: %v", kind.Name, id, result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return errno.Errorf(kind.NotExist, "%s (id: %d) not exist", kind.Name, id)
+		}
+		return nil
+	})
+}
+
+// GetCatalogEntry 根据 ID 获取责任者、主题或丛书
+func GetCatalogEntry(ctx context.Context, kind CatalogKind, id int64) (*CatalogEntry, error) {
+	var entry CatalogEntry
+	err := db.WithContext(ctx).
+		Table(kind.Table).
+		Where("id = ?", id).
+		First(&entry).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.Errorf(kind.NotExist, "%s (id: %d) not exist", kind.Name, id)
+		}
+		return nil, errno.Errorf(errno.InternalDatabaseErrorCode, "get %s (id: %d) failed: %v", kind.Name, id, err)
+	}
+	return &entry, nil
+}
+
+// SearchCatalogEntry 按名称搜索责任者、主题或丛书
+// 1. 名称不为空时按名称模糊匹配。
+// 2. 请求需要时查询总记录数。
+// 3. 根据分页参数查询一页条目并返回。
+func SearchCatalogEntry(ctx context.Context, kind CatalogKind, name *string, page *pagination.Page) ([]*CatalogEntry, *pagination.Result, error) {
+	var results []CatalogEntry
+
+	filter := func(query *gorm.DB) *gorm.DB {
+		if name != nil && *name != "" {
+			query = query.Where("name LIKE ?", "%"+escapeLike(*name)+"%")
+		}
+		return query
+	}
+
+	total, err := countPage(ctx, kind.Table, filter, page)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = paginate(db.WithContext(ctx).Table(kind.Table).Scopes(filter), page).
+		Find(&results).
+		Error
+	if err != nil {
+		return nil, nil, errno.Errorf(errno.InternalDatabaseErrorCode, "search %s failed: %v", kind.Name, err)
+	}
+
+	results, result, err := pageResult(page, results, total)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	entries := make([]*CatalogEntry, 0, len(results))
+	for i := range results {
+		entries = append(entries, &results[i])
+	}
+	return entries, result, nil
+}
@@ -0,0 +1,83 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/2451965602/LMS/biz/model/booktype"
+	"github.com/2451965602/LMS/pkg/constants"
+	"github.com/2451965602/LMS/pkg/pagination"
+)
+
+// TestCatalogHomonyms 同名的责任者是不同的条目，著者说明由责任者生成，按作者搜索和借阅排行按责任者计算
+func TestCatalogHomonyms(t *testing.T) {
+	initTestDB(t)
+	ctx := context.Background()
+	const name = "王伟-homonym"
+
+	first, err := AddCatalogEntry(ctx, ContributorKind, name)
+	if err != nil {
+		t.Fatalf("add contributor: %v", err)
+	}
+	second, err := AddCatalogEntry(ctx, ContributorKind, name)
+	if err != nil {
+		t.Fatalf("add contributor with the same name: %v", err)
+	}
+	if first.ID == second.ID {
+		t.Fatalf("contributors with the same name share id %d", first.ID)
+	}
+	translator, err := AddCatalogEntry(ctx, ContributorKind, "译者-homonym")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bt, err := AddBookType(ctx, booktype.AddBookTypeRequest{
+		Title: "Homonyms", Category: "Test", ISBN: "9780000000103", Publisher: "Test", PublishYear: 2024,
+	}, BookTypeLinks{Contributors: []BookTypeContributor{
+		{ContributorID: first.ID, Role: constants.ContributorRoleAuthor},
+		{ContributorID: translator.ID, Role: constants.ContributorRoleTranslator},
+	}})
+	if err != nil {
+		t.Fatalf("add book type: %v", err)
+	}
+	if bt.Author != name {
+		t.Errorf("derived author = %q, want %q", bt.Author, name)
+	}
+
+	page, err := pagination.Parse(BookTypePageSpec, pagination.Request{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	author := "译者-homo"
+	found, _, err := SearchBookType(ctx, booktype.GetBookTypeRequest{Author: &author}, page)
+	if err != nil {
+		t.Fatalf("search book type by author: %v", err)
+	}
+	if len(found) != 1 || found[0].ISBN != bt.ISBN {
+		t.Errorf("search by contributor name found %d book types, want %s", len(found), bt.ISBN)
+	}
+
+	b := Book{ISBN: bt.ISBN, Location: "A-1", Status: constants.BookStatusAvailable, PurchaseDate: time.Now()}
+	if err = db.Table(Book{}.TableName()).Create(&b).Error; err != nil {
+		t.Fatal(err)
+	}
+	userId := createTestUser(t, "catalog-homonym")
+	if err = db.Table(BorrowRecord{}.TableName()).Create(&BorrowRecord{
+		UserID: &userId, BookID: b.ID, Title: bt.Title, CheckoutDate: time.Now(), DueDate: time.Now().AddDate(0, 0, 30), Status: "returned",
+	}).Error; err != nil {
+		t.Fatal(err)
+	}
+	ranks, err := TopBorrowedAuthors(ctx, 100, nil, nil)
+	if err != nil {
+		t.Fatalf("top borrowed authors: %v", err)
+	}
+	for _, r := range ranks {
+		if r.Name == "译者-homonym" {
+			t.Errorf("translator ranked as author: %+v", r)
+		}
+		if r.Name == name && r.Count != 1 {
+			t.Errorf("author %s ranked with %d loans, want 1", name, r.Count)
+		}
+	}
+}
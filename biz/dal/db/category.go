@@ -0,0 +1,492 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/2451965602/LMS/pkg/errno"
+	"github.com/2451965602/LMS/pkg/pagination"
+)
+
+// CategoryPageSpec 分类列表的分页规则，默认按分类号排序
+var CategoryPageSpec = pagination.Spec{
+	Fields: map[string]pagination.Field{
+		"id":   {Column: "id", Kind: pagination.KindInt},
+		"code": {Column: "code", Kind: pagination.KindString},
+		"name": {Column: "name", Kind: pagination.KindString},
+	},
+	DefaultSort: "code",
+	Key:         pagination.Field{Column: "id", Kind: pagination.KindInt},
+}
+
+// CategorySeed 初始化分类法时的一个节点，ParentCode 为空表示顶级类目
+type CategorySeed struct {
+	Code       string
+	Name       string
+	ParentCode string
+}
+
+// AddCategory 添加一个分类节点
+// 1. 检查分类号是否已被使用，已使用时返回 ServiceCategoryExist。
+// 2. 指定上级节点时检查其是否存在。
+// 3. 插入节点，再根据上级节点的路径和新节点的 ID 设置路径。
+func AddCategory(ctx context.Context, code, name string, parentId *int64) (*CategoryNode, error) {
+	node := CategoryNode{Code: code, Name: name}
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := checkCategoryCode(tx, code, 0); err != nil {
+			return err
+		}
+
+		parentPath := "/"
+		if parentId != nil {
+			parent, err := getCategory(tx, *parentId)
+			if err != nil {
+				return err
+			}
+			node.ParentID = &parent.ID
+			parentPath = parent.Path
+		}
+
+		if err := tx.Table(CategoryNode{}.TableName()).Create(&node).Error; err != nil {
+			return errno.Errorf(errno.InternalDatabaseErrorCode, "create category failed: %v", err)
+		}
+		node.Path = parentPath + strconv.FormatInt(node.ID, 10) + "/"
+		err := tx.Table(CategoryNode{}.TableName()).
+			Where("id = ?", node.ID).
+			Update("path", node.Path).
+			Error
+		if err != nil {
+			return errno.Errorf(errno.InternalDatabaseErrorCode, "set category (id: %d) path failed: %v", node.ID, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &node, nil
+}
+
+// UpdateCategory 修改分类节点的分类号、名称或上级节点
+// 1. 锁定并查询节点，节点不存在时返回 ServiceCategoryNotExist。
+// 2. 修改分类号时检查新分类号是否已被其他节点使用。
+// 3. 修改名称时同步更新引用该节点的书籍类型的分类名称，并增加其版本。
+// 4. 修改上级节点时（parentId 为 0 表示移动为顶级类目），不能移动到自身或其下级节点之下，
+// 并以新路径替换该节点及其全部下级节点路径的前缀。
+func UpdateCategory(ctx context.Context, id int64, code, name *string, parentId *int64) (*CategoryNode, error) {
+	var node *CategoryNode
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		node, err = getCategory(tx.Clauses(clause.Locking{Strength: "UPDATE"}), id)
+		if err != nil {
+			return err
+		}
+
+		updates := make(map[string]interface{})
+		if code != nil && *code != node.Code {
+			if err = checkCategoryCode(tx, *code, id); err != nil {
+				return err
+			}
+			updates["code"] = *code
+			node.Code = *code
+		}
+		if name != nil && *name != node.Name {
+			updates["name"] = *name
+			node.Name = *name
+			err = tx.Table(BookType{}.TableName()).
+				Where("category_id = ?", id).
+				Updates(map[string]interface{}{
+					"category": *name,
+					"version":  gorm.Expr("version + 1"),
+				}).
+				Error
+			if err != nil {
+				return errno.Errorf(errno.InternalDatabaseErrorCode, "rename category of book types (category id: %d) failed: %v", id, err)
+			}
+		}
+		if parentId != nil && !sameParent(node.ParentID, *parentId) {
+			if err = moveCategory(tx, node, *parentId); err != nil {
+				return err
+			}
+			updates["parent_id"] = node.ParentID
+		}
+		if len(updates) == 0 {
+			return nil
+		}
+
+		err = tx.Table(CategoryNode{}.TableName()).
+			Where("id = ?", id).
+			Updates(updates).
+			Error
+		if err != nil {
+			return errno.Errorf(errno.InternalDatabaseErrorCode, "update category (id: %d) failed: %v", id, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+// moveCategory 把节点移动到新的上级节点之下，并更新节点及其全部下级节点的路径
+func moveCategory(tx *gorm.DB, node *CategoryNode, parentId int64) error {
+	parentPath := "/"
+	var newParent *int64
+	if parentId != 0 {
+		parent, err := getCategory(tx, parentId)
+		if err != nil {
+			return err
+		}
+		// 上级节点的路径以该节点的路径开头，说明是该节点自身或其下级节点
+		if strings.HasPrefix(parent.Path, node.Path) {
+			return errno.Errorf(errno.ServiceCategoryCycle, "cannot move category (id: %d) under its descendant (id: %d)", node.ID, parentId)
+		}
+		parentPath = parent.Path
+		newParent = &parent.ID
+	}
+
+	oldPath := node.Path
+	newPath := parentPath + strconv.FormatInt(node.ID, 10) + "/"
+	err := tx.Table(CategoryNode{}.TableName()).
+		Where("path LIKE ?", oldPath+"%").
+		Update("path", gorm.Expr("CONCAT(?, SUBSTRING(path, ?))", newPath, len(oldPath)+1)).
+		Error
+	if err != nil {
+		return errno.Errorf(errno.InternalDatabaseErrorCode, "move category (id: %d) failed: %v", node.ID, err)
+	}
+	node.ParentID = newParent
+	node.Path = newPath
+	return nil
+}
+
+// sameParent 判断请求的上级节点是否就是当前的上级节点，parentId 为 0 表示顶级类目
+func sameParent(current *int64, parentId int64) bool {
+	if current == nil {
+		return parentId == 0
+	}
+	return *current == parentId
+}
+
+// DeleteCategory 删除分类节点
+// 1. 节点仍有下级节点或仍被书籍类型引用时返回 ServiceCategoryInUse。
+// 2. 删除节点，节点不存在时返回 ServiceCategoryNotExist。
+func DeleteCategory(ctx context.Context, id int64) error {
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var children, bookTypes int64
+		err := tx.Table(CategoryNode{}.TableName()).
+			Where("parent_id = ?", id).
+			Count(&children).
+			Error
+		if err != nil {
+			return errno.Errorf(errno.InternalDatabaseErrorCode, "count children of category (id: %d) failed: %v", id, err)
+		}
+		err = tx.Table(BookType{}.TableName()).
+			Where("category_id = ?", id).
+			Count(&bookTypes).
+			Error
+		if err != nil {
+			return errno.Errorf(errno.InternalDatabaseErrorCode, "count book types of category (id: %d) failed: %v", id, err)
+		}
+		if children > 0 || bookTypes > 0 {
+			return errno.Errorf(errno.ServiceCategoryInUse, "category (id: %d) still has %d children and %d book types", id, children, bookTypes)
+		}
+
+		result := tx.Table(CategoryNode{}.TableName()).Where("id = ?", id).Delete(&CategoryNode{})
+		if result.Error != nil {
+			return errno.Errorf(errno.InternalDatabaseErrorCode, "delete category (id: %d) failed: %v", id, result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return errno.Errorf(errno.ServiceCategoryNotExist, "category (id: %d) not exist", id)
+		}
+		return nil
+	})
+}
+
+// GetCategory 根据 ID 获取分类节点
+func GetCategory(ctx context.Context, id int64) (*CategoryNode, error) {
+	return getCategory(db.WithContext(ctx), id)
+}
+
+func getCategory(tx *gorm.DB, id int64) (*CategoryNode, error) {
+	var node CategoryNode
+	err := tx.Table(CategoryNode{}.TableName()).
+		Where("id = ?", id).
+		First(&node).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.Errorf(errno.ServiceCategoryNotExist, "category (id: %d) not exist", id)
+		}
+		return nil, errno.Errorf(errno.InternalDatabaseErrorCode, "get category (id: %d) failed: %v", id, err)
+	}
+	return &node, nil
+}
+
+// SearchCategory 浏览或搜索分类节点
+// 1. 指定分类号时按分类号精确匹配，指定名称时在整个分类法中模糊匹配。
+// 2. 指定上级节点时只返回其直接下级节点，上级节点为 0 或既未指定上级节点也未指定分类号和名称时返回顶级类目。
+// 3. 请求需要时查询总记录数，再根据分页参数查询一页节点。
+func SearchCategory(ctx context.Context, parentId *int64, code, name *string, page *pagination.Page) ([]*CategoryNode, *pagination.Result, error) {
+	var results []CategoryNode
+	hasCode := code != nil && *code != ""
+	hasName := name != nil && *name != ""
+
+	filter := func(query *gorm.DB) *gorm.DB {
+		if hasCode {
+			query = query.Where("code = ?", *code)
+		}
+		if hasName {
+			query = query.Where("name LIKE ?", "%"+escapeLike(*name)+"%")
+		}
+		switch {
+		case parentId != nil && *parentId != 0:
+			query = query.Where("parent_id = ?", *parentId)
+		case parentId != nil || (!hasCode && !hasName):
+			query = query.Where("parent_id IS NULL")
+		}
+		return query
+	}
+
+	total, err := countPage(ctx, CategoryNode{}.TableName(), filter, page)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = paginate(db.WithContext(ctx).Table(CategoryNode{}.TableName()).Scopes(filter), page).
+		Find(&results).
+		Error
+	if err != nil {
+		return nil, nil, errno.Errorf(errno.InternalDatabaseErrorCode, "search category failed: %v", err)
+	}
+
+	results, result, err := pageResult(page, results, total)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	nodes := make([]*CategoryNode, 0, len(results))
+	for i := range results {
+		nodes = append(nodes, &results[i])
+	}
+	return nodes, result, nil
+}
+
+// GetCategoryPath 获取从顶级类目到指定节点的各级节点，用于显示分类的层级
+func GetCategoryPath(ctx context.Context, id int64) ([]*CategoryNode, error) {
+	node, err := GetCategory(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := categoryPathIDs(node.Path)
+
+	var results []*CategoryNode
+	err = db.WithContext(ctx).
+		Table(CategoryNode{}.TableName()).
+		Where("id IN ?", ids).
+		Order("LENGTH(path) ASC").
+		Find(&results).
+		Error
+	if err != nil {
+		return nil, errno.Errorf(errno.InternalDatabaseErrorCode, "get path of category (id: %d) failed: %v", id, err)
+	}
+	return results, nil
+}
+
+// ListCategories 获取分类法中的全部节点，按路径排序，上级节点在前
+func ListCategories(ctx context.Context) ([]*CategoryNode, error) {
+	var results []*CategoryNode
+	err := db.WithContext(ctx).
+		Table(CategoryNode{}.TableName()).
+		Order("path ASC").
+		Find(&results).
+		Error
+	if err != nil {
+		return nil, errno.Errorf(errno.InternalDatabaseErrorCode, "list categories failed: %v", err)
+	}
+	return results, nil
+}
+
+// SeedCategories 按顺序添加分类法的节点，分类号已存在的节点跳过，因此可以重复执行
+// 上级节点需排在下级节点之前，返回新添加的节点数。
+func SeedCategories(ctx context.Context, seeds []CategorySeed) (int, error) {
+	created := 0
+	for _, seed := range seeds {
+		var exist int64
+		err := db.WithContext(ctx).
+			Table(CategoryNode{}.TableName()).
+			Where("code = ?", seed.Code).
+			Count(&exist).
+			Error
+		if err != nil {
+			return created, errno.Errorf(errno.InternalDatabaseErrorCode, "check category %s failed: %v", seed.Code, err)
+		}
+		if exist > 0 {
+			continue
+		}
+
+		var parentId *int64
+		if seed.ParentCode != "" {
+			parent, err := getCategoryByCode(ctx, seed.ParentCode)
+			if err != nil {
+				return created, err
+			}
+			parentId = &parent.ID
+		}
+		if _, err = AddCategory(ctx, seed.Code, seed.Name, parentId); err != nil {
+			return created, err
+		}
+		created++
+	}
+	return created, nil
+}
+
+// getCategoryByCode 根据分类号获取分类节点
+func getCategoryByCode(ctx context.Context, code string) (*CategoryNode, error) {
+	var node CategoryNode
+	err := db.WithContext(ctx).
+		Table(CategoryNode{}.TableName()).
+		Where("code = ?", code).
+		First(&node).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.Errorf(errno.ServiceCategoryNotExist, "category %s not exist", code)
+		}
+		return nil, errno.Errorf(errno.InternalDatabaseErrorCode, "get category %s failed: %v", code, err)
+	}
+	return &node, nil
+}
+
+// checkCategoryCode 检查分类号是否已被除 exceptId 以外的节点使用
+func checkCategoryCode(tx *gorm.DB, code string, exceptId int64) error {
+	var count int64
+	err := tx.Table(CategoryNode{}.TableName()).
+		Where("code = ? AND id <> ?", code, exceptId).
+		Count(&count).
+		Error
+	if err != nil {
+		return errno.Errorf(errno.InternalDatabaseErrorCode, "check category code failed: %v", err)
+	}
+	if count > 0 {
+		return errno.Errorf(errno.ServiceCategoryExist, "category %s already exist", code)
+	}
+	return nil
+}
+
+// categorySubtree 查询一个节点及其全部下级节点 ID 的子查询，用于按分类浏览时包括下级分类
+func categorySubtree(node *CategoryNode) *gorm.DB {
+	return db.Table(CategoryNode{}.TableName()).
+		Select("id").
+		Where("path LIKE ?", node.Path+"%")
+}
+
+// categoryGroups 求统计时各分类节点汇总到的分类
+// 1. parent 为 nil 时汇总到顶级类目，否则汇总到 parent 的直接下级分类，直接关联 parent 的计入 parent 自身。
+// 2. 按节点的路径取对应层级的祖先节点，并查询这些祖先节点。
+// 3. 返回节点 ID 到汇总分类的映射；不在 parent 之下的节点不在映射中。
+func categoryGroups(ctx context.Context, parent *CategoryNode, ids []int64) (map[int64]*CategoryNode, error) {
+	groups := make(map[int64]*CategoryNode, len(ids))
+	if len(ids) == 0 {
+		return groups, nil
+	}
+
+	var nodes []*CategoryNode
+	err := db.WithContext(ctx).
+		Table(CategoryNode{}.TableName()).
+		Where("id IN ?", ids).
+		Find(&nodes).
+		Error
+	if err != nil {
+		return nil, errno.Errorf(errno.InternalDatabaseErrorCode, "get categories for rollup failed: %v", err)
+	}
+
+	depth, prefix := 0, "/"
+	if parent != nil {
+		depth, prefix = len(categoryPathIDs(parent.Path)), parent.Path
+	}
+	groupOf := make(map[int64]int64, len(nodes))
+	var groupIds []int64
+	for _, n := range nodes {
+		if !strings.HasPrefix(n.Path, prefix) {
+			continue
+		}
+		path := categoryPathIDs(n.Path)
+		if len(path) <= depth { // 节点就是 parent 自身
+			groups[n.ID] = parent
+			continue
+		}
+		groupOf[n.ID] = path[depth]
+		groupIds = append(groupIds, path[depth])
+	}
+
+	var ancestors []*CategoryNode
+	if len(groupIds) > 0 {
+		err = db.WithContext(ctx).
+			Table(CategoryNode{}.TableName()).
+			Where("id IN ?", groupIds).
+			Find(&ancestors).
+			Error
+		if err != nil {
+			return nil, errno.Errorf(errno.InternalDatabaseErrorCode, "get categories for rollup failed: %v", err)
+		}
+	}
+	byId := make(map[int64]*CategoryNode, len(ancestors))
+	for _, a := range ancestors {
+		byId[a.ID] = a
+	}
+	for id, group := range groupOf {
+		if a, ok := byId[group]; ok {
+			groups[id] = a
+		}
+	}
+	return groups, nil
+}
+
+// categoryPathIDs 把节点路径拆分为从顶级类目到自身的节点 ID
+func categoryPathIDs(path string) []int64 {
+	var ids []int64
+	for _, s := range strings.Split(strings.Trim(path, "/"), "/") {
+		if id, err := strconv.ParseInt(s, 10, 64); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// GetBookTypesWithoutCategory 获取分类字段不为空、但还没有关联分类节点的书籍类型
+func GetBookTypesWithoutCategory(ctx context.Context) ([]*BookType, error) {
+	var results []*BookType
+	err := db.WithContext(ctx).
+		Table(BookType{}.TableName()).
+		Where("category_id IS NULL AND category <> ''").
+		Order("category ASC, ISBN ASC").
+		Find(&results).
+		Error
+	if err != nil {
+		return nil, errno.Errorf(errno.InternalDatabaseErrorCode, "get book types without category failed: %v", err)
+	}
+	return results, nil
+}
+
+// SetBookTypeCategory 把书籍类型关联到分类节点，分类字段随之改为节点名称，并增加版本
+// 只处理仍未关联分类节点的书籍类型，返回是否更新。
+func SetBookTypeCategory(ctx context.Context, isbn string, node *CategoryNode) (bool, error) {
+	result := db.WithContext(ctx).
+		Table(BookType{}.TableName()).
+		Where("ISBN = ? AND category_id IS NULL", isbn).
+		Updates(map[string]interface{}{
+			"category_id": node.ID,
+			"category":    node.Name,
+			"version":     gorm.Expr("version + 1"),
+		})
+	if result.Error != nil {
+		return false, errno.Errorf(errno.InternalDatabaseErrorCode, "set category of book type %s failed: %v", isbn, result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
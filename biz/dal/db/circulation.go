@@ -0,0 +1,78 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/2451965602/LMS/pkg/constants"
+	"github.com/2451965602/LMS/pkg/errno"
+)
+
+// GetAvailableBookIds 获取指定 ISBN 下在架可借的副本 ID
+func GetAvailableBookIds(ctx context.Context, isbn string) ([]int64, error) {
+	var ids []int64
+	err := db.WithContext(ctx).
+		Table(Book{}.TableName()).
+		Where("ISBN = ? AND status = ?", isbn, constants.BookStatusAvailable).
+		Order("id").
+		Pluck("id", &ids).
+		Error
+	if err != nil {
+		return nil, errno.Errorf(errno.InternalDatabaseErrorCode, "get available books (ISBN: %s) failed: %v", isbn, err)
+	}
+	return ids, nil
+}
+
+// CheckCirculation 检查指定 ISBN 的借阅数据是否一致，返回发现的问题
+// 1. 每个副本最多只有一条借出中的借阅记录。
+// 2. 副本状态为已借出时恰好有一条借出中的借阅记录，否则没有。
+// 3. 书籍类型的可用副本数等于在架副本数，总副本数等于未剔除的副本数。
+func CheckCirculation(ctx context.Context, isbn string) ([]string, error) {
+	var problems []string
+
+	var copies []struct {
+		ID         int64
+		Status     string
+		CheckedOut int64
+	}
+	err := db.WithContext(ctx).Raw(fmt.Sprintf(
+		"SELECT b.id, b.status, COUNT(br.id) AS checked_out FROM %s AS b "+
+			"LEFT JOIN %s AS br ON br.book_id = b.id AND br.status = ? "+
+			"WHERE b.ISBN = ? GROUP BY b.id, b.status",
+		Book{}.TableName(), BorrowRecord{}.TableName()), "checked_out", isbn).
+		Scan(&copies).
+		Error
+	if err != nil {
+		return nil, errno.Errorf(errno.InternalDatabaseErrorCode, "check copies (ISBN: %s) failed: %v", isbn, err)
+	}
+
+	var available, total int64
+	for _, c := range copies {
+		switch {
+		case c.CheckedOut > 1:
+			problems = append(problems, fmt.Sprintf("book %d has %d checked out borrow records", c.ID, c.CheckedOut))
+		case c.Status == constants.BookStatusCheckedOut && c.CheckedOut == 0:
+			problems = append(problems, fmt.Sprintf("book %d is checked out without a borrow record", c.ID))
+		case c.Status != constants.BookStatusCheckedOut && c.CheckedOut == 1:
+			problems = append(problems, fmt.Sprintf("book %d is %s but has a checked out borrow record", c.ID, c.Status))
+		}
+		if c.Status == constants.BookStatusAvailable {
+			available++
+		}
+		if c.Status != constants.BookStatusWithdrawn {
+			total++
+		}
+	}
+
+	var bt BookType
+	if err = db.WithContext(ctx).Table(BookType{}.TableName()).Where("ISBN = ?", isbn).First(&bt).Error; err != nil {
+		return nil, errno.Errorf(errno.InternalDatabaseErrorCode, "get book type (ISBN: %s) failed: %v", isbn, err)
+	}
+	if bt.AvailableCopies != available {
+		problems = append(problems, fmt.Sprintf("book type %s has available_copies %d, %d copies available", isbn, bt.AvailableCopies, available))
+	}
+	if bt.TotalCopies != total {
+		problems = append(problems, fmt.Sprintf("book type %s has total_copies %d, %d copies in collection", isbn, bt.TotalCopies, total))
+	}
+	return problems, nil
+}
@@ -0,0 +1,157 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/2451965602/LMS/pkg/crypt"
+	"github.com/2451965602/LMS/pkg/errno"
+)
+
+// GetUserByExternalIdentity 根据外部账号获取已关联的本地用户
+// 1. 根据身份提供方和外部账号标识查询关联记录。
+// 2. 未关联时返回 ServiceUserNotExist，由调用方决定是否自动创建用户。
+// 3. 返回关联记录和对应的用户信息。
+func GetUserByExternalIdentity(ctx context.Context, provider, subject string) (*ExternalIdentity, *User, error) {
+	var identity ExternalIdentity
+	err := db.WithContext(ctx).
+		Table(ExternalIdentity{}.TableName()).
+		Where("provider = ? AND subject = ?", provider, subject).
+		First(&identity).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, errno.Errorf(errno.ServiceUserNotExist, "external identity (%s: %s) not linked", provider, subject)
+		}
+		return nil, nil, errno.Errorf(errno.InternalDatabaseErrorCode, "get external identity (%s: %s) failed: %v", provider, subject, err)
+	}
+
+	u, err := GetUserById(ctx, identity.UserID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &identity, u, nil
+}
+
+// ProvisionExternalUser 为首次登录的外部账号创建本地用户并建立关联
+// 1. 用户名已被本地账号占用时返回错误，需由该账号登录后手动关联，避免外部账号接管同名的本地账号。
+// 2. 使用随机密码创建用户，该用户只能通过身份提供方登录，直到自行找回密码。
+// 3. 在同一事务中保存关联记录，并标记该用户由外部账号创建，之后登录时按外部组同步权限。
+func ProvisionExternalUser(ctx context.Context, provider, subject, username, patronType string, phone *string, permission string) (*User, error) {
+	randomPassword, err := crypt.RandomToken(32)
+	if err != nil {
+		return nil, err
+	}
+	hashedPassword, err := crypt.PasswordHash(randomPassword)
+	if err != nil {
+		return nil, errno.Errorf(errno.InternalPasswordCryptErrorCode, "encrypt password failed: %v", err)
+	}
+
+	u := User{
+		Name:       username,
+		Password:   hashedPassword,
+		Phone:      phone,
+		PatronType: patronType,
+		Permission: permission,
+		Status:     "active",
+	}
+
+	err = db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		err := tx.Table(User{}.TableName()).
+			Where("name = ?", username).
+			Count(&count).
+			Error
+		if err != nil {
+			return errno.Errorf(errno.InternalDatabaseErrorCode, "check user exist (username: %s) failed: %v", username, err)
+		}
+		if count > 0 {
+			return errno.Errorf(errno.ServiceUserExist, "username %s already taken, log in with the local account and link the %s account", username, provider)
+		}
+
+		if err = tx.Table(User{}.TableName()).Create(&u).Error; err != nil {
+			return errno.Errorf(errno.InternalDatabaseErrorCode, "create user (username: %s) failed: %v", username, err)
+		}
+		now := time.Now()
+		identity := ExternalIdentity{
+			UserID:      u.ID,
+			Provider:    provider,
+			Subject:     subject,
+			Provisioned: true,
+			LastLoginAt: &now,
+		}
+		if err = tx.Table(ExternalIdentity{}.TableName()).Create(&identity).Error; err != nil {
+			return errno.Errorf(errno.InternalDatabaseErrorCode, "create external identity (%s: %s) failed: %v", provider, subject, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
+
+// LinkExternalIdentity 将外部账号关联到已有的本地用户
+// 1. 外部账号已关联到该用户时直接返回。
+// 2. 外部账号已关联到其他用户时返回错误。
+// 3. 否则保存关联记录，之后可以使用该外部账号登录。
+func LinkExternalIdentity(ctx context.Context, userId int64, provider, subject string) error {
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var identity ExternalIdentity
+		err := tx.Table(ExternalIdentity{}.TableName()).
+			Where("provider = ? AND subject = ?", provider, subject).
+			First(&identity).
+			Error
+		if err == nil {
+			if identity.UserID == userId {
+				return nil
+			}
+			return errno.Errorf(errno.ServiceIdentityLinked, "%s account already linked to another user", provider)
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return errno.Errorf(errno.InternalDatabaseErrorCode, "get external identity (%s: %s) failed: %v", provider, subject, err)
+		}
+
+		identity = ExternalIdentity{UserID: userId, Provider: provider, Subject: subject}
+		if err = tx.Table(ExternalIdentity{}.TableName()).Create(&identity).Error; err != nil {
+			return errno.Errorf(errno.InternalDatabaseErrorCode, "link external identity (user id: %d, %s: %s) failed: %v", userId, provider, subject, err)
+		}
+		return nil
+	})
+}
+
+// SyncExternalLogin 记录外部账号的登录并同步权限
+// 1. 更新关联记录的最后登录时间。
+// 2. 只同步由外部账号创建的用户的权限；手动关联的本地账号仍由管理员管理权限，避免外部组配置错误时降级本地管理员。
+// 3. 返回同步后的用户信息。
+func SyncExternalLogin(ctx context.Context, identity *ExternalIdentity, u *User, permission string) (*User, error) {
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Table(ExternalIdentity{}.TableName()).
+			Where("id = ?", identity.ID).
+			Update("last_login_at", time.Now()).
+			Error
+		if err != nil {
+			return errno.Errorf(errno.InternalDatabaseErrorCode, "update external identity (id: %d) failed: %v", identity.ID, err)
+		}
+		if !identity.Provisioned || u.Permission == permission {
+			return nil
+		}
+
+		err = tx.Table(User{}.TableName()).
+			Where("id = ?", u.ID).
+			Update("permission", permission).
+			Error
+		if err != nil {
+			return errno.Errorf(errno.InternalDatabaseErrorCode, "sync permission (user id: %d) failed: %v", u.ID, err)
+		}
+		u.Permission = permission
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return u, nil
+}
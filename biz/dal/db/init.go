@@ -3,6 +3,7 @@ package db
 import (
 	"context"
 	"fmt"
+	"github.com/2451965602/LMS/config"
 	"github.com/2451965602/LMS/pkg/crypt"
 
 	"gorm.io/driver/mysql"
@@ -35,6 +36,16 @@ func Init() error {
 		return errno.Errorf(errno.InternalDatabaseErrorCode, "dal.InitMySQL mysql connect error: %v", err)
 	}
 
+	// 注册 SQL 语句耗时统计插件
+	if err = db.Use(metricsPlugin{}); err != nil {
+		return errno.Errorf(errno.InternalDatabaseErrorCode, "dal.InitMySQL use metrics plugin error: %v", err)
+	}
+
+	// 注册 SQL 语句链路追踪插件
+	if err = db.Use(tracingPlugin{database: config.Mysql.Database}); err != nil {
+		return errno.Errorf(errno.InternalDatabaseErrorCode, "dal.InitMySQL use tracing plugin error: %v", err)
+	}
+
 	sqlDB, err := db.DB() // 尝试获取 DB 实例对象
 	if err != nil {
 		return errno.Errorf(errno.InternalDatabaseErrorCode, fmt.Sprintf("get generic database object error: %v", err))
@@ -45,16 +56,22 @@ func Init() error {
 	sqlDB.SetConnMaxLifetime(constants.ConnMaxLifetime) // 最大可复用时间
 	sqlDB.SetConnMaxIdleTime(constants.ConnMaxIdleTime) // 最长保持空闲状态时间
 	db = db.WithContext(context.Background())
+	registerMetrics(sqlDB, config.Mysql.Database) // 注册连接池统计和业务指标
 
 	// 进行连通性测试
 	if err = sqlDB.Ping(); err != nil {
 		return errno.Errorf(errno.InternalDatabaseErrorCode, fmt.Sprintf("ping database error: %v", err))
 	}
 
-	err = db.AutoMigrate(&User{}, &BookType{}, &Book{}, &BorrowRecord{})
+	err = db.AutoMigrate(&User{}, &BookType{}, &Book{}, &BorrowRecord{}, &Reservation{}, &Session{}, &RefreshToken{}, &LoginAttempt{}, &AuditLog{}, &PasswordResetCode{}, &RecoveryCode{}, &TwoFactorPolicy{}, &ExternalIdentity{},
+		&Contributor{}, &Subject{}, &Series{}, &BookTypeContributor{}, &BookTypeSubject{}, &BookTypeSeries{},
+		&CategoryNode{}, &Attachment{})
 	if err != nil {
 		return errno.Errorf(errno.InternalDatabaseErrorCode, fmt.Sprintf("auto migrate error: %v", err))
 	}
+	if err = dropCatalogNameUnique(); err != nil {
+		return err
+	}
 
 	exist, err := IsUserExist(context.Background(), "admin")
 	if !exist {
@@ -67,6 +84,28 @@ func Init() error {
 	return nil
 }
 
+// dropCatalogNameUnique 删除旧版本在责任者、主题和丛书名称上建立的唯一索引
+// 同名的责任者可以是不同的人，AutoMigrate 不会删除已有的唯一索引，需要单独删除。
+func dropCatalogNameUnique() error {
+	m := db.Migrator()
+	for _, model := range []interface{}{&Contributor{}, &Subject{}, &Series{}} {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(model); err != nil {
+			return errno.Errorf(errno.InternalDatabaseErrorCode, "parse schema failed: %v", err)
+		}
+		// init.sql 中的 UNIQUE 列约束以列名命名，旧的 GORM 标签以 uni_表名_列名 命名
+		for _, name := range []string{"name", "uni_" + stmt.Schema.Table + "_name"} {
+			if !m.HasIndex(model, name) {
+				continue
+			}
+			if err := m.DropIndex(model, name); err != nil {
+				return errno.Errorf(errno.InternalDatabaseErrorCode, "drop unique index %s of %s failed: %v", name, stmt.Schema.Table, err)
+			}
+		}
+	}
+	return nil
+}
+
 func createAdminUser() error {
 	hashedPassword, err := crypt.PasswordHash("admin")
 	if err != nil {
@@ -78,6 +117,8 @@ func createAdminUser() error {
 		Password:   hashedPassword,
 		Permission: "admin",
 		Status:     "active",
+		// 默认管理员使用公开的初始密码，首次登录后必须修改
+		MustChangePassword: true,
 	}
 
 	err = db.WithContext(context.Background()).
@@ -0,0 +1,115 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/2451965602/LMS/pkg/errno"
+	"github.com/2451965602/LMS/pkg/loginguard"
+)
+
+// LoginAttemptStore 基于 MySQL 的登录失败记录存储，多个实例共享同一份失败次数
+type LoginAttemptStore struct{}
+
+// NewLoginAttemptStore 创建基于 MySQL 的登录失败记录存储
+func NewLoginAttemptStore() *LoginAttemptStore {
+	return &LoginAttemptStore{}
+}
+
+// Get 获取键的失败记录
+// 1. 根据键查询失败记录。
+// 2. 如果记录不存在，返回零值。
+func (LoginAttemptStore) Get(ctx context.Context, key string) (loginguard.Attempt, error) {
+	var a LoginAttempt
+	err := db.WithContext(ctx).
+		Table(LoginAttempt{}.TableName()).
+		Where("attempt_key = ?", key).
+		First(&a).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return loginguard.Attempt{}, nil
+		}
+		return loginguard.Attempt{}, errno.Errorf(errno.InternalDatabaseErrorCode, "get login attempt failed: %v", err)
+	}
+	return a.toAttempt(), nil
+}
+
+// Fail 记录一次失败
+// 1. 记录不存在时插入，存在时在同一条语句中累加失败次数，避免多个实例并发更新时丢失计数。
+// 2. 上次失败早于统计窗口时重新从 1 开始计数。
+// 3. 返回更新后的记录。
+func (s LoginAttemptStore) Fail(ctx context.Context, key string, now time.Time, window time.Duration) (loginguard.Attempt, error) {
+	err := db.WithContext(ctx).
+		Table(LoginAttempt{}.TableName()).
+		Clauses(clause.OnConflict{
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"failures":     gorm.Expr("IF(last_failure IS NULL OR last_failure < ?, 1, failures + 1)", now.Add(-window)),
+				"last_failure": now,
+			}),
+		}).
+		Create(&LoginAttempt{Key: key, Failures: 1, LastFailure: &now}).
+		Error
+	if err != nil {
+		return loginguard.Attempt{}, errno.Errorf(errno.InternalDatabaseErrorCode, "record login failure failed: %v", err)
+	}
+	return s.Get(ctx, key)
+}
+
+// Lock 锁定键直到 until
+// 1. 写入锁定截止时间并清空失败次数，记录不存在时插入。
+func (LoginAttemptStore) Lock(ctx context.Context, key string, until time.Time) error {
+	err := db.WithContext(ctx).
+		Table(LoginAttempt{}.TableName()).
+		Clauses(clause.OnConflict{
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"failures":     0,
+				"locked_until": until,
+			}),
+		}).
+		Create(&LoginAttempt{Key: key, LockedUntil: &until}).
+		Error
+	if err != nil {
+		return errno.Errorf(errno.InternalDatabaseErrorCode, "lock login attempt failed: %v", err)
+	}
+	return nil
+}
+
+// Reset 删除键的失败记录
+func (LoginAttemptStore) Reset(ctx context.Context, key string) error {
+	err := db.WithContext(ctx).
+		Where("attempt_key = ?", key).
+		Delete(&LoginAttempt{}).
+		Error
+	if err != nil {
+		return errno.Errorf(errno.InternalDatabaseErrorCode, "reset login attempt failed: %v", err)
+	}
+	return nil
+}
+
+// DeleteStaleLoginAttempts 删除已解除锁定且最近一次失败早于 before 的记录
+// 1. 返回删除的记录数。
+func DeleteStaleLoginAttempts(ctx context.Context, now, before time.Time) (int64, error) {
+	result := db.WithContext(ctx).
+		Where("(locked_until IS NULL OR locked_until < ?) AND (last_failure IS NULL OR last_failure < ?)", now, before).
+		Delete(&LoginAttempt{})
+	if result.Error != nil {
+		return 0, errno.Errorf(errno.InternalDatabaseErrorCode, "delete stale login attempts failed: %v", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+func (a LoginAttempt) toAttempt() loginguard.Attempt {
+	attempt := loginguard.Attempt{Failures: a.Failures}
+	if a.LastFailure != nil {
+		attempt.LastFailure = *a.LastFailure
+	}
+	if a.LockedUntil != nil {
+		attempt.LockedUntil = *a.LockedUntil
+	}
+	return attempt
+}
@@ -0,0 +1,156 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"math"
+	"reflect"
+	"runtime"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+	"gorm.io/gorm"
+
+	"github.com/2451965602/LMS/pkg/constants"
+	"github.com/2451965602/LMS/pkg/metrics"
+)
+
+const metricsStartKey = "lms:metrics_start" // 在 gorm 实例中记录语句开始时间的键名
+
+// dalPackage 当前包的导入路径，用于在调用栈中定位发起查询的 DAL 函数
+var dalPackage = reflect.TypeOf(User{}).PkgPath() + "."
+
+// metricsPlugin 记录每条 SQL 语句耗时的 gorm 插件
+type metricsPlugin struct{}
+
+func (metricsPlugin) Name() string {
+	return "lms:metrics"
+}
+
+// Initialize 在 gorm 的各类回调前后注册计时函数
+func (p metricsPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	registers := []error{
+		cb.Create().Before("gorm:create").Register("lms:metrics_before_create", p.before),
+		cb.Create().After("gorm:create").Register("lms:metrics_after_create", p.after("create")),
+		cb.Query().Before("gorm:query").Register("lms:metrics_before_query", p.before),
+		cb.Query().After("gorm:query").Register("lms:metrics_after_query", p.after("query")),
+		cb.Update().Before("gorm:update").Register("lms:metrics_before_update", p.before),
+		cb.Update().After("gorm:update").Register("lms:metrics_after_update", p.after("update")),
+		cb.Delete().Before("gorm:delete").Register("lms:metrics_before_delete", p.before),
+		cb.Delete().After("gorm:delete").Register("lms:metrics_after_delete", p.after("delete")),
+		cb.Row().Before("gorm:row").Register("lms:metrics_before_row", p.before),
+		cb.Row().After("gorm:row").Register("lms:metrics_after_row", p.after("row")),
+		cb.Raw().Before("gorm:raw").Register("lms:metrics_before_raw", p.before),
+		cb.Raw().After("gorm:raw").Register("lms:metrics_after_raw", p.after("raw")),
+	}
+	for _, err := range registers {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (metricsPlugin) before(tx *gorm.DB) {
+	tx.InstanceSet(metricsStartKey, time.Now())
+}
+
+func (metricsPlugin) after(operation string) func(*gorm.DB) {
+	return func(tx *gorm.DB) {
+		v, ok := tx.InstanceGet(metricsStartKey)
+		if !ok {
+			return
+		}
+		start, ok := v.(time.Time)
+		if !ok {
+			return
+		}
+		metrics.ObserveDBQuery(callerFunction(), operation, time.Since(start))
+	}
+}
+
+// callerFunction 在调用栈中查找发起查询的 DAL 函数名
+// 优先返回最外层的导出函数，例如 getHolds 被 GetActiveHolds 调用时返回 GetActiveHolds。
+func callerFunction() string {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(3, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	fallback := "unknown"
+	for {
+		frame, more := frames.Next()
+		if strings.HasPrefix(frame.Function, dalPackage) {
+			name := strings.TrimPrefix(frame.Function, dalPackage)
+			name = strings.SplitN(name, ".", 2)[0] // 去掉闭包后缀，如 BookBorrow.func1
+			if !strings.HasPrefix(name, "(") {     // 跳过插件自身的方法
+				if unicode.IsUpper([]rune(name)[0]) {
+					return name
+				}
+				fallback = name
+			}
+		}
+		if !more {
+			break
+		}
+	}
+	return fallback
+}
+
+// registerMetrics 注册连接池统计和业务指标
+func registerMetrics(sqlDB *sql.DB, dbName string) {
+	metrics.RegisterDBStats(sqlDB, dbName)
+	metrics.RegisterGauge("active_loans", "Number of loans that have not been returned.", gaugeFunc(countActiveLoans))
+	metrics.RegisterGauge("overdue_loans", "Number of loans past their due date.", gaugeFunc(countOverdueLoans))
+	metrics.RegisterGauge("available_copies", "Number of copies available for checkout.", gaugeFunc(sumAvailableCopies))
+}
+
+// gaugeFunc 将带错误返回的统计函数包装为业务指标的取值函数，查询失败时返回 NaN
+func gaugeFunc(fn func(ctx context.Context) (int64, error)) func() float64 {
+	return func() float64 {
+		ctx, cancel := context.WithTimeout(context.Background(), constants.MetricsGaugeTimeout)
+		defer cancel()
+
+		v, err := fn(ctx)
+		if err != nil {
+			hlog.Errorf("db.gaugeFunc: %v", err)
+			return math.NaN()
+		}
+		return float64(v)
+	}
+}
+
+// countActiveLoans 统计所有未归还的借阅数量
+func countActiveLoans(ctx context.Context) (int64, error) {
+	var count int64
+	err := db.WithContext(ctx).
+		Table(BorrowRecord{}.TableName()).
+		Where("status IN ?", []string{"checked_out", "overdue"}).
+		Count(&count).
+		Error
+	return count, err
+}
+
+// countOverdueLoans 统计所有已逾期的借阅数量
+func countOverdueLoans(ctx context.Context) (int64, error) {
+	var count int64
+	err := db.WithContext(ctx).
+		Table(BorrowRecord{}.TableName()).
+		Where("status = ? OR (status = ? AND due_date < ?)", "overdue", "checked_out", time.Now()).
+		Count(&count).
+		Error
+	return count, err
+}
+
+// sumAvailableCopies 统计所有图书类型的可借副本总数
+func sumAvailableCopies(ctx context.Context) (int64, error) {
+	var total int64
+	err := db.WithContext(ctx).
+		Table(BookType{}.TableName()).
+		Select("COALESCE(SUM(available_copies), 0)").
+		Scan(&total).
+		Error
+	return total, err
+}
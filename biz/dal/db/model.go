@@ -7,13 +7,22 @@ import (
 )
 
 type User struct {
-	ID           int64     `json:"id"            gorm:"primaryKey;autoIncrement"`
-	Name         string    `json:"name"          gorm:"type:varchar(50);not null;unique"`
-	Password     string    `json:"password"      gorm:"type:varchar(255);not null"`
-	Permission   string    `json:"permission"   gorm:"type:enum('admin','librarian','member');default:'member';not null"`
-	Phone        *string   `json:"phone"         gorm:"type:varchar(20)"`
-	RegisterDate time.Time `json:"register_date" gorm:"column:register_date;type:timestamp;default:CURRENT_TIMESTAMP;not null"`
-	Status       string    `json:"status"        gorm:"type:enum('active','suspended','inactive');default:'active';not null"`
+	ID                 int64     `json:"id"            gorm:"primaryKey;autoIncrement"`
+	Name               string    `json:"name"          gorm:"type:varchar(50);not null;unique"`
+	Password           string    `json:"-"             gorm:"type:varchar(255);not null"`
+	Permission         string    `json:"permission"   gorm:"type:enum('admin','librarian','member');default:'member';not null"`
+	Phone              *string   `json:"phone"         gorm:"type:varchar(20)"`
+	RegisterDate       time.Time `json:"register_date" gorm:"column:register_date;type:timestamp;default:CURRENT_TIMESTAMP;not null"`
+	Status             string    `json:"status"        gorm:"type:enum('active','suspended','inactive');default:'active';not null"`
+	KeepHistory        bool      `json:"keep_history"  gorm:"default:false;not null"`
+	TokenVersion       int64     `json:"token_version" gorm:"default:0;not null"`
+	MustChangePassword bool      `json:"must_change_password" gorm:"default:false;not null"`
+	TOTPSecret         string    `json:"-"                    gorm:"column:totp_secret;type:varchar(64);default:'';not null"`
+	TOTPEnabled        bool      `json:"totp_enabled"         gorm:"column:totp_enabled;default:false;not null"`
+	TOTPLastCounter    int64     `json:"-"                    gorm:"column:totp_last_counter;default:0;not null"`
+	Email              *string   `json:"email"                gorm:"type:varchar(254)"`
+	PatronType         string    `json:"patron_type"          gorm:"type:varchar(20);default:'student';not null"`
+	Version            int64     `json:"version"              gorm:"default:0;not null"`
 }
 
 func (User) TableName() string {
@@ -21,15 +30,23 @@ func (User) TableName() string {
 }
 
 type BookType struct {
-	ISBN            string `json:"isbn"             gorm:"type:varchar(20);primaryKey"`
-	Title           string `json:"title"            gorm:"type:varchar(100);not null"`
-	Author          string `json:"author"           gorm:"type:varchar(50);not null"`
-	Category        string `json:"category"         gorm:"type:varchar(50);not null"`
-	Publisher       string `json:"publisher"        gorm:"type:varchar(50);not null"`
-	PublishYear     int64  `json:"publish_year"     gorm:"type:int;not null"`
-	Description     string `json:"description"      gorm:"type:text"`
-	TotalCopies     int64  `json:"total_copies"     gorm:"type:int;default:0;not null"`
-	AvailableCopies int64  `json:"available_copies" gorm:"type:int;default:0;not null"`
+	ISBN            string  `json:"isbn"             gorm:"type:varchar(20);primaryKey"`
+	ISBN10          *string `json:"isbn10"           gorm:"column:isbn10;type:varchar(10);uniqueIndex:idx_booktypes_isbn10"`
+	Title           string  `json:"title"            gorm:"type:varchar(100);not null"`
+	Author          string  `json:"author"           gorm:"type:varchar(255);not null;default:''"`
+	Category        string  `json:"category"         gorm:"type:varchar(50);not null"`
+	CategoryID      *int64  `json:"category_id"      gorm:"index:idx_booktypes_category"`
+	Publisher       string  `json:"publisher"        gorm:"type:varchar(50);not null"`
+	PublishYear     int64   `json:"publish_year"     gorm:"type:int;not null"`
+	Description     string  `json:"description"      gorm:"type:text"`
+	TotalCopies     int64   `json:"total_copies"     gorm:"type:int;default:0;not null"`
+	AvailableCopies int64   `json:"available_copies" gorm:"type:int;default:0;not null"`
+	Version         int64   `json:"version"          gorm:"default:0;not null"`
+
+	Contributors []BookTypeContributorDetail `json:"contributors" gorm:"-"`
+	Subjects     []CatalogEntry              `json:"subjects"     gorm:"-"`
+	Series       []BookTypeSeriesDetail      `json:"series"       gorm:"-"`
+	Cover        *Attachment                 `json:"cover"        gorm:"-"`
 }
 
 func (BookType) TableName() string {
@@ -39,30 +56,264 @@ func (BookType) TableName() string {
 type Book struct {
 	ID            int64      `json:"id"             gorm:"primaryKey;autoIncrement"`
 	ISBN          string     `json:"isbn"           gorm:"type:varchar(20);not null"`
-	Location      string     `json:"location"       gorm:"type:varchar(50);not null"`
-	Status        string     `json:"status"         gorm:"type:enum('available','checked_out','lost','damaged');default:'available'"`
-	PurchaseDate  time.Time  `json:"purchase_date"  gorm:"type:timestamp;not null"`
-	PurchasePrice float64    `json:"purchase_price" gorm:"type:decimal(10,2);not null"`
-	LastCheckout  *time.Time `json:"last_checkout"  gorm:"type:timestamp"`
+	Location      string     `json:"location"       gorm:"type:varchar(50);not null;index:idx_books_location"`
+	Status        string     `json:"status"         gorm:"type:enum('available','checked_out','lost','damaged','in_repair','withdrawn');default:'available'"`
+	PurchaseDate  time.Time  `json:"purchase_date"  gorm:"type:timestamp;not null;index:idx_books_purchase_date"`
+	PurchasePrice float64    `json:"purchase_price" gorm:"type:decimal(10,2);not null;index:idx_books_purchase_price"`
+	LastCheckout  *time.Time `json:"last_checkout"  gorm:"type:timestamp;index:idx_books_last_checkout"`
+	Version       int64      `json:"version"        gorm:"default:0;not null"`
 }
 
 func (Book) TableName() string {
 	return constants.BookTableName
 }
 
+// BookDetail 书籍及其所属书籍类型的书名和作者，只在搜索时关联书籍类型后设置 Title 和 Author
+type BookDetail struct {
+	Book
+	Title  *string `json:"title"`
+	Author *string `json:"author"`
+}
+
 type BorrowRecord struct {
-	ID           int64      `json:"id"             gorm:"primaryKey;autoIncrement"`
-	UserID       int64      `json:"user_id"        gorm:"not null"`
-	BookID       int64      `json:"book_id"        gorm:"not null"`
-	Title        string     `json:"title"            gorm:"type:varchar(100);not null"`
-	CheckoutDate time.Time  `json:"checkout_date"  gorm:"type:timestamp;default:CURRENT_TIMESTAMP"`
-	RenewalCount int64      `json:"renewal_count"  gorm:"type:int;default:0"`
-	DueDate      time.Time  `json:"due_date"       gorm:"type:timestamp;not null"`
-	ReturnDate   *time.Time `json:"return_date"    gorm:"type:timestamp"`
-	Status       string     `json:"status"         gorm:"type:enum('checked_out','returned','overdue','lost');default:'checked_out'"`
-	LateFee      float64    `json:"late_fee"       gorm:"type:decimal(10,2);default:0.00"`
+	ID             int64      `json:"id"             gorm:"primaryKey;autoIncrement;index:idx_borrowrecords_user_checkout,priority:3"`
+	UserID         *int64     `json:"user_id"        gorm:"uniqueIndex:idx_borrowrecords_user_idempotency_key;index:idx_borrowrecords_user_checkout,priority:1"`
+	BookID         int64      `json:"book_id"        gorm:"not null"`
+	Title          string     `json:"title"            gorm:"type:varchar(100);not null"`
+	CheckoutDate   time.Time  `json:"checkout_date"  gorm:"type:timestamp;default:CURRENT_TIMESTAMP;index:idx_borrowrecords_user_checkout,priority:2"`
+	RenewalCount   int64      `json:"renewal_count"  gorm:"type:int;default:0"`
+	DueDate        time.Time  `json:"due_date"       gorm:"type:timestamp;not null"`
+	ReturnDate     *time.Time `json:"return_date"    gorm:"type:timestamp"`
+	Status         string     `json:"status"         gorm:"type:enum('checked_out','returned','overdue','lost');default:'checked_out'"`
+	LateFee        float64    `json:"late_fee"       gorm:"type:decimal(10,2);default:0.00"`
+	FeePaid        bool       `json:"fee_paid"       gorm:"default:false;not null"`
+	FeeWaived      bool       `json:"fee_waived"     gorm:"default:false;not null"`
+	FeeSettledAt   *time.Time `json:"fee_settled_at" gorm:"type:timestamp"`
+	IdempotencyKey *string    `json:"-"              gorm:"type:varchar(64);uniqueIndex:idx_borrowrecords_user_idempotency_key"`
 }
 
 func (BorrowRecord) TableName() string {
 	return constants.BorrowRecordTableName
 }
+
+type Reservation struct {
+	ID          int64      `json:"id"           gorm:"primaryKey;autoIncrement"`
+	UserID      int64      `json:"user_id"      gorm:"not null"`
+	ISBN        string     `json:"isbn"         gorm:"type:varchar(20);not null"`
+	ReserveDate time.Time  `json:"reserve_date" gorm:"type:timestamp;default:CURRENT_TIMESTAMP"`
+	ExpireDate  *time.Time `json:"expire_date"  gorm:"type:timestamp"`
+	Status      string     `json:"status"       gorm:"type:enum('pending','ready','fulfilled','cancelled','expired');default:'pending'"`
+}
+
+func (Reservation) TableName() string {
+	return constants.ReservationTableName
+}
+
+type Session struct {
+	ID         int64      `json:"id"           gorm:"primaryKey;autoIncrement"`
+	UserID     int64      `json:"user_id"      gorm:"not null;index"`
+	Device     string     `json:"device"       gorm:"type:varchar(255);not null"`
+	IP         string     `json:"ip"           gorm:"column:ip;type:varchar(64);not null"`
+	CreatedAt  time.Time  `json:"created_at"   gorm:"type:timestamp;default:CURRENT_TIMESTAMP"`
+	LastUsedAt time.Time  `json:"last_used_at" gorm:"type:timestamp;default:CURRENT_TIMESTAMP"`
+	ExpiresAt  time.Time  `json:"expires_at"   gorm:"type:timestamp;not null"`
+	RevokedAt  *time.Time `json:"revoked_at"   gorm:"type:timestamp"`
+}
+
+func (Session) TableName() string {
+	return constants.SessionTableName
+}
+
+type RefreshToken struct {
+	JTI       string     `json:"jti"        gorm:"column:jti;type:varchar(64);primaryKey"`
+	SessionID int64      `json:"session_id" gorm:"not null;index"`
+	IssuedAt  time.Time  `json:"issued_at"  gorm:"type:timestamp;default:CURRENT_TIMESTAMP"`
+	UsedAt    *time.Time `json:"used_at"    gorm:"type:timestamp"`
+}
+
+func (RefreshToken) TableName() string {
+	return constants.RefreshTokenTableName
+}
+
+type LoginAttempt struct {
+	Key         string     `json:"key"          gorm:"column:attempt_key;type:varchar(128);primaryKey"`
+	Failures    int64      `json:"failures"     gorm:"type:int;default:0;not null"`
+	LastFailure *time.Time `json:"last_failure" gorm:"type:timestamp"`
+	LockedUntil *time.Time `json:"locked_until" gorm:"type:timestamp"`
+}
+
+func (LoginAttempt) TableName() string {
+	return constants.LoginAttemptTableName
+}
+
+type AuditLog struct {
+	ID        int64     `json:"id"         gorm:"primaryKey;autoIncrement"`
+	Action    string    `json:"action"     gorm:"type:varchar(50);not null;index"`
+	Subject   string    `json:"subject"    gorm:"type:varchar(128);not null"`
+	IP        string    `json:"ip"         gorm:"column:ip;type:varchar(64);not null"`
+	Detail    string    `json:"detail"     gorm:"type:text"`
+	CreatedAt time.Time `json:"created_at" gorm:"type:timestamp;default:CURRENT_TIMESTAMP;index"`
+}
+
+func (AuditLog) TableName() string {
+	return constants.AuditLogTableName
+}
+
+type PasswordResetCode struct {
+	ID        int64      `json:"id"         gorm:"primaryKey;autoIncrement"`
+	UserID    int64      `json:"user_id"    gorm:"not null;index"`
+	CodeHash  string     `json:"-"          gorm:"type:char(64);not null"`
+	Attempts  int64      `json:"attempts"   gorm:"type:int;default:0;not null"`
+	CreatedAt time.Time  `json:"created_at" gorm:"type:timestamp;default:CURRENT_TIMESTAMP"`
+	ExpiresAt time.Time  `json:"expires_at" gorm:"type:timestamp;not null"`
+	UsedAt    *time.Time `json:"used_at"    gorm:"type:timestamp"`
+}
+
+func (PasswordResetCode) TableName() string {
+	return constants.PasswordResetCodeTableName
+}
+
+type RecoveryCode struct {
+	ID       int64      `json:"id"        gorm:"primaryKey;autoIncrement"`
+	UserID   int64      `json:"user_id"   gorm:"not null;index"`
+	CodeHash string     `json:"-"         gorm:"type:char(64);not null"`
+	UsedAt   *time.Time `json:"used_at"   gorm:"type:timestamp"`
+}
+
+func (RecoveryCode) TableName() string {
+	return constants.RecoveryCodeTableName
+}
+
+type TwoFactorPolicy struct {
+	Role     string `json:"role"     gorm:"type:varchar(20);primaryKey"`
+	Required bool   `json:"required" gorm:"default:false;not null"`
+}
+
+func (TwoFactorPolicy) TableName() string {
+	return constants.TwoFactorPolicyTableName
+}
+
+type ExternalIdentity struct {
+	ID          int64      `json:"id"            gorm:"primaryKey;autoIncrement"`
+	UserID      int64      `json:"user_id"       gorm:"not null;index"`
+	Provider    string     `json:"provider"      gorm:"type:varchar(20);not null;uniqueIndex:idx_externalidentities_provider_subject"`
+	Subject     string     `json:"subject"       gorm:"type:varchar(255);not null;uniqueIndex:idx_externalidentities_provider_subject"`
+	Provisioned bool       `json:"provisioned"   gorm:"default:false;not null"`
+	CreatedAt   time.Time  `json:"created_at"    gorm:"type:timestamp;default:CURRENT_TIMESTAMP"`
+	LastLoginAt *time.Time `json:"last_login_at" gorm:"type:timestamp"`
+}
+
+func (ExternalIdentity) TableName() string {
+	return constants.ExternalIdentityTableName
+}
+
+type Contributor struct {
+	ID   int64  `json:"id"   gorm:"primaryKey;autoIncrement"`
+	Name string `json:"name" gorm:"type:varchar(100);not null;index:idx_contributors_name"`
+}
+
+func (Contributor) TableName() string {
+	return constants.ContributorTableName
+}
+
+type Subject struct {
+	ID   int64  `json:"id"   gorm:"primaryKey;autoIncrement"`
+	Name string `json:"name" gorm:"type:varchar(100);not null;index:idx_subjects_name"`
+}
+
+func (Subject) TableName() string {
+	return constants.SubjectTableName
+}
+
+type Series struct {
+	ID   int64  `json:"id"   gorm:"primaryKey;autoIncrement"`
+	Name string `json:"name" gorm:"type:varchar(100);not null;index:idx_series_name"`
+}
+
+func (Series) TableName() string {
+	return constants.SeriesTableName
+}
+
+type BookTypeContributor struct {
+	ISBN          string `json:"isbn"           gorm:"type:varchar(20);primaryKey"`
+	ContributorID int64  `json:"contributor_id" gorm:"primaryKey;index:idx_booktypecontributors_contributor"`
+	Role          string `json:"role"           gorm:"type:enum('author','editor','translator','illustrator');primaryKey"`
+	Position      int64  `json:"position"       gorm:"type:int;default:0;not null"`
+}
+
+func (BookTypeContributor) TableName() string {
+	return constants.BookTypeContributorTableName
+}
+
+type BookTypeSubject struct {
+	ISBN      string `json:"isbn"       gorm:"type:varchar(20);primaryKey"`
+	SubjectID int64  `json:"subject_id" gorm:"primaryKey;index:idx_booktypesubjects_subject"`
+}
+
+func (BookTypeSubject) TableName() string {
+	return constants.BookTypeSubjectTableName
+}
+
+type BookTypeSeries struct {
+	ISBN     string  `json:"isbn"      gorm:"type:varchar(20);primaryKey"`
+	SeriesID int64   `json:"series_id" gorm:"primaryKey;index:idx_booktypeseries_series"`
+	Volume   *string `json:"volume"    gorm:"type:varchar(20)"`
+}
+
+func (BookTypeSeries) TableName() string {
+	return constants.BookTypeSeriesTableName
+}
+
+// CatalogEntry 责任者、主题或丛书，三者都只有 ID 和名称，按 CatalogKind 区分所在的表
+type CatalogEntry struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+// BookTypeContributorDetail 书籍类型的一位责任者及其角色
+type BookTypeContributorDetail struct {
+	ISBN          string `json:"isbn"`
+	ContributorID int64  `json:"contributor_id"`
+	Name          string `json:"name"`
+	Role          string `json:"role"`
+}
+
+// BookTypeSeriesDetail 书籍类型所属的一套丛书及其卷次
+type BookTypeSeriesDetail struct {
+	ISBN     string  `json:"isbn"`
+	SeriesID int64   `json:"series_id"`
+	Name     string  `json:"name"`
+	Volume   *string `json:"volume"`
+}
+
+// CategoryNode 分类法（如中图法、杜威十进分类法）中的一个节点
+// Path 为从根节点到自身的 ID 路径，如 /1/5/，以前缀匹配查询一个节点及其全部下级节点。
+type CategoryNode struct {
+	ID       int64  `json:"id"        gorm:"primaryKey;autoIncrement"`
+	Code     string `json:"code"      gorm:"type:varchar(20);not null;unique"`
+	Name     string `json:"name"      gorm:"type:varchar(50);not null"`
+	ParentID *int64 `json:"parent_id" gorm:"index:idx_categories_parent_id"`
+	Path     string `json:"path"      gorm:"type:varchar(255);default:'';not null;index:idx_categories_path"`
+}
+
+func (CategoryNode) TableName() string {
+	return constants.CategoryTableName
+}
+
+// Attachment 书籍类型的封面或目录等附件，内容保存在附件存储中，表中只记录存储 key
+type Attachment struct {
+	ID           int64     `json:"id"            gorm:"primaryKey;autoIncrement"`
+	ISBN         string    `json:"isbn"          gorm:"type:varchar(20);not null;index:idx_attachments_isbn_kind"`
+	Kind         string    `json:"kind"          gorm:"type:enum('cover','document');not null;index:idx_attachments_isbn_kind"`
+	Filename     string    `json:"filename"      gorm:"type:varchar(255);not null"`
+	ContentType  string    `json:"content_type"  gorm:"type:varchar(100);not null"`
+	Size         int64     `json:"size"          gorm:"not null"`
+	StorageKey   string    `json:"-"             gorm:"type:varchar(255);not null"`
+	ThumbnailKey *string   `json:"-"             gorm:"type:varchar(255)"`
+	UploadedBy   *int64    `json:"uploaded_by"`
+	CreatedAt    time.Time `json:"created_at"    gorm:"type:timestamp;default:CURRENT_TIMESTAMP"`
+}
+
+func (Attachment) TableName() string {
+	return constants.AttachmentTableName
+}
@@ -0,0 +1,82 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"gorm.io/gorm"
+
+	"github.com/2451965602/LMS/pkg/errno"
+	"github.com/2451965602/LMS/pkg/pagination"
+)
+
+// paginate 按分页参数为查询添加游标条件、排序和数量限制
+// 1. 带游标时只查询游标指向的记录之后的数据（keyset），不使用 OFFSET，翻页的耗时与页数无关。
+// 2. 兼容按页码分页的请求，此时使用 OFFSET。
+// 3. 先按排序列、再按唯一键排序，排序值相同的记录也有确定的顺序。
+// 4. 多查询一条记录，用于判断是否还有下一页。
+func paginate(query *gorm.DB, page *pagination.Page) *gorm.DB {
+	op, dir := ">", "ASC"
+	if page.Desc {
+		op, dir = "<", "DESC"
+	}
+
+	if page.After != nil {
+		if page.Column == page.Key.Column {
+			query = query.Where(fmt.Sprintf("%s %s ?", page.Key.Column, op), page.After.Key)
+		} else {
+			query = query.Where(fmt.Sprintf("(%s %s ? OR (%s = ? AND %s %s ?))", page.Column, op, page.Column, page.Key.Column, op),
+				page.After.Value, page.After.Value, page.After.Key)
+		}
+	} else if page.Offset > 0 {
+		query = query.Offset(page.Offset)
+	}
+
+	order := page.Column + " " + dir
+	if page.Column != page.Key.Column {
+		order += ", " + page.Key.Column + " " + dir
+	}
+	return query.Order(order).Limit(page.Size + 1)
+}
+
+// countPage 在请求需要时查询符合条件的总数
+// 1. 不需要总数时返回 nil，省去大表上的 COUNT 查询。
+// 2. 否则按相同的过滤条件查询总数。
+func countPage(ctx context.Context, table string, filter func(*gorm.DB) *gorm.DB, page *pagination.Page) (*int64, error) {
+	if !page.WithTotal {
+		return nil, nil
+	}
+	var total int64
+	err := db.WithContext(ctx).Table(table).Scopes(filter).Count(&total).Error
+	if err != nil {
+		return nil, errno.Errorf(errno.InternalDatabaseErrorCode, "count %s failed: %v", table, err)
+	}
+	return &total, nil
+}
+
+// pageResult 整理一页的查询结果
+// 1. 查询结果超过每页数量时说明还有下一页，去掉多查询的一条记录。
+// 2. 还有下一页时按本页最后一条记录的排序值和唯一键生成游标。
+func pageResult[T any](page *pagination.Page, rows []T, total *int64) ([]T, *pagination.Result, error) {
+	result := &pagination.Result{Total: total}
+	if len(rows) <= page.Size {
+		return rows, result, nil
+	}
+	rows = rows[:page.Size]
+
+	last := reflect.ValueOf(&rows[len(rows)-1])
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(last.Interface()); err != nil {
+		return nil, nil, errno.Errorf(errno.InternalDatabaseErrorCode, "parse schema for cursor failed: %v", err)
+	}
+	column := stmt.Schema.LookUpField(page.Column)
+	key := stmt.Schema.LookUpField(page.Key.Column)
+	if column == nil || key == nil {
+		return nil, nil, errno.Errorf(errno.InternalServiceErrorCode, "cursor column %s or %s not found in %s", page.Column, page.Key.Column, stmt.Schema.Name)
+	}
+	value, _ := column.ValueOf(context.Background(), last.Elem())
+	keyValue, _ := key.ValueOf(context.Background(), last.Elem())
+	result.NextCursor = page.Cursor(value, keyValue)
+	return rows, result, nil
+}
@@ -0,0 +1,187 @@
+package db
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/2451965602/LMS/pkg/constants"
+	"github.com/2451965602/LMS/pkg/crypt"
+	"github.com/2451965602/LMS/pkg/errno"
+)
+
+// ChangePassword 校验当前密码后修改为新密码
+// 1. 根据用户 ID 查询用户信息，如果用户不存在，返回错误。
+// 2. 校验当前密码，不匹配时返回错误。
+// 3. 更新密码哈希，增加 Token 版本使之前签发的 Token 全部失效，并清除必须修改密码的标记。
+func ChangePassword(ctx context.Context, userId int64, oldPassword, newPassword string) error {
+	var u User
+	err := db.WithContext(ctx).
+		Table(User{}.TableName()).
+		Where("id = ?", userId).
+		First(&u).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errno.Errorf(errno.ServiceUserNotExist, "user (id: %d) not exist", userId)
+		}
+		return errno.Errorf(errno.InternalDatabaseErrorCode, "get user (id: %d) for password change failed: %v", userId, err)
+	}
+
+	if !crypt.PasswordVerify(oldPassword, u.Password) {
+		return errno.Errorf(errno.ServicePasswordMismatch, "current password is incorrect")
+	}
+	return setPassword(db.WithContext(ctx), userId, newPassword)
+}
+
+// CreatePasswordResetCode 为用户创建找回密码验证码
+// 1. 锁定用户记录，使同一用户的并发请求串行执行。
+// 2. 检查距上次发送是否超过 resendInterval，以及最近 constants.PasswordResetSendWindow 内的发送次数是否超过 maxDailySends。
+// 3. 作废该用户之前未使用的验证码，只保留最新的一个。
+// 4. 保存新验证码的摘要。
+func CreatePasswordResetCode(ctx context.Context, userId int64, codeHash string, ttl, resendInterval time.Duration, maxDailySends int64) error {
+	now := time.Now()
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Table(User{}.TableName()).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", userId).
+			Select("id").
+			First(&User{}).
+			Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errno.Errorf(errno.ServiceUserNotExist, "user (id: %d) not exist", userId)
+			}
+			return errno.Errorf(errno.InternalDatabaseErrorCode, "lock user (id: %d) failed: %v", userId, err)
+		}
+
+		var recent []PasswordResetCode
+		err = tx.Table(PasswordResetCode{}.TableName()).
+			Where("user_id = ? AND created_at > ?", userId, now.Add(-constants.PasswordResetSendWindow)).
+			Order("created_at DESC").
+			Find(&recent).
+			Error
+		if err != nil {
+			return errno.Errorf(errno.InternalDatabaseErrorCode, "get password reset codes (user id: %d) failed: %v", userId, err)
+		}
+		if len(recent) > 0 && now.Sub(recent[0].CreatedAt) < resendInterval {
+			return errno.Errorf(errno.ServiceResetCodeTooFrequent, "reset code requested too frequently, retry after %d seconds",
+				int64((resendInterval-now.Sub(recent[0].CreatedAt)).Seconds())+1)
+		}
+		if maxDailySends > 0 && int64(len(recent)) >= maxDailySends {
+			return errno.Errorf(errno.ServiceResetCodeTooFrequent, "too many reset codes requested in the last 24 hours")
+		}
+
+		err = tx.Table(PasswordResetCode{}.TableName()).
+			Where("user_id = ? AND used_at IS NULL", userId).
+			Update("used_at", now).
+			Error
+		if err != nil {
+			return errno.Errorf(errno.InternalDatabaseErrorCode, "invalidate password reset codes (user id: %d) failed: %v", userId, err)
+		}
+
+		err = tx.Table(PasswordResetCode{}.TableName()).
+			Create(&PasswordResetCode{UserID: userId, CodeHash: codeHash, CreatedAt: now, ExpiresAt: now.Add(ttl)}).
+			Error
+		if err != nil {
+			return errno.Errorf(errno.InternalDatabaseErrorCode, "create password reset code (user id: %d) failed: %v", userId, err)
+		}
+		return nil
+	})
+}
+
+// ResetPasswordWithCode 使用验证码重置密码
+// 1. 锁定用户最新的未使用且未过期的验证码，不存在时返回错误。
+// 2. 输错次数已达上限时返回错误，该验证码不再可用。
+// 3. 验证码不匹配时增加输错次数并返回错误。
+// 4. 验证码匹配时将其标记为已使用，并更新密码、增加 Token 版本、清除必须修改密码的标记。
+func ResetPasswordWithCode(ctx context.Context, userId int64, codeHash, newPassword string, maxAttempts int64) error {
+	var mismatch bool
+	now := time.Now()
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var code PasswordResetCode
+		err := tx.Table(PasswordResetCode{}.TableName()).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("user_id = ? AND used_at IS NULL AND expires_at > ?", userId, now).
+			Order("created_at DESC").
+			First(&code).
+			Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errno.Errorf(errno.ServiceResetCodeInvalid, "reset code invalid or expired")
+			}
+			return errno.Errorf(errno.InternalDatabaseErrorCode, "get password reset code (user id: %d) failed: %v", userId, err)
+		}
+		if maxAttempts > 0 && code.Attempts >= maxAttempts {
+			return errno.Errorf(errno.ServiceResetCodeInvalid, "reset code invalid or expired")
+		}
+
+		if subtle.ConstantTimeCompare([]byte(code.CodeHash), []byte(codeHash)) != 1 {
+			mismatch = true
+			err = tx.Table(PasswordResetCode{}.TableName()).
+				Where("id = ?", code.ID).
+				Update("attempts", gorm.Expr("attempts + 1")).
+				Error
+			if err != nil {
+				return errno.Errorf(errno.InternalDatabaseErrorCode, "update password reset code (id: %d) failed: %v", code.ID, err)
+			}
+			return nil
+		}
+
+		err = tx.Table(PasswordResetCode{}.TableName()).
+			Where("id = ?", code.ID).
+			Update("used_at", now).
+			Error
+		if err != nil {
+			return errno.Errorf(errno.InternalDatabaseErrorCode, "mark password reset code (id: %d) used failed: %v", code.ID, err)
+		}
+		return setPassword(tx, userId, newPassword)
+	})
+	if err != nil {
+		return err
+	}
+	if mismatch {
+		return errno.Errorf(errno.ServiceResetCodeInvalid, "reset code invalid or expired")
+	}
+	return nil
+}
+
+// DeleteExpiredPasswordResetCodes 删除创建时间早于 before 的验证码
+// 1. 保留最近的记录用于发送频率限制。
+// 2. 返回删除的记录数。
+func DeleteExpiredPasswordResetCodes(ctx context.Context, before time.Time) (int64, error) {
+	result := db.WithContext(ctx).
+		Where("created_at < ?", before).
+		Delete(&PasswordResetCode{})
+	if result.Error != nil {
+		return 0, errno.Errorf(errno.InternalDatabaseErrorCode, "delete expired password reset codes failed: %v", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// setPassword 更新用户密码
+// 1. 对新密码进行哈希处理。
+// 2. 更新密码哈希，增加 Token 版本使之前签发的 Token 全部失效，并清除必须修改密码的标记。
+func setPassword(tx *gorm.DB, userId int64, newPassword string) error {
+	hashedPassword, err := crypt.PasswordHash(newPassword)
+	if err != nil {
+		return errno.Errorf(errno.InternalPasswordCryptErrorCode, "encrypt password failed: %v", err)
+	}
+
+	err = tx.Table(User{}.TableName()).
+		Where("id = ?", userId).
+		Updates(map[string]interface{}{
+			"password":             hashedPassword,
+			"token_version":        gorm.Expr("token_version + 1"),
+			"must_change_password": false,
+		}).
+		Error
+	if err != nil {
+		return errno.Errorf(errno.InternalDatabaseErrorCode, "update password (user id: %d) failed: %v", userId, err)
+	}
+	return nil
+}
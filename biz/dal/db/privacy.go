@@ -0,0 +1,63 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/2451965602/LMS/pkg/errno"
+)
+
+// UpdateKeepHistory 更新用户是否保留借阅历史的设置
+// 1. 更新用户的 keep_history 字段。
+// 2. 如果用户不存在，返回错误。
+// 3. 返回更新后的用户信息。
+func UpdateKeepHistory(ctx context.Context, userId int64, keep bool) (*User, error) {
+	result := db.WithContext(ctx).
+		Table(User{}.TableName()).
+		Where("id = ?", userId).
+		Update("keep_history", keep)
+	if result.Error != nil {
+		return nil, errno.Errorf(errno.InternalDatabaseErrorCode, "update keep history (id: %d) failed: %v", userId, result.Error)
+	}
+
+	return GetUserById(ctx, userId)
+}
+
+// AnonymizeBorrowRecords 匿名化过期的借阅记录
+// 1. 选出归还日期早于 before、已归还且逾期费用已缴纳或减免（或无逾期费用）的借阅记录。
+// 2. 只处理未选择保留借阅历史的用户的记录。
+// 3. 将这些记录的 user_id 置空，保留书籍、日期等统计所需的字段。
+// 4. 返回被匿名化的记录数。
+func AnonymizeBorrowRecords(ctx context.Context, before time.Time) (int64, error) {
+	optedOut := db.WithContext(ctx).
+		Table(User{}.TableName()).
+		Select("id").
+		Where("keep_history = ?", false)
+
+	result := db.WithContext(ctx).
+		Table(BorrowRecord{}.TableName()).
+		Where("user_id IN (?)", optedOut).
+		Where("status = ? AND return_date < ?", "returned", before).
+		Where("(fee_paid = ? OR fee_waived = ? OR late_fee = 0)", true, true).
+		Update("user_id", nil)
+	if result.Error != nil {
+		return 0, errno.Errorf(errno.InternalDatabaseErrorCode, "anonymize borrow records failed: %v", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// GetAllBorrowRecords 获取用户的全部借阅记录
+// 按借出日期倒序返回，用于导出个人数据。
+func GetAllBorrowRecords(ctx context.Context, userId int64) ([]*BorrowRecord, error) {
+	var results []*BorrowRecord
+	err := db.WithContext(ctx).
+		Table(BorrowRecord{}.TableName()).
+		Where("user_id = ?", userId).
+		Order("checkout_date DESC").
+		Find(&results).
+		Error
+	if err != nil {
+		return nil, errno.Errorf(errno.InternalDatabaseErrorCode, "get all borrow records failed: %v", err)
+	}
+	return results, nil
+}
@@ -0,0 +1,43 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/2451965602/LMS/pkg/constants"
+)
+
+// TestAnonymizeSettledFines 逾期费用缴纳或减免后借阅记录可以匿名化，未结清的保留用户
+func TestAnonymizeSettledFines(t *testing.T) {
+	initTestDB(t)
+	ctx := context.Background()
+	bookIds := createTestCopies(t, "9780000000073", 3)
+	userId := createTestUser(t, "anonymize-fines")
+	paid := createTestFine(t, userId, bookIds[0], 1.5)
+	waived := createTestFine(t, userId, bookIds[1], 1)
+	unpaid := createTestFine(t, userId, bookIds[2], 0.5)
+
+	audit := &AuditLog{Action: constants.AuditActionFinePay, Subject: "anonymize-fines", IP: "127.0.0.1"}
+	if _, err := SettleFine(ctx, paid, false, audit); err != nil {
+		t.Fatalf("pay fine: %v", err)
+	}
+	audit = &AuditLog{Action: constants.AuditActionFineWaive, Subject: "anonymize-fines", IP: "127.0.0.1"}
+	if _, err := SettleFine(ctx, waived, true, audit); err != nil {
+		t.Fatalf("waive fine: %v", err)
+	}
+
+	if _, err := AnonymizeBorrowRecords(ctx, time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("AnonymizeBorrowRecords: %v", err)
+	}
+	for id, anonymized := range map[int64]bool{paid: true, waived: true, unpaid: false} {
+		var br BorrowRecord
+		if err := db.Table(BorrowRecord{}.TableName()).Where("id = ?", id).First(&br).Error; err != nil {
+			t.Fatal(err)
+		}
+		if got := br.UserID == nil; got != anonymized {
+			t.Errorf("borrow record %d (fee %.2f, paid %v, waived %v): anonymized = %v, want %v",
+				id, br.LateFee, br.FeePaid, br.FeeWaived, got, anonymized)
+		}
+	}
+}
@@ -0,0 +1,367 @@
+package db
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/2451965602/LMS/pkg/constants"
+	"github.com/2451965602/LMS/pkg/errno"
+	"github.com/2451965602/LMS/pkg/pagination"
+)
+
+// PeriodCount 按时间周期统计的数量
+type PeriodCount struct {
+	Period string `json:"period"`
+	Count  int64  `json:"count"`
+}
+
+// PeriodAmount 按时间周期统计的金额
+type PeriodAmount struct {
+	Period string  `json:"period"`
+	Amount float64 `json:"amount"`
+}
+
+// RankItem 借阅排行榜条目
+type RankItem struct {
+	Name  string `json:"name"`
+	ISBN  string `json:"isbn"`
+	Count int64  `json:"count"`
+}
+
+// ReportCategory 报表中一行统计所属的分类节点，未关联分类节点时 CategoryID 为空，Category 为空字符串
+type ReportCategory struct {
+	CategoryID *int64 `json:"category_id"`
+	Category   string `json:"category"`
+}
+
+// reportCategory 返回统计行所属的分类，用于 rollUpCategory
+func (r *ReportCategory) reportCategory() *ReportCategory {
+	return r
+}
+
+// CategoryTurnover 分类的副本数与借阅次数
+type CategoryTurnover struct {
+	ReportCategory
+	Copies int64 `json:"copies"`
+	Loans  int64 `json:"loans"`
+}
+
+// IdleCopy 自购入以来从未被借阅的副本
+type IdleCopy struct {
+	BookID       int64     `json:"book_id"`
+	ISBN         string    `json:"isbn"`
+	Title        string    `json:"title"`
+	CategoryID   *int64    `json:"category_id"`
+	Category     string    `json:"category"`
+	Location     string    `json:"location"`
+	PurchaseDate time.Time `json:"purchase_date"`
+}
+
+// RoleOverdue 按用户角色统计的借阅与逾期次数
+type RoleOverdue struct {
+	Role    string `json:"role"`
+	Loans   int64  `json:"loans"`
+	Overdue int64  `json:"overdue"`
+}
+
+// CategoryValue 分类的副本数与馆藏价值
+type CategoryValue struct {
+	ReportCategory
+	Copies int64   `json:"copies"`
+	Value  float64 `json:"value"`
+}
+
+// withTimeRange 为查询添加时间范围条件，start 或 end 为 nil 时不限制对应边界
+func withTimeRange(query *gorm.DB, column string, start, end *time.Time) *gorm.DB {
+	if start != nil {
+		query = query.Where(column+" >= ?", *start)
+	}
+	if end != nil {
+		query = query.Where(column+" < ?", *end)
+	}
+	return query
+}
+
+// CountLoansByPeriod 按时间周期统计借阅次数
+// 1. 按 dateFormat 对借出日期分组。
+// 2. 返回每个周期的借阅次数，按周期升序排列。
+func CountLoansByPeriod(ctx context.Context, dateFormat string, start, end *time.Time) ([]*PeriodCount, error) {
+	var results []*PeriodCount
+	query := db.WithContext(ctx).
+		Table(BorrowRecord{}.TableName()).
+		Select("DATE_FORMAT(checkout_date, ?) AS period, COUNT(*) AS count", dateFormat)
+	err := withTimeRange(query, "checkout_date", start, end).
+		Group("period").
+		Order("period ASC").
+		Scan(&results).
+		Error
+	if err != nil {
+		return nil, errno.Errorf(errno.InternalDatabaseErrorCode, "count loans by period failed: %v", err)
+	}
+	return results, nil
+}
+
+// TopBorrowedTitles 统计借阅次数最多的书名
+func TopBorrowedTitles(ctx context.Context, limit int, start, end *time.Time) ([]*RankItem, error) {
+	query := db.WithContext(ctx).
+		Table(BorrowRecord{}.TableName() + " AS br").
+		Select("bt.title AS name, bt.ISBN AS isbn, COUNT(*) AS count").
+		Joins("JOIN " + Book{}.TableName() + " AS b ON b.id = br.book_id").
+		Joins("JOIN " + BookType{}.TableName() + " AS bt ON bt.ISBN = b.ISBN")
+	return topBorrowed(query, "bt.ISBN, bt.title", limit, start, end)
+}
+
+// TopBorrowedAuthors 统计借阅次数最多的著者
+// 按责任者统计，同名的不同责任者分别计数；一本书有多位著者时，每次借阅计入每位著者。
+func TopBorrowedAuthors(ctx context.Context, limit int, start, end *time.Time) ([]*RankItem, error) {
+	query := db.WithContext(ctx).
+		Table(BorrowRecord{}.TableName()+" AS br").
+		Select("c.name AS name, '' AS isbn, COUNT(*) AS count").
+		Joins("JOIN "+Book{}.TableName()+" AS b ON b.id = br.book_id").
+		Joins("JOIN "+ContributorKind.LinkTable+" AS l ON l.ISBN = b.ISBN AND l.role = ?", constants.ContributorRoleAuthor).
+		Joins("JOIN " + ContributorKind.Table + " AS c ON c.id = l.contributor_id")
+	return topBorrowed(query, "c.id, c.name", limit, start, end)
+}
+
+// topBorrowed 按指定字段分组统计借阅次数，返回前 limit 条
+func topBorrowed(query *gorm.DB, group string, limit int, start, end *time.Time) ([]*RankItem, error) {
+	var results []*RankItem
+	err := withTimeRange(query, "br.checkout_date", start, end).
+		Group(group).
+		Order("count DESC").
+		Limit(limit).
+		Scan(&results).
+		Error
+	if err != nil {
+		return nil, errno.Errorf(errno.InternalDatabaseErrorCode, "top borrowed failed: %v", err)
+	}
+	return results, nil
+}
+
+// CategoryTurnoverStats 统计每个分类的副本数和借阅次数
+// 1. 以 Books 为主表联合 BookTypes 得到每个副本的分类节点，已剔除的副本不计入；指定 parent 时只统计其下的分类。
+// 2. 左联 BorrowRecords 统计时间范围内的借阅次数，没有借阅的副本也计入副本数。
+// 3. 按分类节点分组统计后，汇总到顶级类目或 parent 的直接下级分类，按分类名称排序。
+func CategoryTurnoverStats(ctx context.Context, parent *CategoryNode, start, end *time.Time) ([]*CategoryTurnover, error) {
+	var results []*CategoryTurnover
+
+	joinCond := "br.book_id = b.id"
+	var joinArgs []interface{}
+	if start != nil {
+		joinCond += " AND br.checkout_date >= ?"
+		joinArgs = append(joinArgs, *start)
+	}
+	if end != nil {
+		joinCond += " AND br.checkout_date < ?"
+		joinArgs = append(joinArgs, *end)
+	}
+
+	query := db.WithContext(ctx).
+		Table(Book{}.TableName()+" AS b").
+		Select("bt.category_id AS category_id, COUNT(DISTINCT b.id) AS copies, COUNT(br.id) AS loans").
+		Joins("JOIN "+BookType{}.TableName()+" AS bt ON bt.ISBN = b.ISBN").
+		Joins("LEFT JOIN "+BorrowRecord{}.TableName()+" AS br ON "+joinCond, joinArgs...).
+		Where("b.status <> ?", constants.BookStatusWithdrawn)
+	err := withCategory(query, parent).
+		Group("bt.category_id").
+		Scan(&results).
+		Error
+	if err != nil {
+		return nil, errno.Errorf(errno.InternalDatabaseErrorCode, "category turnover stats failed: %v", err)
+	}
+
+	results, err = rollUpCategory(ctx, parent, results, nil, func(dst, src *CategoryTurnover) {
+		dst.Copies += src.Copies
+		dst.Loans += src.Loans
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(results, func(i, j int) bool { return results[i].Category < results[j].Category })
+	return results, nil
+}
+
+// IdleCopyPageSpec 闲置副本列表的分页规则，默认按购入日期升序，即闲置最久的副本在前
+var IdleCopyPageSpec = pagination.Spec{
+	Fields: map[string]pagination.Field{
+		"book_id":       {Column: "book_id", Kind: pagination.KindInt},
+		"purchase_date": {Column: "purchase_date", Kind: pagination.KindTime},
+	},
+	DefaultSort: "purchase_date",
+	Key:         pagination.Field{Column: "book_id", Kind: pagination.KindInt},
+}
+
+// GetIdleCopies 获取自购入以来从未被借阅的副本
+// 1. 联合 BookTypes 获取书名和分类，已剔除的副本不列入。
+// 2. 排除购入日期之后存在借阅记录的副本。
+// 3. 可按分类节点（包括其全部下级分类）或分类名称过滤。
+// 4. 以上查询作为子查询，请求需要时查询总数，再按分页参数查询一页。
+func GetIdleCopies(ctx context.Context, node *CategoryNode, category *string, page *pagination.Page) ([]*IdleCopy, *pagination.Result, error) {
+	var results []IdleCopy
+	query := db.WithContext(ctx).
+		Table(Book{}.TableName()+" AS b").
+		Select("b.id AS book_id, b.ISBN AS isbn, bt.title, bt.category_id, bt.category, b.location, b.purchase_date").
+		Joins("JOIN "+BookType{}.TableName()+" AS bt ON bt.ISBN = b.ISBN").
+		Where("b.status <> ?", constants.BookStatusWithdrawn).
+		Where("NOT EXISTS (SELECT 1 FROM " + BorrowRecord{}.TableName() + " AS br WHERE br.book_id = b.id AND br.checkout_date >= b.purchase_date)")
+	query = withCategory(query, node)
+	if category != nil && *category != "" {
+		query = query.Where("bt.category = ?", *category)
+	}
+
+	// 排序列和唯一键是子查询中的别名，在外层查询中分页
+	var total *int64
+	if page.WithTotal {
+		var count int64
+		if err := db.WithContext(ctx).Table("(?) AS idle", query).Count(&count).Error; err != nil {
+			return nil, nil, errno.Errorf(errno.InternalDatabaseErrorCode, "count idle copies failed: %v", err)
+		}
+		total = &count
+	}
+
+	err := paginate(db.WithContext(ctx).Table("(?) AS idle", query), page).
+		Find(&results).
+		Error
+	if err != nil {
+		return nil, nil, errno.Errorf(errno.InternalDatabaseErrorCode, "get idle copies failed: %v", err)
+	}
+
+	results, result, err := pageResult(page, results, total)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	copies := make([]*IdleCopy, 0, len(results))
+	for i := range results {
+		copies = append(copies, &results[i])
+	}
+	return copies, result, nil
+}
+
+// OverdueStatsByRole 按用户角色统计借阅次数和逾期次数
+// 逾期包括：状态为 "overdue"、归还日期晚于到期日期、以及仍未归还但已过到期日期的记录。
+// 已匿名化的借阅记录无法关联用户，不计入统计。
+func OverdueStatsByRole(ctx context.Context, start, end *time.Time) ([]*RoleOverdue, error) {
+	var results []*RoleOverdue
+	query := db.WithContext(ctx).
+		Table(BorrowRecord{}.TableName()+" AS br").
+		Select("u.permission AS role, COUNT(*) AS loans, "+
+			"SUM(CASE WHEN br.status = 'overdue' OR br.return_date > br.due_date "+
+			"OR (br.status = 'checked_out' AND br.due_date < ?) THEN 1 ELSE 0 END) AS overdue", time.Now()).
+		Joins("JOIN " + User{}.TableName() + " AS u ON u.id = br.user_id")
+	err := withTimeRange(query, "br.checkout_date", start, end).
+		Group("u.permission").
+		Order("u.permission ASC").
+		Scan(&results).
+		Error
+	if err != nil {
+		return nil, errno.Errorf(errno.InternalDatabaseErrorCode, "overdue stats by role failed: %v", err)
+	}
+	return results, nil
+}
+
+// SumFineRevenueByPeriod 按缴纳时间的周期统计读者已缴纳的逾期费用，减免的费用不计入
+func SumFineRevenueByPeriod(ctx context.Context, dateFormat string, start, end *time.Time) ([]*PeriodAmount, error) {
+	var results []*PeriodAmount
+	query := db.WithContext(ctx).
+		Table(BorrowRecord{}.TableName()).
+		Select("DATE_FORMAT(fee_settled_at, ?) AS period, SUM(late_fee) AS amount", dateFormat).
+		Where("fee_paid = ? AND late_fee > 0 AND fee_settled_at IS NOT NULL", true)
+	err := withTimeRange(query, "fee_settled_at", start, end).
+		Group("period").
+		Order("period ASC").
+		Scan(&results).
+		Error
+	if err != nil {
+		return nil, errno.Errorf(errno.InternalDatabaseErrorCode, "sum fine revenue failed: %v", err)
+	}
+	return results, nil
+}
+
+// CollectionValueByCategory 按分类统计副本数与购入价格总和，已剔除的副本不计入
+// 按分类节点分组统计后，汇总到顶级类目或 parent 的直接下级分类，按馆藏价值倒序排列。
+func CollectionValueByCategory(ctx context.Context, parent *CategoryNode) ([]*CategoryValue, error) {
+	var results []*CategoryValue
+	query := db.WithContext(ctx).
+		Table(Book{}.TableName()+" AS b").
+		Select("bt.category_id AS category_id, COUNT(*) AS copies, COALESCE(SUM(b.purchase_price), 0) AS value").
+		Joins("JOIN "+BookType{}.TableName()+" AS bt ON bt.ISBN = b.ISBN").
+		Where("b.status <> ?", constants.BookStatusWithdrawn)
+	err := withCategory(query, parent).
+		Group("bt.category_id").
+		Scan(&results).
+		Error
+	if err != nil {
+		return nil, errno.Errorf(errno.InternalDatabaseErrorCode, "collection value by category failed: %v", err)
+	}
+
+	results, err = rollUpCategory(ctx, parent, results, nil, func(dst, src *CategoryValue) {
+		dst.Copies += src.Copies
+		dst.Value += src.Value
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(results, func(i, j int) bool { return results[i].Value > results[j].Value })
+	return results, nil
+}
+
+// withCategory 指定分类节点时只统计该节点及其全部下级分类的书籍类型
+func withCategory(query *gorm.DB, node *CategoryNode) *gorm.DB {
+	if node == nil {
+		return query
+	}
+	return query.Where("bt.category_id IN (?)", categorySubtree(node))
+}
+
+// rollUpCategory 把按分类节点统计的行汇总到顶级类目或 parent 的直接下级分类
+// 1. 按 categoryGroups 求出每行的分类节点汇总到的分类，未关联分类节点的行汇总为一行未分类。
+// 2. 汇总到的分类相同、且 key 相同的行由 merge 累加到第一行，key 为 nil 时只按分类汇总。
+// 3. 返回的行按第一次出现的顺序排列，分类 ID 和名称为汇总到的分类。
+func rollUpCategory[T interface{ reportCategory() *ReportCategory }](ctx context.Context, parent *CategoryNode, rows []T, key func(T) string, merge func(dst, src T)) ([]T, error) {
+	ids := make([]int64, 0, len(rows))
+	for _, r := range rows {
+		if id := r.reportCategory().CategoryID; id != nil {
+			ids = append(ids, *id)
+		}
+	}
+	groups, err := categoryGroups(ctx, parent, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	type groupKey struct {
+		categoryID int64 // 未分类时为 0
+		key        string
+	}
+	merged := make(map[groupKey]T, len(rows))
+	results := make([]T, 0, len(rows))
+	for _, r := range rows {
+		ref := r.reportCategory()
+		var group *CategoryNode
+		if ref.CategoryID != nil {
+			group = groups[*ref.CategoryID]
+		}
+		k := groupKey{}
+		if group != nil {
+			k.categoryID = group.ID
+		}
+		if key != nil {
+			k.key = key(r)
+		}
+		if dst, ok := merged[k]; ok {
+			merge(dst, r)
+			continue
+		}
+		ref.CategoryID, ref.Category = nil, ""
+		if group != nil {
+			ref.CategoryID, ref.Category = &group.ID, group.Name
+		}
+		merged[k] = r
+		results = append(results, r)
+	}
+	return results, nil
+}
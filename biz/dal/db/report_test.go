@@ -0,0 +1,191 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/2451965602/LMS/pkg/constants"
+	"github.com/2451965602/LMS/pkg/errno"
+	"github.com/2451965602/LMS/pkg/pagination"
+)
+
+// createTestFine 创建一条已归还且有逾期费用的借阅记录，返回借阅记录ID
+func createTestFine(t *testing.T, userId, bookId int64, fee float64) int64 {
+	t.Helper()
+	now := time.Now()
+	br := BorrowRecord{UserID: &userId, BookID: bookId, Title: "Fine", CheckoutDate: now.AddDate(0, 0, -40),
+		DueDate: now.AddDate(0, 0, -10), ReturnDate: &now, Status: "returned", LateFee: fee}
+	if err := db.Table(BorrowRecord{}.TableName()).Create(&br).Error; err != nil {
+		t.Fatalf("create borrow record with late fee: %v", err)
+	}
+	return br.ID
+}
+
+// fineRevenue 返回报表中全部周期的罚款收入之和
+func fineRevenue(t *testing.T) float64 {
+	t.Helper()
+	periods, err := SumFineRevenueByPeriod(context.Background(), constants.ReportPeriodFormats[constants.ReportPeriodDay], nil, nil)
+	if err != nil {
+		t.Fatalf("SumFineRevenueByPeriod: %v", err)
+	}
+	var sum float64
+	for _, p := range periods {
+		sum += p.Amount
+	}
+	return sum
+}
+
+// TestSettleFineRevenue 缴纳的逾期费用计入罚款收入，减免的不计入，已结清的费用不能再次结清
+func TestSettleFineRevenue(t *testing.T) {
+	initTestDB(t)
+	ctx := context.Background()
+	bookIds := createTestCopies(t, "9780000000059", 2)
+	userId := createTestUser(t, "fine-settle")
+	paid := createTestFine(t, userId, bookIds[0], 3.5)
+	waived := createTestFine(t, userId, bookIds[1], 2)
+
+	before := fineRevenue(t)
+	record, err := SettleFine(ctx, paid, false, &AuditLog{Action: constants.AuditActionFinePay, Subject: "fine-settle", IP: "127.0.0.1"})
+	if err != nil {
+		t.Fatalf("pay fine: %v", err)
+	}
+	if !record.FeePaid || record.FeeWaived || record.FeeSettledAt == nil {
+		t.Errorf("paid record: fee_paid = %v, fee_waived = %v, fee_settled_at = %v", record.FeePaid, record.FeeWaived, record.FeeSettledAt)
+	}
+	record, err = SettleFine(ctx, waived, true, &AuditLog{Action: constants.AuditActionFineWaive, Subject: "fine-settle", IP: "127.0.0.1"})
+	if err != nil {
+		t.Fatalf("waive fine: %v", err)
+	}
+	if record.FeePaid || !record.FeeWaived || record.FeeSettledAt == nil {
+		t.Errorf("waived record: fee_paid = %v, fee_waived = %v, fee_settled_at = %v", record.FeePaid, record.FeeWaived, record.FeeSettledAt)
+	}
+
+	if got := fineRevenue(t) - before; got != 3.5 {
+		t.Errorf("fine revenue grew by %v after paying 3.5 and waiving 2, want 3.5", got)
+	}
+
+	for _, id := range []int64{paid, waived} {
+		_, err = SettleFine(ctx, id, false, &AuditLog{Action: constants.AuditActionFinePay, Subject: "fine-settle", IP: "127.0.0.1"})
+		if err == nil || errno.ConvertErr(err).ErrorCode != errno.ServiceActionNotAllowed {
+			t.Errorf("settle borrow record %d again: err = %v, want ServiceActionNotAllowed", id, err)
+		}
+	}
+	var logs int64
+	err = db.Table(AuditLog{}.TableName()).
+		Where("subject = ? AND action IN ?", "fine-settle", []string{constants.AuditActionFinePay, constants.AuditActionFineWaive}).
+		Count(&logs).
+		Error
+	if err != nil {
+		t.Fatal(err)
+	}
+	if logs != 2 {
+		t.Errorf("%d fine audit logs, want 2", logs)
+	}
+}
+
+// TestGetIdleCopiesPages 按游标翻页可以取到全部闲置副本，且不重复
+func TestGetIdleCopiesPages(t *testing.T) {
+	initTestDB(t)
+	ctx := context.Background()
+	createTestCopies(t, "9780000000066", 5)
+
+	size, withTotal := int64(2), true
+	var (
+		seen   = make(map[int64]bool)
+		cursor *string
+		total  int64
+	)
+	for pages := 0; ; pages++ {
+		if pages > 100 {
+			t.Fatal("too many pages")
+		}
+		page, err := pagination.Parse(IdleCopyPageSpec, pagination.Request{Cursor: cursor, PageSize: &size, WithTotal: &withTotal})
+		if err != nil {
+			t.Fatal(err)
+		}
+		copies, result, err := GetIdleCopies(ctx, nil, nil, page)
+		if err != nil {
+			t.Fatalf("GetIdleCopies: %v", err)
+		}
+		if int64(len(copies)) > size {
+			t.Fatalf("page has %d copies, want at most %d", len(copies), size)
+		}
+		total = *result.Total
+		for _, c := range copies {
+			if seen[c.BookID] {
+				t.Errorf("book %d returned on more than one page", c.BookID)
+			}
+			seen[c.BookID] = true
+		}
+		if result.NextCursor == "" {
+			break
+		}
+		cursor = &result.NextCursor
+	}
+	if int64(len(seen)) != total || total < 5 {
+		t.Errorf("paged through %d idle copies, total = %d, want equal and at least 5", len(seen), total)
+	}
+}
+
+// TestCategoryRollup 分类统计按分类节点汇总到顶级类目，指定上级分类时汇总到其直接下级分类，直接关联上级分类的计入其自身
+func TestCategoryRollup(t *testing.T) {
+	initTestDB(t)
+	ctx := context.Background()
+	root, err := AddCategory(ctx, "T-ROLLUP", "Rollup", nil)
+	if err != nil {
+		t.Fatalf("add root category: %v", err)
+	}
+	child, err := AddCategory(ctx, "T-ROLLUP.1", "Rollup child", &root.ID)
+	if err != nil {
+		t.Fatalf("add child category: %v", err)
+	}
+	leaf, err := AddCategory(ctx, "T-ROLLUP.1.1", "Rollup leaf", &child.ID)
+	if err != nil {
+		t.Fatalf("add leaf category: %v", err)
+	}
+	for isbn, node := range map[string]*CategoryNode{"9780000000110": leaf, "9780000000127": root} {
+		n := 2
+		if node == root {
+			n = 1
+		}
+		createTestCopies(t, isbn, n)
+		err = db.Table(BookType{}.TableName()).Where("ISBN = ?", isbn).Update("category_id", node.ID).Error
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	copiesOf := func(rows []*CategoryTurnover) map[int64]int64 {
+		copies := make(map[int64]int64)
+		for _, r := range rows {
+			if r.CategoryID != nil {
+				copies[*r.CategoryID] = r.Copies
+			}
+		}
+		return copies
+	}
+	rows, err := CategoryTurnoverStats(ctx, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("CategoryTurnoverStats: %v", err)
+	}
+	if got := copiesOf(rows); got[root.ID] != 3 || got[child.ID] != 0 || got[leaf.ID] != 0 {
+		t.Errorf("top-level copies = %v, want all 3 copies under root %d", got, root.ID)
+	}
+
+	rows, err = CategoryTurnoverStats(ctx, root, nil, nil)
+	if err != nil {
+		t.Fatalf("CategoryTurnoverStats under root: %v", err)
+	}
+	if got := copiesOf(rows); len(got) != 2 || got[child.ID] != 2 || got[root.ID] != 1 {
+		t.Errorf("copies under root = %v, want child %d: 2 and root %d: 1", got, child.ID, root.ID)
+	}
+
+	values, err := CollectionValueByCategory(ctx, root)
+	if err != nil {
+		t.Fatalf("CollectionValueByCategory: %v", err)
+	}
+	if len(values) != 2 || values[0].CategoryID == nil || *values[0].CategoryID != child.ID || values[0].Value != 20 {
+		t.Errorf("values under root = %+v, want child %d with value 20 first", values, child.ID)
+	}
+}
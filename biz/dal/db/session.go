@@ -0,0 +1,256 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/2451965602/LMS/pkg/constants"
+	"github.com/2451965602/LMS/pkg/errno"
+)
+
+// GetTokenVersion 获取用户当前的 Token 版本
+// 1. 根据用户 ID 查询 token_version 字段。
+// 2. 如果用户不存在，返回错误。
+func GetTokenVersion(ctx context.Context, userId int64) (int64, error) {
+	var u User
+	err := db.WithContext(ctx).
+		Table(User{}.TableName()).
+		Where("id = ?", userId).
+		Select("token_version").
+		First(&u).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, errno.Errorf(errno.ServiceUserNotExist, "user (id: %d) not exist", userId)
+		}
+		return 0, errno.Errorf(errno.InternalDatabaseErrorCode, "get token version (id: %d) failed: %v", userId, err)
+	}
+	return u.TokenVersion, nil
+}
+
+// SessionAuthState 校验 Access Token 时需要的会话和用户状态
+type SessionAuthState struct {
+	TokenVersion           int64 // 用户当前的 Token 版本
+	MustChangePassword     bool  // 用户是否必须先修改密码
+	TwoFactorSetupRequired bool  // 用户所属角色要求两步验证但用户尚未启用
+}
+
+// GetSessionAuthState 获取有效会话所属用户当前的鉴权状态
+// 1. 会话必须属于该用户、未被吊销且未过期。
+// 2. 关联两步验证角色策略，判断用户是否还需要先启用两步验证。
+// 3. 如果会话无效或用户不存在，返回错误。
+func GetSessionAuthState(ctx context.Context, sessionId, userId int64) (*SessionAuthState, error) {
+	var state SessionAuthState
+	err := db.WithContext(ctx).
+		Table(Session{}.TableName()+" AS s").
+		Joins("JOIN "+User{}.TableName()+" AS u ON u.id = s.user_id").
+		Joins("LEFT JOIN "+TwoFactorPolicy{}.TableName()+" AS p ON p.role = u.permission").
+		Where("s.id = ? AND s.user_id = ? AND s.revoked_at IS NULL AND s.expires_at > ?", sessionId, userId, time.Now()).
+		Select("u.token_version, u.must_change_password, COALESCE(p.required, FALSE) AND NOT u.totp_enabled").
+		Row().
+		Scan(&state.TokenVersion, &state.MustChangePassword, &state.TwoFactorSetupRequired)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errno.Errorf(errno.AuthInvalidCode, "session (id: %d) not active", sessionId)
+		}
+		return nil, errno.Errorf(errno.InternalDatabaseErrorCode, "get session auth state (id: %d) failed: %v", sessionId, err)
+	}
+	return &state, nil
+}
+
+// IncreaseTokenVersion 将用户的 Token 版本加一，使该用户之前签发的所有 Token 失效
+// 1. 更新用户的 token_version 字段。
+// 2. 如果用户不存在，返回错误。
+func IncreaseTokenVersion(ctx context.Context, userId int64) error {
+	result := db.WithContext(ctx).
+		Table(User{}.TableName()).
+		Where("id = ?", userId).
+		Update("token_version", gorm.Expr("token_version + 1"))
+	if result.Error != nil {
+		return errno.Errorf(errno.InternalDatabaseErrorCode, "increase token version (id: %d) failed: %v", userId, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errno.Errorf(errno.ServiceUserNotExist, "user (id: %d) not exist", userId)
+	}
+	return nil
+}
+
+// CreateSession 创建登录会话并记录第一个 Refresh Token
+// 1. 创建会话，过期时间为当前时间加上 Refresh Token 的有效期。
+// 2. 记录该会话签发的 Refresh Token 的 jti。
+// 3. 返回创建的会话。
+func CreateSession(ctx context.Context, userId int64, device, ip, jti string) (*Session, error) {
+	now := time.Now()
+	s := Session{
+		UserID:     userId,
+		Device:     device,
+		IP:         ip,
+		CreatedAt:  now,
+		LastUsedAt: now,
+		ExpiresAt:  now.Add(constants.RefreshTokenTTL),
+	}
+
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Table(Session{}.TableName()).Create(&s).Error; err != nil {
+			return err
+		}
+		return tx.Table(RefreshToken{}.TableName()).
+			Create(&RefreshToken{JTI: jti, SessionID: s.ID, IssuedAt: now}).
+			Error
+	})
+	if err != nil {
+		return nil, errno.Errorf(errno.InternalDatabaseErrorCode, "create session (user id: %d) failed: %v", userId, err)
+	}
+	return &s, nil
+}
+
+// RotateRefreshToken 使用 Refresh Token 换取新的 Refresh Token
+// 1. 锁定 jti 对应的 Refresh Token 记录，记录不存在或不属于该会话时返回错误。
+// 2. 如果该 Refresh Token 已被使用过，说明可能已泄露，吊销整个会话并返回错误。
+// 3. 检查会话未被吊销且未过期。
+// 4. 将旧的 Refresh Token 标记为已使用，记录新的 jti，并更新会话的最后使用时间、IP 和过期时间。
+func RotateRefreshToken(ctx context.Context, sessionId int64, oldJTI, newJTI, ip string) (*Session, error) {
+	var (
+		s      Session
+		reused bool
+	)
+	now := time.Now()
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var t RefreshToken
+		err := tx.Table(RefreshToken{}.TableName()).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("jti = ? AND session_id = ?", oldJTI, sessionId).
+			First(&t).
+			Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errno.Errorf(errno.AuthRefreshExpiredCode, "refresh token not issued for session (id: %d)", sessionId)
+			}
+			return errno.Errorf(errno.InternalDatabaseErrorCode, "get refresh token failed: %v", err)
+		}
+
+		if t.UsedAt != nil {
+			reused = true
+			err = tx.Table(Session{}.TableName()).
+				Where("id = ? AND revoked_at IS NULL", sessionId).
+				Update("revoked_at", now).
+				Error
+			if err != nil {
+				return errno.Errorf(errno.InternalDatabaseErrorCode, "revoke session (id: %d) failed: %v", sessionId, err)
+			}
+			return nil
+		}
+
+		err = tx.Table(Session{}.TableName()).
+			Where("id = ? AND revoked_at IS NULL AND expires_at > ?", sessionId, now).
+			First(&s).
+			Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errno.Errorf(errno.AuthRefreshExpiredCode, "session (id: %d) not active", sessionId)
+			}
+			return errno.Errorf(errno.InternalDatabaseErrorCode, "get session (id: %d) failed: %v", sessionId, err)
+		}
+
+		err = tx.Table(RefreshToken{}.TableName()).
+			Where("jti = ?", oldJTI).
+			Update("used_at", now).
+			Error
+		if err != nil {
+			return errno.Errorf(errno.InternalDatabaseErrorCode, "mark refresh token used failed: %v", err)
+		}
+		err = tx.Table(RefreshToken{}.TableName()).
+			Create(&RefreshToken{JTI: newJTI, SessionID: sessionId, IssuedAt: now}).
+			Error
+		if err != nil {
+			return errno.Errorf(errno.InternalDatabaseErrorCode, "create refresh token failed: %v", err)
+		}
+
+		s.LastUsedAt = now
+		s.IP = ip
+		s.ExpiresAt = now.Add(constants.RefreshTokenTTL)
+		err = tx.Table(Session{}.TableName()).
+			Where("id = ?", sessionId).
+			Updates(map[string]interface{}{
+				"last_used_at": s.LastUsedAt,
+				"ip":           s.IP,
+				"expires_at":   s.ExpiresAt,
+			}).
+			Error
+		if err != nil {
+			return errno.Errorf(errno.InternalDatabaseErrorCode, "update session (id: %d) failed: %v", sessionId, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if reused {
+		return nil, errno.Errorf(errno.AuthRefreshReusedCode, "refresh token reused, session (id: %d) revoked", sessionId)
+	}
+	return &s, nil
+}
+
+// GetActiveSessions 获取用户所有未吊销且未过期的会话
+// 1. 按最后使用时间倒序返回。
+func GetActiveSessions(ctx context.Context, userId int64) ([]*Session, error) {
+	var sessions []*Session
+	err := db.WithContext(ctx).
+		Table(Session{}.TableName()).
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userId, time.Now()).
+		Order("last_used_at DESC").
+		Find(&sessions).
+		Error
+	if err != nil {
+		return nil, errno.Errorf(errno.InternalDatabaseErrorCode, "get sessions (user id: %d) failed: %v", userId, err)
+	}
+	return sessions, nil
+}
+
+// RevokeSession 吊销用户的会话
+// 1. 只吊销属于该用户且尚未吊销的会话。
+// 2. 如果会话不存在，返回错误。
+func RevokeSession(ctx context.Context, userId, sessionId int64) error {
+	result := db.WithContext(ctx).
+		Table(Session{}.TableName()).
+		Where("id = ? AND user_id = ? AND revoked_at IS NULL", sessionId, userId).
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return errno.Errorf(errno.InternalDatabaseErrorCode, "revoke session (id: %d) failed: %v", sessionId, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errno.Errorf(errno.ServiceSessionNotExist, "session (id: %d) not exist", sessionId)
+	}
+	return nil
+}
+
+// DeleteExpiredSessions 删除已过期的会话及其 Refresh Token 记录
+// 1. 已吊销的会话保留到过期时间，便于用户查看和排查。
+// 2. 返回删除的会话数。
+func DeleteExpiredSessions(ctx context.Context, now time.Time) (int64, error) {
+	var count int64
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		expired := tx.Table(Session{}.TableName()).
+			Select("id").
+			Where("expires_at < ?", now)
+		err := tx.Where("session_id IN (?)", expired).
+			Delete(&RefreshToken{}).
+			Error
+		if err != nil {
+			return err
+		}
+		result := tx.Where("expires_at < ?", now).
+			Delete(&Session{})
+		count = result.RowsAffected
+		return result.Error
+	})
+	if err != nil {
+		return 0, errno.Errorf(errno.InternalDatabaseErrorCode, "delete expired sessions failed: %v", err)
+	}
+	return count, nil
+}
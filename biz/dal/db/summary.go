@@ -0,0 +1,162 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/2451965602/LMS/pkg/errno"
+)
+
+// BorrowDetail 借阅记录与图书副本、图书类型的联合查询结果
+type BorrowDetail struct {
+	ID           int64      `json:"id"`
+	BookID       int64      `json:"book_id"`
+	ISBN         string     `json:"isbn"`
+	Title        string     `json:"title"`
+	Author       string     `json:"author"`
+	Location     string     `json:"location"`
+	CheckoutDate time.Time  `json:"checkout_date"`
+	DueDate      time.Time  `json:"due_date"`
+	ReturnDate   *time.Time `json:"return_date"`
+	RenewalCount int64      `json:"renewal_count"`
+	Status       string     `json:"status"`
+	LateFee      float64    `json:"late_fee"`
+}
+
+// HoldDetail 预约记录与图书类型的联合查询结果
+type HoldDetail struct {
+	ID          int64      `json:"id"`
+	ISBN        string     `json:"isbn"`
+	Title       string     `json:"title"`
+	Author      string     `json:"author"`
+	Status      string     `json:"status"`
+	ReserveDate time.Time  `json:"reserve_date"`
+	ExpireDate  *time.Time `json:"expire_date"`
+}
+
+// borrowDetailColumns 联合查询借阅详情时选择的字段
+const borrowDetailColumns = "br.id, br.book_id, b.ISBN AS isbn, COALESCE(bt.title, br.title) AS title, " +
+	"COALESCE(bt.author, '') AS author, b.location, br.checkout_date, br.due_date, br.return_date, " +
+	"br.renewal_count, br.status, br.late_fee"
+
+// GetCurrentLoans 获取用户当前未归还的借阅
+// 1. 联合 Books 和 BookTypes 查询状态为 "checked_out" 或 "overdue" 的借阅记录。
+// 2. 按到期日期升序返回，最先到期的排在前面。
+func GetCurrentLoans(ctx context.Context, userId int64) ([]*BorrowDetail, error) {
+	var results []*BorrowDetail
+	err := db.WithContext(ctx).
+		Table(BorrowRecord{}.TableName()+" AS br").
+		Select(borrowDetailColumns).
+		Joins("JOIN "+Book{}.TableName()+" AS b ON b.id = br.book_id").
+		Joins("LEFT JOIN "+BookType{}.TableName()+" AS bt ON bt.ISBN = b.ISBN").
+		Where("br.user_id = ? AND br.status IN ?", userId, []string{"checked_out", "overdue"}).
+		Order("br.due_date ASC").
+		Scan(&results).
+		Error
+	if err != nil {
+		return nil, errno.Errorf(errno.InternalDatabaseErrorCode, "get current loans failed: %v", err)
+	}
+	return results, nil
+}
+
+// CountOverdueLoans 统计用户已逾期的借阅数量
+// 状态为 "overdue" 或状态仍为 "checked_out" 但已过到期日期的记录都计为逾期。
+func CountOverdueLoans(ctx context.Context, userId int64) (int64, error) {
+	var count int64
+	err := db.WithContext(ctx).
+		Table(BorrowRecord{}.TableName()).
+		Where("user_id = ?", userId).
+		Where("status = ? OR (status = ? AND due_date < ?)", "overdue", "checked_out", time.Now()).
+		Count(&count).
+		Error
+	if err != nil {
+		return 0, errno.Errorf(errno.InternalDatabaseErrorCode, "count overdue loans failed: %v", err)
+	}
+	return count, nil
+}
+
+// GetFineBalance 获取用户尚未缴清的逾期费用总额，已缴纳或已减免的费用不计入
+func GetFineBalance(ctx context.Context, userId int64) (float64, error) {
+	var balance float64
+	err := db.WithContext(ctx).
+		Table(BorrowRecord{}.TableName()).
+		Select("COALESCE(SUM(late_fee), 0)").
+		Where("user_id = ? AND late_fee > 0 AND fee_paid = ? AND fee_waived = ?", userId, false, false).
+		Scan(&balance).
+		Error
+	if err != nil {
+		return 0, errno.Errorf(errno.InternalDatabaseErrorCode, "get fine balance failed: %v", err)
+	}
+	return balance, nil
+}
+
+// GetActiveHolds 获取用户仍然有效的预约
+// 状态为 "pending" 或 "ready" 的预约视为有效，按预约时间升序返回。
+func GetActiveHolds(ctx context.Context, userId int64) ([]*HoldDetail, error) {
+	return getHolds(ctx, userId, []string{"pending", "ready"})
+}
+
+// GetAllHolds 获取用户的全部预约，用于导出个人数据
+func GetAllHolds(ctx context.Context, userId int64) ([]*HoldDetail, error) {
+	return getHolds(ctx, userId, nil)
+}
+
+// getHolds 联合 BookTypes 查询用户的预约，statuses 为空时不按状态过滤
+func getHolds(ctx context.Context, userId int64, statuses []string) ([]*HoldDetail, error) {
+	var results []*HoldDetail
+	query := db.WithContext(ctx).
+		Table(Reservation{}.TableName()+" AS r").
+		Select("r.id, r.ISBN AS isbn, COALESCE(bt.title, '') AS title, COALESCE(bt.author, '') AS author, "+
+			"r.status, r.reserve_date, r.expire_date").
+		Joins("LEFT JOIN "+BookType{}.TableName()+" AS bt ON bt.ISBN = r.ISBN").
+		Where("r.user_id = ?", userId)
+	if len(statuses) > 0 {
+		query = query.Where("r.status IN ?", statuses)
+	}
+
+	err := query.Order("r.reserve_date ASC").
+		Scan(&results).
+		Error
+	if err != nil {
+		return nil, errno.Errorf(errno.InternalDatabaseErrorCode, "get holds failed: %v", err)
+	}
+	return results, nil
+}
+
+// GetReadingHistory 分页获取用户已结束的借阅记录
+// 1. 查询状态为 "returned" 或 "lost" 的借阅记录总数。
+// 2. 联合 Books 和 BookTypes 按借出日期倒序，跳过 offset 条后查询 size 条。
+func GetReadingHistory(ctx context.Context, userId int64, offset, size int) ([]*BorrowDetail, int64, error) {
+	var results []*BorrowDetail
+	var total int64
+
+	statuses := []string{"returned", "lost"}
+	err := db.WithContext(ctx).
+		Table(BorrowRecord{}.TableName()).
+		Where("user_id = ? AND status IN ?", userId, statuses).
+		Count(&total).
+		Error
+	if err != nil {
+		return nil, 0, errno.Errorf(errno.InternalDatabaseErrorCode, "count reading history failed: %v", err)
+	}
+
+	if total == 0 {
+		return []*BorrowDetail{}, 0, nil
+	}
+
+	err = db.WithContext(ctx).
+		Table(BorrowRecord{}.TableName()+" AS br").
+		Select(borrowDetailColumns).
+		Joins("JOIN "+Book{}.TableName()+" AS b ON b.id = br.book_id").
+		Joins("LEFT JOIN "+BookType{}.TableName()+" AS bt ON bt.ISBN = b.ISBN").
+		Where("br.user_id = ? AND br.status IN ?", userId, statuses).
+		Order("br.checkout_date DESC").
+		Offset(offset).
+		Limit(size).
+		Scan(&results).
+		Error
+	if err != nil {
+		return nil, 0, errno.Errorf(errno.InternalDatabaseErrorCode, "get reading history failed: %v", err)
+	}
+	return results, total, nil
+}
@@ -0,0 +1,43 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/2451965602/LMS/pkg/constants"
+)
+
+// TestFineBalanceExcludesSettled 缴纳或减免后的逾期费用不再计入未缴清余额
+func TestFineBalanceExcludesSettled(t *testing.T) {
+	initTestDB(t)
+	ctx := context.Background()
+	bookIds := createTestCopies(t, "9780000000080", 3)
+	userId := createTestUser(t, "fine-balance")
+	paid := createTestFine(t, userId, bookIds[0], 4)
+	waived := createTestFine(t, userId, bookIds[1], 2)
+	createTestFine(t, userId, bookIds[2], 1)
+
+	balance, err := GetFineBalance(ctx, userId)
+	if err != nil {
+		t.Fatalf("GetFineBalance: %v", err)
+	}
+	if balance != 7 {
+		t.Errorf("balance before settlement = %v, want 7", balance)
+	}
+
+	audit := &AuditLog{Action: constants.AuditActionFinePay, Subject: "fine-balance", IP: "127.0.0.1"}
+	if _, err = SettleFine(ctx, paid, false, audit); err != nil {
+		t.Fatalf("pay fine: %v", err)
+	}
+	audit = &AuditLog{Action: constants.AuditActionFineWaive, Subject: "fine-balance", IP: "127.0.0.1"}
+	if _, err = SettleFine(ctx, waived, true, audit); err != nil {
+		t.Fatalf("waive fine: %v", err)
+	}
+
+	if balance, err = GetFineBalance(ctx, userId); err != nil {
+		t.Fatalf("GetFineBalance: %v", err)
+	}
+	if balance != 1 {
+		t.Errorf("balance after paying 4 and waiving 2 = %v, want 1", balance)
+	}
+}
@@ -0,0 +1,85 @@
+package db
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+
+	"github.com/2451965602/LMS/pkg/tracing"
+)
+
+const tracingSpanKey = "lms:tracing_span" // 在 gorm 实例中记录当前语句 span 的键名
+
+// tracingPlugin 为每条 SQL 语句创建 span 的 gorm 插件
+// span 以发起查询的 DAL 函数命名，父节点取自 WithContext 传入的请求上下文。
+type tracingPlugin struct {
+	database string // 数据库名称
+}
+
+func (tracingPlugin) Name() string {
+	return "lms:tracing"
+}
+
+// Initialize 在 gorm 的各类回调前后注册 span 的创建和结束函数
+func (p tracingPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	registers := []error{
+		cb.Create().Before("gorm:create").Register("lms:tracing_before_create", p.before),
+		cb.Create().After("gorm:create").Register("lms:tracing_after_create", p.after("create")),
+		cb.Query().Before("gorm:query").Register("lms:tracing_before_query", p.before),
+		cb.Query().After("gorm:query").Register("lms:tracing_after_query", p.after("query")),
+		cb.Update().Before("gorm:update").Register("lms:tracing_before_update", p.before),
+		cb.Update().After("gorm:update").Register("lms:tracing_after_update", p.after("update")),
+		cb.Delete().Before("gorm:delete").Register("lms:tracing_before_delete", p.before),
+		cb.Delete().After("gorm:delete").Register("lms:tracing_after_delete", p.after("delete")),
+		cb.Row().Before("gorm:row").Register("lms:tracing_before_row", p.before),
+		cb.Row().After("gorm:row").Register("lms:tracing_after_row", p.after("row")),
+		cb.Raw().Before("gorm:raw").Register("lms:tracing_before_raw", p.before),
+		cb.Raw().After("gorm:raw").Register("lms:tracing_after_raw", p.after("raw")),
+	}
+	for _, err := range registers {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p tracingPlugin) before(tx *gorm.DB) {
+	_, span := tracing.Start(tx.Statement.Context, "db."+callerFunction(),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemMySQL,
+			semconv.DBNamespace(p.database),
+		),
+	)
+	tx.InstanceSet(tracingSpanKey, span)
+}
+
+func (tracingPlugin) after(operation string) func(*gorm.DB) {
+	return func(tx *gorm.DB) {
+		v, ok := tx.InstanceGet(tracingSpanKey)
+		if !ok {
+			return
+		}
+		span, ok := v.(trace.Span)
+		if !ok {
+			return
+		}
+		defer span.End()
+
+		// 只记录带占位符的 SQL，不记录参数值，避免用户信息进入追踪数据
+		span.SetAttributes(
+			semconv.DBOperationName(operation),
+			semconv.DBCollectionName(tx.Statement.Table),
+			semconv.DBQueryText(tx.Statement.SQL.String()),
+			attribute.Int64("db.rows_affected", tx.Statement.RowsAffected),
+		)
+		if !errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+			tracing.RecordError(span, tx.Error)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/2451965602/LMS/pkg/errno"
+)
+
+// SetTOTPSecret 为尚未启用两步验证的用户保存新的密钥
+// 1. 只更新未启用两步验证的用户，已启用时返回错误，需先关闭再重新设置。
+// 2. 重新设置时覆盖之前未完成启用的密钥。
+func SetTOTPSecret(ctx context.Context, userId int64, secret string) error {
+	result := db.WithContext(ctx).
+		Table(User{}.TableName()).
+		Where("id = ? AND totp_enabled = ?", userId, false).
+		Updates(map[string]interface{}{
+			"totp_secret":       secret,
+			"totp_last_counter": 0,
+		})
+	if result.Error != nil {
+		return errno.Errorf(errno.InternalDatabaseErrorCode, "set totp secret (user id: %d) failed: %v", userId, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errno.Errorf(errno.ServiceTwoFactorAlreadyEnabled, "two-factor authentication already enabled")
+	}
+	return nil
+}
+
+// EnableTOTP 启用两步验证并保存恢复码
+// 1. 只更新已设置密钥且尚未启用的用户，并记录本次验证使用的时间步，防止同一验证码再次使用。
+// 2. 删除旧的恢复码并保存新的恢复码摘要。
+func EnableTOTP(ctx context.Context, userId, counter int64, codeHashes []string) error {
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Table(User{}.TableName()).
+			Where("id = ? AND totp_enabled = ? AND totp_secret <> ''", userId, false).
+			Updates(map[string]interface{}{
+				"totp_enabled":      true,
+				"totp_last_counter": counter,
+			})
+		if result.Error != nil {
+			return errno.Errorf(errno.InternalDatabaseErrorCode, "enable totp (user id: %d) failed: %v", userId, result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return errno.Errorf(errno.ServiceTwoFactorAlreadyEnabled, "two-factor authentication already enabled or not set up")
+		}
+		return replaceRecoveryCodes(tx, userId, codeHashes)
+	})
+}
+
+// DisableTOTP 关闭两步验证
+// 1. 清除密钥、启用标记和已使用的时间步。
+// 2. 删除该用户的所有恢复码。
+func DisableTOTP(ctx context.Context, userId int64) error {
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Table(User{}.TableName()).
+			Where("id = ?", userId).
+			Updates(map[string]interface{}{
+				"totp_secret":       "",
+				"totp_enabled":      false,
+				"totp_last_counter": 0,
+			}).
+			Error
+		if err != nil {
+			return errno.Errorf(errno.InternalDatabaseErrorCode, "disable totp (user id: %d) failed: %v", userId, err)
+		}
+		return replaceRecoveryCodes(tx, userId, nil)
+	})
+}
+
+// UseTOTPCounter 记录已使用的时间步，同一时间步或更早的验证码不能再次使用
+// 1. 只在 counter 大于已记录的时间步时更新，并发提交同一验证码时只有一个请求成功。
+// 2. 返回是否更新成功。
+func UseTOTPCounter(ctx context.Context, userId, counter int64) (bool, error) {
+	result := db.WithContext(ctx).
+		Table(User{}.TableName()).
+		Where("id = ? AND totp_last_counter < ?", userId, counter).
+		Update("totp_last_counter", counter)
+	if result.Error != nil {
+		return false, errno.Errorf(errno.InternalDatabaseErrorCode, "use totp counter (user id: %d) failed: %v", userId, result.Error)
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// UseRecoveryCode 使用一个恢复码
+// 1. 只更新属于该用户且未使用过的恢复码，每个恢复码只能使用一次。
+// 2. 返回是否使用成功。
+func UseRecoveryCode(ctx context.Context, userId int64, codeHash string) (bool, error) {
+	result := db.WithContext(ctx).
+		Table(RecoveryCode{}.TableName()).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userId, codeHash).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return false, errno.Errorf(errno.InternalDatabaseErrorCode, "use recovery code (user id: %d) failed: %v", userId, result.Error)
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// ReplaceRecoveryCodes 用新的恢复码替换用户现有的恢复码
+func ReplaceRecoveryCodes(ctx context.Context, userId int64, codeHashes []string) error {
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return replaceRecoveryCodes(tx, userId, codeHashes)
+	})
+}
+
+// replaceRecoveryCodes 在事务中删除用户现有的恢复码并保存新的恢复码摘要
+func replaceRecoveryCodes(tx *gorm.DB, userId int64, codeHashes []string) error {
+	err := tx.Where("user_id = ?", userId).
+		Delete(&RecoveryCode{}).
+		Error
+	if err != nil {
+		return errno.Errorf(errno.InternalDatabaseErrorCode, "delete recovery codes (user id: %d) failed: %v", userId, err)
+	}
+	if len(codeHashes) == 0 {
+		return nil
+	}
+
+	codes := make([]RecoveryCode, 0, len(codeHashes))
+	for _, hash := range codeHashes {
+		codes = append(codes, RecoveryCode{UserID: userId, CodeHash: hash})
+	}
+	err = tx.Table(RecoveryCode{}.TableName()).
+		Create(&codes).
+		Error
+	if err != nil {
+		return errno.Errorf(errno.InternalDatabaseErrorCode, "create recovery codes (user id: %d) failed: %v", userId, err)
+	}
+	return nil
+}
+
+// SetTwoFactorPolicy 设置角色是否必须启用两步验证
+// 1. 角色的策略不存在时插入，存在时更新。
+func SetTwoFactorPolicy(ctx context.Context, role string, required bool) error {
+	err := db.WithContext(ctx).
+		Table(TwoFactorPolicy{}.TableName()).
+		Clauses(clause.OnConflict{DoUpdates: clause.AssignmentColumns([]string{"required"})}).
+		Create(&TwoFactorPolicy{Role: role, Required: required}).
+		Error
+	if err != nil {
+		return errno.Errorf(errno.InternalDatabaseErrorCode, "set two-factor policy (role: %s) failed: %v", role, err)
+	}
+	return nil
+}
+
+// GetTwoFactorPolicies 获取所有角色的两步验证策略
+func GetTwoFactorPolicies(ctx context.Context) ([]*TwoFactorPolicy, error) {
+	var policies []*TwoFactorPolicy
+	err := db.WithContext(ctx).
+		Table(TwoFactorPolicy{}.TableName()).
+		Order("role").
+		Find(&policies).
+		Error
+	if err != nil {
+		return nil, errno.Errorf(errno.InternalDatabaseErrorCode, "get two-factor policies failed: %v", err)
+	}
+	return policies, nil
+}
+
+// IsTwoFactorRequired 判断角色是否必须启用两步验证
+// 1. 角色没有设置策略时视为不要求。
+func IsTwoFactorRequired(ctx context.Context, role string) (bool, error) {
+	var p TwoFactorPolicy
+	err := db.WithContext(ctx).
+		Table(TwoFactorPolicy{}.TableName()).
+		Where("role = ?", role).
+		First(&p).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, errno.Errorf(errno.InternalDatabaseErrorCode, "get two-factor policy (role: %s) failed: %v", role, err)
+	}
+	return p.Required, nil
+}
@@ -43,7 +43,7 @@ func LoginUser(ctx context.Context, username, password string) (*User, error) {
 // 2. 创建新的用户实例并填充请求参数。
 // 3. 将用户信息插入到数据库。
 // 4. 如果插入成功，返回用户的 ID，否则返回错误。
-func RegisterUser(ctx context.Context, username, password, phone string) (int64, error) {
+func RegisterUser(ctx context.Context, username, password, patronType string, phone, email *string) (int64, error) {
 	hashedPassword, err := crypt.PasswordHash(password)
 	if err != nil {
 		return 0, errno.Errorf(errno.InternalPasswordCryptErrorCode, "encrypt password failed: %v", err)
@@ -52,7 +52,9 @@ func RegisterUser(ctx context.Context, username, password, phone string) (int64,
 	u := User{
 		Name:       username,
 		Password:   hashedPassword,
-		Phone:      &phone,
+		Phone:      phone,
+		Email:      email,
+		PatronType: patronType,
 		Permission: "member",
 		Status:     "active",
 	}
@@ -70,7 +72,7 @@ func RegisterUser(ctx context.Context, username, password, phone string) (int64,
 // UpdateUser 更新用户信息
 // 1. 根据用户 ID 查询用户信息。
 // 2. 如果用户不存在，返回错误。
-// 3. 根据请求参数构建更新字段。
+// 3. 根据请求参数构建更新字段，密码需通过 ChangePassword 修改。
 // 4. 更新用户信息。
 // 5. 如果更新成功，返回更新后的用户信息，否则返回错误。
 func UpdateUser(ctx context.Context, userId int64, req user.UpdateUserRequest) (*User, error) {
@@ -83,23 +85,21 @@ func UpdateUser(ctx context.Context, userId int64, req user.UpdateUserRequest) (
 	}
 
 	updates := make(map[string]interface{})
-	if req.Password != nil && *req.Password != "" {
-		hashedPassword, err := crypt.PasswordHash(*req.Password)
-		if err != nil {
-			return nil, errno.Errorf(errno.InternalPasswordCryptErrorCode, "encrypt password failed for update: %v", err)
-		}
-		updates["password"] = hashedPassword
-		u.Password = hashedPassword
-	}
 	if req.Phone != nil {
 		updates["phone"] = *req.Phone
 		u.Phone = req.Phone
 	}
+	if req.Email != nil {
+		updates["email"] = nullableString(*req.Email)
+		u.Email = req.Email
+	}
 
 	if len(updates) == 0 {
 		return nil, errno.Errorf(errno.ParamMissingErrorCode, "no fields to update")
 	}
 
+	updates["version"] = gorm.Expr("version + 1") // 本人修改联系方式同样使管理员手中的旧版本失效
+
 	err := db.WithContext(ctx).
 		Table(User{}.TableName()).
 		Where("id = ?", userId).
@@ -109,6 +109,7 @@ func UpdateUser(ctx context.Context, userId int64, req user.UpdateUserRequest) (
 		return nil, errno.Errorf(errno.InternalDatabaseErrorCode, "update user (id: %d) failed: %v", userId, err)
 	}
 
+	u.Version++
 	return &u, nil
 }
 
@@ -200,10 +201,11 @@ func DeleteUser(ctx context.Context, userId int64, username string) error {
 // AdminUpdateUser 管理员更新用户信息
 // 1. 根据用户 ID 查询用户信息。
 // 2. 如果用户不存在，返回错误。
-// 3. 根据请求参数构建更新字段。
-// 4. 更新用户信息。
-// 5. 如果更新成功，返回更新后的用户信息，否则返回错误。
-func AdminUpdateUser(ctx context.Context, req user.AdminUpdateUserRequest) (*User, error) {
+// 3. 传入期望的版本时检查版本，不一致时返回当前的用户信息和 ServiceVersionConflict。
+// 4. 根据请求参数构建更新字段并增加版本，修改密码、权限或状态时增加 Token 版本。
+// 5. 以读取时的版本为条件更新用户信息，期间被他人修改时同样返回当前的用户信息和 ServiceVersionConflict。
+// 6. 如果更新成功，返回更新后的用户信息，否则返回错误。
+func AdminUpdateUser(ctx context.Context, req user.AdminUpdateUserRequest, expectedVersion *int64) (*User, error) {
 	var u User
 	err := db.WithContext(ctx).
 		Table(User{}.TableName()).
@@ -215,6 +217,9 @@ func AdminUpdateUser(ctx context.Context, req user.AdminUpdateUserRequest) (*Use
 		}
 		return nil, errno.Errorf(errno.InternalDatabaseErrorCode, "get user for admin update failed: %v", err)
 	}
+	if err = checkVersion("user", req.UserID, expectedVersion, u.Version); err != nil {
+		return &u, err
+	}
 
 	updates := make(map[string]interface{})
 	if req.Username != nil && *req.Username != "" {
@@ -234,14 +239,24 @@ func AdminUpdateUser(ctx context.Context, req user.AdminUpdateUserRequest) (*Use
 			return nil, errno.Errorf(errno.InternalPasswordCryptErrorCode, "encrypt password for admin update failed: %v", err)
 		}
 		updates["password"] = hashedPassword
+		updates["must_change_password"] = true // 管理员重置的密码只用于临时登录，用户需要自行修改
 		u.Password = hashedPassword
+		u.MustChangePassword = true
 	}
 	if req.Phone != nil {
 		updates["phone"] = *req.Phone
 		u.Phone = req.Phone
 	}
+	if req.Email != nil {
+		updates["email"] = nullableString(*req.Email)
+		u.Email = req.Email
+	}
+	if req.PatronType != nil && *req.PatronType != "" {
+		updates["patron_type"] = *req.PatronType
+		u.PatronType = *req.PatronType
+	}
 	if req.Permission != nil {
-		updates["permissions"] = *req.Permission
+		updates["permission"] = *req.Permission
 		u.Permission = *req.Permission
 	}
 	if req.Status != nil {
@@ -253,14 +268,29 @@ func AdminUpdateUser(ctx context.Context, req user.AdminUpdateUserRequest) (*Use
 		return nil, errno.Errorf(errno.ParamMissingErrorCode, "no fields to update for admin")
 	}
 
-	err = db.WithContext(ctx).
+	// 修改密码、权限或状态后之前签发的 Token 全部失效
+	if (req.Password != nil && *req.Password != "") || req.Permission != nil || req.Status != nil {
+		updates["token_version"] = gorm.Expr("token_version + 1")
+		u.TokenVersion++
+	}
+
+	updates["version"] = gorm.Expr("version + 1")
+
+	result := db.WithContext(ctx).
 		Table(User{}.TableName()).
-		Where("id = ?", req.UserID).
-		Updates(updates).
-		Error
-	if err != nil {
-		return nil, errno.Errorf(errno.InternalDatabaseErrorCode, "admin update user (id: %d) failed: %v", req.UserID, err)
+		Where("id = ? AND version = ?", req.UserID, u.Version).
+		Updates(updates)
+	if result.Error != nil {
+		return nil, errno.Errorf(errno.InternalDatabaseErrorCode, "admin update user (id: %d) failed: %v", req.UserID, result.Error)
 	}
+	if result.RowsAffected == 0 {
+		current, err := GetUserById(ctx, req.UserID)
+		if err != nil {
+			return nil, err
+		}
+		return current, versionConflict("user", req.UserID, current.Version)
+	}
+	u.Version++
 	return &u, nil
 }
 
@@ -346,3 +376,11 @@ func IsPermission(ctx context.Context, userId int64, requiredPermission string)
 
 	return u.Permission == requiredPermission, nil
 }
+
+// nullableString 空字符串保存为 NULL
+func nullableString(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
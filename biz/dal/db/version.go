@@ -0,0 +1,19 @@
+package db
+
+import (
+	"github.com/2451965602/LMS/pkg/errno"
+)
+
+// checkVersion 检查调用方期望的数据版本，expected 为 nil 时不检查
+// 版本不一致说明数据在调用方读取之后已被他人修改，返回 ServiceVersionConflict，调用方应同时返回当前的数据供客户端合并。
+func checkVersion(kind string, key interface{}, expected *int64, current int64) error {
+	if expected == nil || *expected == current {
+		return nil
+	}
+	return versionConflict(kind, key, current)
+}
+
+// versionConflict 数据已被他人修改时返回的错误
+func versionConflict(kind string, key interface{}, current int64) error {
+	return errno.Errorf(errno.ServiceVersionConflict, "%s %v has been modified by someone else, current version is %d", kind, key, current)
+}
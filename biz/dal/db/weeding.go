@@ -0,0 +1,194 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/2451965602/LMS/pkg/constants"
+	"github.com/2451965602/LMS/pkg/errno"
+	"github.com/2451965602/LMS/pkg/pagination"
+)
+
+// WeedingCandidate 长期未被借出、可以考虑剔旧的副本
+type WeedingCandidate struct {
+	BookID       int64      `json:"book_id"`
+	ISBN         string     `json:"isbn"`
+	Title        string     `json:"title"`
+	CategoryID   *int64     `json:"category_id"`
+	Category     string     `json:"category"`
+	Location     string     `json:"location"`
+	Status       string     `json:"status"`
+	PurchaseDate time.Time  `json:"purchase_date"`
+	LastCheckout *time.Time `json:"last_checkout"`
+	IdleSince    time.Time  `json:"-"` // 最后借出时间，从未借出时为购入日期，用于排序和分页
+}
+
+// WeedingGroup 按分类和馆藏位置汇总的剔旧候选副本数
+type WeedingGroup struct {
+	ReportCategory
+	Location string `json:"location"`
+	Copies   int64  `json:"copies"`
+}
+
+// BackfillLastCheckout 根据借阅记录回填书籍的最后借出时间
+// 1. 按书籍统计借阅记录中最近的借出时间，已匿名化的借阅记录仍保留书籍 ID，一并计入。
+// 2. 只更新最后借出时间为空或早于借阅记录的书籍，可以重复执行。
+// 3. 返回更新的书籍数量。
+func BackfillLastCheckout(ctx context.Context) (int64, error) {
+	result := db.WithContext(ctx).Exec(fmt.Sprintf(
+		"UPDATE %s AS b JOIN (SELECT book_id, MAX(checkout_date) AS last_checkout FROM %s GROUP BY book_id) AS br ON br.book_id = b.id "+
+			"SET b.last_checkout = br.last_checkout "+
+			"WHERE b.last_checkout IS NULL OR b.last_checkout < br.last_checkout",
+		Book{}.TableName(), BorrowRecord{}.TableName()))
+	if result.Error != nil {
+		return 0, errno.Errorf(errno.InternalDatabaseErrorCode, "backfill last checkout failed: %v", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// WeedingCandidatePageSpec 剔旧候选副本列表的分页规则，默认按闲置起始时间升序，即闲置最久的副本在前
+var WeedingCandidatePageSpec = pagination.Spec{
+	Fields: map[string]pagination.Field{
+		"book_id":       {Column: "book_id", Kind: pagination.KindInt},
+		"purchase_date": {Column: "purchase_date", Kind: pagination.KindTime},
+		"idle_since":    {Column: "idle_since", Kind: pagination.KindTime},
+	},
+	DefaultSort: "idle_since",
+	Key:         pagination.Field{Column: "book_id", Kind: pagination.KindInt},
+}
+
+// weedingCandidates 查询指定时间之后没有被借出过的副本
+// 1. 最后借出时间早于 before 的副本；从未借出的副本按购入日期判断，避免刚入库的新书被列入。
+// 2. 只包括在架和损坏的副本，借出中、遗失和已剔除的副本不列入。
+// 3. 可按分类节点（包括其全部下级分类）、分类名称和馆藏位置过滤。
+func weedingCandidates(ctx context.Context, before time.Time, node *CategoryNode, category, location *string) *gorm.DB {
+	query := db.WithContext(ctx).
+		Table(Book{}.TableName()+" AS b").
+		Joins("JOIN "+BookType{}.TableName()+" AS bt ON bt.ISBN = b.ISBN").
+		Where("b.status IN ?", []string{constants.BookStatusAvailable, constants.BookStatusDamaged}).
+		Where("(b.last_checkout < ? OR (b.last_checkout IS NULL AND b.purchase_date < ?))", before, before)
+	query = withCategory(query, node)
+	if category != nil && *category != "" {
+		query = query.Where("bt.category = ?", *category)
+	}
+	if location != nil && *location != "" {
+		query = query.Where("b.location = ?", *location)
+	}
+	return query
+}
+
+// GetWeedingCandidates 获取指定时间之后没有被借出过的副本
+// 1. 按 weedingCandidates 的条件查询，作为子查询。
+// 2. 请求需要时查询总数，再按分页参数在外层查询一页。
+func GetWeedingCandidates(ctx context.Context, before time.Time, node *CategoryNode, category, location *string, page *pagination.Page) ([]*WeedingCandidate, *pagination.Result, error) {
+	var results []WeedingCandidate
+	query := weedingCandidates(ctx, before, node, category, location).
+		Select("b.id AS book_id, b.ISBN AS isbn, bt.title, bt.category_id, bt.category, b.location, b.status, b.purchase_date, b.last_checkout, " +
+			"COALESCE(b.last_checkout, b.purchase_date) AS idle_since")
+
+	// 排序列和唯一键是子查询中的别名，在外层查询中分页
+	var total *int64
+	if page.WithTotal {
+		var count int64
+		if err := db.WithContext(ctx).Table("(?) AS weeding", query).Count(&count).Error; err != nil {
+			return nil, nil, errno.Errorf(errno.InternalDatabaseErrorCode, "count weeding candidates failed: %v", err)
+		}
+		total = &count
+	}
+
+	err := paginate(db.WithContext(ctx).Table("(?) AS weeding", query), page).
+		Find(&results).
+		Error
+	if err != nil {
+		return nil, nil, errno.Errorf(errno.InternalDatabaseErrorCode, "get weeding candidates failed: %v", err)
+	}
+
+	results, result, err := pageResult(page, results, total)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	candidates := make([]*WeedingCandidate, 0, len(results))
+	for i := range results {
+		candidates = append(candidates, &results[i])
+	}
+	return candidates, result, nil
+}
+
+// GetWeedingGroups 按分类和馆藏位置汇总指定时间之后没有被借出过的副本数
+// 1. 条件与 GetWeedingCandidates 相同，汇总全部候选副本而不只是当前一页。
+// 2. 按分类节点和馆藏位置分组统计后，分类汇总到顶级类目或 node 的直接下级分类，按分类名称和馆藏位置排序。
+func GetWeedingGroups(ctx context.Context, before time.Time, node *CategoryNode, category, location *string) ([]*WeedingGroup, error) {
+	var results []*WeedingGroup
+	err := weedingCandidates(ctx, before, node, category, location).
+		Select("bt.category_id AS category_id, b.location AS location, COUNT(*) AS copies").
+		Group("bt.category_id, b.location").
+		Scan(&results).
+		Error
+	if err != nil {
+		return nil, errno.Errorf(errno.InternalDatabaseErrorCode, "get weeding groups failed: %v", err)
+	}
+
+	results, err = rollUpCategory(ctx, node, results, func(g *WeedingGroup) string { return g.Location }, func(dst, src *WeedingGroup) {
+		dst.Copies += src.Copies
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Category != results[j].Category {
+			return results[i].Category < results[j].Category
+		}
+		return results[i].Location < results[j].Location
+	})
+	return results, nil
+}
+
+// WithdrawBooks 批量剔除副本
+// 1. 在同一事务中锁定并逐个检查副本，按副本状态变更规则不能剔除的副本（借出中、已剔除）跳过。
+// 2. 将副本状态改为已剔除，并从所属书籍类型的总副本数中减去；在架的副本同时从可借副本数中减去。
+// 3. 不存在的副本也视为跳过，返回已剔除和跳过的副本 ID。
+func WithdrawBooks(ctx context.Context, bookIds []int64) ([]int64, []int64, error) {
+	withdrawn := make([]int64, 0, len(bookIds))
+	skipped := make([]int64, 0)
+
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var books []Book
+		err := tx.Table(Book{}.TableName()).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id IN ?", bookIds).
+			Find(&books).
+			Error
+		if err != nil {
+			return errno.Errorf(errno.InternalDatabaseErrorCode, "get books for withdraw failed: %v", err)
+		}
+		found := make(map[int64]Book, len(books))
+		for _, bk := range books {
+			found[bk.ID] = bk
+		}
+
+		for _, id := range bookIds {
+			bk, ok := found[id]
+			if !ok || !CanTransitBookStatus(bk.Status, constants.BookStatusWithdrawn) {
+				skipped = append(skipped, id)
+				continue
+			}
+			delete(found, id) // 请求中重复的 ID 只处理一次
+
+			if err = transitBookStatus(tx, &bk, constants.BookStatusWithdrawn); err != nil {
+				return err
+			}
+			withdrawn = append(withdrawn, id)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return withdrawn, skipped, nil
+}
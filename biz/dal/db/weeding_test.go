@@ -0,0 +1,71 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/2451965602/LMS/pkg/pagination"
+)
+
+// TestGetWeedingCandidatesPages 按游标翻页可以取到全部剔旧候选副本且不重复，汇总的副本数不受分页影响
+func TestGetWeedingCandidatesPages(t *testing.T) {
+	initTestDB(t)
+	ctx := context.Background()
+	ids := createTestCopies(t, "9780000000097", 5)
+	location := "W-weeding"
+	err := db.Table(Book{}.TableName()).
+		Where("id IN ?", ids).
+		Updates(map[string]interface{}{"location": location, "purchase_date": time.Now().AddDate(-3, 0, 0)}).
+		Error
+	if err != nil {
+		t.Fatal(err)
+	}
+	before := time.Now().AddDate(-1, 0, 0)
+
+	groups, err := GetWeedingGroups(ctx, before, nil, nil, &location)
+	if err != nil {
+		t.Fatalf("GetWeedingGroups: %v", err)
+	}
+	if len(groups) != 1 || groups[0].Copies != int64(len(ids)) {
+		t.Errorf("groups = %+v, want one group of %d copies", groups, len(ids))
+	}
+
+	size, withTotal := int64(2), true
+	var (
+		seen   = make(map[int64]bool)
+		cursor *string
+	)
+	for pages := 0; ; pages++ {
+		if pages > 100 {
+			t.Fatal("too many pages")
+		}
+		page, err := pagination.Parse(WeedingCandidatePageSpec, pagination.Request{Cursor: cursor, PageSize: &size, WithTotal: &withTotal})
+		if err != nil {
+			t.Fatal(err)
+		}
+		candidates, result, err := GetWeedingCandidates(ctx, before, nil, nil, &location, page)
+		if err != nil {
+			t.Fatalf("GetWeedingCandidates: %v", err)
+		}
+		if int64(len(candidates)) > size {
+			t.Fatalf("page has %d candidates, want at most %d", len(candidates), size)
+		}
+		if *result.Total != int64(len(ids)) {
+			t.Errorf("total = %d, want %d", *result.Total, len(ids))
+		}
+		for _, c := range candidates {
+			if seen[c.BookID] {
+				t.Errorf("book %d returned on more than one page", c.BookID)
+			}
+			seen[c.BookID] = true
+		}
+		if result.NextCursor == "" {
+			break
+		}
+		cursor = &result.NextCursor
+	}
+	if len(seen) != len(ids) {
+		t.Errorf("paged through %d candidates, want %d", len(seen), len(ids))
+	}
+}
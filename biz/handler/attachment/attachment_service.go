@@ -0,0 +1,117 @@
+// Code generated by hertz generator.
+
+package attachment
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/protocol/consts"
+
+	attachment "github.com/2451965602/LMS/biz/model/attachment"
+	"github.com/2451965602/LMS/biz/pack"
+	"github.com/2451965602/LMS/biz/service"
+	"github.com/2451965602/LMS/pkg/constants"
+)
+
+// UploadAttachment .
+// @router /attachment/upload [POST]
+func UploadAttachment(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req attachment.UploadAttachmentRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		pack.SendFailResponse(c, pack.BuildBindError(c, err))
+		return
+	}
+
+	resp := new(attachment.UploadAttachmentResponse)
+
+	info, err := service.NewAttachmentService(ctx, c).UploadAttachment(ctx, req)
+	if err != nil {
+		pack.SendFailResponse(c, err)
+		return
+	}
+
+	resp.Base = pack.BuildBaseResp(nil)
+	resp.Data = pack.BuildAttachmentResp(info)
+
+	pack.SendResponse(c, resp)
+}
+
+// DeleteAttachment .
+// @router /attachment/delete [DELETE]
+func DeleteAttachment(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req attachment.DeleteAttachmentRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		pack.SendFailResponse(c, pack.BuildBindError(c, err))
+		return
+	}
+
+	resp := new(attachment.DeleteAttachmentResponse)
+
+	err = service.NewAttachmentService(ctx, c).DeleteAttachment(ctx, req)
+	if err != nil {
+		pack.SendFailResponse(c, err)
+		return
+	}
+
+	resp.Base = pack.BuildBaseResp(nil)
+
+	pack.SendResponse(c, resp)
+}
+
+// GetAttachment .
+// @router /attachment/get [GET]
+func GetAttachment(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req attachment.GetAttachmentRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		pack.SendFailResponse(c, pack.BuildBindError(c, err))
+		return
+	}
+
+	resp := new(attachment.GetAttachmentResponse)
+
+	infos, err := service.NewAttachmentService(ctx, c).GetAttachments(ctx, req)
+	if err != nil {
+		pack.SendFailResponse(c, err)
+		return
+	}
+
+	resp.Base = pack.BuildBaseResp(nil)
+	resp.Data = pack.BuildAttachmentListResp(infos)
+
+	pack.SendResponse(c, resp)
+}
+
+// DownloadAttachment .
+// @router /attachment/download [GET]
+func DownloadAttachment(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req attachment.DownloadAttachmentRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		pack.SendFailResponse(c, pack.BuildBindError(c, err))
+		return
+	}
+
+	info, data, contentType, err := service.NewAttachmentService(ctx, c).DownloadAttachment(ctx, req)
+	if err != nil {
+		pack.SendFailResponse(c, err)
+		return
+	}
+
+	// 成功时直接返回附件内容而不是 JSON，封面可以直接用作图片地址
+	c.Header("Cache-Control", constants.AttachmentCacheControl)
+	c.Header("X-Content-Type-Options", "nosniff")
+	if info.Kind == constants.AttachmentKindDocument && (req.Thumbnail == nil || !*req.Thumbnail) {
+		c.Header("Content-Disposition", fmt.Sprintf("inline; filename*=UTF-8''%s", url.PathEscape(info.Filename)))
+	}
+	c.Data(consts.StatusOK, contentType, data)
+}
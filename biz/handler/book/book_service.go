@@ -20,7 +20,7 @@ func AddBook(ctx context.Context, c *app.RequestContext) {
 	var req book.AddBookRequest
 	err = c.BindAndValidate(&req)
 	if err != nil {
-		pack.SendFailResponse(c, err)
+		pack.SendFailResponse(c, pack.BuildBindError(c, err))
 		return
 	}
 
@@ -45,7 +45,7 @@ func UpdateBook(ctx context.Context, c *app.RequestContext) {
 	var req book.UpdateBookRequest
 	err = c.BindAndValidate(&req)
 	if err != nil {
-		pack.SendFailResponse(c, err)
+		pack.SendFailResponse(c, pack.BuildBindError(c, err))
 		return
 	}
 
@@ -53,10 +53,19 @@ func UpdateBook(ctx context.Context, c *app.RequestContext) {
 
 	info, err := service.NewBookService(ctx, c).UpdateBook(ctx, req)
 	if err != nil {
-		pack.SendFailResponse(c, err)
+		if info == nil {
+			pack.SendFailResponse(c, err)
+			return
+		}
+		// 版本冲突时同时返回当前的数据，供客户端合并后重新提交
+		pack.SetETag(c, info.Version)
+		resp.Base = pack.BuildFailBaseResp(c, err)
+		resp.Data = pack.BuildBookResp(info)
+		pack.SendResponse(c, resp)
 		return
 	}
 
+	pack.SetETag(c, info.Version)
 	resp.Base = pack.BuildBaseResp(err)
 	resp.Data = pack.BuildBookResp(info)
 
@@ -70,7 +79,7 @@ func DeleteBook(ctx context.Context, c *app.RequestContext) {
 	var req book.DeleteBookRequest
 	err = c.BindAndValidate(&req)
 	if err != nil {
-		pack.SendFailResponse(c, err)
+		pack.SendFailResponse(c, pack.BuildBindError(c, err))
 		return
 	}
 
@@ -94,21 +103,52 @@ func GetBook(ctx context.Context, c *app.RequestContext) {
 	var req book.GetBookRequest
 	err = c.BindAndValidate(&req)
 	if err != nil {
-		pack.SendFailResponse(c, err)
+		pack.SendFailResponse(c, pack.BuildBindError(c, err))
 		return
 	}
 
 	resp := new(book.GetBookResponse)
 
-	info, total, err := service.NewBookService(ctx, c).SearchBook(ctx, req)
+	info, result, err := service.NewBookService(ctx, c).SearchBook(ctx, req)
+	if err != nil {
+		pack.SendFailResponse(c, err)
+		return
+	}
+
+	// 按 ID 查询单本图书时返回其版本，供更新时通过 If-Match 带回
+	if req.BookID != nil && len(info) == 1 {
+		pack.SetETag(c, info[0].Version)
+	}
+	resp.Base = pack.BuildBaseResp(err)
+	resp.Data = pack.BuildBookDetailListResp(info)
+	resp.TotalCount = result.Total
+	resp.NextCursor = pack.BuildNextCursor(result)
+
+	pack.SendResponse(c, resp)
+}
+
+// WithdrawBook .
+// @router /book/withdraw [POST]
+func WithdrawBook(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req book.WithdrawBookRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		pack.SendFailResponse(c, pack.BuildBindError(c, err))
+		return
+	}
+
+	resp := new(book.WithdrawBookResponse)
+
+	withdrawn, skipped, err := service.NewBookService(ctx, c).WithdrawBooks(ctx, req)
 	if err != nil {
 		pack.SendFailResponse(c, err)
 		return
 	}
 
 	resp.Base = pack.BuildBaseResp(err)
-	resp.Data = pack.BuildBookListResp(info)
-	resp.TotalCount = total
+	resp.Withdrawn = withdrawn
+	resp.Skipped = skipped
 
 	pack.SendResponse(c, resp)
 }
@@ -20,7 +20,7 @@ func AddBookType(ctx context.Context, c *app.RequestContext) {
 	var req booktype.AddBookTypeRequest
 	err = c.BindAndValidate(&req)
 	if err != nil {
-		pack.SendFailResponse(c, err)
+		pack.SendFailResponse(c, pack.BuildBindError(c, err))
 		return
 	}
 
@@ -45,7 +45,7 @@ func UpdateBookType(ctx context.Context, c *app.RequestContext) {
 	var req booktype.UpdateBookTypeRequest
 	err = c.BindAndValidate(&req)
 	if err != nil {
-		pack.SendFailResponse(c, err)
+		pack.SendFailResponse(c, pack.BuildBindError(c, err))
 		return
 	}
 
@@ -53,10 +53,19 @@ func UpdateBookType(ctx context.Context, c *app.RequestContext) {
 
 	info, err := service.NewBookTypeService(ctx, c).UpdateBookType(ctx, req)
 	if err != nil {
-		pack.SendFailResponse(c, err)
+		if info == nil {
+			pack.SendFailResponse(c, err)
+			return
+		}
+		// 版本冲突时同时返回当前的数据，供客户端合并后重新提交
+		pack.SetETag(c, info.Version)
+		resp.Base = pack.BuildFailBaseResp(c, err)
+		resp.Data = pack.BuildBookTypeResp(info)
+		pack.SendResponse(c, resp)
 		return
 	}
 
+	pack.SetETag(c, info.Version)
 	resp.Base = pack.BuildBaseResp(nil)
 	resp.Data = pack.BuildBookTypeResp(info)
 
@@ -70,7 +79,7 @@ func DeleteBookType(ctx context.Context, c *app.RequestContext) {
 	var req booktype.DeleteBookTypeRequest
 	err = c.BindAndValidate(&req)
 	if err != nil {
-		pack.SendFailResponse(c, err)
+		pack.SendFailResponse(c, pack.BuildBindError(c, err))
 		return
 	}
 
@@ -94,21 +103,26 @@ func GetBookType(ctx context.Context, c *app.RequestContext) {
 	var req booktype.GetBookTypeRequest
 	err = c.BindAndValidate(&req)
 	if err != nil {
-		pack.SendFailResponse(c, err)
+		pack.SendFailResponse(c, pack.BuildBindError(c, err))
 		return
 	}
 
 	resp := new(booktype.GetBookTypeResponse)
 
-	info, total, err := service.NewBookTypeService(ctx, c).SearchBookType(ctx, req)
+	info, result, err := service.NewBookTypeService(ctx, c).SearchBookType(ctx, req)
 	if err != nil {
 		pack.SendFailResponse(c, err)
 		return
 	}
 
+	// 按 ISBN 查询单个图书类型时返回其版本，供更新时通过 If-Match 带回
+	if req.ISBN != nil && len(info) == 1 {
+		pack.SetETag(c, info[0].Version)
+	}
 	resp.Base = pack.BuildBaseResp(nil)
 	resp.Data = pack.BuildBookTypeListResp(info)
-	resp.Total = total
+	resp.Total = result.Total
+	resp.NextCursor = pack.BuildNextCursor(result)
 
 	pack.SendResponse(c, resp)
 }
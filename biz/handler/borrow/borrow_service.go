@@ -20,7 +20,7 @@ func Borrow(ctx context.Context, c *app.RequestContext) {
 	var req borrow.BorrowRequest
 	err = c.BindAndValidate(&req)
 	if err != nil {
-		pack.SendFailResponse(c, err)
+		pack.SendFailResponse(c, pack.BuildBindError(c, err))
 		return
 	}
 
@@ -44,7 +44,7 @@ func ReturnBook(ctx context.Context, c *app.RequestContext) {
 	var req borrow.ReturnRequest
 	err = c.BindAndValidate(&req)
 	if err != nil {
-		pack.SendFailResponse(c, err)
+		pack.SendFailResponse(c, pack.BuildBindError(c, err))
 		return
 	}
 
@@ -69,7 +69,7 @@ func Renew(ctx context.Context, c *app.RequestContext) {
 	var req borrow.RenewRequest
 	err = c.BindAndValidate(&req)
 	if err != nil {
-		pack.SendFailResponse(c, err)
+		pack.SendFailResponse(c, pack.BuildBindError(c, err))
 		return
 	}
 
@@ -94,13 +94,13 @@ func GetBorrowRecord(ctx context.Context, c *app.RequestContext) {
 	var req borrow.GetBorrowRecordRequest
 	err = c.BindAndValidate(&req)
 	if err != nil {
-		pack.SendFailResponse(c, err)
+		pack.SendFailResponse(c, pack.BuildBindError(c, err))
 		return
 	}
 
 	resp := new(borrow.GetBorrowRecordResponse)
 
-	record, total, err := service.NewBorrowService(ctx, c).GetCurrentBorrowRecord(ctx, req)
+	record, result, err := service.NewBorrowService(ctx, c).GetCurrentBorrowRecord(ctx, req)
 	if err != nil {
 		pack.SendFailResponse(c, err)
 		return
@@ -108,7 +108,33 @@ func GetBorrowRecord(ctx context.Context, c *app.RequestContext) {
 
 	resp.Base = pack.BuildBaseResp(err)
 	resp.Data = pack.BuildBorrowRecordListResp(record)
-	resp.Total = total
+	resp.Total = result.Total
+	resp.NextCursor = pack.BuildNextCursor(result)
+
+	pack.SendResponse(c, resp)
+}
+
+// SettleFine .
+// @router /book/fine/settle [POST]
+func SettleFine(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req borrow.SettleFineRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		pack.SendFailResponse(c, pack.BuildBindError(c, err))
+		return
+	}
+
+	resp := new(borrow.SettleFineResponse)
+
+	record, err := service.NewBorrowService(ctx, c).SettleFine(ctx, req)
+	if err != nil {
+		pack.SendFailResponse(c, err)
+		return
+	}
+
+	resp.Base = pack.BuildBaseResp(err)
+	resp.Data = pack.BuildBorrowRecordResp(record)
 
 	pack.SendResponse(c, resp)
 }
@@ -0,0 +1,317 @@
+// Code generated by hertz generator.
+
+package catalog
+
+import (
+	"context"
+
+	"github.com/cloudwego/hertz/pkg/app"
+
+	"github.com/2451965602/LMS/biz/dal/db"
+	catalog "github.com/2451965602/LMS/biz/model/catalog"
+	"github.com/2451965602/LMS/biz/pack"
+	"github.com/2451965602/LMS/biz/service"
+)
+
+// AddContributor .
+// @router /contributor/add [POST]
+func AddContributor(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req catalog.AddCatalogEntryRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		pack.SendFailResponse(c, pack.BuildBindError(c, err))
+		return
+	}
+
+	resp := new(catalog.AddCatalogEntryResponse)
+
+	info, err := service.NewCatalogService(ctx, c).AddEntry(ctx, db.ContributorKind, req)
+	if err != nil {
+		pack.SendFailResponse(c, err)
+		return
+	}
+
+	resp.Base = pack.BuildBaseResp(nil)
+	resp.Data = pack.BuildCatalogEntryResp(info)
+
+	pack.SendResponse(c, resp)
+}
+
+// UpdateContributor .
+// @router /contributor/update [PUT]
+func UpdateContributor(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req catalog.UpdateCatalogEntryRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		pack.SendFailResponse(c, pack.BuildBindError(c, err))
+		return
+	}
+
+	resp := new(catalog.UpdateCatalogEntryResponse)
+
+	info, err := service.NewCatalogService(ctx, c).UpdateEntry(ctx, db.ContributorKind, req)
+	if err != nil {
+		pack.SendFailResponse(c, err)
+		return
+	}
+
+	resp.Base = pack.BuildBaseResp(nil)
+	resp.Data = pack.BuildCatalogEntryResp(info)
+
+	pack.SendResponse(c, resp)
+}
+
+// DeleteContributor .
+// @router /contributor/delete [DELETE]
+func DeleteContributor(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req catalog.DeleteCatalogEntryRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		pack.SendFailResponse(c, pack.BuildBindError(c, err))
+		return
+	}
+
+	resp := new(catalog.DeleteCatalogEntryResponse)
+
+	err = service.NewCatalogService(ctx, c).DeleteEntry(ctx, db.ContributorKind, req)
+	if err != nil {
+		pack.SendFailResponse(c, err)
+		return
+	}
+
+	resp.Base = pack.BuildBaseResp(nil)
+
+	pack.SendResponse(c, resp)
+}
+
+// GetContributor .
+// @router /contributor/get [GET]
+func GetContributor(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req catalog.GetCatalogEntryRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		pack.SendFailResponse(c, pack.BuildBindError(c, err))
+		return
+	}
+
+	resp := new(catalog.GetCatalogEntryResponse)
+
+	info, result, err := service.NewCatalogService(ctx, c).SearchEntry(ctx, db.ContributorKind, req)
+	if err != nil {
+		pack.SendFailResponse(c, err)
+		return
+	}
+
+	resp.Base = pack.BuildBaseResp(nil)
+	resp.Data = pack.BuildCatalogEntryListResp(info)
+	resp.Total = result.Total
+	resp.NextCursor = pack.BuildNextCursor(result)
+
+	pack.SendResponse(c, resp)
+}
+
+// AddSubject .
+// @router /subject/add [POST]
+func AddSubject(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req catalog.AddCatalogEntryRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		pack.SendFailResponse(c, pack.BuildBindError(c, err))
+		return
+	}
+
+	resp := new(catalog.AddCatalogEntryResponse)
+
+	info, err := service.NewCatalogService(ctx, c).AddEntry(ctx, db.SubjectKind, req)
+	if err != nil {
+		pack.SendFailResponse(c, err)
+		return
+	}
+
+	resp.Base = pack.BuildBaseResp(nil)
+	resp.Data = pack.BuildCatalogEntryResp(info)
+
+	pack.SendResponse(c, resp)
+}
+
+// UpdateSubject .
+// @router /subject/update [PUT]
+func UpdateSubject(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req catalog.UpdateCatalogEntryRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		pack.SendFailResponse(c, pack.BuildBindError(c, err))
+		return
+	}
+
+	resp := new(catalog.UpdateCatalogEntryResponse)
+
+	info, err := service.NewCatalogService(ctx, c).UpdateEntry(ctx, db.SubjectKind, req)
+	if err != nil {
+		pack.SendFailResponse(c, err)
+		return
+	}
+
+	resp.Base = pack.BuildBaseResp(nil)
+	resp.Data = pack.BuildCatalogEntryResp(info)
+
+	pack.SendResponse(c, resp)
+}
+
+// DeleteSubject .
+// @router /subject/delete [DELETE]
+func DeleteSubject(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req catalog.DeleteCatalogEntryRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		pack.SendFailResponse(c, pack.BuildBindError(c, err))
+		return
+	}
+
+	resp := new(catalog.DeleteCatalogEntryResponse)
+
+	err = service.NewCatalogService(ctx, c).DeleteEntry(ctx, db.SubjectKind, req)
+	if err != nil {
+		pack.SendFailResponse(c, err)
+		return
+	}
+
+	resp.Base = pack.BuildBaseResp(nil)
+
+	pack.SendResponse(c, resp)
+}
+
+// GetSubject .
+// @router /subject/get [GET]
+func GetSubject(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req catalog.GetCatalogEntryRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		pack.SendFailResponse(c, pack.BuildBindError(c, err))
+		return
+	}
+
+	resp := new(catalog.GetCatalogEntryResponse)
+
+	info, result, err := service.NewCatalogService(ctx, c).SearchEntry(ctx, db.SubjectKind, req)
+	if err != nil {
+		pack.SendFailResponse(c, err)
+		return
+	}
+
+	resp.Base = pack.BuildBaseResp(nil)
+	resp.Data = pack.BuildCatalogEntryListResp(info)
+	resp.Total = result.Total
+	resp.NextCursor = pack.BuildNextCursor(result)
+
+	pack.SendResponse(c, resp)
+}
+
+// AddSeries .
+// @router /series/add [POST]
+func AddSeries(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req catalog.AddCatalogEntryRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		pack.SendFailResponse(c, pack.BuildBindError(c, err))
+		return
+	}
+
+	resp := new(catalog.AddCatalogEntryResponse)
+
+	info, err := service.NewCatalogService(ctx, c).AddEntry(ctx, db.SeriesKind, req)
+	if err != nil {
+		pack.SendFailResponse(c, err)
+		return
+	}
+
+	resp.Base = pack.BuildBaseResp(nil)
+	resp.Data = pack.BuildCatalogEntryResp(info)
+
+	pack.SendResponse(c, resp)
+}
+
+// UpdateSeries .
+// @router /series/update [PUT]
+func UpdateSeries(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req catalog.UpdateCatalogEntryRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		pack.SendFailResponse(c, pack.BuildBindError(c, err))
+		return
+	}
+
+	resp := new(catalog.UpdateCatalogEntryResponse)
+
+	info, err := service.NewCatalogService(ctx, c).UpdateEntry(ctx, db.SeriesKind, req)
+	if err != nil {
+		pack.SendFailResponse(c, err)
+		return
+	}
+
+	resp.Base = pack.BuildBaseResp(nil)
+	resp.Data = pack.BuildCatalogEntryResp(info)
+
+	pack.SendResponse(c, resp)
+}
+
+// DeleteSeries .
+// @router /series/delete [DELETE]
+func DeleteSeries(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req catalog.DeleteCatalogEntryRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		pack.SendFailResponse(c, pack.BuildBindError(c, err))
+		return
+	}
+
+	resp := new(catalog.DeleteCatalogEntryResponse)
+
+	err = service.NewCatalogService(ctx, c).DeleteEntry(ctx, db.SeriesKind, req)
+	if err != nil {
+		pack.SendFailResponse(c, err)
+		return
+	}
+
+	resp.Base = pack.BuildBaseResp(nil)
+
+	pack.SendResponse(c, resp)
+}
+
+// GetSeries .
+// @router /series/get [GET]
+func GetSeries(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req catalog.GetCatalogEntryRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		pack.SendFailResponse(c, pack.BuildBindError(c, err))
+		return
+	}
+
+	resp := new(catalog.GetCatalogEntryResponse)
+
+	info, result, err := service.NewCatalogService(ctx, c).SearchEntry(ctx, db.SeriesKind, req)
+	if err != nil {
+		pack.SendFailResponse(c, err)
+		return
+	}
+
+	resp.Base = pack.BuildBaseResp(nil)
+	resp.Data = pack.BuildCatalogEntryListResp(info)
+	resp.Total = result.Total
+	resp.NextCursor = pack.BuildNextCursor(result)
+
+	pack.SendResponse(c, resp)
+}
@@ -0,0 +1,139 @@
+// Code generated by hertz generator.
+
+package category
+
+import (
+	"context"
+
+	"github.com/cloudwego/hertz/pkg/app"
+
+	category "github.com/2451965602/LMS/biz/model/category"
+	"github.com/2451965602/LMS/biz/pack"
+	"github.com/2451965602/LMS/biz/service"
+)
+
+// AddCategory .
+// @router /category/add [POST]
+func AddCategory(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req category.AddCategoryRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		pack.SendFailResponse(c, pack.BuildBindError(c, err))
+		return
+	}
+
+	resp := new(category.AddCategoryResponse)
+
+	info, err := service.NewCategoryService(ctx, c).AddCategory(ctx, req)
+	if err != nil {
+		pack.SendFailResponse(c, err)
+		return
+	}
+
+	resp.Base = pack.BuildBaseResp(nil)
+	resp.Data = pack.BuildCategoryResp(info)
+
+	pack.SendResponse(c, resp)
+}
+
+// UpdateCategory .
+// @router /category/update [PUT]
+func UpdateCategory(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req category.UpdateCategoryRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		pack.SendFailResponse(c, pack.BuildBindError(c, err))
+		return
+	}
+
+	resp := new(category.UpdateCategoryResponse)
+
+	info, err := service.NewCategoryService(ctx, c).UpdateCategory(ctx, req)
+	if err != nil {
+		pack.SendFailResponse(c, err)
+		return
+	}
+
+	resp.Base = pack.BuildBaseResp(nil)
+	resp.Data = pack.BuildCategoryResp(info)
+
+	pack.SendResponse(c, resp)
+}
+
+// DeleteCategory .
+// @router /category/delete [DELETE]
+func DeleteCategory(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req category.DeleteCategoryRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		pack.SendFailResponse(c, pack.BuildBindError(c, err))
+		return
+	}
+
+	resp := new(category.DeleteCategoryResponse)
+
+	err = service.NewCategoryService(ctx, c).DeleteCategory(ctx, req)
+	if err != nil {
+		pack.SendFailResponse(c, err)
+		return
+	}
+
+	resp.Base = pack.BuildBaseResp(nil)
+
+	pack.SendResponse(c, resp)
+}
+
+// GetCategory .
+// @router /category/get [GET]
+func GetCategory(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req category.GetCategoryRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		pack.SendFailResponse(c, pack.BuildBindError(c, err))
+		return
+	}
+
+	resp := new(category.GetCategoryResponse)
+
+	info, result, err := service.NewCategoryService(ctx, c).SearchCategory(ctx, req)
+	if err != nil {
+		pack.SendFailResponse(c, err)
+		return
+	}
+
+	resp.Base = pack.BuildBaseResp(nil)
+	resp.Data = pack.BuildCategoryListResp(info)
+	resp.Total = result.Total
+	resp.NextCursor = pack.BuildNextCursor(result)
+
+	pack.SendResponse(c, resp)
+}
+
+// GetCategoryPath .
+// @router /category/path [GET]
+func GetCategoryPath(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req category.GetCategoryPathRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		pack.SendFailResponse(c, pack.BuildBindError(c, err))
+		return
+	}
+
+	resp := new(category.GetCategoryPathResponse)
+
+	info, err := service.NewCategoryService(ctx, c).GetCategoryPath(ctx, req)
+	if err != nil {
+		pack.SendFailResponse(c, err)
+		return
+	}
+
+	resp.Base = pack.BuildBaseResp(nil)
+	resp.Data = pack.BuildCategoryListResp(info)
+
+	pack.SendResponse(c, resp)
+}
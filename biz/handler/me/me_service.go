@@ -0,0 +1,97 @@
+// Code generated by hertz generator.
+
+package me
+
+import (
+	"context"
+
+	"github.com/2451965602/LMS/biz/pack"
+	"github.com/2451965602/LMS/biz/service"
+
+	"github.com/cloudwego/hertz/pkg/app"
+
+	"github.com/2451965602/LMS/biz/model/me"
+)
+
+// GetSummary .
+// @router /me/summary [GET]
+func GetSummary(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req me.GetSummaryRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		pack.SendFailResponse(c, pack.BuildBindError(c, err))
+		return
+	}
+
+	resp := new(me.GetSummaryResponse)
+
+	summary, err := service.NewMeService(ctx, c).GetSummary(ctx, req)
+	if err != nil {
+		pack.SendFailResponse(c, err)
+		return
+	}
+
+	resp.Base = pack.BuildBaseResp(err)
+	resp.Loans = pack.BuildLoanListResp(summary.Loans)
+	resp.OverdueCount = summary.OverdueCount
+	resp.FineBalance = summary.FineBalance
+	resp.Holds = pack.BuildHoldListResp(summary.Holds)
+	resp.History = pack.BuildHistoryListResp(summary.History)
+	resp.HistoryTotal = summary.HistoryTotal
+
+	pack.SendResponse(c, resp)
+}
+
+// UpdatePrivacy .
+// @router /me/privacy [PUT]
+func UpdatePrivacy(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req me.UpdatePrivacyRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		pack.SendFailResponse(c, pack.BuildBindError(c, err))
+		return
+	}
+
+	resp := new(me.UpdatePrivacyResponse)
+
+	info, err := service.NewMeService(ctx, c).UpdatePrivacy(ctx, req)
+	if err != nil {
+		pack.SendFailResponse(c, err)
+		return
+	}
+
+	resp.Base = pack.BuildBaseResp(err)
+	resp.Data = pack.BuildUserResp(info)
+
+	pack.SendResponse(c, resp)
+}
+
+// ExportData .
+// @router /me/export [GET]
+func ExportData(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req me.ExportDataRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		pack.SendFailResponse(c, pack.BuildBindError(c, err))
+		return
+	}
+
+	resp := new(me.ExportDataResponse)
+
+	data, err := service.NewMeService(ctx, c).ExportData(ctx)
+	if err != nil {
+		pack.SendFailResponse(c, err)
+		return
+	}
+
+	resp.Base = pack.BuildBaseResp(err)
+	resp.User = pack.BuildUserResp(data.User)
+	resp.BorrowRecords = pack.BuildBorrowRecordListResp(data.BorrowRecords)
+	resp.Holds = pack.BuildHoldListResp(data.Holds)
+
+	c.Header("Content-Disposition", "attachment; filename=\"my-data.json\"")
+	pack.SendResponse(c, resp)
+}
@@ -0,0 +1,24 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/adaptor"
+
+	"github.com/2451965602/LMS/biz/pack"
+	"github.com/2451965602/LMS/pkg/errno"
+	"github.com/2451965602/LMS/pkg/metrics"
+)
+
+var metricsHandler = metrics.Handler()
+
+// Metrics 以 Prometheus 文本格式暴露服务指标
+func Metrics(ctx context.Context, c *app.RequestContext) {
+	req, err := adaptor.GetCompatRequest(&c.Request)
+	if err != nil {
+		pack.SendFailResponse(c, errno.InternalServiceError.WithError(err))
+		return
+	}
+	metricsHandler.ServeHTTP(adaptor.GetCompatResponseWriter(&c.Response), req)
+}
@@ -0,0 +1,261 @@
+// Code generated by hertz generator.
+
+package report
+
+import (
+	"context"
+
+	"github.com/2451965602/LMS/biz/pack"
+	"github.com/2451965602/LMS/biz/service"
+
+	"github.com/cloudwego/hertz/pkg/app"
+
+	"github.com/2451965602/LMS/biz/model/report"
+)
+
+// LoanStats .
+// @router /report/loans [GET]
+func LoanStats(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req report.LoanStatsRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		pack.SendFailResponse(c, pack.BuildBindError(c, err))
+		return
+	}
+
+	resp := new(report.LoanStatsResponse)
+
+	data, err := service.NewReportService(ctx, c).LoanStats(ctx, req)
+	if err != nil {
+		pack.SendFailResponse(c, err)
+		return
+	}
+
+	if pack.IsCSVFormat(req.Format) {
+		pack.SendCSVResponse(c, "loans.csv", pack.BuildPeriodCountCSV(data))
+		return
+	}
+
+	resp.Base = pack.BuildBaseResp(err)
+	resp.Data = pack.BuildPeriodCountListResp(data)
+
+	pack.SendResponse(c, resp)
+}
+
+// TopBorrowed .
+// @router /report/top [GET]
+func TopBorrowed(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req report.TopBorrowedRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		pack.SendFailResponse(c, pack.BuildBindError(c, err))
+		return
+	}
+
+	resp := new(report.TopBorrowedResponse)
+
+	data, err := service.NewReportService(ctx, c).TopBorrowed(ctx, req)
+	if err != nil {
+		pack.SendFailResponse(c, err)
+		return
+	}
+
+	if pack.IsCSVFormat(req.Format) {
+		pack.SendCSVResponse(c, "top_borrowed.csv", pack.BuildRankItemCSV(data))
+		return
+	}
+
+	resp.Base = pack.BuildBaseResp(err)
+	resp.Data = pack.BuildRankItemListResp(data)
+
+	pack.SendResponse(c, resp)
+}
+
+// Turnover .
+// @router /report/turnover [GET]
+func Turnover(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req report.TurnoverRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		pack.SendFailResponse(c, pack.BuildBindError(c, err))
+		return
+	}
+
+	resp := new(report.TurnoverResponse)
+
+	data, err := service.NewReportService(ctx, c).Turnover(ctx, req)
+	if err != nil {
+		pack.SendFailResponse(c, err)
+		return
+	}
+
+	if pack.IsCSVFormat(req.Format) {
+		pack.SendCSVResponse(c, "turnover.csv", pack.BuildCategoryTurnoverCSV(data))
+		return
+	}
+
+	resp.Base = pack.BuildBaseResp(err)
+	resp.Data = pack.BuildCategoryTurnoverListResp(data)
+
+	pack.SendResponse(c, resp)
+}
+
+// IdleCopies .
+// @router /report/idle [GET]
+func IdleCopies(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req report.IdleCopiesRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		pack.SendFailResponse(c, pack.BuildBindError(c, err))
+		return
+	}
+
+	resp := new(report.IdleCopiesResponse)
+
+	data, result, err := service.NewReportService(ctx, c).IdleCopies(ctx, req)
+	if err != nil {
+		pack.SendFailResponse(c, err)
+		return
+	}
+
+	if pack.IsCSVFormat(req.Format) {
+		pack.SetPageHeaders(c, result)
+		pack.SendCSVResponse(c, "idle_copies.csv", pack.BuildIdleCopyCSV(data))
+		return
+	}
+
+	resp.Base = pack.BuildBaseResp(err)
+	resp.Data = pack.BuildIdleCopyListResp(data)
+	resp.Total = result.Total
+	resp.NextCursor = pack.BuildNextCursor(result)
+
+	pack.SendResponse(c, resp)
+}
+
+// OverdueRate .
+// @router /report/overdue [GET]
+func OverdueRate(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req report.OverdueRateRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		pack.SendFailResponse(c, pack.BuildBindError(c, err))
+		return
+	}
+
+	resp := new(report.OverdueRateResponse)
+
+	data, err := service.NewReportService(ctx, c).OverdueRate(ctx, req)
+	if err != nil {
+		pack.SendFailResponse(c, err)
+		return
+	}
+
+	if pack.IsCSVFormat(req.Format) {
+		pack.SendCSVResponse(c, "overdue_rate.csv", pack.BuildRoleOverdueCSV(data))
+		return
+	}
+
+	resp.Base = pack.BuildBaseResp(err)
+	resp.Data = pack.BuildRoleOverdueListResp(data)
+
+	pack.SendResponse(c, resp)
+}
+
+// FineRevenue .
+// @router /report/fines [GET]
+func FineRevenue(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req report.FineRevenueRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		pack.SendFailResponse(c, pack.BuildBindError(c, err))
+		return
+	}
+
+	resp := new(report.FineRevenueResponse)
+
+	data, err := service.NewReportService(ctx, c).FineRevenue(ctx, req)
+	if err != nil {
+		pack.SendFailResponse(c, err)
+		return
+	}
+
+	if pack.IsCSVFormat(req.Format) {
+		pack.SendCSVResponse(c, "fine_revenue.csv", pack.BuildPeriodAmountCSV(data))
+		return
+	}
+
+	resp.Base = pack.BuildBaseResp(err)
+	resp.Data = pack.BuildPeriodAmountListResp(data)
+
+	pack.SendResponse(c, resp)
+}
+
+// CollectionValue .
+// @router /report/value [GET]
+func CollectionValue(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req report.CollectionValueRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		pack.SendFailResponse(c, pack.BuildBindError(c, err))
+		return
+	}
+
+	resp := new(report.CollectionValueResponse)
+
+	data, err := service.NewReportService(ctx, c).CollectionValue(ctx, req)
+	if err != nil {
+		pack.SendFailResponse(c, err)
+		return
+	}
+
+	if pack.IsCSVFormat(req.Format) {
+		pack.SendCSVResponse(c, "collection_value.csv", pack.BuildCategoryValueCSV(data))
+		return
+	}
+
+	resp.Base = pack.BuildBaseResp(err)
+	resp.Data = pack.BuildCategoryValueListResp(data)
+
+	pack.SendResponse(c, resp)
+}
+
+// Weeding .
+// @router /report/weeding [GET]
+func Weeding(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req report.WeedingRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		pack.SendFailResponse(c, pack.BuildBindError(c, err))
+		return
+	}
+
+	resp := new(report.WeedingResponse)
+
+	groups, data, result, err := service.NewReportService(ctx, c).Weeding(ctx, req)
+	if err != nil {
+		pack.SendFailResponse(c, err)
+		return
+	}
+
+	if pack.IsCSVFormat(req.Format) {
+		pack.SetPageHeaders(c, result)
+		pack.SendCSVResponse(c, "weeding.csv", pack.BuildWeedingCandidateCSV(data))
+		return
+	}
+
+	resp.Base = pack.BuildBaseResp(err)
+	resp.Groups = pack.BuildWeedingGroupListResp(groups)
+	resp.Data = pack.BuildWeedingCandidateListResp(data)
+	resp.Total = result.Total
+	resp.NextCursor = pack.BuildNextCursor(result)
+
+	pack.SendResponse(c, resp)
+}
@@ -20,7 +20,7 @@ func AdminUpdateUser(ctx context.Context, c *app.RequestContext) {
 	var req user.AdminUpdateUserRequest
 	err = c.BindAndValidate(&req)
 	if err != nil {
-		pack.SendFailResponse(c, err)
+		pack.SendFailResponse(c, pack.BuildBindError(c, err))
 		return
 	}
 
@@ -28,10 +28,19 @@ func AdminUpdateUser(ctx context.Context, c *app.RequestContext) {
 
 	info, err := service.NewUserService(ctx, c).AdminUpdateUser(ctx, req)
 	if err != nil {
-		pack.SendFailResponse(c, err)
+		if info == nil {
+			pack.SendFailResponse(c, err)
+			return
+		}
+		// 版本冲突时同时返回当前的数据，供客户端合并后重新提交
+		pack.SetETag(c, info.Version)
+		resp.Base = pack.BuildFailBaseResp(c, err)
+		resp.Data = pack.BuildUserResp(info)
+		pack.SendResponse(c, resp)
 		return
 	}
 
+	pack.SetETag(c, info.Version)
 	resp.Base = pack.BuildBaseResp(err)
 	resp.Data = pack.BuildUserResp(info)
 	pack.SendResponse(c, resp)
@@ -44,7 +53,7 @@ func AdminDeleteUser(ctx context.Context, c *app.RequestContext) {
 	var req user.AdminDeleteUserRequest
 	err = c.BindAndValidate(&req)
 	if err != nil {
-		pack.SendFailResponse(c, err)
+		pack.SendFailResponse(c, pack.BuildBindError(c, err))
 		return
 	}
 
@@ -58,3 +67,50 @@ func AdminDeleteUser(ctx context.Context, c *app.RequestContext) {
 	resp.Base = pack.BuildBaseResp(err)
 	pack.SendResponse(c, resp)
 }
+
+// SetTwoFactorPolicy .
+// @router /user/admin/2fa/policy [PUT]
+func SetTwoFactorPolicy(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req user.SetTwoFactorPolicyRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		pack.SendFailResponse(c, pack.BuildBindError(c, err))
+		return
+	}
+
+	resp := new(user.SetTwoFactorPolicyResponse)
+
+	err = service.NewTwoFactorService(ctx, c).SetPolicy(ctx, req.Role, req.Required)
+	if err != nil {
+		pack.SendFailResponse(c, err)
+		return
+	}
+
+	resp.Base = pack.BuildBaseResp(nil)
+	pack.SendResponse(c, resp)
+}
+
+// ListTwoFactorPolicy .
+// @router /user/admin/2fa/policy [GET]
+func ListTwoFactorPolicy(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req user.ListTwoFactorPolicyRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		pack.SendFailResponse(c, pack.BuildBindError(c, err))
+		return
+	}
+
+	resp := new(user.ListTwoFactorPolicyResponse)
+
+	policies, err := service.NewTwoFactorService(ctx, c).ListPolicies(ctx)
+	if err != nil {
+		pack.SendFailResponse(c, err)
+		return
+	}
+
+	resp.Base = pack.BuildBaseResp(nil)
+	resp.Data = pack.BuildTwoFactorPolicyListResp(policies)
+	pack.SendResponse(c, resp)
+}
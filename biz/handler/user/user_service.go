@@ -4,13 +4,20 @@ package user
 
 import (
 	"context"
+	"crypto/subtle"
+	"strings"
 
+	"github.com/2451965602/LMS/biz/dal/db"
 	mw "github.com/2451965602/LMS/biz/middleware"
 	"github.com/2451965602/LMS/biz/pack"
 	"github.com/2451965602/LMS/biz/service"
-	metainfoContext "github.com/2451965602/LMS/pkg/base/context"
+	"github.com/2451965602/LMS/config"
+	"github.com/2451965602/LMS/pkg/constants"
+	"github.com/2451965602/LMS/pkg/crypt"
+	"github.com/2451965602/LMS/pkg/errno"
 
 	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/protocol"
 
 	"github.com/2451965602/LMS/biz/model/user"
 )
@@ -22,13 +29,13 @@ func Register(ctx context.Context, c *app.RequestContext) {
 	var req user.RegisterRequest
 	err = c.BindAndValidate(&req)
 	if err != nil {
-		pack.SendFailResponse(c, err)
+		pack.SendFailResponse(c, pack.BuildBindError(c, err))
 		return
 	}
 
 	resp := new(user.RegisterResponse)
 
-	userId, err := service.NewUserService(ctx, c).Register(ctx, req.Username, req.Password, req.Phone)
+	userId, err := service.NewUserService(ctx, c).Register(ctx, req)
 	if err != nil {
 		pack.SendFailResponse(c, err)
 		return
@@ -46,7 +53,7 @@ func Login(ctx context.Context, c *app.RequestContext) {
 	var req user.LoginRequest
 	err = c.BindAndValidate(&req)
 	if err != nil {
-		pack.SendFailResponse(c, err)
+		pack.SendFailResponse(c, pack.BuildBindError(c, err))
 		return
 	}
 
@@ -58,14 +65,10 @@ func Login(ctx context.Context, c *app.RequestContext) {
 		return
 	}
 
-	mw.AccessTokenJwtMiddleware.LoginHandler(ctx, c)
-	mw.RefreshTokenJwtMiddleware.LoginHandler(ctx, c)
-
-	resp.Base = pack.BuildBaseResp(nil)
-	resp.Data = pack.BuildUserResp(info)
-
-	c.Header("Access-Token", c.GetString("Access-Token"))
-	c.Header("Refresh-Token", c.GetString("Refresh-Token"))
+	if err = completeLogin(ctx, c, info, resp); err != nil {
+		pack.SendFailResponse(c, err)
+		return
+	}
 
 	pack.SendResponse(c, resp)
 }
@@ -77,7 +80,7 @@ func UpdateUser(ctx context.Context, c *app.RequestContext) {
 	var req user.UpdateUserRequest
 	err = c.BindAndValidate(&req)
 	if err != nil {
-		pack.SendFailResponse(c, err)
+		pack.SendFailResponse(c, pack.BuildBindError(c, err))
 		return
 	}
 
@@ -102,7 +105,7 @@ func DeleteUser(ctx context.Context, c *app.RequestContext) {
 	var req user.DeleteUserRequest
 	err = c.BindAndValidate(&req)
 	if err != nil {
-		pack.SendFailResponse(c, err)
+		pack.SendFailResponse(c, pack.BuildBindError(c, err))
 		return
 	}
 
@@ -125,20 +128,25 @@ func GetUserInfo(ctx context.Context, c *app.RequestContext) {
 	var req user.UserInfoRequest
 	err = c.BindAndValidate(&req)
 	if err != nil {
-		pack.SendFailResponse(c, err)
+		pack.SendFailResponse(c, pack.BuildBindError(c, err))
 		return
 	}
 
 	resp := new(user.UserInfoResponse)
 
-	info, err := service.NewUserService(ctx, c).GetUserById(ctx, req.UserID)
+	info, full, err := service.NewUserService(ctx, c).GetUserInfo(ctx, req.UserID)
 	if err != nil {
 		pack.SendFailResponse(c, err)
 		return
 	}
 
 	resp.Base = pack.BuildBaseResp(nil)
-	resp.Data = pack.BuildUserResp(info)
+	if full {
+		pack.SetETag(c, info.Version) // 只有完整信息带有版本，公开信息不用于更新
+		resp.Data = pack.BuildUserResp(info)
+	} else {
+		resp.Profile = pack.BuildPublicUserResp(info)
+	}
 	pack.SendResponse(c, resp)
 }
 
@@ -146,14 +154,581 @@ func GetUserInfo(ctx context.Context, c *app.RequestContext) {
 // @router /user/refresh [POST]
 func RefreshToken(ctx context.Context, c *app.RequestContext) {
 	resp := new(user.RefreshTokenResponse)
-	userid, err := metainfoContext.GetLoginData(ctx)
+	claims, ok := mw.CurrentTokenClaims(ctx, c)
+	if !ok {
+		pack.SendFailResponse(c, errno.AuthInvalid)
+		return
+	}
+
+	// 轮换 Refresh Token，旧的 Refresh Token 从此失效
+	jti, err := service.NewSessionService(ctx, c).Refresh(ctx, claims.SessionID, claims.ID)
+	if err != nil {
+		pack.SendFailResponse(c, err)
+		return
+	}
+
+	accessToken, err := mw.GenerateToken(constants.TokenTypeAccess, mw.TokenClaims{
+		UserID:    claims.UserID,
+		Version:   claims.Version,
+		SessionID: claims.SessionID,
+	})
+	if err != nil {
+		pack.SendFailResponse(c, err)
+		return
+	}
+	refreshToken, err := mw.GenerateToken(constants.TokenTypeRefresh, mw.TokenClaims{
+		ID:        jti,
+		UserID:    claims.UserID,
+		Version:   claims.Version,
+		SessionID: claims.SessionID,
+	})
+	if err != nil {
+		pack.SendFailResponse(c, err)
+		return
+	}
+
+	c.Header("New-Access-Token", accessToken)
+	c.Header("New-Refresh-Token", refreshToken)
+
+	resp.Base = pack.BuildBaseResp(nil)
+	pack.SendResponse(c, resp)
+}
+
+// Logout .
+// @router /user/logout [POST]
+func Logout(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req user.LogoutRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		pack.SendFailResponse(c, pack.BuildBindError(c, err))
+		return
+	}
+
+	resp := new(user.LogoutResponse)
+
+	claims, ok := mw.CurrentTokenClaims(ctx, c)
+	if !ok {
+		pack.SendFailResponse(c, errno.AuthInvalid)
+		return
+	}
+
+	// 吊销当前会话，该会话的 Access Token 和 Refresh Token 同时失效
+	err = service.NewSessionService(ctx, c).RevokeSession(ctx, claims.SessionID)
 	if err != nil {
 		pack.SendFailResponse(c, err)
 		return
 	}
 
-	mw.GenerateAccessToken(c, userid)
+	resp.Base = pack.BuildBaseResp(nil)
+	pack.SendResponse(c, resp)
+}
+
+// LogoutAll .
+// @router /user/logout/all [POST]
+func LogoutAll(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req user.LogoutAllRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		pack.SendFailResponse(c, pack.BuildBindError(c, err))
+		return
+	}
+
+	resp := new(user.LogoutAllResponse)
+
+	err = service.NewUserService(ctx, c).LogoutAll(ctx)
+	if err != nil {
+		pack.SendFailResponse(c, err)
+		return
+	}
+
+	resp.Base = pack.BuildBaseResp(nil)
+	pack.SendResponse(c, resp)
+}
+
+// ListSessions .
+// @router /user/session/list [GET]
+func ListSessions(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req user.ListSessionsRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		pack.SendFailResponse(c, pack.BuildBindError(c, err))
+		return
+	}
+
+	resp := new(user.ListSessionsResponse)
+
+	claims, ok := mw.CurrentTokenClaims(ctx, c)
+	if !ok {
+		pack.SendFailResponse(c, errno.AuthInvalid)
+		return
+	}
+
+	sessions, err := service.NewSessionService(ctx, c).ListSessions(ctx)
+	if err != nil {
+		pack.SendFailResponse(c, err)
+		return
+	}
+
+	resp.Base = pack.BuildBaseResp(nil)
+	resp.Data = pack.BuildSessionListResp(sessions, claims.SessionID)
+	pack.SendResponse(c, resp)
+}
+
+// RevokeSession .
+// @router /user/session/revoke [DELETE]
+func RevokeSession(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req user.RevokeSessionRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		pack.SendFailResponse(c, pack.BuildBindError(c, err))
+		return
+	}
+
+	resp := new(user.RevokeSessionResponse)
+
+	err = service.NewSessionService(ctx, c).RevokeSession(ctx, req.SessionID)
+	if err != nil {
+		pack.SendFailResponse(c, err)
+		return
+	}
+
+	resp.Base = pack.BuildBaseResp(nil)
+	pack.SendResponse(c, resp)
+}
+
+// ChangePassword .
+// @router /user/password [PUT]
+func ChangePassword(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req user.ChangePasswordRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		pack.SendFailResponse(c, pack.BuildBindError(c, err))
+		return
+	}
+
+	resp := new(user.ChangePasswordResponse)
+
+	err = service.NewUserService(ctx, c).ChangePassword(ctx, req.OldPassword, req.NewPassword)
+	if err != nil {
+		pack.SendFailResponse(c, err)
+		return
+	}
+
+	resp.Base = pack.BuildBaseResp(nil)
+	pack.SendResponse(c, resp)
+}
+
+// SendResetCode .
+// @router /user/password/reset/code [POST]
+func SendResetCode(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req user.SendResetCodeRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		pack.SendFailResponse(c, pack.BuildBindError(c, err))
+		return
+	}
+
+	resp := new(user.SendResetCodeResponse)
+
+	err = service.NewUserService(ctx, c).SendResetCode(ctx, req.Username)
+	if err != nil {
+		pack.SendFailResponse(c, err)
+		return
+	}
+
+	resp.Base = pack.BuildBaseResp(nil)
+	pack.SendResponse(c, resp)
+}
+
+// ResetPassword .
+// @router /user/password/reset [POST]
+func ResetPassword(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req user.ResetPasswordRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		pack.SendFailResponse(c, pack.BuildBindError(c, err))
+		return
+	}
+
+	resp := new(user.ResetPasswordResponse)
+
+	err = service.NewUserService(ctx, c).ResetPassword(ctx, req.Username, req.Code, req.NewPassword)
+	if err != nil {
+		pack.SendFailResponse(c, err)
+		return
+	}
+
+	resp.Base = pack.BuildBaseResp(nil)
+	pack.SendResponse(c, resp)
+}
+
+// LoginTwoFactor .
+// @router /user/login/2fa [POST]
+func LoginTwoFactor(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req user.LoginTwoFactorRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		pack.SendFailResponse(c, pack.BuildBindError(c, err))
+		return
+	}
+
+	resp := new(user.LoginTwoFactorResponse)
+
+	claims, err := mw.ParseChallengeToken(req.ChallengeToken)
+	if err != nil {
+		pack.SendFailResponse(c, err)
+		return
+	}
+
+	info, err := service.NewTwoFactorService(ctx, c).VerifyLogin(ctx, claims.UserID, claims.Version, req.Code)
+	if err != nil {
+		pack.SendFailResponse(c, err)
+		return
+	}
+
+	if err = issueTokens(ctx, c, info); err != nil {
+		pack.SendFailResponse(c, err)
+		return
+	}
+
+	resp.Base = pack.BuildBaseResp(nil)
+	resp.Data = pack.BuildUserResp(info)
+	pack.SendResponse(c, resp)
+}
+
+// SetupTwoFactor .
+// @router /user/2fa/setup [POST]
+func SetupTwoFactor(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req user.SetupTwoFactorRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		pack.SendFailResponse(c, pack.BuildBindError(c, err))
+		return
+	}
+
+	resp := new(user.SetupTwoFactorResponse)
+
+	secret, uri, err := service.NewTwoFactorService(ctx, c).Setup(ctx)
+	if err != nil {
+		pack.SendFailResponse(c, err)
+		return
+	}
+
+	resp.Base = pack.BuildBaseResp(nil)
+	resp.Secret = secret
+	resp.ProvisioningURI = uri
+	pack.SendResponse(c, resp)
+}
+
+// EnableTwoFactor .
+// @router /user/2fa/enable [POST]
+func EnableTwoFactor(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req user.EnableTwoFactorRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		pack.SendFailResponse(c, pack.BuildBindError(c, err))
+		return
+	}
+
+	resp := new(user.EnableTwoFactorResponse)
+
+	codes, err := service.NewTwoFactorService(ctx, c).Enable(ctx, req.Code)
+	if err != nil {
+		pack.SendFailResponse(c, err)
+		return
+	}
+
+	resp.Base = pack.BuildBaseResp(nil)
+	resp.RecoveryCodes = codes
+	pack.SendResponse(c, resp)
+}
+
+// DisableTwoFactor .
+// @router /user/2fa/disable [POST]
+func DisableTwoFactor(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req user.DisableTwoFactorRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		pack.SendFailResponse(c, pack.BuildBindError(c, err))
+		return
+	}
+
+	resp := new(user.DisableTwoFactorResponse)
+
+	err = service.NewTwoFactorService(ctx, c).Disable(ctx, req.Password, req.Code)
+	if err != nil {
+		pack.SendFailResponse(c, err)
+		return
+	}
+
+	resp.Base = pack.BuildBaseResp(nil)
+	pack.SendResponse(c, resp)
+}
+
+// RegenerateRecoveryCodes .
+// @router /user/2fa/recovery [POST]
+func RegenerateRecoveryCodes(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req user.RegenerateRecoveryCodesRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		pack.SendFailResponse(c, pack.BuildBindError(c, err))
+		return
+	}
+
+	resp := new(user.RegenerateRecoveryCodesResponse)
+
+	codes, err := service.NewTwoFactorService(ctx, c).RegenerateRecoveryCodes(ctx, req.Code)
+	if err != nil {
+		pack.SendFailResponse(c, err)
+		return
+	}
+
+	resp.Base = pack.BuildBaseResp(nil)
+	resp.RecoveryCodes = codes
+	pack.SendResponse(c, resp)
+}
+
+// completeLogin 完成登录并填充登录响应
+// 启用了两步验证时只返回挑战Token，通过 /user/login/2fa 校验验证码后再签发Token。
+func completeLogin(ctx context.Context, c *app.RequestContext, info *db.User, resp *user.LoginResponse) error {
+	resp.Base = pack.BuildBaseResp(nil)
+	if info.TOTPEnabled {
+		challenge, err := mw.GenerateToken(constants.TokenTypeChallenge, mw.TokenClaims{UserID: info.ID, Version: info.TokenVersion})
+		if err != nil {
+			return err
+		}
+		resp.ChallengeToken = &challenge
+		return nil
+	}
+
+	if err := issueTokens(ctx, c, info); err != nil {
+		return err
+	}
+	resp.Data = pack.BuildUserResp(info)
+	return nil
+}
+
+// oidcAuthorizeURL 生成 OIDC 登录页地址
+// state 参数为签名的Token，其中的 jti 作为 nonce；关联外部账号时还带有发起关联的用户ID和Token版本。
+// nonce 同时写入 Cookie，回调时只接受当前浏览器发起的 state，避免攻击者诱导用户完成攻击者发起的登录或关联。
+func oidcAuthorizeURL(ctx context.Context, c *app.RequestContext, claims mw.TokenClaims) (string, error) {
+	nonce, err := crypt.RandomToken(constants.TokenIDLength)
+	if err != nil {
+		return "", err
+	}
+	claims.ID = nonce
+	state, err := mw.GenerateToken(constants.TokenTypeOIDCState, claims)
+	if err != nil {
+		return "", err
+	}
+	authURL, err := service.NewSSOService(ctx, c).OIDCAuthorizeURL(ctx, state, nonce)
+	if err != nil {
+		return "", err
+	}
+	setOIDCNonceCookie(c, nonce, int(constants.OIDCStateTTL.Seconds()))
+	return authURL, nil
+}
+
+// checkOIDCNonceCookie 检查 state 中的 nonce 与当前浏览器的 Cookie 一致，并清除该 Cookie
+func checkOIDCNonceCookie(c *app.RequestContext, nonce string) error {
+	cookie := c.Cookie(constants.OIDCNonceCookie)
+	setOIDCNonceCookie(c, "", -1)
+	if len(cookie) == 0 || subtle.ConstantTimeCompare(cookie, []byte(nonce)) != 1 {
+		return errno.Errorf(errno.AuthInvalidCode, "oidc state was not issued to this browser")
+	}
+	return nil
+}
+
+// setOIDCNonceCookie 设置保存 nonce 的 Cookie，maxAge 小于 0 时删除
+// 身份提供方通过浏览器跳转回调接口，SameSite 需为 Lax；回调地址为 HTTPS 时只通过 HTTPS 发送。
+func setOIDCNonceCookie(c *app.RequestContext, nonce string, maxAge int) {
+	secure := strings.HasPrefix(config.SSO.OIDC.RedirectURL, "https://")
+	c.SetCookie(constants.OIDCNonceCookie, nonce, maxAge, constants.OIDCCookiePath, "", protocol.CookieSameSiteLaxMode, secure, true)
+}
+
+// issueTokens 为登录成功的用户创建会话，并在响应头中返回 Access Token 和 Refresh Token
+func issueTokens(ctx context.Context, c *app.RequestContext, info *db.User) error {
+	session, jti, err := service.NewSessionService(ctx, c).Create(ctx, info.ID)
+	if err != nil {
+		return err
+	}
+
+	claims := mw.TokenClaims{UserID: info.ID, Version: info.TokenVersion, SessionID: session.ID}
+	accessToken, err := mw.GenerateToken(constants.TokenTypeAccess, claims)
+	if err != nil {
+		return err
+	}
+	claims.ID = jti
+	refreshToken, err := mw.GenerateToken(constants.TokenTypeRefresh, claims)
+	if err != nil {
+		return err
+	}
+
+	c.Header("Access-Token", accessToken)
+	c.Header("Refresh-Token", refreshToken)
+	return nil
+}
+
+// LdapLogin .
+// @router /user/sso/ldap/login [POST]
+func LdapLogin(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req user.LDAPLoginRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		pack.SendFailResponse(c, pack.BuildBindError(c, err))
+		return
+	}
+
+	resp := new(user.LoginResponse)
+
+	info, err := service.NewSSOService(ctx, c).LDAPLogin(ctx, req.Username, req.Password)
+	if err != nil {
+		pack.SendFailResponse(c, err)
+		return
+	}
+
+	if err = completeLogin(ctx, c, info, resp); err != nil {
+		pack.SendFailResponse(c, err)
+		return
+	}
+
+	pack.SendResponse(c, resp)
+}
+
+// OidcAuthorize .
+// @router /user/sso/oidc/authorize [GET]
+func OidcAuthorize(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req user.OIDCAuthorizeRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		pack.SendFailResponse(c, pack.BuildBindError(c, err))
+		return
+	}
+
+	resp := new(user.OIDCAuthorizeResponse)
+
+	url, err := oidcAuthorizeURL(ctx, c, mw.TokenClaims{})
+	if err != nil {
+		pack.SendFailResponse(c, err)
+		return
+	}
+
+	resp.Base = pack.BuildBaseResp(nil)
+	resp.AuthorizationURL = url
+	pack.SendResponse(c, resp)
+}
+
+// OidcCallback .
+// @router /user/sso/oidc/callback [GET]
+func OidcCallback(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req user.OIDCCallbackRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		pack.SendFailResponse(c, pack.BuildBindError(c, err))
+		return
+	}
+
+	resp := new(user.LoginResponse)
+
+	state, err := mw.ParseOIDCStateToken(req.State)
+	if err != nil {
+		pack.SendFailResponse(c, err)
+		return
+	}
+	if err = checkOIDCNonceCookie(c, state.ID); err != nil {
+		pack.SendFailResponse(c, err)
+		return
+	}
+
+	if state.UserID != 0 { // state 中带有用户ID时为关联外部账号，不签发新的Token
+		info, err := service.NewSSOService(ctx, c).LinkOIDC(ctx, state.UserID, state.Version, req.Code, state.ID)
+		if err != nil {
+			pack.SendFailResponse(c, err)
+			return
+		}
+		resp.Base = pack.BuildBaseResp(nil)
+		resp.Data = pack.BuildUserResp(info)
+		pack.SendResponse(c, resp)
+		return
+	}
+
+	info, err := service.NewSSOService(ctx, c).OIDCLogin(ctx, req.Code, state.ID)
+	if err != nil {
+		pack.SendFailResponse(c, err)
+		return
+	}
+
+	if err = completeLogin(ctx, c, info, resp); err != nil {
+		pack.SendFailResponse(c, err)
+		return
+	}
+
+	pack.SendResponse(c, resp)
+}
+
+// LinkLDAP .
+// @router /user/sso/ldap/link [POST]
+func LinkLDAP(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req user.LinkLDAPRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		pack.SendFailResponse(c, pack.BuildBindError(c, err))
+		return
+	}
+
+	resp := new(user.LinkLDAPResponse)
+
+	err = service.NewSSOService(ctx, c).LinkLDAP(ctx, req.Username, req.Password)
+	if err != nil {
+		pack.SendFailResponse(c, err)
+		return
+	}
+
+	resp.Base = pack.BuildBaseResp(nil)
+	pack.SendResponse(c, resp)
+}
+
+// LinkOIDC .
+// @router /user/sso/oidc/link [GET]
+func LinkOIDC(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req user.LinkOIDCRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		pack.SendFailResponse(c, pack.BuildBindError(c, err))
+		return
+	}
+
+	resp := new(user.OIDCAuthorizeResponse)
+
+	claims, ok := mw.CurrentTokenClaims(ctx, c)
+	if !ok {
+		pack.SendFailResponse(c, errno.AuthInvalid)
+		return
+	}
+	url, err := oidcAuthorizeURL(ctx, c, mw.TokenClaims{UserID: claims.UserID, Version: claims.Version})
+	if err != nil {
+		pack.SendFailResponse(c, err)
+		return
+	}
 
 	resp.Base = pack.BuildBaseResp(nil)
+	resp.AuthorizationURL = url
 	pack.SendResponse(c, resp)
 }
@@ -0,0 +1,251 @@
+package mw
+
+import (
+	"crypto"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+	jwtv4 "github.com/golang-jwt/jwt/v4"
+
+	"github.com/2451965602/LMS/config"
+	"github.com/2451965602/LMS/pkg/constants"
+)
+
+// signingKey 单个JWT密钥，sign 为空时只能用于校验
+type signingKey struct {
+	id     string
+	method jwtv4.SigningMethod
+	sign   interface{} // 签名用的密钥：HMAC 为 []byte，RS256 为 *rsa.PrivateKey，EdDSA 为 ed25519.PrivateKey
+	verify interface{} // 校验用的密钥：HMAC 为 []byte，RS256 为 *rsa.PublicKey，EdDSA 为 ed25519.PublicKey
+}
+
+// keySet 按 kid 索引的密钥集合，active 为当前用于签发的密钥
+type keySet struct {
+	keys   map[string]*signingKey
+	active *signingKey
+}
+
+var (
+	keysMu     sync.Mutex
+	keys       *keySet
+	keysSource interface{} // 生成 keys 时使用的配置，配置热更新后会重新加载
+)
+
+// currentKeys 返回当前配置对应的密钥集合，配置变化时重新加载
+// 新配置加载失败时继续使用旧的密钥集合，避免错误的配置导致所有Token失效。
+func currentKeys() (*keySet, error) {
+	keysMu.Lock()
+	defer keysMu.Unlock()
+
+	if keys != nil && keysSource == interface{}(config.JWT) {
+		return keys, nil
+	}
+
+	ks, err := loadKeySet()
+	if err != nil {
+		if keys != nil {
+			hlog.Errorf("mw.currentKeys: reload jwt keys failed, keep using previous keys: %v", err)
+			keysSource = config.JWT
+			return keys, nil
+		}
+		return nil, err
+	}
+	keys, keysSource = ks, config.JWT
+	return keys, nil
+}
+
+// loadKeySet 根据 config.JWT 加载所有密钥
+// 1. 逐个解析配置中的密钥，未提供 HMAC 密钥的条目会被跳过。
+// 2. 选出 activeKid 对应的密钥用于签发，该密钥必须包含签名材料。
+// 3. 没有任何可用密钥时生成一个临时的 HMAC 密钥，服务重启后已签发的Token会失效。
+func loadKeySet() (*keySet, error) {
+	ks := &keySet{keys: make(map[string]*signingKey)}
+	if config.JWT == nil {
+		return ephemeralKeySet()
+	}
+
+	for _, k := range config.JWT.Keys {
+		if k.Kid == "" {
+			return nil, errors.New("jwt key without kid")
+		}
+		if _, ok := ks.keys[k.Kid]; ok {
+			return nil, fmt.Errorf("duplicate jwt kid %q", k.Kid)
+		}
+
+		algorithm := k.Algorithm
+		if algorithm == "" {
+			algorithm = constants.JWTDefaultAlgorithm
+		}
+		var (
+			key *signingKey
+			err error
+		)
+		switch algorithm {
+		case jwtv4.SigningMethodHS256.Alg():
+			secret := k.Secret
+			if k.SecretEnv != "" && os.Getenv(k.SecretEnv) != "" {
+				secret = os.Getenv(k.SecretEnv)
+			}
+			if secret == "" {
+				hlog.Warnf("mw.loadKeySet: jwt key %q has no secret, skipped", k.Kid)
+				continue
+			}
+			key, err = hmacKey(k.Kid, secret)
+		case jwtv4.SigningMethodRS256.Alg():
+			key, err = rsaKey(k.Kid, k.PrivateKeyFile, k.PublicKeyFile)
+		case jwtv4.SigningMethodEdDSA.Alg():
+			key, err = ed25519Key(k.Kid, k.PrivateKeyFile, k.PublicKeyFile)
+		default:
+			return nil, fmt.Errorf("jwt key %q: unsupported algorithm %q", k.Kid, algorithm)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("jwt key %q: %w", k.Kid, err)
+		}
+		ks.keys[k.Kid] = key
+	}
+
+	if len(ks.keys) == 0 {
+		return ephemeralKeySet()
+	}
+
+	active, ok := ks.keys[config.JWT.ActiveKid]
+	if !ok {
+		return nil, fmt.Errorf("active jwt kid %q not found", config.JWT.ActiveKid)
+	}
+	if active.sign == nil {
+		return nil, fmt.Errorf("active jwt key %q has no private key", active.id)
+	}
+	ks.active = active
+	return ks, nil
+}
+
+// hmacKey 使用 HMAC 密钥创建 HS256 密钥
+func hmacKey(kid, secret string) (*signingKey, error) {
+	if len(secret) < constants.JWTSecretMinLength {
+		return nil, fmt.Errorf("secret must be at least %d bytes", constants.JWTSecretMinLength)
+	}
+	return &signingKey{
+		id:     kid,
+		method: jwtv4.SigningMethodHS256,
+		sign:   []byte(secret),
+		verify: []byte(secret),
+	}, nil
+}
+
+// rsaKey 读取 PEM 格式的 RSA 密钥，只提供私钥时从私钥推导公钥
+func rsaKey(kid, privateKeyFile, publicKeyFile string) (*signingKey, error) {
+	key := &signingKey{id: kid, method: jwtv4.SigningMethodRS256}
+	if privateKeyFile != "" {
+		data, err := os.ReadFile(privateKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		priv, err := jwtv4.ParseRSAPrivateKeyFromPEM(data)
+		if err != nil {
+			return nil, err
+		}
+		key.sign, key.verify = priv, &priv.PublicKey
+	}
+	if publicKeyFile != "" {
+		data, err := os.ReadFile(publicKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		pub, err := jwtv4.ParseRSAPublicKeyFromPEM(data)
+		if err != nil {
+			return nil, err
+		}
+		key.verify = pub
+	}
+	if key.verify == nil {
+		return nil, errors.New("privateKeyFile or publicKeyFile is required")
+	}
+	return key, nil
+}
+
+// ed25519Key 读取 PEM 格式的 Ed25519 密钥，只提供私钥时从私钥推导公钥
+func ed25519Key(kid, privateKeyFile, publicKeyFile string) (*signingKey, error) {
+	key := &signingKey{id: kid, method: jwtv4.SigningMethodEdDSA}
+	if privateKeyFile != "" {
+		data, err := os.ReadFile(privateKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		priv, err := jwtv4.ParseEdPrivateKeyFromPEM(data)
+		if err != nil {
+			return nil, err
+		}
+		signer, ok := priv.(interface{ Public() crypto.PublicKey })
+		if !ok {
+			return nil, errors.New("invalid ed25519 private key")
+		}
+		key.sign, key.verify = priv, signer.Public()
+	}
+	if publicKeyFile != "" {
+		data, err := os.ReadFile(publicKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		pub, err := jwtv4.ParseEdPublicKeyFromPEM(data)
+		if err != nil {
+			return nil, err
+		}
+		key.verify = pub
+	}
+	if key.verify == nil {
+		return nil, errors.New("privateKeyFile or publicKeyFile is required")
+	}
+	return key, nil
+}
+
+// ephemeralKeySet 生成一个仅在当前进程内有效的 HMAC 密钥
+func ephemeralKeySet() (*keySet, error) {
+	secret := make([]byte, constants.JWTSecretMinLength)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, fmt.Errorf("generate ephemeral jwt key: %w", err)
+	}
+	hlog.Warn("mw.loadKeySet: no jwt key configured, using an ephemeral key; tokens will be invalid after restart")
+
+	key := &signingKey{
+		id:     constants.JWTEphemeralKeyID,
+		method: jwtv4.SigningMethodHS256,
+		sign:   secret,
+		verify: secret,
+	}
+	return &keySet{
+		keys:   map[string]*signingKey{key.id: key},
+		active: key,
+	}, nil
+}
+
+// keyFunc 根据Token头部的 kid 选择校验密钥，并确认签名算法与密钥一致
+func keyFunc(token *jwtv4.Token) (interface{}, error) {
+	ks, err := currentKeys()
+	if err != nil {
+		return nil, err
+	}
+	kid, _ := token.Header["kid"].(string)
+	key, ok := ks.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown jwt kid %q", kid)
+	}
+	if token.Method.Alg() != key.method.Alg() {
+		return nil, fmt.Errorf("jwt kid %q: unexpected signing algorithm %q", kid, token.Method.Alg())
+	}
+	return key.verify, nil
+}
+
+// signToken 使用当前的签发密钥签名，并在头部写入 kid
+func signToken(claims jwtv4.MapClaims) (string, error) {
+	ks, err := currentKeys()
+	if err != nil {
+		return "", err
+	}
+	token := jwtv4.NewWithClaims(ks.active.method, claims)
+	token.Header["kid"] = ks.active.id
+	return token.SignedString(ks.active.sign)
+}
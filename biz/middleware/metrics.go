@@ -0,0 +1,28 @@
+package mw
+
+import (
+	"context"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/app"
+
+	"github.com/2451965602/LMS/pkg/constants"
+	"github.com/2451965602/LMS/pkg/errno"
+	"github.com/2451965602/LMS/pkg/metrics"
+)
+
+// Metrics 记录每个请求的次数和耗时，按路由和响应中的业务错误码区分
+func Metrics() app.HandlerFunc {
+	return func(ctx context.Context, c *app.RequestContext) {
+		start := time.Now()
+		c.Next(ctx)
+
+		code := int64(errno.SuccessCode) // 未记录错误码的请求视为成功
+		if v, ok := c.Get(constants.ErrnoKey); ok {
+			if n, ok := v.(int64); ok {
+				code = n
+			}
+		}
+		metrics.ObserveRequest(string(c.Method()), c.FullPath(), code, time.Since(start))
+	}
+}
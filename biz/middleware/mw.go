@@ -7,13 +7,13 @@ import (
 
 	"github.com/cloudwego/hertz/pkg/app"
 	"github.com/cloudwego/hertz/pkg/common/hlog"
+	jwtv4 "github.com/golang-jwt/jwt/v4"
 	"github.com/hertz-contrib/jwt"
 
 	"github.com/2451965602/LMS/biz/dal/db"
-	"github.com/2451965602/LMS/biz/model/user"
 	"github.com/2451965602/LMS/biz/pack"
-	metainfoContext "github.com/2451965602/LMS/pkg/base/context"
 	"github.com/2451965602/LMS/pkg/constants"
+	"github.com/2451965602/LMS/pkg/crypt"
 	"github.com/2451965602/LMS/pkg/errno"
 )
 
@@ -23,9 +23,11 @@ var (
 )
 
 // initJWTCommonConfig 初始化JWT中间件的通用配置
+// 签名密钥由 keyFunc 按Token头部的 kid 选择，Token的签发统一由 GenerateToken 完成。
 func initJWTCommonConfig() *jwt.HertzJWTMiddleware {
 	return &jwt.HertzJWTMiddleware{
 		Realm:                       "LMS",                 // JWT的Realm名称
+		KeyFunc:                     keyFunc,               // 根据 kid 选择校验密钥
 		WithoutDefaultTokenHeadName: true,                  // 不使用默认的Token头名称
 		IdentityKey:                 constants.IdentityKey, // 用户身份标识的键名
 		Unauthorized: func(ctx context.Context, c *app.RequestContext, code int, message string) {
@@ -37,42 +39,17 @@ func initJWTCommonConfig() *jwt.HertzJWTMiddleware {
 // AccessTokenJwt 初始化Access Token的JWT中间件
 func AccessTokenJwt() {
 	config := initJWTCommonConfig()
-	config.Key = []byte("AccessToken_key")       // Access Token的密钥
 	config.Timeout = constants.AccessTokenTTL    // Access Token的有效期
 	config.TokenLookup = "header: Authorization" // 从请求头的Authorization字段查找Token
 
-	config.PayloadFunc = func(data interface{}) jwt.MapClaims {
-		return jwt.MapClaims{
-			config.IdentityKey: data,     // 用户身份标识
-			"token_type":       "access", // Token类型为Access Token
-		}
-	}
-
 	config.IdentityHandler = func(ctx context.Context, c *app.RequestContext) interface{} {
 		claims := jwt.ExtractClaims(ctx, c) // 提取JWT中的Claims
 		return claims[config.IdentityKey]   // 返回用户身份标识
 	}
 
-	config.LoginResponse = func(ctx context.Context, c *app.RequestContext, code int, token string, expire time.Time) {
-		c.Set("Access-Token", token) // 将Access Token设置到上下文中
-	}
-
-	config.Authenticator = func(ctx context.Context, c *app.RequestContext) (interface{}, error) {
-		var loginStruct user.LoginRequest
-		if err := c.BindAndValidate(&loginStruct); err != nil { // 绑定并验证登录请求
-			return nil, err
-		}
-		users, err := db.LoginUser(ctx, loginStruct.Username, loginStruct.Password) // 验证用户登录
-		if err != nil {
-			return nil, err
-		}
-		ctx = metainfoContext.WithLoginData(ctx, users.ID) // 将用户ID设置到上下文中
-		return users.ID, nil
-	}
-
 	config.Authorizator = func(data interface{}, ctx context.Context, c *app.RequestContext) bool {
-		claims := jwt.ExtractClaims(ctx, c)                                              // 提取JWT中的Claims
-		if tokenType, ok := claims["token_type"].(string); ok && tokenType == "access" { // 检查Token类型是否为Access Token
+		claims := jwt.ExtractClaims(ctx, c)                                                                         // 提取JWT中的Claims
+		if tokenType, ok := claims[constants.TokenTypeKey].(string); ok && tokenType == constants.TokenTypeAccess { // 检查Token类型是否为Access Token
 			return true
 		}
 		return false
@@ -88,42 +65,17 @@ func AccessTokenJwt() {
 // RefreshTokenJwt 初始化Refresh Token的JWT中间件
 func RefreshTokenJwt() {
 	config := initJWTCommonConfig()
-	config.Key = []byte("refresh_token_key")     // Refresh Token的密钥
 	config.Timeout = constants.RefreshTokenTTL   // Refresh Token的有效期
 	config.TokenLookup = "header: Refresh-Token" // 从请求头的Refresh-Token字段查找Token
 
-	config.PayloadFunc = func(data interface{}) jwt.MapClaims {
-		return jwt.MapClaims{
-			config.IdentityKey: data,      // 用户身份标识
-			"token_type":       "refresh", // Token类型为Refresh Token
-		}
-	}
-
 	config.IdentityHandler = func(ctx context.Context, c *app.RequestContext) interface{} {
 		claims := jwt.ExtractClaims(ctx, c) // 提取JWT中的Claims
 		return claims[config.IdentityKey]   // 返回用户身份标识
 	}
 
-	config.LoginResponse = func(ctx context.Context, c *app.RequestContext, code int, token string, expire time.Time) {
-		c.Set("Refresh-Token", token) // 将Refresh Token设置到上下文中
-	}
-
-	config.Authenticator = func(ctx context.Context, c *app.RequestContext) (interface{}, error) {
-		var loginStruct user.LoginRequest
-		if err := c.BindAndValidate(&loginStruct); err != nil { // 绑定并验证登录请求
-			return nil, err
-		}
-		users, err := db.LoginUser(ctx, loginStruct.Username, loginStruct.Password) // 验证用户登录
-		if err != nil {
-			return nil, err
-		}
-		ctx = metainfoContext.WithLoginData(ctx, users.ID) // 将用户ID设置到上下文中
-		return users.ID, nil
-	}
-
 	config.Authorizator = func(data interface{}, ctx context.Context, c *app.RequestContext) bool {
-		claims := jwt.ExtractClaims(ctx, c)                                               // 提取JWT中的Claims
-		if tokenType, ok := claims["token_type"].(string); ok && tokenType == "refresh" { // 检查Token类型是否为Refresh Token
+		claims := jwt.ExtractClaims(ctx, c)                                                                          // 提取JWT中的Claims
+		if tokenType, ok := claims[constants.TokenTypeKey].(string); ok && tokenType == constants.TokenTypeRefresh { // 检查Token类型是否为Refresh Token
 			return true
 		}
 		return false
@@ -136,10 +88,173 @@ func RefreshTokenJwt() {
 	}
 }
 
-// GenerateAccessToken 生成新的Access Token
-func GenerateAccessToken(c *app.RequestContext, userId int64) {
-	tokenString, _, _ := AccessTokenJwtMiddleware.TokenGenerator(userId) // 调用Token生成器生成新的Access Token
-	c.Header("New-Access-Token", tokenString)                            // 将新的Access Token设置到响应头中
+// TokenClaims 签发Token时写入的用户信息
+type TokenClaims struct {
+	ID        string // Token的唯一ID（jti），为空时自动生成
+	UserID    int64  // 用户ID
+	Version   int64  // 用户当前的Token版本，版本变化后之前签发的Token全部失效
+	SessionID int64  // Token所属的登录会话ID，会话吊销后该会话的Token全部失效
+}
+
+// GenerateToken 签发指定类型的Token
+// 参数：
+//   - tokenType: Token类型，constants.TokenTypeAccess、constants.TokenTypeRefresh、constants.TokenTypeChallenge 或 constants.TokenTypeOIDCState
+//   - claims: 写入Token的用户信息
+//
+// 返回值：
+//   - string: 签名后的Token
+//   - error: 错误信息，如果签名失败会返回错误
+func GenerateToken(tokenType string, claims TokenClaims) (string, error) {
+	ttl := constants.AccessTokenTTL
+	switch tokenType {
+	case constants.TokenTypeRefresh:
+		ttl = constants.RefreshTokenTTL
+	case constants.TokenTypeChallenge:
+		ttl = constants.ChallengeTokenTTL
+	case constants.TokenTypeOIDCState:
+		ttl = constants.OIDCStateTTL
+	}
+
+	if claims.ID == "" {
+		id, err := crypt.RandomToken(constants.TokenIDLength)
+		if err != nil {
+			return "", err
+		}
+		claims.ID = id
+	}
+
+	now := time.Now()
+	token, err := signToken(jwtv4.MapClaims{
+		constants.IdentityKey:     claims.UserID,
+		constants.TokenTypeKey:    tokenType,
+		constants.TokenIDKey:      claims.ID,
+		constants.TokenVersionKey: claims.Version,
+		constants.SessionIDKey:    claims.SessionID,
+		"iat":                     now.Unix(),
+		"exp":                     now.Add(ttl).Unix(),
+	})
+	if err != nil {
+		return "", errno.Errorf(errno.InternalServiceErrorCode, "sign %s token failed: %v", tokenType, err)
+	}
+	return token, nil
+}
+
+// CurrentTokenClaims 返回当前请求中已校验Token的用户信息，需在鉴权中间件之后调用
+func CurrentTokenClaims(ctx context.Context, c *app.RequestContext) (*TokenClaims, bool) {
+	claims := jwt.ExtractClaims(ctx, c)
+	jti, ok := claims[constants.TokenIDKey].(string)
+	if !ok || jti == "" {
+		return nil, false
+	}
+	userId, ok := claimInt64(claims[constants.IdentityKey])
+	if !ok {
+		return nil, false
+	}
+	version, ok := claimInt64(claims[constants.TokenVersionKey])
+	if !ok {
+		return nil, false
+	}
+	sessionId, ok := claimInt64(claims[constants.SessionIDKey])
+	if !ok {
+		return nil, false
+	}
+	return &TokenClaims{
+		ID:        jti,
+		UserID:    userId,
+		Version:   version,
+		SessionID: sessionId,
+	}, true
+}
+
+// ParseChallengeToken 校验两步验证挑战Token并返回其中的用户信息
+// 挑战Token由客户端放在请求体中提交，不经过鉴权中间件，因此在此处单独校验签名、有效期和类型。
+// 参数：
+//   - token: 挑战Token
+//
+// 返回值：
+//   - *TokenClaims: Token中的用户ID和签发时的Token版本
+//   - error: 错误信息，如果Token无效、过期或类型不符会返回错误
+func ParseChallengeToken(token string) (*TokenClaims, error) {
+	return parseToken(token, constants.TokenTypeChallenge)
+}
+
+// ParseOIDCStateToken 校验 OIDC 登录回调中的 state 参数
+// 参数：
+//   - token: 回调中的 state 参数
+//
+// 返回值：
+//   - *TokenClaims: ID 为 nonce；关联外部账号时 UserID 和 Version 为发起关联的用户及其Token版本，登录时均为 0
+//   - error: 错误信息，如果 state 无效、过期或类型不符会返回错误
+func ParseOIDCStateToken(token string) (*TokenClaims, error) {
+	return parseToken(token, constants.TokenTypeOIDCState)
+}
+
+// parseToken 校验不经过鉴权中间件的Token的签名、有效期和类型
+func parseToken(token, tokenType string) (*TokenClaims, error) {
+	parsed, err := jwtv4.Parse(token, keyFunc)
+	if err != nil || !parsed.Valid {
+		return nil, errno.Errorf(errno.AuthInvalidCode, "invalid %s token", tokenType)
+	}
+	claims, ok := parsed.Claims.(jwtv4.MapClaims)
+	if !ok {
+		return nil, errno.Errorf(errno.AuthInvalidCode, "invalid %s token", tokenType)
+	}
+	if t, ok := claims[constants.TokenTypeKey].(string); !ok || t != tokenType {
+		return nil, errno.Errorf(errno.AuthInvalidCode, "invalid %s token", tokenType)
+	}
+	if _, ok := claims["exp"]; !ok { // 没有 exp 时 jwt 库不会检查有效期
+		return nil, errno.Errorf(errno.AuthInvalidCode, "invalid %s token", tokenType)
+	}
+	userId, ok := claimInt64(claims[constants.IdentityKey])
+	if !ok {
+		return nil, errno.Errorf(errno.AuthInvalidCode, "invalid %s token", tokenType)
+	}
+	version, ok := claimInt64(claims[constants.TokenVersionKey])
+	if !ok {
+		return nil, errno.Errorf(errno.AuthInvalidCode, "invalid %s token", tokenType)
+	}
+	jti, _ := claims[constants.TokenIDKey].(string)
+	return &TokenClaims{ID: jti, UserID: userId, Version: version}, nil
+}
+
+// isTokenActive 检查Token是否仍然有效
+// 1. Token必须带有 jti、ver 和 sid。
+// 2. sid 对应的会话必须属于该用户、未被吊销且未过期。
+// 3. ver 必须等于用户当前的Token版本。
+// 4. 将用户是否必须修改密码、是否必须先启用两步验证写入请求上下文，供鉴权中间件限制可访问的接口。
+func isTokenActive(ctx context.Context, c *app.RequestContext, claims jwt.MapClaims, userId int64) bool {
+	if jti, ok := claims[constants.TokenIDKey].(string); !ok || jti == "" {
+		return false
+	}
+	version, ok := claimInt64(claims[constants.TokenVersionKey])
+	if !ok {
+		return false
+	}
+	sessionId, ok := claimInt64(claims[constants.SessionIDKey])
+	if !ok {
+		return false
+	}
+
+	state, err := db.GetSessionAuthState(ctx, sessionId, userId)
+	if err != nil || state.TokenVersion != version {
+		return false
+	}
+	c.Set(constants.MustChangePasswordKey, state.MustChangePassword)
+	c.Set(constants.TwoFactorSetupRequiredKey, state.TwoFactorSetupRequired)
+	return true
+}
+
+// claimInt64 将 Claims 中的数值转换为 int64
+func claimInt64(v interface{}) (int64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return int64(n), true
+	case json.Number:
+		i, err := n.Int64()
+		return i, err == nil
+	default:
+		return 0, false
+	}
 }
 
 // IsAccessTokenAvailable 检查Access Token是否有效
@@ -149,7 +264,7 @@ func IsAccessTokenAvailable(ctx context.Context, c *app.RequestContext) (bool, i
 		return false, 0
 	}
 
-	if tokenType, ok := claims["token_type"].(string); !ok || tokenType != "access" { // 检查Token类型是否为Access Token
+	if tokenType, ok := claims[constants.TokenTypeKey].(string); !ok || tokenType != constants.TokenTypeAccess { // 检查Token类型是否为Access Token
 		return false, 0
 	}
 
@@ -182,6 +297,10 @@ func IsAccessTokenAvailable(ctx context.Context, c *app.RequestContext) (bool, i
 		return false, 0
 	}
 
+	if !isTokenActive(ctx, c, claims, userID) { // 检查Token所属会话和版本是否仍然有效
+		return false, 0
+	}
+
 	if identity != nil {
 		c.Set(AccessTokenJwtMiddleware.IdentityKey, identity) // 将用户身份标识设置到上下文中
 	}
@@ -197,7 +316,7 @@ func IsRefreshTokenAvailable(ctx context.Context, c *app.RequestContext) (bool,
 		return false, 0
 	}
 
-	if tokenType, ok := claims["token_type"].(string); !ok || tokenType != "refresh" { // 检查Token类型是否为Refresh Token
+	if tokenType, ok := claims[constants.TokenTypeKey].(string); !ok || tokenType != constants.TokenTypeRefresh { // 检查Token类型是否为Refresh Token
 		return false, 0
 	}
 
@@ -230,6 +349,10 @@ func IsRefreshTokenAvailable(ctx context.Context, c *app.RequestContext) (bool,
 		return false, 0
 	}
 
+	if !isTokenActive(ctx, c, claims, userID) { // 检查Token所属会话和版本是否仍然有效
+		return false, 0
+	}
+
 	if identity != nil {
 		c.Set(RefreshTokenJwtMiddleware.IdentityKey, identity) // 将用户身份标识设置到上下文中
 	}
@@ -240,6 +363,10 @@ func IsRefreshTokenAvailable(ctx context.Context, c *app.RequestContext) (bool,
 
 // Init 初始化JWT中间件
 func Init() {
+	if _, err := currentKeys(); err != nil { // 加载JWT签名密钥
+		hlog.Fatal("JWT keys Error:" + err.Error())
+	}
+
 	AccessTokenJwt()  // 初始化Access Token的JWT中间件
 	RefreshTokenJwt() // 初始化Refresh Token的JWT中间件
 
@@ -0,0 +1,40 @@
+package mw
+
+import (
+	"context"
+
+	"github.com/cloudwego/hertz/pkg/app"
+
+	"github.com/2451965602/LMS/pkg/constants"
+	"github.com/2451965602/LMS/pkg/crypt"
+)
+
+// RequestID 为每个请求分配请求ID，写入请求上下文和响应头
+// 请求头中带有合法的 X-Request-Id 时沿用，便于和网关、客户端的日志对应；否则生成新的ID。
+// 内部错误的响应只返回错误码和请求ID，排查时按请求ID查找日志中的实际原因。
+func RequestID() app.HandlerFunc {
+	return func(ctx context.Context, c *app.RequestContext) {
+		id := string(c.GetHeader(constants.RequestIDHeader))
+		if !validRequestID(id) {
+			id, _ = crypt.RandomToken(constants.RequestIDLength)
+		}
+		c.Set(constants.RequestIDKey, id)
+		c.Header(constants.RequestIDHeader, id)
+		c.Next(ctx)
+	}
+}
+
+// validRequestID 检查客户端传入的请求ID，只接受字母、数字和 -_. ，避免日志注入
+func validRequestID(id string) bool {
+	if id == "" || len(id) > constants.RequestIDMaxLength {
+		return false
+	}
+	for _, r := range id {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+		default:
+			return false
+		}
+	}
+	return true
+}
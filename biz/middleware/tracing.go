@@ -0,0 +1,92 @@
+package mw
+
+import (
+	"context"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+	"github.com/cloudwego/hertz/pkg/protocol"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/2451965602/LMS/pkg/constants"
+	"github.com/2451965602/LMS/pkg/errno"
+	"github.com/2451965602/LMS/pkg/tracing"
+)
+
+// Tracing 为每个请求创建一个服务端 span，并在响应头中返回追踪ID
+// 请求头中带有 W3C traceparent 时沿用上游的追踪ID。
+// 业务错误码会记录在 span 上，内部错误（500xx）同时将 span 标记为失败，并输出带请求ID的实际原因和调用栈。
+func Tracing() app.HandlerFunc {
+	return func(ctx context.Context, c *app.RequestContext) {
+		method := string(c.Method())
+		route := c.FullPath()
+		name := method + " " + route
+		if route == "" {
+			name = method // 未匹配到路由时不使用原始路径，避免 span 名称数量无限增长
+		}
+
+		ctx = otel.GetTextMapPropagator().Extract(ctx, headerCarrier{&c.Request.Header})
+		ctx, span := tracing.Start(ctx, name,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(method),
+				semconv.HTTPRoute(route),
+				semconv.URLPath(string(c.Request.URI().Path())),
+				semconv.ClientAddress(c.ClientIP()),
+			),
+		)
+		defer span.End()
+
+		c.Header(constants.TraceIDHeader, span.SpanContext().TraceID().String())
+		c.Next(ctx)
+
+		span.SetAttributes(semconv.HTTPResponseStatusCode(c.Response.StatusCode()))
+		v, ok := c.Get(constants.ErrnoKey)
+		if !ok {
+			return
+		}
+		code, _ := v.(int64)
+		msg := c.GetString(constants.ErrmsgKey)
+		requestID := c.GetString(constants.RequestIDKey)
+		span.SetAttributes(
+			attribute.Int64("lms.errno", code),
+			attribute.String("lms.errmsg", msg),
+			attribute.String("lms.request_id", requestID),
+		)
+		if errno.IsInternal(code) {
+			span.SetStatus(codes.Error, msg)
+			var stack string
+			if v, ok := c.Get(constants.ErrorKey); ok {
+				if e, ok := v.(errno.ErrNo); ok {
+					stack = e.Stack()
+				}
+			}
+			hlog.CtxErrorf(ctx, "%s %s: request_id=%s errno=%d cause=%s\n%s", method, c.Request.URI().Path(), requestID, code, msg, stack)
+		}
+	}
+}
+
+// headerCarrier 将 Hertz 的请求头适配为 OpenTelemetry 的 TextMapCarrier
+type headerCarrier struct {
+	header *protocol.RequestHeader
+}
+
+func (h headerCarrier) Get(key string) string {
+	return string(h.header.Peek(key))
+}
+
+func (h headerCarrier) Set(key, value string) {
+	h.header.Set(key, value)
+}
+
+func (h headerCarrier) Keys() []string {
+	keys := make([]string, 0, h.header.Len())
+	h.header.VisitAll(func(k, _ []byte) {
+		keys = append(keys, string(k))
+	})
+	return keys
+}
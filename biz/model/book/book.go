@@ -5,8 +5,10 @@ package book
 import (
 	"context"
 	"fmt"
-	"github.com/2451965602/LMS/biz/model/model"
+
 	"github.com/apache/thrift/lib/go/thrift"
+
+	"github.com/2451965602/LMS/biz/model/model"
 )
 
 type AddBookRequest struct {
@@ -1430,10 +1432,21 @@ func (p *DeleteBookResponse) String() string {
 }
 
 type GetBookRequest struct {
-	BookID   *int64  `thrift:"book_id,1,optional" form:"book_id" json:"book_id,omitempty" query:"book_id"`
-	ISBN     *string `thrift:"ISBN,2,optional" form:"ISBN" json:"ISBN,omitempty" query:"ISBN"`
-	PageSize int64   `thrift:"page_size,3,required" form:"page_size,required" json:"page_size,required" query:"page_size,required"`
-	PageNum  int64   `thrift:"page_num,4,required" form:"page_num,required" json:"page_num,required" query:"page_num,required"`
+	BookID             *int64   `thrift:"book_id,1,optional" form:"book_id" json:"book_id,omitempty" query:"book_id"`
+	ISBN               *string  `thrift:"ISBN,2,optional" form:"ISBN" json:"ISBN,omitempty" query:"ISBN"`
+	PageSize           *int64   `thrift:"page_size,3,optional" form:"page_size" json:"page_size,omitempty" query:"page_size"`
+	PageNum            *int64   `thrift:"page_num,4,optional" form:"page_num" json:"page_num,omitempty" query:"page_num"`
+	Cursor             *string  `thrift:"cursor,5,optional" form:"cursor" json:"cursor,omitempty" query:"cursor"`
+	Sort               *string  `thrift:"sort,6,optional" form:"sort" json:"sort,omitempty" query:"sort"`
+	WithTotal          *bool    `thrift:"with_total,7,optional" form:"with_total" json:"with_total,omitempty" query:"with_total"`
+	Status             []string `thrift:"status,8,optional" form:"status" json:"status,omitempty" query:"status"`
+	LocationPrefix     *string  `thrift:"location_prefix,9,optional" form:"location_prefix" json:"location_prefix,omitempty" query:"location_prefix"`
+	PurchasedAfter     *int64   `thrift:"purchased_after,10,optional" form:"purchased_after" json:"purchased_after,omitempty" query:"purchased_after"`
+	PurchasedBefore    *int64   `thrift:"purchased_before,11,optional" form:"purchased_before" json:"purchased_before,omitempty" query:"purchased_before"`
+	MinPrice           *float64 `thrift:"min_price,12,optional" form:"min_price" json:"min_price,omitempty" query:"min_price"`
+	MaxPrice           *float64 `thrift:"max_price,13,optional" form:"max_price" json:"max_price,omitempty" query:"max_price"`
+	LastCheckoutBefore *int64   `thrift:"last_checkout_before,14,optional" form:"last_checkout_before" json:"last_checkout_before,omitempty" query:"last_checkout_before"`
+	WithBookType       *bool    `thrift:"with_book_type,15,optional" form:"with_book_type" json:"with_book_type,omitempty" query:"with_book_type"`
 }
 
 func NewGetBookRequest() *GetBookRequest {
@@ -1461,19 +1474,139 @@ func (p *GetBookRequest) GetISBN() (v string) {
 	return *p.ISBN
 }
 
+var GetBookRequest_PageSize_DEFAULT int64
+
 func (p *GetBookRequest) GetPageSize() (v int64) {
-	return p.PageSize
+	if !p.IsSetPageSize() {
+		return GetBookRequest_PageSize_DEFAULT
+	}
+	return *p.PageSize
 }
 
+var GetBookRequest_PageNum_DEFAULT int64
+
 func (p *GetBookRequest) GetPageNum() (v int64) {
-	return p.PageNum
+	if !p.IsSetPageNum() {
+		return GetBookRequest_PageNum_DEFAULT
+	}
+	return *p.PageNum
+}
+
+var GetBookRequest_Cursor_DEFAULT string
+
+func (p *GetBookRequest) GetCursor() (v string) {
+	if !p.IsSetCursor() {
+		return GetBookRequest_Cursor_DEFAULT
+	}
+	return *p.Cursor
+}
+
+var GetBookRequest_Sort_DEFAULT string
+
+func (p *GetBookRequest) GetSort() (v string) {
+	if !p.IsSetSort() {
+		return GetBookRequest_Sort_DEFAULT
+	}
+	return *p.Sort
+}
+
+var GetBookRequest_WithTotal_DEFAULT bool
+
+func (p *GetBookRequest) GetWithTotal() (v bool) {
+	if !p.IsSetWithTotal() {
+		return GetBookRequest_WithTotal_DEFAULT
+	}
+	return *p.WithTotal
+}
+
+var GetBookRequest_Status_DEFAULT []string
+
+func (p *GetBookRequest) GetStatus() (v []string) {
+	if !p.IsSetStatus() {
+		return GetBookRequest_Status_DEFAULT
+	}
+	return p.Status
+}
+
+var GetBookRequest_LocationPrefix_DEFAULT string
+
+func (p *GetBookRequest) GetLocationPrefix() (v string) {
+	if !p.IsSetLocationPrefix() {
+		return GetBookRequest_LocationPrefix_DEFAULT
+	}
+	return *p.LocationPrefix
+}
+
+var GetBookRequest_PurchasedAfter_DEFAULT int64
+
+func (p *GetBookRequest) GetPurchasedAfter() (v int64) {
+	if !p.IsSetPurchasedAfter() {
+		return GetBookRequest_PurchasedAfter_DEFAULT
+	}
+	return *p.PurchasedAfter
+}
+
+var GetBookRequest_PurchasedBefore_DEFAULT int64
+
+func (p *GetBookRequest) GetPurchasedBefore() (v int64) {
+	if !p.IsSetPurchasedBefore() {
+		return GetBookRequest_PurchasedBefore_DEFAULT
+	}
+	return *p.PurchasedBefore
+}
+
+var GetBookRequest_MinPrice_DEFAULT float64
+
+func (p *GetBookRequest) GetMinPrice() (v float64) {
+	if !p.IsSetMinPrice() {
+		return GetBookRequest_MinPrice_DEFAULT
+	}
+	return *p.MinPrice
+}
+
+var GetBookRequest_MaxPrice_DEFAULT float64
+
+func (p *GetBookRequest) GetMaxPrice() (v float64) {
+	if !p.IsSetMaxPrice() {
+		return GetBookRequest_MaxPrice_DEFAULT
+	}
+	return *p.MaxPrice
+}
+
+var GetBookRequest_LastCheckoutBefore_DEFAULT int64
+
+func (p *GetBookRequest) GetLastCheckoutBefore() (v int64) {
+	if !p.IsSetLastCheckoutBefore() {
+		return GetBookRequest_LastCheckoutBefore_DEFAULT
+	}
+	return *p.LastCheckoutBefore
+}
+
+var GetBookRequest_WithBookType_DEFAULT bool
+
+func (p *GetBookRequest) GetWithBookType() (v bool) {
+	if !p.IsSetWithBookType() {
+		return GetBookRequest_WithBookType_DEFAULT
+	}
+	return *p.WithBookType
 }
 
 var fieldIDToName_GetBookRequest = map[int16]string{
-	1: "book_id",
-	2: "ISBN",
-	3: "page_size",
-	4: "page_num",
+	1:  "book_id",
+	2:  "ISBN",
+	3:  "page_size",
+	4:  "page_num",
+	5:  "cursor",
+	6:  "sort",
+	7:  "with_total",
+	8:  "status",
+	9:  "location_prefix",
+	10: "purchased_after",
+	11: "purchased_before",
+	12: "min_price",
+	13: "max_price",
+	14: "last_checkout_before",
+	15: "with_book_type",
 }
 
 func (p *GetBookRequest) IsSetBookID() bool {
@@ -1484,11 +1617,61 @@ func (p *GetBookRequest) IsSetISBN() bool {
 	return p.ISBN != nil
 }
 
+func (p *GetBookRequest) IsSetPageSize() bool {
+	return p.PageSize != nil
+}
+
+func (p *GetBookRequest) IsSetPageNum() bool {
+	return p.PageNum != nil
+}
+
+func (p *GetBookRequest) IsSetCursor() bool {
+	return p.Cursor != nil
+}
+
+func (p *GetBookRequest) IsSetSort() bool {
+	return p.Sort != nil
+}
+
+func (p *GetBookRequest) IsSetWithTotal() bool {
+	return p.WithTotal != nil
+}
+
+func (p *GetBookRequest) IsSetStatus() bool {
+	return p.Status != nil
+}
+
+func (p *GetBookRequest) IsSetLocationPrefix() bool {
+	return p.LocationPrefix != nil
+}
+
+func (p *GetBookRequest) IsSetPurchasedAfter() bool {
+	return p.PurchasedAfter != nil
+}
+
+func (p *GetBookRequest) IsSetPurchasedBefore() bool {
+	return p.PurchasedBefore != nil
+}
+
+func (p *GetBookRequest) IsSetMinPrice() bool {
+	return p.MinPrice != nil
+}
+
+func (p *GetBookRequest) IsSetMaxPrice() bool {
+	return p.MaxPrice != nil
+}
+
+func (p *GetBookRequest) IsSetLastCheckoutBefore() bool {
+	return p.LastCheckoutBefore != nil
+}
+
+func (p *GetBookRequest) IsSetWithBookType() bool {
+	return p.WithBookType != nil
+}
+
 func (p *GetBookRequest) Read(iprot thrift.TProtocol) (err error) {
 	var fieldTypeId thrift.TType
 	var fieldId int16
-	var issetPageSize bool = false
-	var issetPageNum bool = false
 
 	if _, err = iprot.ReadStructBegin(); err != nil {
 		goto ReadStructBeginError
@@ -1525,7 +1708,6 @@ func (p *GetBookRequest) Read(iprot thrift.TProtocol) (err error) {
 				if err = p.ReadField3(iprot); err != nil {
 					goto ReadFieldError
 				}
-				issetPageSize = true
 			} else if err = iprot.Skip(fieldTypeId); err != nil {
 				goto SkipFieldError
 			}
@@ -1534,7 +1716,94 @@ func (p *GetBookRequest) Read(iprot thrift.TProtocol) (err error) {
 				if err = p.ReadField4(iprot); err != nil {
 					goto ReadFieldError
 				}
-				issetPageNum = true
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		case 5:
+			if fieldTypeId == thrift.STRING {
+				if err = p.ReadField5(iprot); err != nil {
+					goto ReadFieldError
+				}
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		case 6:
+			if fieldTypeId == thrift.STRING {
+				if err = p.ReadField6(iprot); err != nil {
+					goto ReadFieldError
+				}
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		case 7:
+			if fieldTypeId == thrift.BOOL {
+				if err = p.ReadField7(iprot); err != nil {
+					goto ReadFieldError
+				}
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		case 8:
+			if fieldTypeId == thrift.LIST {
+				if err = p.ReadField8(iprot); err != nil {
+					goto ReadFieldError
+				}
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		case 9:
+			if fieldTypeId == thrift.STRING {
+				if err = p.ReadField9(iprot); err != nil {
+					goto ReadFieldError
+				}
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		case 10:
+			if fieldTypeId == thrift.I64 {
+				if err = p.ReadField10(iprot); err != nil {
+					goto ReadFieldError
+				}
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		case 11:
+			if fieldTypeId == thrift.I64 {
+				if err = p.ReadField11(iprot); err != nil {
+					goto ReadFieldError
+				}
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		case 12:
+			if fieldTypeId == thrift.DOUBLE {
+				if err = p.ReadField12(iprot); err != nil {
+					goto ReadFieldError
+				}
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		case 13:
+			if fieldTypeId == thrift.DOUBLE {
+				if err = p.ReadField13(iprot); err != nil {
+					goto ReadFieldError
+				}
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		case 14:
+			if fieldTypeId == thrift.I64 {
+				if err = p.ReadField14(iprot); err != nil {
+					goto ReadFieldError
+				}
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		case 15:
+			if fieldTypeId == thrift.BOOL {
+				if err = p.ReadField15(iprot); err != nil {
+					goto ReadFieldError
+				}
 			} else if err = iprot.Skip(fieldTypeId); err != nil {
 				goto SkipFieldError
 			}
@@ -1551,15 +1820,6 @@ func (p *GetBookRequest) Read(iprot thrift.TProtocol) (err error) {
 		goto ReadStructEndError
 	}
 
-	if !issetPageSize {
-		fieldId = 3
-		goto RequiredFieldNotSetError
-	}
-
-	if !issetPageNum {
-		fieldId = 4
-		goto RequiredFieldNotSetError
-	}
 	return nil
 ReadStructBeginError:
 	return thrift.PrependError(fmt.Sprintf("%T read struct begin error: ", p), err)
@@ -1574,8 +1834,6 @@ ReadFieldEndError:
 	return thrift.PrependError(fmt.Sprintf("%T read field end error", p), err)
 ReadStructEndError:
 	return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
-RequiredFieldNotSetError:
-	return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("required field %s is not set", fieldIDToName_GetBookRequest[fieldId]))
 }
 
 func (p *GetBookRequest) ReadField1(iprot thrift.TProtocol) error {
@@ -1602,124 +1860,1046 @@ func (p *GetBookRequest) ReadField2(iprot thrift.TProtocol) error {
 }
 func (p *GetBookRequest) ReadField3(iprot thrift.TProtocol) error {
 
-	var _field int64
+	var _field *int64
 	if v, err := iprot.ReadI64(); err != nil {
 		return err
 	} else {
-		_field = v
+		_field = &v
 	}
 	p.PageSize = _field
 	return nil
 }
 func (p *GetBookRequest) ReadField4(iprot thrift.TProtocol) error {
 
-	var _field int64
+	var _field *int64
 	if v, err := iprot.ReadI64(); err != nil {
 		return err
 	} else {
-		_field = v
+		_field = &v
 	}
 	p.PageNum = _field
 	return nil
 }
+func (p *GetBookRequest) ReadField5(iprot thrift.TProtocol) error {
 
-func (p *GetBookRequest) Write(oprot thrift.TProtocol) (err error) {
-	var fieldId int16
-	if err = oprot.WriteStructBegin("GetBookRequest"); err != nil {
-		goto WriteStructBeginError
-	}
-	if p != nil {
-		if err = p.writeField1(oprot); err != nil {
-			fieldId = 1
-			goto WriteFieldError
-		}
-		if err = p.writeField2(oprot); err != nil {
-			fieldId = 2
-			goto WriteFieldError
-		}
-		if err = p.writeField3(oprot); err != nil {
-			fieldId = 3
-			goto WriteFieldError
-		}
-		if err = p.writeField4(oprot); err != nil {
-			fieldId = 4
-			goto WriteFieldError
-		}
-	}
-	if err = oprot.WriteFieldStop(); err != nil {
-		goto WriteFieldStopError
+	var _field *string
+	if v, err := iprot.ReadString(); err != nil {
+		return err
+	} else {
+		_field = &v
 	}
-	if err = oprot.WriteStructEnd(); err != nil {
-		goto WriteStructEndError
+	p.Cursor = _field
+	return nil
+}
+func (p *GetBookRequest) ReadField6(iprot thrift.TProtocol) error {
+
+	var _field *string
+	if v, err := iprot.ReadString(); err != nil {
+		return err
+	} else {
+		_field = &v
 	}
+	p.Sort = _field
 	return nil
-WriteStructBeginError:
-	return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
-WriteFieldError:
-	return thrift.PrependError(fmt.Sprintf("%T write field %d error: ", p, fieldId), err)
-WriteFieldStopError:
-	return thrift.PrependError(fmt.Sprintf("%T write field stop error: ", p), err)
-WriteStructEndError:
-	return thrift.PrependError(fmt.Sprintf("%T write struct end error: ", p), err)
 }
+func (p *GetBookRequest) ReadField7(iprot thrift.TProtocol) error {
 
-func (p *GetBookRequest) writeField1(oprot thrift.TProtocol) (err error) {
-	if p.IsSetBookID() {
-		if err = oprot.WriteFieldBegin("book_id", thrift.I64, 1); err != nil {
-			goto WriteFieldBeginError
-		}
-		if err := oprot.WriteI64(*p.BookID); err != nil {
-			return err
-		}
-		if err = oprot.WriteFieldEnd(); err != nil {
-			goto WriteFieldEndError
-		}
+	var _field *bool
+	if v, err := iprot.ReadBool(); err != nil {
+		return err
+	} else {
+		_field = &v
 	}
+	p.WithTotal = _field
 	return nil
-WriteFieldBeginError:
-	return thrift.PrependError(fmt.Sprintf("%T write field 1 begin error: ", p), err)
-WriteFieldEndError:
-	return thrift.PrependError(fmt.Sprintf("%T write field 1 end error: ", p), err)
 }
-func (p *GetBookRequest) writeField2(oprot thrift.TProtocol) (err error) {
-	if p.IsSetISBN() {
-		if err = oprot.WriteFieldBegin("ISBN", thrift.STRING, 2); err != nil {
-			goto WriteFieldBeginError
-		}
-		if err := oprot.WriteString(*p.ISBN); err != nil {
+func (p *GetBookRequest) ReadField8(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return err
+	}
+	_field := make([]string, 0, size)
+	for i := 0; i < size; i++ {
+
+		var _elem string
+		if v, err := iprot.ReadString(); err != nil {
 			return err
+		} else {
+			_elem = v
 		}
-		if err = oprot.WriteFieldEnd(); err != nil {
-			goto WriteFieldEndError
-		}
+
+		_field = append(_field, _elem)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return err
 	}
+	p.Status = _field
 	return nil
-WriteFieldBeginError:
-	return thrift.PrependError(fmt.Sprintf("%T write field 2 begin error: ", p), err)
-WriteFieldEndError:
-	return thrift.PrependError(fmt.Sprintf("%T write field 2 end error: ", p), err)
 }
-func (p *GetBookRequest) writeField3(oprot thrift.TProtocol) (err error) {
-	if err = oprot.WriteFieldBegin("page_size", thrift.I64, 3); err != nil {
+func (p *GetBookRequest) ReadField9(iprot thrift.TProtocol) error {
+
+	var _field *string
+	if v, err := iprot.ReadString(); err != nil {
+		return err
+	} else {
+		_field = &v
+	}
+	p.LocationPrefix = _field
+	return nil
+}
+func (p *GetBookRequest) ReadField10(iprot thrift.TProtocol) error {
+
+	var _field *int64
+	if v, err := iprot.ReadI64(); err != nil {
+		return err
+	} else {
+		_field = &v
+	}
+	p.PurchasedAfter = _field
+	return nil
+}
+func (p *GetBookRequest) ReadField11(iprot thrift.TProtocol) error {
+
+	var _field *int64
+	if v, err := iprot.ReadI64(); err != nil {
+		return err
+	} else {
+		_field = &v
+	}
+	p.PurchasedBefore = _field
+	return nil
+}
+func (p *GetBookRequest) ReadField12(iprot thrift.TProtocol) error {
+
+	var _field *float64
+	if v, err := iprot.ReadDouble(); err != nil {
+		return err
+	} else {
+		_field = &v
+	}
+	p.MinPrice = _field
+	return nil
+}
+func (p *GetBookRequest) ReadField13(iprot thrift.TProtocol) error {
+
+	var _field *float64
+	if v, err := iprot.ReadDouble(); err != nil {
+		return err
+	} else {
+		_field = &v
+	}
+	p.MaxPrice = _field
+	return nil
+}
+func (p *GetBookRequest) ReadField14(iprot thrift.TProtocol) error {
+
+	var _field *int64
+	if v, err := iprot.ReadI64(); err != nil {
+		return err
+	} else {
+		_field = &v
+	}
+	p.LastCheckoutBefore = _field
+	return nil
+}
+func (p *GetBookRequest) ReadField15(iprot thrift.TProtocol) error {
+
+	var _field *bool
+	if v, err := iprot.ReadBool(); err != nil {
+		return err
+	} else {
+		_field = &v
+	}
+	p.WithBookType = _field
+	return nil
+}
+
+func (p *GetBookRequest) Write(oprot thrift.TProtocol) (err error) {
+	var fieldId int16
+	if err = oprot.WriteStructBegin("GetBookRequest"); err != nil {
+		goto WriteStructBeginError
+	}
+	if p != nil {
+		if err = p.writeField1(oprot); err != nil {
+			fieldId = 1
+			goto WriteFieldError
+		}
+		if err = p.writeField2(oprot); err != nil {
+			fieldId = 2
+			goto WriteFieldError
+		}
+		if err = p.writeField3(oprot); err != nil {
+			fieldId = 3
+			goto WriteFieldError
+		}
+		if err = p.writeField4(oprot); err != nil {
+			fieldId = 4
+			goto WriteFieldError
+		}
+		if err = p.writeField5(oprot); err != nil {
+			fieldId = 5
+			goto WriteFieldError
+		}
+		if err = p.writeField6(oprot); err != nil {
+			fieldId = 6
+			goto WriteFieldError
+		}
+		if err = p.writeField7(oprot); err != nil {
+			fieldId = 7
+			goto WriteFieldError
+		}
+		if err = p.writeField8(oprot); err != nil {
+			fieldId = 8
+			goto WriteFieldError
+		}
+		if err = p.writeField9(oprot); err != nil {
+			fieldId = 9
+			goto WriteFieldError
+		}
+		if err = p.writeField10(oprot); err != nil {
+			fieldId = 10
+			goto WriteFieldError
+		}
+		if err = p.writeField11(oprot); err != nil {
+			fieldId = 11
+			goto WriteFieldError
+		}
+		if err = p.writeField12(oprot); err != nil {
+			fieldId = 12
+			goto WriteFieldError
+		}
+		if err = p.writeField13(oprot); err != nil {
+			fieldId = 13
+			goto WriteFieldError
+		}
+		if err = p.writeField14(oprot); err != nil {
+			fieldId = 14
+			goto WriteFieldError
+		}
+		if err = p.writeField15(oprot); err != nil {
+			fieldId = 15
+			goto WriteFieldError
+		}
+	}
+	if err = oprot.WriteFieldStop(); err != nil {
+		goto WriteFieldStopError
+	}
+	if err = oprot.WriteStructEnd(); err != nil {
+		goto WriteStructEndError
+	}
+	return nil
+WriteStructBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+WriteFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T write field %d error: ", p, fieldId), err)
+WriteFieldStopError:
+	return thrift.PrependError(fmt.Sprintf("%T write field stop error: ", p), err)
+WriteStructEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write struct end error: ", p), err)
+}
+
+func (p *GetBookRequest) writeField1(oprot thrift.TProtocol) (err error) {
+	if p.IsSetBookID() {
+		if err = oprot.WriteFieldBegin("book_id", thrift.I64, 1); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := oprot.WriteI64(*p.BookID); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 1 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 1 end error: ", p), err)
+}
+func (p *GetBookRequest) writeField2(oprot thrift.TProtocol) (err error) {
+	if p.IsSetISBN() {
+		if err = oprot.WriteFieldBegin("ISBN", thrift.STRING, 2); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := oprot.WriteString(*p.ISBN); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 2 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 2 end error: ", p), err)
+}
+func (p *GetBookRequest) writeField3(oprot thrift.TProtocol) (err error) {
+	if p.IsSetPageSize() {
+		if err = oprot.WriteFieldBegin("page_size", thrift.I64, 3); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := oprot.WriteI64(*p.PageSize); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 3 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 3 end error: ", p), err)
+}
+func (p *GetBookRequest) writeField4(oprot thrift.TProtocol) (err error) {
+	if p.IsSetPageNum() {
+		if err = oprot.WriteFieldBegin("page_num", thrift.I64, 4); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := oprot.WriteI64(*p.PageNum); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 4 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 4 end error: ", p), err)
+}
+func (p *GetBookRequest) writeField5(oprot thrift.TProtocol) (err error) {
+	if p.IsSetCursor() {
+		if err = oprot.WriteFieldBegin("cursor", thrift.STRING, 5); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := oprot.WriteString(*p.Cursor); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 5 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 5 end error: ", p), err)
+}
+func (p *GetBookRequest) writeField6(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSort() {
+		if err = oprot.WriteFieldBegin("sort", thrift.STRING, 6); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := oprot.WriteString(*p.Sort); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 6 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 6 end error: ", p), err)
+}
+func (p *GetBookRequest) writeField7(oprot thrift.TProtocol) (err error) {
+	if p.IsSetWithTotal() {
+		if err = oprot.WriteFieldBegin("with_total", thrift.BOOL, 7); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := oprot.WriteBool(*p.WithTotal); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 7 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 7 end error: ", p), err)
+}
+func (p *GetBookRequest) writeField8(oprot thrift.TProtocol) (err error) {
+	if p.IsSetStatus() {
+		if err = oprot.WriteFieldBegin("status", thrift.LIST, 8); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := oprot.WriteListBegin(thrift.STRING, len(p.Status)); err != nil {
+			return err
+		}
+		for _, v := range p.Status {
+			if err := oprot.WriteString(v); err != nil {
+				return err
+			}
+		}
+		if err := oprot.WriteListEnd(); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 8 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 8 end error: ", p), err)
+}
+func (p *GetBookRequest) writeField9(oprot thrift.TProtocol) (err error) {
+	if p.IsSetLocationPrefix() {
+		if err = oprot.WriteFieldBegin("location_prefix", thrift.STRING, 9); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := oprot.WriteString(*p.LocationPrefix); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 9 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 9 end error: ", p), err)
+}
+func (p *GetBookRequest) writeField10(oprot thrift.TProtocol) (err error) {
+	if p.IsSetPurchasedAfter() {
+		if err = oprot.WriteFieldBegin("purchased_after", thrift.I64, 10); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := oprot.WriteI64(*p.PurchasedAfter); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 10 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 10 end error: ", p), err)
+}
+func (p *GetBookRequest) writeField11(oprot thrift.TProtocol) (err error) {
+	if p.IsSetPurchasedBefore() {
+		if err = oprot.WriteFieldBegin("purchased_before", thrift.I64, 11); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := oprot.WriteI64(*p.PurchasedBefore); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 11 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 11 end error: ", p), err)
+}
+func (p *GetBookRequest) writeField12(oprot thrift.TProtocol) (err error) {
+	if p.IsSetMinPrice() {
+		if err = oprot.WriteFieldBegin("min_price", thrift.DOUBLE, 12); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := oprot.WriteDouble(*p.MinPrice); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 12 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 12 end error: ", p), err)
+}
+func (p *GetBookRequest) writeField13(oprot thrift.TProtocol) (err error) {
+	if p.IsSetMaxPrice() {
+		if err = oprot.WriteFieldBegin("max_price", thrift.DOUBLE, 13); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := oprot.WriteDouble(*p.MaxPrice); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 13 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 13 end error: ", p), err)
+}
+func (p *GetBookRequest) writeField14(oprot thrift.TProtocol) (err error) {
+	if p.IsSetLastCheckoutBefore() {
+		if err = oprot.WriteFieldBegin("last_checkout_before", thrift.I64, 14); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := oprot.WriteI64(*p.LastCheckoutBefore); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 14 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 14 end error: ", p), err)
+}
+func (p *GetBookRequest) writeField15(oprot thrift.TProtocol) (err error) {
+	if p.IsSetWithBookType() {
+		if err = oprot.WriteFieldBegin("with_book_type", thrift.BOOL, 15); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := oprot.WriteBool(*p.WithBookType); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 15 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 15 end error: ", p), err)
+}
+
+func (p *GetBookRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GetBookRequest(%+v)", *p)
+
+}
+
+type GetBookResponse struct {
+	Base       *model.BaseResp `thrift:"base,1" form:"base" json:"base" query:"base"`
+	Data       []*model.Book   `thrift:"data,2,required" form:"data,required" json:"data,required" query:"data,required"`
+	TotalCount *int64          `thrift:"total_count,3,optional" form:"total_count" json:"total_count,omitempty" query:"total_count"`
+	NextCursor *string         `thrift:"next_cursor,4,optional" form:"next_cursor" json:"next_cursor,omitempty" query:"next_cursor"`
+}
+
+func NewGetBookResponse() *GetBookResponse {
+	return &GetBookResponse{}
+}
+
+func (p *GetBookResponse) InitDefault() {
+}
+
+var GetBookResponse_Base_DEFAULT *model.BaseResp
+
+func (p *GetBookResponse) GetBase() (v *model.BaseResp) {
+	if !p.IsSetBase() {
+		return GetBookResponse_Base_DEFAULT
+	}
+	return p.Base
+}
+
+func (p *GetBookResponse) GetData() (v []*model.Book) {
+	return p.Data
+}
+
+var GetBookResponse_TotalCount_DEFAULT int64
+
+func (p *GetBookResponse) GetTotalCount() (v int64) {
+	if !p.IsSetTotalCount() {
+		return GetBookResponse_TotalCount_DEFAULT
+	}
+	return *p.TotalCount
+}
+
+var GetBookResponse_NextCursor_DEFAULT string
+
+func (p *GetBookResponse) GetNextCursor() (v string) {
+	if !p.IsSetNextCursor() {
+		return GetBookResponse_NextCursor_DEFAULT
+	}
+	return *p.NextCursor
+}
+
+var fieldIDToName_GetBookResponse = map[int16]string{
+	1: "base",
+	2: "data",
+	3: "total_count",
+	4: "next_cursor",
+}
+
+func (p *GetBookResponse) IsSetBase() bool {
+	return p.Base != nil
+}
+
+func (p *GetBookResponse) IsSetTotalCount() bool {
+	return p.TotalCount != nil
+}
+
+func (p *GetBookResponse) IsSetNextCursor() bool {
+	return p.NextCursor != nil
+}
+
+func (p *GetBookResponse) Read(iprot thrift.TProtocol) (err error) {
+	var fieldTypeId thrift.TType
+	var fieldId int16
+	var issetData bool = false
+
+	if _, err = iprot.ReadStructBegin(); err != nil {
+		goto ReadStructBeginError
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err = iprot.ReadFieldBegin()
+		if err != nil {
+			goto ReadFieldBeginError
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+
+		switch fieldId {
+		case 1:
+			if fieldTypeId == thrift.STRUCT {
+				if err = p.ReadField1(iprot); err != nil {
+					goto ReadFieldError
+				}
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		case 2:
+			if fieldTypeId == thrift.LIST {
+				if err = p.ReadField2(iprot); err != nil {
+					goto ReadFieldError
+				}
+				issetData = true
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		case 3:
+			if fieldTypeId == thrift.I64 {
+				if err = p.ReadField3(iprot); err != nil {
+					goto ReadFieldError
+				}
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		case 4:
+			if fieldTypeId == thrift.STRING {
+				if err = p.ReadField4(iprot); err != nil {
+					goto ReadFieldError
+				}
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		default:
+			if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		}
+		if err = iprot.ReadFieldEnd(); err != nil {
+			goto ReadFieldEndError
+		}
+	}
+	if err = iprot.ReadStructEnd(); err != nil {
+		goto ReadStructEndError
+	}
+
+	if !issetData {
+		fieldId = 2
+		goto RequiredFieldNotSetError
+	}
+	return nil
+ReadStructBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T read struct begin error: ", p), err)
+ReadFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T read field %d begin error: ", p, fieldId), err)
+ReadFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T read field %d '%s' error: ", p, fieldId, fieldIDToName_GetBookResponse[fieldId]), err)
+SkipFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T field %d skip type %d error: ", p, fieldId, fieldTypeId), err)
+
+ReadFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T read field end error", p), err)
+ReadStructEndError:
+	return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+RequiredFieldNotSetError:
+	return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("required field %s is not set", fieldIDToName_GetBookResponse[fieldId]))
+}
+
+func (p *GetBookResponse) ReadField1(iprot thrift.TProtocol) error {
+	_field := model.NewBaseResp()
+	if err := _field.Read(iprot); err != nil {
+		return err
+	}
+	p.Base = _field
+	return nil
+}
+func (p *GetBookResponse) ReadField2(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return err
+	}
+	_field := make([]*model.Book, 0, size)
+	values := make([]model.Book, size)
+	for i := 0; i < size; i++ {
+		_elem := &values[i]
+		_elem.InitDefault()
+
+		if err := _elem.Read(iprot); err != nil {
+			return err
+		}
+
+		_field = append(_field, _elem)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return err
+	}
+	p.Data = _field
+	return nil
+}
+func (p *GetBookResponse) ReadField3(iprot thrift.TProtocol) error {
+
+	var _field *int64
+	if v, err := iprot.ReadI64(); err != nil {
+		return err
+	} else {
+		_field = &v
+	}
+	p.TotalCount = _field
+	return nil
+}
+func (p *GetBookResponse) ReadField4(iprot thrift.TProtocol) error {
+
+	var _field *string
+	if v, err := iprot.ReadString(); err != nil {
+		return err
+	} else {
+		_field = &v
+	}
+	p.NextCursor = _field
+	return nil
+}
+
+func (p *GetBookResponse) Write(oprot thrift.TProtocol) (err error) {
+	var fieldId int16
+	if err = oprot.WriteStructBegin("GetBookResponse"); err != nil {
+		goto WriteStructBeginError
+	}
+	if p != nil {
+		if err = p.writeField1(oprot); err != nil {
+			fieldId = 1
+			goto WriteFieldError
+		}
+		if err = p.writeField2(oprot); err != nil {
+			fieldId = 2
+			goto WriteFieldError
+		}
+		if err = p.writeField3(oprot); err != nil {
+			fieldId = 3
+			goto WriteFieldError
+		}
+		if err = p.writeField4(oprot); err != nil {
+			fieldId = 4
+			goto WriteFieldError
+		}
+	}
+	if err = oprot.WriteFieldStop(); err != nil {
+		goto WriteFieldStopError
+	}
+	if err = oprot.WriteStructEnd(); err != nil {
+		goto WriteStructEndError
+	}
+	return nil
+WriteStructBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+WriteFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T write field %d error: ", p, fieldId), err)
+WriteFieldStopError:
+	return thrift.PrependError(fmt.Sprintf("%T write field stop error: ", p), err)
+WriteStructEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write struct end error: ", p), err)
+}
+
+func (p *GetBookResponse) writeField1(oprot thrift.TProtocol) (err error) {
+	if err = oprot.WriteFieldBegin("base", thrift.STRUCT, 1); err != nil {
+		goto WriteFieldBeginError
+	}
+	if err := p.Base.Write(oprot); err != nil {
+		return err
+	}
+	if err = oprot.WriteFieldEnd(); err != nil {
+		goto WriteFieldEndError
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 1 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 1 end error: ", p), err)
+}
+func (p *GetBookResponse) writeField2(oprot thrift.TProtocol) (err error) {
+	if err = oprot.WriteFieldBegin("data", thrift.LIST, 2); err != nil {
 		goto WriteFieldBeginError
 	}
-	if err := oprot.WriteI64(p.PageSize); err != nil {
+	if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Data)); err != nil {
+		return err
+	}
+	for _, v := range p.Data {
+		if err := v.Write(oprot); err != nil {
+			return err
+		}
+	}
+	if err := oprot.WriteListEnd(); err != nil {
+		return err
+	}
+	if err = oprot.WriteFieldEnd(); err != nil {
+		goto WriteFieldEndError
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 2 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 2 end error: ", p), err)
+}
+func (p *GetBookResponse) writeField3(oprot thrift.TProtocol) (err error) {
+	if p.IsSetTotalCount() {
+		if err = oprot.WriteFieldBegin("total_count", thrift.I64, 3); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := oprot.WriteI64(*p.TotalCount); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 3 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 3 end error: ", p), err)
+}
+func (p *GetBookResponse) writeField4(oprot thrift.TProtocol) (err error) {
+	if p.IsSetNextCursor() {
+		if err = oprot.WriteFieldBegin("next_cursor", thrift.STRING, 4); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := oprot.WriteString(*p.NextCursor); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 4 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 4 end error: ", p), err)
+}
+
+func (p *GetBookResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GetBookResponse(%+v)", *p)
+
+}
+
+type WithdrawBookRequest struct {
+	BookIds []int64 `thrift:"book_ids,1,required" form:"book_ids,required" json:"book_ids,required" query:"book_ids,required"`
+	Reason  *string `thrift:"reason,2,optional" form:"reason" json:"reason,omitempty" query:"reason"`
+}
+
+func NewWithdrawBookRequest() *WithdrawBookRequest {
+	return &WithdrawBookRequest{}
+}
+
+func (p *WithdrawBookRequest) InitDefault() {
+}
+
+func (p *WithdrawBookRequest) GetBookIds() (v []int64) {
+	return p.BookIds
+}
+
+var WithdrawBookRequest_Reason_DEFAULT string
+
+func (p *WithdrawBookRequest) GetReason() (v string) {
+	if !p.IsSetReason() {
+		return WithdrawBookRequest_Reason_DEFAULT
+	}
+	return *p.Reason
+}
+
+var fieldIDToName_WithdrawBookRequest = map[int16]string{
+	1: "book_ids",
+	2: "reason",
+}
+
+func (p *WithdrawBookRequest) IsSetReason() bool {
+	return p.Reason != nil
+}
+
+func (p *WithdrawBookRequest) Read(iprot thrift.TProtocol) (err error) {
+	var fieldTypeId thrift.TType
+	var fieldId int16
+	var issetBookIds bool = false
+
+	if _, err = iprot.ReadStructBegin(); err != nil {
+		goto ReadStructBeginError
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err = iprot.ReadFieldBegin()
+		if err != nil {
+			goto ReadFieldBeginError
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+
+		switch fieldId {
+		case 1:
+			if fieldTypeId == thrift.LIST {
+				if err = p.ReadField1(iprot); err != nil {
+					goto ReadFieldError
+				}
+				issetBookIds = true
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		case 2:
+			if fieldTypeId == thrift.STRING {
+				if err = p.ReadField2(iprot); err != nil {
+					goto ReadFieldError
+				}
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		default:
+			if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		}
+		if err = iprot.ReadFieldEnd(); err != nil {
+			goto ReadFieldEndError
+		}
+	}
+	if err = iprot.ReadStructEnd(); err != nil {
+		goto ReadStructEndError
+	}
+
+	if !issetBookIds {
+		fieldId = 1
+		goto RequiredFieldNotSetError
+	}
+	return nil
+ReadStructBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T read struct begin error: ", p), err)
+ReadFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T read field %d begin error: ", p, fieldId), err)
+ReadFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T read field %d '%s' error: ", p, fieldId, fieldIDToName_WithdrawBookRequest[fieldId]), err)
+SkipFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T field %d skip type %d error: ", p, fieldId, fieldTypeId), err)
+
+ReadFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T read field end error", p), err)
+ReadStructEndError:
+	return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+RequiredFieldNotSetError:
+	return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("required field %s is not set", fieldIDToName_WithdrawBookRequest[fieldId]))
+}
+
+func (p *WithdrawBookRequest) ReadField1(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return err
+	}
+	_field := make([]int64, 0, size)
+	for i := 0; i < size; i++ {
+
+		var _elem int64
+		if v, err := iprot.ReadI64(); err != nil {
+			return err
+		} else {
+			_elem = v
+		}
+
+		_field = append(_field, _elem)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
 		return err
 	}
-	if err = oprot.WriteFieldEnd(); err != nil {
-		goto WriteFieldEndError
+	p.BookIds = _field
+	return nil
+}
+func (p *WithdrawBookRequest) ReadField2(iprot thrift.TProtocol) error {
+
+	var _field *string
+	if v, err := iprot.ReadString(); err != nil {
+		return err
+	} else {
+		_field = &v
 	}
+	p.Reason = _field
 	return nil
-WriteFieldBeginError:
-	return thrift.PrependError(fmt.Sprintf("%T write field 3 begin error: ", p), err)
-WriteFieldEndError:
-	return thrift.PrependError(fmt.Sprintf("%T write field 3 end error: ", p), err)
 }
-func (p *GetBookRequest) writeField4(oprot thrift.TProtocol) (err error) {
-	if err = oprot.WriteFieldBegin("page_num", thrift.I64, 4); err != nil {
+
+func (p *WithdrawBookRequest) Write(oprot thrift.TProtocol) (err error) {
+	var fieldId int16
+	if err = oprot.WriteStructBegin("WithdrawBookRequest"); err != nil {
+		goto WriteStructBeginError
+	}
+	if p != nil {
+		if err = p.writeField1(oprot); err != nil {
+			fieldId = 1
+			goto WriteFieldError
+		}
+		if err = p.writeField2(oprot); err != nil {
+			fieldId = 2
+			goto WriteFieldError
+		}
+	}
+	if err = oprot.WriteFieldStop(); err != nil {
+		goto WriteFieldStopError
+	}
+	if err = oprot.WriteStructEnd(); err != nil {
+		goto WriteStructEndError
+	}
+	return nil
+WriteStructBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+WriteFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T write field %d error: ", p, fieldId), err)
+WriteFieldStopError:
+	return thrift.PrependError(fmt.Sprintf("%T write field stop error: ", p), err)
+WriteStructEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write struct end error: ", p), err)
+}
+
+func (p *WithdrawBookRequest) writeField1(oprot thrift.TProtocol) (err error) {
+	if err = oprot.WriteFieldBegin("book_ids", thrift.LIST, 1); err != nil {
 		goto WriteFieldBeginError
 	}
-	if err := oprot.WriteI64(p.PageNum); err != nil {
+	if err := oprot.WriteListBegin(thrift.I64, len(p.BookIds)); err != nil {
+		return err
+	}
+	for _, v := range p.BookIds {
+		if err := oprot.WriteI64(v); err != nil {
+			return err
+		}
+	}
+	if err := oprot.WriteListEnd(); err != nil {
 		return err
 	}
 	if err = oprot.WriteFieldEnd(); err != nil {
@@ -1727,64 +2907,82 @@ func (p *GetBookRequest) writeField4(oprot thrift.TProtocol) (err error) {
 	}
 	return nil
 WriteFieldBeginError:
-	return thrift.PrependError(fmt.Sprintf("%T write field 4 begin error: ", p), err)
+	return thrift.PrependError(fmt.Sprintf("%T write field 1 begin error: ", p), err)
 WriteFieldEndError:
-	return thrift.PrependError(fmt.Sprintf("%T write field 4 end error: ", p), err)
+	return thrift.PrependError(fmt.Sprintf("%T write field 1 end error: ", p), err)
+}
+func (p *WithdrawBookRequest) writeField2(oprot thrift.TProtocol) (err error) {
+	if p.IsSetReason() {
+		if err = oprot.WriteFieldBegin("reason", thrift.STRING, 2); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := oprot.WriteString(*p.Reason); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 2 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 2 end error: ", p), err)
 }
 
-func (p *GetBookRequest) String() string {
+func (p *WithdrawBookRequest) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("GetBookRequest(%+v)", *p)
+	return fmt.Sprintf("WithdrawBookRequest(%+v)", *p)
 
 }
 
-type GetBookResponse struct {
-	Base       *model.BaseResp `thrift:"base,1" form:"base" json:"base" query:"base"`
-	Data       []*model.Book   `thrift:"data,2,required" form:"data,required" json:"data,required" query:"data,required"`
-	TotalCount int64           `thrift:"total_count,3,required" form:"total_count,required" json:"total_count,required" query:"total_count,required"`
+type WithdrawBookResponse struct {
+	Base      *model.BaseResp `thrift:"base,1" form:"base" json:"base" query:"base"`
+	Withdrawn []int64         `thrift:"withdrawn,2,required" form:"withdrawn,required" json:"withdrawn,required" query:"withdrawn,required"`
+	Skipped   []int64         `thrift:"skipped,3,required" form:"skipped,required" json:"skipped,required" query:"skipped,required"`
 }
 
-func NewGetBookResponse() *GetBookResponse {
-	return &GetBookResponse{}
+func NewWithdrawBookResponse() *WithdrawBookResponse {
+	return &WithdrawBookResponse{}
 }
 
-func (p *GetBookResponse) InitDefault() {
+func (p *WithdrawBookResponse) InitDefault() {
 }
 
-var GetBookResponse_Base_DEFAULT *model.BaseResp
+var WithdrawBookResponse_Base_DEFAULT *model.BaseResp
 
-func (p *GetBookResponse) GetBase() (v *model.BaseResp) {
+func (p *WithdrawBookResponse) GetBase() (v *model.BaseResp) {
 	if !p.IsSetBase() {
-		return GetBookResponse_Base_DEFAULT
+		return WithdrawBookResponse_Base_DEFAULT
 	}
 	return p.Base
 }
 
-func (p *GetBookResponse) GetData() (v []*model.Book) {
-	return p.Data
+func (p *WithdrawBookResponse) GetWithdrawn() (v []int64) {
+	return p.Withdrawn
 }
 
-func (p *GetBookResponse) GetTotalCount() (v int64) {
-	return p.TotalCount
+func (p *WithdrawBookResponse) GetSkipped() (v []int64) {
+	return p.Skipped
 }
 
-var fieldIDToName_GetBookResponse = map[int16]string{
+var fieldIDToName_WithdrawBookResponse = map[int16]string{
 	1: "base",
-	2: "data",
-	3: "total_count",
+	2: "withdrawn",
+	3: "skipped",
 }
 
-func (p *GetBookResponse) IsSetBase() bool {
+func (p *WithdrawBookResponse) IsSetBase() bool {
 	return p.Base != nil
 }
 
-func (p *GetBookResponse) Read(iprot thrift.TProtocol) (err error) {
+func (p *WithdrawBookResponse) Read(iprot thrift.TProtocol) (err error) {
 	var fieldTypeId thrift.TType
 	var fieldId int16
-	var issetData bool = false
-	var issetTotalCount bool = false
+	var issetWithdrawn bool = false
+	var issetSkipped bool = false
 
 	if _, err = iprot.ReadStructBegin(); err != nil {
 		goto ReadStructBeginError
@@ -1813,16 +3011,16 @@ func (p *GetBookResponse) Read(iprot thrift.TProtocol) (err error) {
 				if err = p.ReadField2(iprot); err != nil {
 					goto ReadFieldError
 				}
-				issetData = true
+				issetWithdrawn = true
 			} else if err = iprot.Skip(fieldTypeId); err != nil {
 				goto SkipFieldError
 			}
 		case 3:
-			if fieldTypeId == thrift.I64 {
+			if fieldTypeId == thrift.LIST {
 				if err = p.ReadField3(iprot); err != nil {
 					goto ReadFieldError
 				}
-				issetTotalCount = true
+				issetSkipped = true
 			} else if err = iprot.Skip(fieldTypeId); err != nil {
 				goto SkipFieldError
 			}
@@ -1839,12 +3037,12 @@ func (p *GetBookResponse) Read(iprot thrift.TProtocol) (err error) {
 		goto ReadStructEndError
 	}
 
-	if !issetData {
+	if !issetWithdrawn {
 		fieldId = 2
 		goto RequiredFieldNotSetError
 	}
 
-	if !issetTotalCount {
+	if !issetSkipped {
 		fieldId = 3
 		goto RequiredFieldNotSetError
 	}
@@ -1854,7 +3052,7 @@ ReadStructBeginError:
 ReadFieldBeginError:
 	return thrift.PrependError(fmt.Sprintf("%T read field %d begin error: ", p, fieldId), err)
 ReadFieldError:
-	return thrift.PrependError(fmt.Sprintf("%T read field %d '%s' error: ", p, fieldId, fieldIDToName_GetBookResponse[fieldId]), err)
+	return thrift.PrependError(fmt.Sprintf("%T read field %d '%s' error: ", p, fieldId, fieldIDToName_WithdrawBookResponse[fieldId]), err)
 SkipFieldError:
 	return thrift.PrependError(fmt.Sprintf("%T field %d skip type %d error: ", p, fieldId, fieldTypeId), err)
 
@@ -1863,10 +3061,10 @@ ReadFieldEndError:
 ReadStructEndError:
 	return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 RequiredFieldNotSetError:
-	return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("required field %s is not set", fieldIDToName_GetBookResponse[fieldId]))
+	return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("required field %s is not set", fieldIDToName_WithdrawBookResponse[fieldId]))
 }
 
-func (p *GetBookResponse) ReadField1(iprot thrift.TProtocol) error {
+func (p *WithdrawBookResponse) ReadField1(iprot thrift.TProtocol) error {
 	_field := model.NewBaseResp()
 	if err := _field.Read(iprot); err != nil {
 		return err
@@ -1874,19 +3072,19 @@ func (p *GetBookResponse) ReadField1(iprot thrift.TProtocol) error {
 	p.Base = _field
 	return nil
 }
-func (p *GetBookResponse) ReadField2(iprot thrift.TProtocol) error {
+func (p *WithdrawBookResponse) ReadField2(iprot thrift.TProtocol) error {
 	_, size, err := iprot.ReadListBegin()
 	if err != nil {
 		return err
 	}
-	_field := make([]*model.Book, 0, size)
-	values := make([]model.Book, size)
+	_field := make([]int64, 0, size)
 	for i := 0; i < size; i++ {
-		_elem := &values[i]
-		_elem.InitDefault()
 
-		if err := _elem.Read(iprot); err != nil {
+		var _elem int64
+		if v, err := iprot.ReadI64(); err != nil {
 			return err
+		} else {
+			_elem = v
 		}
 
 		_field = append(_field, _elem)
@@ -1894,24 +3092,36 @@ func (p *GetBookResponse) ReadField2(iprot thrift.TProtocol) error {
 	if err := iprot.ReadListEnd(); err != nil {
 		return err
 	}
-	p.Data = _field
+	p.Withdrawn = _field
 	return nil
 }
-func (p *GetBookResponse) ReadField3(iprot thrift.TProtocol) error {
+func (p *WithdrawBookResponse) ReadField3(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return err
+	}
+	_field := make([]int64, 0, size)
+	for i := 0; i < size; i++ {
 
-	var _field int64
-	if v, err := iprot.ReadI64(); err != nil {
+		var _elem int64
+		if v, err := iprot.ReadI64(); err != nil {
+			return err
+		} else {
+			_elem = v
+		}
+
+		_field = append(_field, _elem)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
 		return err
-	} else {
-		_field = v
 	}
-	p.TotalCount = _field
+	p.Skipped = _field
 	return nil
 }
 
-func (p *GetBookResponse) Write(oprot thrift.TProtocol) (err error) {
+func (p *WithdrawBookResponse) Write(oprot thrift.TProtocol) (err error) {
 	var fieldId int16
-	if err = oprot.WriteStructBegin("GetBookResponse"); err != nil {
+	if err = oprot.WriteStructBegin("WithdrawBookResponse"); err != nil {
 		goto WriteStructBeginError
 	}
 	if p != nil {
@@ -1945,7 +3155,7 @@ WriteStructEndError:
 	return thrift.PrependError(fmt.Sprintf("%T write struct end error: ", p), err)
 }
 
-func (p *GetBookResponse) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *WithdrawBookResponse) writeField1(oprot thrift.TProtocol) (err error) {
 	if err = oprot.WriteFieldBegin("base", thrift.STRUCT, 1); err != nil {
 		goto WriteFieldBeginError
 	}
@@ -1961,15 +3171,15 @@ WriteFieldBeginError:
 WriteFieldEndError:
 	return thrift.PrependError(fmt.Sprintf("%T write field 1 end error: ", p), err)
 }
-func (p *GetBookResponse) writeField2(oprot thrift.TProtocol) (err error) {
-	if err = oprot.WriteFieldBegin("data", thrift.LIST, 2); err != nil {
+func (p *WithdrawBookResponse) writeField2(oprot thrift.TProtocol) (err error) {
+	if err = oprot.WriteFieldBegin("withdrawn", thrift.LIST, 2); err != nil {
 		goto WriteFieldBeginError
 	}
-	if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Data)); err != nil {
+	if err := oprot.WriteListBegin(thrift.I64, len(p.Withdrawn)); err != nil {
 		return err
 	}
-	for _, v := range p.Data {
-		if err := v.Write(oprot); err != nil {
+	for _, v := range p.Withdrawn {
+		if err := oprot.WriteI64(v); err != nil {
 			return err
 		}
 	}
@@ -1985,11 +3195,19 @@ WriteFieldBeginError:
 WriteFieldEndError:
 	return thrift.PrependError(fmt.Sprintf("%T write field 2 end error: ", p), err)
 }
-func (p *GetBookResponse) writeField3(oprot thrift.TProtocol) (err error) {
-	if err = oprot.WriteFieldBegin("total_count", thrift.I64, 3); err != nil {
+func (p *WithdrawBookResponse) writeField3(oprot thrift.TProtocol) (err error) {
+	if err = oprot.WriteFieldBegin("skipped", thrift.LIST, 3); err != nil {
 		goto WriteFieldBeginError
 	}
-	if err := oprot.WriteI64(p.TotalCount); err != nil {
+	if err := oprot.WriteListBegin(thrift.I64, len(p.Skipped)); err != nil {
+		return err
+	}
+	for _, v := range p.Skipped {
+		if err := oprot.WriteI64(v); err != nil {
+			return err
+		}
+	}
+	if err := oprot.WriteListEnd(); err != nil {
 		return err
 	}
 	if err = oprot.WriteFieldEnd(); err != nil {
@@ -2002,11 +3220,11 @@ WriteFieldEndError:
 	return thrift.PrependError(fmt.Sprintf("%T write field 3 end error: ", p), err)
 }
 
-func (p *GetBookResponse) String() string {
+func (p *WithdrawBookResponse) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("GetBookResponse(%+v)", *p)
+	return fmt.Sprintf("WithdrawBookResponse(%+v)", *p)
 
 }
 
@@ -2018,6 +3236,8 @@ type BookService interface {
 	DeleteBook(ctx context.Context, req *DeleteBookRequest) (r *DeleteBookResponse, err error)
 
 	GetBook(ctx context.Context, req *GetBookRequest) (r *GetBookResponse, err error)
+
+	WithdrawBook(ctx context.Context, req *WithdrawBookRequest) (r *WithdrawBookResponse, err error)
 }
 
 type BookServiceClient struct {
@@ -2082,6 +3302,15 @@ func (p *BookServiceClient) GetBook(ctx context.Context, req *GetBookRequest) (r
 	}
 	return _result.GetSuccess(), nil
 }
+func (p *BookServiceClient) WithdrawBook(ctx context.Context, req *WithdrawBookRequest) (r *WithdrawBookResponse, err error) {
+	var _args BookServiceWithdrawBookArgs
+	_args.Req = req
+	var _result BookServiceWithdrawBookResult
+	if err = p.Client_().Call(ctx, "withdrawBook", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
 
 type BookServiceProcessor struct {
 	processorMap map[string]thrift.TProcessorFunction
@@ -2107,6 +3336,7 @@ func NewBookServiceProcessor(handler BookService) *BookServiceProcessor {
 	self.AddToProcessorMap("updateBook", &bookServiceProcessorUpdateBook{handler: handler})
 	self.AddToProcessorMap("deleteBook", &bookServiceProcessorDeleteBook{handler: handler})
 	self.AddToProcessorMap("getBook", &bookServiceProcessorGetBook{handler: handler})
+	self.AddToProcessorMap("withdrawBook", &bookServiceProcessorWithdrawBook{handler: handler})
 	return self
 }
 func (p *BookServiceProcessor) Process(ctx context.Context, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
@@ -2301,7 +3531,55 @@ func (p *bookServiceProcessorGetBook) Process(ctx context.Context, seqId int32,
 	} else {
 		result.Success = retval
 	}
-	if err2 = oprot.WriteMessageBegin("getBook", thrift.REPLY, seqId); err2 != nil {
+	if err2 = oprot.WriteMessageBegin("getBook", thrift.REPLY, seqId); err2 != nil {
+		err = err2
+	}
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.Flush(ctx); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type bookServiceProcessorWithdrawBook struct {
+	handler BookService
+}
+
+func (p *bookServiceProcessorWithdrawBook) Process(ctx context.Context, seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := BookServiceWithdrawBookArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("withdrawBook", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush(ctx)
+		return false, err
+	}
+
+	iprot.ReadMessageEnd()
+	var err2 error
+	result := BookServiceWithdrawBookResult{}
+	var retval *WithdrawBookResponse
+	if retval, err2 = p.handler.WithdrawBook(ctx, args.Req); err2 != nil {
+		x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing withdrawBook: "+err2.Error())
+		oprot.WriteMessageBegin("withdrawBook", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush(ctx)
+		return true, err2
+	} else {
+		result.Success = retval
+	}
+	if err2 = oprot.WriteMessageBegin("withdrawBook", thrift.REPLY, seqId); err2 != nil {
 		err = err2
 	}
 	if err2 = result.Write(oprot); err == nil && err2 != nil {
@@ -3486,3 +4764,295 @@ func (p *BookServiceGetBookResult) String() string {
 	return fmt.Sprintf("BookServiceGetBookResult(%+v)", *p)
 
 }
+
+type BookServiceWithdrawBookArgs struct {
+	Req *WithdrawBookRequest `thrift:"req,1"`
+}
+
+func NewBookServiceWithdrawBookArgs() *BookServiceWithdrawBookArgs {
+	return &BookServiceWithdrawBookArgs{}
+}
+
+func (p *BookServiceWithdrawBookArgs) InitDefault() {
+}
+
+var BookServiceWithdrawBookArgs_Req_DEFAULT *WithdrawBookRequest
+
+func (p *BookServiceWithdrawBookArgs) GetReq() (v *WithdrawBookRequest) {
+	if !p.IsSetReq() {
+		return BookServiceWithdrawBookArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+
+var fieldIDToName_BookServiceWithdrawBookArgs = map[int16]string{
+	1: "req",
+}
+
+func (p *BookServiceWithdrawBookArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *BookServiceWithdrawBookArgs) Read(iprot thrift.TProtocol) (err error) {
+	var fieldTypeId thrift.TType
+	var fieldId int16
+
+	if _, err = iprot.ReadStructBegin(); err != nil {
+		goto ReadStructBeginError
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err = iprot.ReadFieldBegin()
+		if err != nil {
+			goto ReadFieldBeginError
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+
+		switch fieldId {
+		case 1:
+			if fieldTypeId == thrift.STRUCT {
+				if err = p.ReadField1(iprot); err != nil {
+					goto ReadFieldError
+				}
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		default:
+			if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		}
+		if err = iprot.ReadFieldEnd(); err != nil {
+			goto ReadFieldEndError
+		}
+	}
+	if err = iprot.ReadStructEnd(); err != nil {
+		goto ReadStructEndError
+	}
+
+	return nil
+ReadStructBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T read struct begin error: ", p), err)
+ReadFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T read field %d begin error: ", p, fieldId), err)
+ReadFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T read field %d '%s' error: ", p, fieldId, fieldIDToName_BookServiceWithdrawBookArgs[fieldId]), err)
+SkipFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T field %d skip type %d error: ", p, fieldId, fieldTypeId), err)
+
+ReadFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T read field end error", p), err)
+ReadStructEndError:
+	return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+}
+
+func (p *BookServiceWithdrawBookArgs) ReadField1(iprot thrift.TProtocol) error {
+	_field := NewWithdrawBookRequest()
+	if err := _field.Read(iprot); err != nil {
+		return err
+	}
+	p.Req = _field
+	return nil
+}
+
+func (p *BookServiceWithdrawBookArgs) Write(oprot thrift.TProtocol) (err error) {
+	var fieldId int16
+	if err = oprot.WriteStructBegin("withdrawBook_args"); err != nil {
+		goto WriteStructBeginError
+	}
+	if p != nil {
+		if err = p.writeField1(oprot); err != nil {
+			fieldId = 1
+			goto WriteFieldError
+		}
+	}
+	if err = oprot.WriteFieldStop(); err != nil {
+		goto WriteFieldStopError
+	}
+	if err = oprot.WriteStructEnd(); err != nil {
+		goto WriteStructEndError
+	}
+	return nil
+WriteStructBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+WriteFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T write field %d error: ", p, fieldId), err)
+WriteFieldStopError:
+	return thrift.PrependError(fmt.Sprintf("%T write field stop error: ", p), err)
+WriteStructEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write struct end error: ", p), err)
+}
+
+func (p *BookServiceWithdrawBookArgs) writeField1(oprot thrift.TProtocol) (err error) {
+	if err = oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
+		goto WriteFieldBeginError
+	}
+	if err := p.Req.Write(oprot); err != nil {
+		return err
+	}
+	if err = oprot.WriteFieldEnd(); err != nil {
+		goto WriteFieldEndError
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 1 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 1 end error: ", p), err)
+}
+
+func (p *BookServiceWithdrawBookArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("BookServiceWithdrawBookArgs(%+v)", *p)
+
+}
+
+type BookServiceWithdrawBookResult struct {
+	Success *WithdrawBookResponse `thrift:"success,0,optional"`
+}
+
+func NewBookServiceWithdrawBookResult() *BookServiceWithdrawBookResult {
+	return &BookServiceWithdrawBookResult{}
+}
+
+func (p *BookServiceWithdrawBookResult) InitDefault() {
+}
+
+var BookServiceWithdrawBookResult_Success_DEFAULT *WithdrawBookResponse
+
+func (p *BookServiceWithdrawBookResult) GetSuccess() (v *WithdrawBookResponse) {
+	if !p.IsSetSuccess() {
+		return BookServiceWithdrawBookResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+var fieldIDToName_BookServiceWithdrawBookResult = map[int16]string{
+	0: "success",
+}
+
+func (p *BookServiceWithdrawBookResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *BookServiceWithdrawBookResult) Read(iprot thrift.TProtocol) (err error) {
+	var fieldTypeId thrift.TType
+	var fieldId int16
+
+	if _, err = iprot.ReadStructBegin(); err != nil {
+		goto ReadStructBeginError
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err = iprot.ReadFieldBegin()
+		if err != nil {
+			goto ReadFieldBeginError
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+
+		switch fieldId {
+		case 0:
+			if fieldTypeId == thrift.STRUCT {
+				if err = p.ReadField0(iprot); err != nil {
+					goto ReadFieldError
+				}
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		default:
+			if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		}
+		if err = iprot.ReadFieldEnd(); err != nil {
+			goto ReadFieldEndError
+		}
+	}
+	if err = iprot.ReadStructEnd(); err != nil {
+		goto ReadStructEndError
+	}
+
+	return nil
+ReadStructBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T read struct begin error: ", p), err)
+ReadFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T read field %d begin error: ", p, fieldId), err)
+ReadFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T read field %d '%s' error: ", p, fieldId, fieldIDToName_BookServiceWithdrawBookResult[fieldId]), err)
+SkipFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T field %d skip type %d error: ", p, fieldId, fieldTypeId), err)
+
+ReadFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T read field end error", p), err)
+ReadStructEndError:
+	return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+}
+
+func (p *BookServiceWithdrawBookResult) ReadField0(iprot thrift.TProtocol) error {
+	_field := NewWithdrawBookResponse()
+	if err := _field.Read(iprot); err != nil {
+		return err
+	}
+	p.Success = _field
+	return nil
+}
+
+func (p *BookServiceWithdrawBookResult) Write(oprot thrift.TProtocol) (err error) {
+	var fieldId int16
+	if err = oprot.WriteStructBegin("withdrawBook_result"); err != nil {
+		goto WriteStructBeginError
+	}
+	if p != nil {
+		if err = p.writeField0(oprot); err != nil {
+			fieldId = 0
+			goto WriteFieldError
+		}
+	}
+	if err = oprot.WriteFieldStop(); err != nil {
+		goto WriteFieldStopError
+	}
+	if err = oprot.WriteStructEnd(); err != nil {
+		goto WriteStructEndError
+	}
+	return nil
+WriteStructBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+WriteFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T write field %d error: ", p, fieldId), err)
+WriteFieldStopError:
+	return thrift.PrependError(fmt.Sprintf("%T write field stop error: ", p), err)
+WriteStructEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write struct end error: ", p), err)
+}
+
+func (p *BookServiceWithdrawBookResult) writeField0(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSuccess() {
+		if err = oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := p.Success.Write(oprot); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 0 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 0 end error: ", p), err)
+}
+
+func (p *BookServiceWithdrawBookResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("BookServiceWithdrawBookResult(%+v)", *p)
+
+}
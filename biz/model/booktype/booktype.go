@@ -5,18 +5,426 @@ package booktype
 import (
 	"context"
 	"fmt"
-	"github.com/2451965602/LMS/biz/model/model"
+
 	"github.com/apache/thrift/lib/go/thrift"
+
+	"github.com/2451965602/LMS/biz/model/model"
 )
 
+type ContributorLink struct {
+	ContributorID int64  `thrift:"contributor_id,1,required" form:"contributor_id,required" json:"contributor_id,required" query:"contributor_id,required"`
+	Role          string `thrift:"role,2,required" form:"role,required" json:"role,required" query:"role,required"`
+}
+
+func NewContributorLink() *ContributorLink {
+	return &ContributorLink{}
+}
+
+func (p *ContributorLink) InitDefault() {
+}
+
+func (p *ContributorLink) GetContributorID() (v int64) {
+	return p.ContributorID
+}
+
+func (p *ContributorLink) GetRole() (v string) {
+	return p.Role
+}
+
+var fieldIDToName_ContributorLink = map[int16]string{
+	1: "contributor_id",
+	2: "role",
+}
+
+func (p *ContributorLink) Read(iprot thrift.TProtocol) (err error) {
+	var fieldTypeId thrift.TType
+	var fieldId int16
+	var issetContributorID bool = false
+	var issetRole bool = false
+
+	if _, err = iprot.ReadStructBegin(); err != nil {
+		goto ReadStructBeginError
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err = iprot.ReadFieldBegin()
+		if err != nil {
+			goto ReadFieldBeginError
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+
+		switch fieldId {
+		case 1:
+			if fieldTypeId == thrift.I64 {
+				if err = p.ReadField1(iprot); err != nil {
+					goto ReadFieldError
+				}
+				issetContributorID = true
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		case 2:
+			if fieldTypeId == thrift.STRING {
+				if err = p.ReadField2(iprot); err != nil {
+					goto ReadFieldError
+				}
+				issetRole = true
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		default:
+			if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		}
+		if err = iprot.ReadFieldEnd(); err != nil {
+			goto ReadFieldEndError
+		}
+	}
+	if err = iprot.ReadStructEnd(); err != nil {
+		goto ReadStructEndError
+	}
+
+	if !issetContributorID {
+		fieldId = 1
+		goto RequiredFieldNotSetError
+	}
+
+	if !issetRole {
+		fieldId = 2
+		goto RequiredFieldNotSetError
+	}
+	return nil
+ReadStructBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T read struct begin error: ", p), err)
+ReadFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T read field %d begin error: ", p, fieldId), err)
+ReadFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T read field %d '%s' error: ", p, fieldId, fieldIDToName_ContributorLink[fieldId]), err)
+SkipFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T field %d skip type %d error: ", p, fieldId, fieldTypeId), err)
+
+ReadFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T read field end error", p), err)
+ReadStructEndError:
+	return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+RequiredFieldNotSetError:
+	return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("required field %s is not set", fieldIDToName_ContributorLink[fieldId]))
+}
+
+func (p *ContributorLink) ReadField1(iprot thrift.TProtocol) error {
+
+	var _field int64
+	if v, err := iprot.ReadI64(); err != nil {
+		return err
+	} else {
+		_field = v
+	}
+	p.ContributorID = _field
+	return nil
+}
+func (p *ContributorLink) ReadField2(iprot thrift.TProtocol) error {
+
+	var _field string
+	if v, err := iprot.ReadString(); err != nil {
+		return err
+	} else {
+		_field = v
+	}
+	p.Role = _field
+	return nil
+}
+
+func (p *ContributorLink) Write(oprot thrift.TProtocol) (err error) {
+	var fieldId int16
+	if err = oprot.WriteStructBegin("ContributorLink"); err != nil {
+		goto WriteStructBeginError
+	}
+	if p != nil {
+		if err = p.writeField1(oprot); err != nil {
+			fieldId = 1
+			goto WriteFieldError
+		}
+		if err = p.writeField2(oprot); err != nil {
+			fieldId = 2
+			goto WriteFieldError
+		}
+	}
+	if err = oprot.WriteFieldStop(); err != nil {
+		goto WriteFieldStopError
+	}
+	if err = oprot.WriteStructEnd(); err != nil {
+		goto WriteStructEndError
+	}
+	return nil
+WriteStructBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+WriteFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T write field %d error: ", p, fieldId), err)
+WriteFieldStopError:
+	return thrift.PrependError(fmt.Sprintf("%T write field stop error: ", p), err)
+WriteStructEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write struct end error: ", p), err)
+}
+
+func (p *ContributorLink) writeField1(oprot thrift.TProtocol) (err error) {
+	if err = oprot.WriteFieldBegin("contributor_id", thrift.I64, 1); err != nil {
+		goto WriteFieldBeginError
+	}
+	if err := oprot.WriteI64(p.ContributorID); err != nil {
+		return err
+	}
+	if err = oprot.WriteFieldEnd(); err != nil {
+		goto WriteFieldEndError
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 1 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 1 end error: ", p), err)
+}
+func (p *ContributorLink) writeField2(oprot thrift.TProtocol) (err error) {
+	if err = oprot.WriteFieldBegin("role", thrift.STRING, 2); err != nil {
+		goto WriteFieldBeginError
+	}
+	if err := oprot.WriteString(p.Role); err != nil {
+		return err
+	}
+	if err = oprot.WriteFieldEnd(); err != nil {
+		goto WriteFieldEndError
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 2 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 2 end error: ", p), err)
+}
+
+func (p *ContributorLink) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ContributorLink(%+v)", *p)
+
+}
+
+type SeriesLink struct {
+	SeriesID int64   `thrift:"series_id,1,required" form:"series_id,required" json:"series_id,required" query:"series_id,required"`
+	Volume   *string `thrift:"volume,2,optional" form:"volume" json:"volume,omitempty" query:"volume"`
+}
+
+func NewSeriesLink() *SeriesLink {
+	return &SeriesLink{}
+}
+
+func (p *SeriesLink) InitDefault() {
+}
+
+func (p *SeriesLink) GetSeriesID() (v int64) {
+	return p.SeriesID
+}
+
+var SeriesLink_Volume_DEFAULT string
+
+func (p *SeriesLink) GetVolume() (v string) {
+	if !p.IsSetVolume() {
+		return SeriesLink_Volume_DEFAULT
+	}
+	return *p.Volume
+}
+
+var fieldIDToName_SeriesLink = map[int16]string{
+	1: "series_id",
+	2: "volume",
+}
+
+func (p *SeriesLink) IsSetVolume() bool {
+	return p.Volume != nil
+}
+
+func (p *SeriesLink) Read(iprot thrift.TProtocol) (err error) {
+	var fieldTypeId thrift.TType
+	var fieldId int16
+	var issetSeriesID bool = false
+
+	if _, err = iprot.ReadStructBegin(); err != nil {
+		goto ReadStructBeginError
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err = iprot.ReadFieldBegin()
+		if err != nil {
+			goto ReadFieldBeginError
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+
+		switch fieldId {
+		case 1:
+			if fieldTypeId == thrift.I64 {
+				if err = p.ReadField1(iprot); err != nil {
+					goto ReadFieldError
+				}
+				issetSeriesID = true
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		case 2:
+			if fieldTypeId == thrift.STRING {
+				if err = p.ReadField2(iprot); err != nil {
+					goto ReadFieldError
+				}
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		default:
+			if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		}
+		if err = iprot.ReadFieldEnd(); err != nil {
+			goto ReadFieldEndError
+		}
+	}
+	if err = iprot.ReadStructEnd(); err != nil {
+		goto ReadStructEndError
+	}
+
+	if !issetSeriesID {
+		fieldId = 1
+		goto RequiredFieldNotSetError
+	}
+	return nil
+ReadStructBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T read struct begin error: ", p), err)
+ReadFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T read field %d begin error: ", p, fieldId), err)
+ReadFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T read field %d '%s' error: ", p, fieldId, fieldIDToName_SeriesLink[fieldId]), err)
+SkipFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T field %d skip type %d error: ", p, fieldId, fieldTypeId), err)
+
+ReadFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T read field end error", p), err)
+ReadStructEndError:
+	return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+RequiredFieldNotSetError:
+	return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("required field %s is not set", fieldIDToName_SeriesLink[fieldId]))
+}
+
+func (p *SeriesLink) ReadField1(iprot thrift.TProtocol) error {
+
+	var _field int64
+	if v, err := iprot.ReadI64(); err != nil {
+		return err
+	} else {
+		_field = v
+	}
+	p.SeriesID = _field
+	return nil
+}
+func (p *SeriesLink) ReadField2(iprot thrift.TProtocol) error {
+
+	var _field *string
+	if v, err := iprot.ReadString(); err != nil {
+		return err
+	} else {
+		_field = &v
+	}
+	p.Volume = _field
+	return nil
+}
+
+func (p *SeriesLink) Write(oprot thrift.TProtocol) (err error) {
+	var fieldId int16
+	if err = oprot.WriteStructBegin("SeriesLink"); err != nil {
+		goto WriteStructBeginError
+	}
+	if p != nil {
+		if err = p.writeField1(oprot); err != nil {
+			fieldId = 1
+			goto WriteFieldError
+		}
+		if err = p.writeField2(oprot); err != nil {
+			fieldId = 2
+			goto WriteFieldError
+		}
+	}
+	if err = oprot.WriteFieldStop(); err != nil {
+		goto WriteFieldStopError
+	}
+	if err = oprot.WriteStructEnd(); err != nil {
+		goto WriteStructEndError
+	}
+	return nil
+WriteStructBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+WriteFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T write field %d error: ", p, fieldId), err)
+WriteFieldStopError:
+	return thrift.PrependError(fmt.Sprintf("%T write field stop error: ", p), err)
+WriteStructEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write struct end error: ", p), err)
+}
+
+func (p *SeriesLink) writeField1(oprot thrift.TProtocol) (err error) {
+	if err = oprot.WriteFieldBegin("series_id", thrift.I64, 1); err != nil {
+		goto WriteFieldBeginError
+	}
+	if err := oprot.WriteI64(p.SeriesID); err != nil {
+		return err
+	}
+	if err = oprot.WriteFieldEnd(); err != nil {
+		goto WriteFieldEndError
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 1 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 1 end error: ", p), err)
+}
+func (p *SeriesLink) writeField2(oprot thrift.TProtocol) (err error) {
+	if p.IsSetVolume() {
+		if err = oprot.WriteFieldBegin("volume", thrift.STRING, 2); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := oprot.WriteString(*p.Volume); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 2 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 2 end error: ", p), err)
+}
+
+func (p *SeriesLink) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("SeriesLink(%+v)", *p)
+
+}
+
 type AddBookTypeRequest struct {
-	Title       string `thrift:"title,1,required" form:"title,required" json:"title,required" query:"title,required"`
-	Author      string `thrift:"author,2,required" form:"author,required" json:"author,required" query:"author,required"`
-	Category    string `thrift:"category,3,required" form:"category,required" json:"category,required" query:"category,required"`
-	ISBN        string `thrift:"ISBN,4,required" form:"ISBN,required" json:"ISBN,required" query:"ISBN,required"`
-	Publisher   string `thrift:"publisher,5,required" form:"publisher,required" json:"publisher,required" query:"publisher,required"`
-	PublishYear int64  `thrift:"publish_year,6,required" form:"publish_year,required" json:"publish_year,required" query:"publish_year,required"`
-	Description string `thrift:"description,7,required" form:"description,required" json:"description,required" query:"description,required"`
+	Title        string             `thrift:"title,1,required" form:"title,required" json:"title,required" query:"title,required"`
+	Author       *string            `thrift:"author,2,optional" form:"author" json:"author,omitempty" query:"author"`
+	Category     string             `thrift:"category,3,required" form:"category,required" json:"category,required" query:"category,required"`
+	ISBN         string             `thrift:"ISBN,4,required" form:"ISBN,required" json:"ISBN,required" query:"ISBN,required"`
+	Publisher    string             `thrift:"publisher,5,required" form:"publisher,required" json:"publisher,required" query:"publisher,required"`
+	PublishYear  int64              `thrift:"publish_year,6,required" form:"publish_year,required" json:"publish_year,required" query:"publish_year,required"`
+	Description  string             `thrift:"description,7,required" form:"description,required" json:"description,required" query:"description,required"`
+	Contributors []*ContributorLink `thrift:"contributors,8,optional" form:"contributors" json:"contributors,omitempty" query:"contributors"`
+	SubjectIds   []int64            `thrift:"subject_ids,9,optional" form:"subject_ids" json:"subject_ids,omitempty" query:"subject_ids"`
+	Series       []*SeriesLink      `thrift:"series,10,optional" form:"series" json:"series,omitempty" query:"series"`
+	CategoryID   *int64             `thrift:"category_id,11,optional" form:"category_id" json:"category_id,omitempty" query:"category_id"`
 }
 
 func NewAddBookTypeRequest() *AddBookTypeRequest {
@@ -30,8 +438,13 @@ func (p *AddBookTypeRequest) GetTitle() (v string) {
 	return p.Title
 }
 
+var AddBookTypeRequest_Author_DEFAULT string
+
 func (p *AddBookTypeRequest) GetAuthor() (v string) {
-	return p.Author
+	if !p.IsSetAuthor() {
+		return AddBookTypeRequest_Author_DEFAULT
+	}
+	return *p.Author
 }
 
 func (p *AddBookTypeRequest) GetCategory() (v string) {
@@ -54,21 +467,80 @@ func (p *AddBookTypeRequest) GetDescription() (v string) {
 	return p.Description
 }
 
+var AddBookTypeRequest_Contributors_DEFAULT []*ContributorLink
+
+func (p *AddBookTypeRequest) GetContributors() (v []*ContributorLink) {
+	if !p.IsSetContributors() {
+		return AddBookTypeRequest_Contributors_DEFAULT
+	}
+	return p.Contributors
+}
+
+var AddBookTypeRequest_SubjectIds_DEFAULT []int64
+
+func (p *AddBookTypeRequest) GetSubjectIds() (v []int64) {
+	if !p.IsSetSubjectIds() {
+		return AddBookTypeRequest_SubjectIds_DEFAULT
+	}
+	return p.SubjectIds
+}
+
+var AddBookTypeRequest_Series_DEFAULT []*SeriesLink
+
+func (p *AddBookTypeRequest) GetSeries() (v []*SeriesLink) {
+	if !p.IsSetSeries() {
+		return AddBookTypeRequest_Series_DEFAULT
+	}
+	return p.Series
+}
+
+var AddBookTypeRequest_CategoryID_DEFAULT int64
+
+func (p *AddBookTypeRequest) GetCategoryID() (v int64) {
+	if !p.IsSetCategoryID() {
+		return AddBookTypeRequest_CategoryID_DEFAULT
+	}
+	return *p.CategoryID
+}
+
 var fieldIDToName_AddBookTypeRequest = map[int16]string{
-	1: "title",
-	2: "author",
-	3: "category",
-	4: "ISBN",
-	5: "publisher",
-	6: "publish_year",
-	7: "description",
+	1:  "title",
+	2:  "author",
+	3:  "category",
+	4:  "ISBN",
+	5:  "publisher",
+	6:  "publish_year",
+	7:  "description",
+	8:  "contributors",
+	9:  "subject_ids",
+	10: "series",
+	11: "category_id",
+}
+
+func (p *AddBookTypeRequest) IsSetAuthor() bool {
+	return p.Author != nil
+}
+
+func (p *AddBookTypeRequest) IsSetContributors() bool {
+	return p.Contributors != nil
+}
+
+func (p *AddBookTypeRequest) IsSetSubjectIds() bool {
+	return p.SubjectIds != nil
+}
+
+func (p *AddBookTypeRequest) IsSetSeries() bool {
+	return p.Series != nil
+}
+
+func (p *AddBookTypeRequest) IsSetCategoryID() bool {
+	return p.CategoryID != nil
 }
 
 func (p *AddBookTypeRequest) Read(iprot thrift.TProtocol) (err error) {
 	var fieldTypeId thrift.TType
 	var fieldId int16
 	var issetTitle bool = false
-	var issetAuthor bool = false
 	var issetCategory bool = false
 	var issetISBN bool = false
 	var issetPublisher bool = false
@@ -103,7 +575,6 @@ func (p *AddBookTypeRequest) Read(iprot thrift.TProtocol) (err error) {
 				if err = p.ReadField2(iprot); err != nil {
 					goto ReadFieldError
 				}
-				issetAuthor = true
 			} else if err = iprot.Skip(fieldTypeId); err != nil {
 				goto SkipFieldError
 			}
@@ -152,6 +623,38 @@ func (p *AddBookTypeRequest) Read(iprot thrift.TProtocol) (err error) {
 			} else if err = iprot.Skip(fieldTypeId); err != nil {
 				goto SkipFieldError
 			}
+		case 8:
+			if fieldTypeId == thrift.LIST {
+				if err = p.ReadField8(iprot); err != nil {
+					goto ReadFieldError
+				}
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		case 9:
+			if fieldTypeId == thrift.LIST {
+				if err = p.ReadField9(iprot); err != nil {
+					goto ReadFieldError
+				}
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		case 10:
+			if fieldTypeId == thrift.LIST {
+				if err = p.ReadField10(iprot); err != nil {
+					goto ReadFieldError
+				}
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		case 11:
+			if fieldTypeId == thrift.I64 {
+				if err = p.ReadField11(iprot); err != nil {
+					goto ReadFieldError
+				}
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
 		default:
 			if err = iprot.Skip(fieldTypeId); err != nil {
 				goto SkipFieldError
@@ -170,11 +673,6 @@ func (p *AddBookTypeRequest) Read(iprot thrift.TProtocol) (err error) {
 		goto RequiredFieldNotSetError
 	}
 
-	if !issetAuthor {
-		fieldId = 2
-		goto RequiredFieldNotSetError
-	}
-
 	if !issetCategory {
 		fieldId = 3
 		goto RequiredFieldNotSetError
@@ -230,11 +728,11 @@ func (p *AddBookTypeRequest) ReadField1(iprot thrift.TProtocol) error {
 }
 func (p *AddBookTypeRequest) ReadField2(iprot thrift.TProtocol) error {
 
-	var _field string
+	var _field *string
 	if v, err := iprot.ReadString(); err != nil {
 		return err
 	} else {
-		_field = v
+		_field = &v
 	}
 	p.Author = _field
 	return nil
@@ -244,54 +742,134 @@ func (p *AddBookTypeRequest) ReadField3(iprot thrift.TProtocol) error {
 	var _field string
 	if v, err := iprot.ReadString(); err != nil {
 		return err
-	} else {
-		_field = v
+	} else {
+		_field = v
+	}
+	p.Category = _field
+	return nil
+}
+func (p *AddBookTypeRequest) ReadField4(iprot thrift.TProtocol) error {
+
+	var _field string
+	if v, err := iprot.ReadString(); err != nil {
+		return err
+	} else {
+		_field = v
+	}
+	p.ISBN = _field
+	return nil
+}
+func (p *AddBookTypeRequest) ReadField5(iprot thrift.TProtocol) error {
+
+	var _field string
+	if v, err := iprot.ReadString(); err != nil {
+		return err
+	} else {
+		_field = v
+	}
+	p.Publisher = _field
+	return nil
+}
+func (p *AddBookTypeRequest) ReadField6(iprot thrift.TProtocol) error {
+
+	var _field int64
+	if v, err := iprot.ReadI64(); err != nil {
+		return err
+	} else {
+		_field = v
+	}
+	p.PublishYear = _field
+	return nil
+}
+func (p *AddBookTypeRequest) ReadField7(iprot thrift.TProtocol) error {
+
+	var _field string
+	if v, err := iprot.ReadString(); err != nil {
+		return err
+	} else {
+		_field = v
+	}
+	p.Description = _field
+	return nil
+}
+func (p *AddBookTypeRequest) ReadField8(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return err
+	}
+	_field := make([]*ContributorLink, 0, size)
+	values := make([]ContributorLink, size)
+	for i := 0; i < size; i++ {
+		_elem := &values[i]
+		_elem.InitDefault()
+
+		if err := _elem.Read(iprot); err != nil {
+			return err
+		}
+
+		_field = append(_field, _elem)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return err
 	}
-	p.Category = _field
+	p.Contributors = _field
 	return nil
 }
-func (p *AddBookTypeRequest) ReadField4(iprot thrift.TProtocol) error {
-
-	var _field string
-	if v, err := iprot.ReadString(); err != nil {
+func (p *AddBookTypeRequest) ReadField9(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
 		return err
-	} else {
-		_field = v
 	}
-	p.ISBN = _field
-	return nil
-}
-func (p *AddBookTypeRequest) ReadField5(iprot thrift.TProtocol) error {
+	_field := make([]int64, 0, size)
+	for i := 0; i < size; i++ {
 
-	var _field string
-	if v, err := iprot.ReadString(); err != nil {
+		var _elem int64
+		if v, err := iprot.ReadI64(); err != nil {
+			return err
+		} else {
+			_elem = v
+		}
+
+		_field = append(_field, _elem)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
 		return err
-	} else {
-		_field = v
 	}
-	p.Publisher = _field
+	p.SubjectIds = _field
 	return nil
 }
-func (p *AddBookTypeRequest) ReadField6(iprot thrift.TProtocol) error {
+func (p *AddBookTypeRequest) ReadField10(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return err
+	}
+	_field := make([]*SeriesLink, 0, size)
+	values := make([]SeriesLink, size)
+	for i := 0; i < size; i++ {
+		_elem := &values[i]
+		_elem.InitDefault()
 
-	var _field int64
-	if v, err := iprot.ReadI64(); err != nil {
+		if err := _elem.Read(iprot); err != nil {
+			return err
+		}
+
+		_field = append(_field, _elem)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
 		return err
-	} else {
-		_field = v
 	}
-	p.PublishYear = _field
+	p.Series = _field
 	return nil
 }
-func (p *AddBookTypeRequest) ReadField7(iprot thrift.TProtocol) error {
+func (p *AddBookTypeRequest) ReadField11(iprot thrift.TProtocol) error {
 
-	var _field string
-	if v, err := iprot.ReadString(); err != nil {
+	var _field *int64
+	if v, err := iprot.ReadI64(); err != nil {
 		return err
 	} else {
-		_field = v
+		_field = &v
 	}
-	p.Description = _field
+	p.CategoryID = _field
 	return nil
 }
 
@@ -329,6 +907,22 @@ func (p *AddBookTypeRequest) Write(oprot thrift.TProtocol) (err error) {
 			fieldId = 7
 			goto WriteFieldError
 		}
+		if err = p.writeField8(oprot); err != nil {
+			fieldId = 8
+			goto WriteFieldError
+		}
+		if err = p.writeField9(oprot); err != nil {
+			fieldId = 9
+			goto WriteFieldError
+		}
+		if err = p.writeField10(oprot); err != nil {
+			fieldId = 10
+			goto WriteFieldError
+		}
+		if err = p.writeField11(oprot); err != nil {
+			fieldId = 11
+			goto WriteFieldError
+		}
 	}
 	if err = oprot.WriteFieldStop(); err != nil {
 		goto WriteFieldStopError
@@ -364,14 +958,16 @@ WriteFieldEndError:
 	return thrift.PrependError(fmt.Sprintf("%T write field 1 end error: ", p), err)
 }
 func (p *AddBookTypeRequest) writeField2(oprot thrift.TProtocol) (err error) {
-	if err = oprot.WriteFieldBegin("author", thrift.STRING, 2); err != nil {
-		goto WriteFieldBeginError
-	}
-	if err := oprot.WriteString(p.Author); err != nil {
-		return err
-	}
-	if err = oprot.WriteFieldEnd(); err != nil {
-		goto WriteFieldEndError
+	if p.IsSetAuthor() {
+		if err = oprot.WriteFieldBegin("author", thrift.STRING, 2); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := oprot.WriteString(*p.Author); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
 	}
 	return nil
 WriteFieldBeginError:
@@ -459,6 +1055,102 @@ WriteFieldBeginError:
 WriteFieldEndError:
 	return thrift.PrependError(fmt.Sprintf("%T write field 7 end error: ", p), err)
 }
+func (p *AddBookTypeRequest) writeField8(oprot thrift.TProtocol) (err error) {
+	if p.IsSetContributors() {
+		if err = oprot.WriteFieldBegin("contributors", thrift.LIST, 8); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Contributors)); err != nil {
+			return err
+		}
+		for _, v := range p.Contributors {
+			if err := v.Write(oprot); err != nil {
+				return err
+			}
+		}
+		if err := oprot.WriteListEnd(); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 8 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 8 end error: ", p), err)
+}
+func (p *AddBookTypeRequest) writeField9(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSubjectIds() {
+		if err = oprot.WriteFieldBegin("subject_ids", thrift.LIST, 9); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := oprot.WriteListBegin(thrift.I64, len(p.SubjectIds)); err != nil {
+			return err
+		}
+		for _, v := range p.SubjectIds {
+			if err := oprot.WriteI64(v); err != nil {
+				return err
+			}
+		}
+		if err := oprot.WriteListEnd(); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 9 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 9 end error: ", p), err)
+}
+func (p *AddBookTypeRequest) writeField10(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSeries() {
+		if err = oprot.WriteFieldBegin("series", thrift.LIST, 10); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Series)); err != nil {
+			return err
+		}
+		for _, v := range p.Series {
+			if err := v.Write(oprot); err != nil {
+				return err
+			}
+		}
+		if err := oprot.WriteListEnd(); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 10 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 10 end error: ", p), err)
+}
+func (p *AddBookTypeRequest) writeField11(oprot thrift.TProtocol) (err error) {
+	if p.IsSetCategoryID() {
+		if err = oprot.WriteFieldBegin("category_id", thrift.I64, 11); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := oprot.WriteI64(*p.CategoryID); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 11 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 11 end error: ", p), err)
+}
 
 func (p *AddBookTypeRequest) String() string {
 	if p == nil {
@@ -673,13 +1365,17 @@ func (p *AddBookTypeResponse) String() string {
 }
 
 type UpdateBookTypeRequest struct {
-	Title       *string `thrift:"title,1,optional" form:"title" json:"title,omitempty" query:"title"`
-	Author      *string `thrift:"author,2,optional" form:"author" json:"author,omitempty" query:"author"`
-	Category    *string `thrift:"category,3,optional" form:"category" json:"category,omitempty" query:"category"`
-	ISBN        string  `thrift:"ISBN,4,required" form:"ISBN,required" json:"ISBN,required" query:"ISBN,required"`
-	Publisher   *string `thrift:"publisher,5,optional" form:"publisher" json:"publisher,omitempty" query:"publisher"`
-	PublishYear *int64  `thrift:"publish_year,6,optional" form:"publish_year" json:"publish_year,omitempty" query:"publish_year"`
-	Description *string `thrift:"description,7,optional" form:"description" json:"description,omitempty" query:"description"`
+	Title        *string            `thrift:"title,1,optional" form:"title" json:"title,omitempty" query:"title"`
+	Author       *string            `thrift:"author,2,optional" form:"author" json:"author,omitempty" query:"author"`
+	Category     *string            `thrift:"category,3,optional" form:"category" json:"category,omitempty" query:"category"`
+	ISBN         string             `thrift:"ISBN,4,required" form:"ISBN,required" json:"ISBN,required" query:"ISBN,required"`
+	Publisher    *string            `thrift:"publisher,5,optional" form:"publisher" json:"publisher,omitempty" query:"publisher"`
+	PublishYear  *int64             `thrift:"publish_year,6,optional" form:"publish_year" json:"publish_year,omitempty" query:"publish_year"`
+	Description  *string            `thrift:"description,7,optional" form:"description" json:"description,omitempty" query:"description"`
+	Contributors []*ContributorLink `thrift:"contributors,8,optional" form:"contributors" json:"contributors,omitempty" query:"contributors"`
+	SubjectIds   []int64            `thrift:"subject_ids,9,optional" form:"subject_ids" json:"subject_ids,omitempty" query:"subject_ids"`
+	Series       []*SeriesLink      `thrift:"series,10,optional" form:"series" json:"series,omitempty" query:"series"`
+	CategoryID   *int64             `thrift:"category_id,11,optional" form:"category_id" json:"category_id,omitempty" query:"category_id"`
 }
 
 func NewUpdateBookTypeRequest() *UpdateBookTypeRequest {
@@ -747,14 +1443,54 @@ func (p *UpdateBookTypeRequest) GetDescription() (v string) {
 	return *p.Description
 }
 
+var UpdateBookTypeRequest_Contributors_DEFAULT []*ContributorLink
+
+func (p *UpdateBookTypeRequest) GetContributors() (v []*ContributorLink) {
+	if !p.IsSetContributors() {
+		return UpdateBookTypeRequest_Contributors_DEFAULT
+	}
+	return p.Contributors
+}
+
+var UpdateBookTypeRequest_SubjectIds_DEFAULT []int64
+
+func (p *UpdateBookTypeRequest) GetSubjectIds() (v []int64) {
+	if !p.IsSetSubjectIds() {
+		return UpdateBookTypeRequest_SubjectIds_DEFAULT
+	}
+	return p.SubjectIds
+}
+
+var UpdateBookTypeRequest_Series_DEFAULT []*SeriesLink
+
+func (p *UpdateBookTypeRequest) GetSeries() (v []*SeriesLink) {
+	if !p.IsSetSeries() {
+		return UpdateBookTypeRequest_Series_DEFAULT
+	}
+	return p.Series
+}
+
+var UpdateBookTypeRequest_CategoryID_DEFAULT int64
+
+func (p *UpdateBookTypeRequest) GetCategoryID() (v int64) {
+	if !p.IsSetCategoryID() {
+		return UpdateBookTypeRequest_CategoryID_DEFAULT
+	}
+	return *p.CategoryID
+}
+
 var fieldIDToName_UpdateBookTypeRequest = map[int16]string{
-	1: "title",
-	2: "author",
-	3: "category",
-	4: "ISBN",
-	5: "publisher",
-	6: "publish_year",
-	7: "description",
+	1:  "title",
+	2:  "author",
+	3:  "category",
+	4:  "ISBN",
+	5:  "publisher",
+	6:  "publish_year",
+	7:  "description",
+	8:  "contributors",
+	9:  "subject_ids",
+	10: "series",
+	11: "category_id",
 }
 
 func (p *UpdateBookTypeRequest) IsSetTitle() bool {
@@ -781,6 +1517,22 @@ func (p *UpdateBookTypeRequest) IsSetDescription() bool {
 	return p.Description != nil
 }
 
+func (p *UpdateBookTypeRequest) IsSetContributors() bool {
+	return p.Contributors != nil
+}
+
+func (p *UpdateBookTypeRequest) IsSetSubjectIds() bool {
+	return p.SubjectIds != nil
+}
+
+func (p *UpdateBookTypeRequest) IsSetSeries() bool {
+	return p.Series != nil
+}
+
+func (p *UpdateBookTypeRequest) IsSetCategoryID() bool {
+	return p.CategoryID != nil
+}
+
 func (p *UpdateBookTypeRequest) Read(iprot thrift.TProtocol) (err error) {
 	var fieldTypeId thrift.TType
 	var fieldId int16
@@ -857,6 +1609,38 @@ func (p *UpdateBookTypeRequest) Read(iprot thrift.TProtocol) (err error) {
 			} else if err = iprot.Skip(fieldTypeId); err != nil {
 				goto SkipFieldError
 			}
+		case 8:
+			if fieldTypeId == thrift.LIST {
+				if err = p.ReadField8(iprot); err != nil {
+					goto ReadFieldError
+				}
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		case 9:
+			if fieldTypeId == thrift.LIST {
+				if err = p.ReadField9(iprot); err != nil {
+					goto ReadFieldError
+				}
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		case 10:
+			if fieldTypeId == thrift.LIST {
+				if err = p.ReadField10(iprot); err != nil {
+					goto ReadFieldError
+				}
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		case 11:
+			if fieldTypeId == thrift.I64 {
+				if err = p.ReadField11(iprot); err != nil {
+					goto ReadFieldError
+				}
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
 		default:
 			if err = iprot.Skip(fieldTypeId); err != nil {
 				goto SkipFieldError
@@ -949,24 +1733,104 @@ func (p *UpdateBookTypeRequest) ReadField5(iprot thrift.TProtocol) error {
 }
 func (p *UpdateBookTypeRequest) ReadField6(iprot thrift.TProtocol) error {
 
-	var _field *int64
-	if v, err := iprot.ReadI64(); err != nil {
+	var _field *int64
+	if v, err := iprot.ReadI64(); err != nil {
+		return err
+	} else {
+		_field = &v
+	}
+	p.PublishYear = _field
+	return nil
+}
+func (p *UpdateBookTypeRequest) ReadField7(iprot thrift.TProtocol) error {
+
+	var _field *string
+	if v, err := iprot.ReadString(); err != nil {
+		return err
+	} else {
+		_field = &v
+	}
+	p.Description = _field
+	return nil
+}
+func (p *UpdateBookTypeRequest) ReadField8(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return err
+	}
+	_field := make([]*ContributorLink, 0, size)
+	values := make([]ContributorLink, size)
+	for i := 0; i < size; i++ {
+		_elem := &values[i]
+		_elem.InitDefault()
+
+		if err := _elem.Read(iprot); err != nil {
+			return err
+		}
+
+		_field = append(_field, _elem)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return err
+	}
+	p.Contributors = _field
+	return nil
+}
+func (p *UpdateBookTypeRequest) ReadField9(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return err
+	}
+	_field := make([]int64, 0, size)
+	for i := 0; i < size; i++ {
+
+		var _elem int64
+		if v, err := iprot.ReadI64(); err != nil {
+			return err
+		} else {
+			_elem = v
+		}
+
+		_field = append(_field, _elem)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return err
+	}
+	p.SubjectIds = _field
+	return nil
+}
+func (p *UpdateBookTypeRequest) ReadField10(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return err
+	}
+	_field := make([]*SeriesLink, 0, size)
+	values := make([]SeriesLink, size)
+	for i := 0; i < size; i++ {
+		_elem := &values[i]
+		_elem.InitDefault()
+
+		if err := _elem.Read(iprot); err != nil {
+			return err
+		}
+
+		_field = append(_field, _elem)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
 		return err
-	} else {
-		_field = &v
 	}
-	p.PublishYear = _field
+	p.Series = _field
 	return nil
 }
-func (p *UpdateBookTypeRequest) ReadField7(iprot thrift.TProtocol) error {
+func (p *UpdateBookTypeRequest) ReadField11(iprot thrift.TProtocol) error {
 
-	var _field *string
-	if v, err := iprot.ReadString(); err != nil {
+	var _field *int64
+	if v, err := iprot.ReadI64(); err != nil {
 		return err
 	} else {
 		_field = &v
 	}
-	p.Description = _field
+	p.CategoryID = _field
 	return nil
 }
 
@@ -1004,6 +1868,22 @@ func (p *UpdateBookTypeRequest) Write(oprot thrift.TProtocol) (err error) {
 			fieldId = 7
 			goto WriteFieldError
 		}
+		if err = p.writeField8(oprot); err != nil {
+			fieldId = 8
+			goto WriteFieldError
+		}
+		if err = p.writeField9(oprot); err != nil {
+			fieldId = 9
+			goto WriteFieldError
+		}
+		if err = p.writeField10(oprot); err != nil {
+			fieldId = 10
+			goto WriteFieldError
+		}
+		if err = p.writeField11(oprot); err != nil {
+			fieldId = 11
+			goto WriteFieldError
+		}
 	}
 	if err = oprot.WriteFieldStop(); err != nil {
 		goto WriteFieldStopError
@@ -1146,6 +2026,102 @@ WriteFieldBeginError:
 WriteFieldEndError:
 	return thrift.PrependError(fmt.Sprintf("%T write field 7 end error: ", p), err)
 }
+func (p *UpdateBookTypeRequest) writeField8(oprot thrift.TProtocol) (err error) {
+	if p.IsSetContributors() {
+		if err = oprot.WriteFieldBegin("contributors", thrift.LIST, 8); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Contributors)); err != nil {
+			return err
+		}
+		for _, v := range p.Contributors {
+			if err := v.Write(oprot); err != nil {
+				return err
+			}
+		}
+		if err := oprot.WriteListEnd(); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 8 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 8 end error: ", p), err)
+}
+func (p *UpdateBookTypeRequest) writeField9(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSubjectIds() {
+		if err = oprot.WriteFieldBegin("subject_ids", thrift.LIST, 9); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := oprot.WriteListBegin(thrift.I64, len(p.SubjectIds)); err != nil {
+			return err
+		}
+		for _, v := range p.SubjectIds {
+			if err := oprot.WriteI64(v); err != nil {
+				return err
+			}
+		}
+		if err := oprot.WriteListEnd(); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 9 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 9 end error: ", p), err)
+}
+func (p *UpdateBookTypeRequest) writeField10(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSeries() {
+		if err = oprot.WriteFieldBegin("series", thrift.LIST, 10); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Series)); err != nil {
+			return err
+		}
+		for _, v := range p.Series {
+			if err := v.Write(oprot); err != nil {
+				return err
+			}
+		}
+		if err := oprot.WriteListEnd(); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 10 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 10 end error: ", p), err)
+}
+func (p *UpdateBookTypeRequest) writeField11(oprot thrift.TProtocol) (err error) {
+	if p.IsSetCategoryID() {
+		if err = oprot.WriteFieldBegin("category_id", thrift.I64, 11); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := oprot.WriteI64(*p.CategoryID); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 11 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 11 end error: ", p), err)
+}
 
 func (p *UpdateBookTypeRequest) String() string {
 	if p == nil {
@@ -1652,12 +2628,20 @@ func (p *DeleteBookTypeResponse) String() string {
 }
 
 type GetBookTypeRequest struct {
-	ISBN     *string `thrift:"ISBN,1,optional" form:"ISBN" json:"ISBN,omitempty" query:"ISBN"`
-	Title    *string `thrift:"title,2,optional" form:"title" json:"title,omitempty" query:"title"`
-	Author   *string `thrift:"author,3,optional" form:"author" json:"author,omitempty" query:"author"`
-	Category *string `thrift:"category,4,optional" form:"category" json:"category,omitempty" query:"category"`
-	PageSize int64   `thrift:"page_size,5,required" form:"page_size,required" json:"page_size,required" query:"page_size,required"`
-	PageNum  int64   `thrift:"page_num,6,required" form:"page_num,required" json:"page_num,required" query:"page_num,required"`
+	ISBN          *string `thrift:"ISBN,1,optional" form:"ISBN" json:"ISBN,omitempty" query:"ISBN"`
+	Title         *string `thrift:"title,2,optional" form:"title" json:"title,omitempty" query:"title"`
+	Author        *string `thrift:"author,3,optional" form:"author" json:"author,omitempty" query:"author"`
+	Category      *string `thrift:"category,4,optional" form:"category" json:"category,omitempty" query:"category"`
+	PageSize      *int64  `thrift:"page_size,5,optional" form:"page_size" json:"page_size,omitempty" query:"page_size"`
+	PageNum       *int64  `thrift:"page_num,6,optional" form:"page_num" json:"page_num,omitempty" query:"page_num"`
+	Cursor        *string `thrift:"cursor,7,optional" form:"cursor" json:"cursor,omitempty" query:"cursor"`
+	Sort          *string `thrift:"sort,8,optional" form:"sort" json:"sort,omitempty" query:"sort"`
+	WithTotal     *bool   `thrift:"with_total,9,optional" form:"with_total" json:"with_total,omitempty" query:"with_total"`
+	ContributorID *int64  `thrift:"contributor_id,10,optional" form:"contributor_id" json:"contributor_id,omitempty" query:"contributor_id"`
+	Role          *string `thrift:"role,11,optional" form:"role" json:"role,omitempty" query:"role"`
+	SubjectID     *int64  `thrift:"subject_id,12,optional" form:"subject_id" json:"subject_id,omitempty" query:"subject_id"`
+	SeriesID      *int64  `thrift:"series_id,13,optional" form:"series_id" json:"series_id,omitempty" query:"series_id"`
+	CategoryID    *int64  `thrift:"category_id,14,optional" form:"category_id" json:"category_id,omitempty" query:"category_id"`
 }
 
 func NewGetBookTypeRequest() *GetBookTypeRequest {
@@ -1703,21 +2687,111 @@ func (p *GetBookTypeRequest) GetCategory() (v string) {
 	return *p.Category
 }
 
+var GetBookTypeRequest_PageSize_DEFAULT int64
+
 func (p *GetBookTypeRequest) GetPageSize() (v int64) {
-	return p.PageSize
+	if !p.IsSetPageSize() {
+		return GetBookTypeRequest_PageSize_DEFAULT
+	}
+	return *p.PageSize
 }
 
+var GetBookTypeRequest_PageNum_DEFAULT int64
+
 func (p *GetBookTypeRequest) GetPageNum() (v int64) {
-	return p.PageNum
+	if !p.IsSetPageNum() {
+		return GetBookTypeRequest_PageNum_DEFAULT
+	}
+	return *p.PageNum
+}
+
+var GetBookTypeRequest_Cursor_DEFAULT string
+
+func (p *GetBookTypeRequest) GetCursor() (v string) {
+	if !p.IsSetCursor() {
+		return GetBookTypeRequest_Cursor_DEFAULT
+	}
+	return *p.Cursor
+}
+
+var GetBookTypeRequest_Sort_DEFAULT string
+
+func (p *GetBookTypeRequest) GetSort() (v string) {
+	if !p.IsSetSort() {
+		return GetBookTypeRequest_Sort_DEFAULT
+	}
+	return *p.Sort
+}
+
+var GetBookTypeRequest_WithTotal_DEFAULT bool
+
+func (p *GetBookTypeRequest) GetWithTotal() (v bool) {
+	if !p.IsSetWithTotal() {
+		return GetBookTypeRequest_WithTotal_DEFAULT
+	}
+	return *p.WithTotal
+}
+
+var GetBookTypeRequest_ContributorID_DEFAULT int64
+
+func (p *GetBookTypeRequest) GetContributorID() (v int64) {
+	if !p.IsSetContributorID() {
+		return GetBookTypeRequest_ContributorID_DEFAULT
+	}
+	return *p.ContributorID
+}
+
+var GetBookTypeRequest_Role_DEFAULT string
+
+func (p *GetBookTypeRequest) GetRole() (v string) {
+	if !p.IsSetRole() {
+		return GetBookTypeRequest_Role_DEFAULT
+	}
+	return *p.Role
+}
+
+var GetBookTypeRequest_SubjectID_DEFAULT int64
+
+func (p *GetBookTypeRequest) GetSubjectID() (v int64) {
+	if !p.IsSetSubjectID() {
+		return GetBookTypeRequest_SubjectID_DEFAULT
+	}
+	return *p.SubjectID
+}
+
+var GetBookTypeRequest_SeriesID_DEFAULT int64
+
+func (p *GetBookTypeRequest) GetSeriesID() (v int64) {
+	if !p.IsSetSeriesID() {
+		return GetBookTypeRequest_SeriesID_DEFAULT
+	}
+	return *p.SeriesID
+}
+
+var GetBookTypeRequest_CategoryID_DEFAULT int64
+
+func (p *GetBookTypeRequest) GetCategoryID() (v int64) {
+	if !p.IsSetCategoryID() {
+		return GetBookTypeRequest_CategoryID_DEFAULT
+	}
+	return *p.CategoryID
 }
 
 var fieldIDToName_GetBookTypeRequest = map[int16]string{
-	1: "ISBN",
-	2: "title",
-	3: "author",
-	4: "category",
-	5: "page_size",
-	6: "page_num",
+	1:  "ISBN",
+	2:  "title",
+	3:  "author",
+	4:  "category",
+	5:  "page_size",
+	6:  "page_num",
+	7:  "cursor",
+	8:  "sort",
+	9:  "with_total",
+	10: "contributor_id",
+	11: "role",
+	12: "subject_id",
+	13: "series_id",
+	14: "category_id",
 }
 
 func (p *GetBookTypeRequest) IsSetISBN() bool {
@@ -1736,11 +2810,49 @@ func (p *GetBookTypeRequest) IsSetCategory() bool {
 	return p.Category != nil
 }
 
+func (p *GetBookTypeRequest) IsSetPageSize() bool {
+	return p.PageSize != nil
+}
+
+func (p *GetBookTypeRequest) IsSetPageNum() bool {
+	return p.PageNum != nil
+}
+
+func (p *GetBookTypeRequest) IsSetCursor() bool {
+	return p.Cursor != nil
+}
+
+func (p *GetBookTypeRequest) IsSetSort() bool {
+	return p.Sort != nil
+}
+
+func (p *GetBookTypeRequest) IsSetWithTotal() bool {
+	return p.WithTotal != nil
+}
+
+func (p *GetBookTypeRequest) IsSetContributorID() bool {
+	return p.ContributorID != nil
+}
+
+func (p *GetBookTypeRequest) IsSetRole() bool {
+	return p.Role != nil
+}
+
+func (p *GetBookTypeRequest) IsSetSubjectID() bool {
+	return p.SubjectID != nil
+}
+
+func (p *GetBookTypeRequest) IsSetSeriesID() bool {
+	return p.SeriesID != nil
+}
+
+func (p *GetBookTypeRequest) IsSetCategoryID() bool {
+	return p.CategoryID != nil
+}
+
 func (p *GetBookTypeRequest) Read(iprot thrift.TProtocol) (err error) {
 	var fieldTypeId thrift.TType
 	var fieldId int16
-	var issetPageSize bool = false
-	var issetPageNum bool = false
 
 	if _, err = iprot.ReadStructBegin(); err != nil {
 		goto ReadStructBeginError
@@ -1793,7 +2905,6 @@ func (p *GetBookTypeRequest) Read(iprot thrift.TProtocol) (err error) {
 				if err = p.ReadField5(iprot); err != nil {
 					goto ReadFieldError
 				}
-				issetPageSize = true
 			} else if err = iprot.Skip(fieldTypeId); err != nil {
 				goto SkipFieldError
 			}
@@ -1802,7 +2913,70 @@ func (p *GetBookTypeRequest) Read(iprot thrift.TProtocol) (err error) {
 				if err = p.ReadField6(iprot); err != nil {
 					goto ReadFieldError
 				}
-				issetPageNum = true
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		case 7:
+			if fieldTypeId == thrift.STRING {
+				if err = p.ReadField7(iprot); err != nil {
+					goto ReadFieldError
+				}
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		case 8:
+			if fieldTypeId == thrift.STRING {
+				if err = p.ReadField8(iprot); err != nil {
+					goto ReadFieldError
+				}
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		case 9:
+			if fieldTypeId == thrift.BOOL {
+				if err = p.ReadField9(iprot); err != nil {
+					goto ReadFieldError
+				}
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		case 10:
+			if fieldTypeId == thrift.I64 {
+				if err = p.ReadField10(iprot); err != nil {
+					goto ReadFieldError
+				}
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		case 11:
+			if fieldTypeId == thrift.STRING {
+				if err = p.ReadField11(iprot); err != nil {
+					goto ReadFieldError
+				}
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		case 12:
+			if fieldTypeId == thrift.I64 {
+				if err = p.ReadField12(iprot); err != nil {
+					goto ReadFieldError
+				}
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		case 13:
+			if fieldTypeId == thrift.I64 {
+				if err = p.ReadField13(iprot); err != nil {
+					goto ReadFieldError
+				}
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		case 14:
+			if fieldTypeId == thrift.I64 {
+				if err = p.ReadField14(iprot); err != nil {
+					goto ReadFieldError
+				}
 			} else if err = iprot.Skip(fieldTypeId); err != nil {
 				goto SkipFieldError
 			}
@@ -1819,15 +2993,6 @@ func (p *GetBookTypeRequest) Read(iprot thrift.TProtocol) (err error) {
 		goto ReadStructEndError
 	}
 
-	if !issetPageSize {
-		fieldId = 5
-		goto RequiredFieldNotSetError
-	}
-
-	if !issetPageNum {
-		fieldId = 6
-		goto RequiredFieldNotSetError
-	}
 	return nil
 ReadStructBeginError:
 	return thrift.PrependError(fmt.Sprintf("%T read struct begin error: ", p), err)
@@ -1842,11 +3007,75 @@ ReadFieldEndError:
 	return thrift.PrependError(fmt.Sprintf("%T read field end error", p), err)
 ReadStructEndError:
 	return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
-RequiredFieldNotSetError:
-	return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("required field %s is not set", fieldIDToName_GetBookTypeRequest[fieldId]))
 }
-
-func (p *GetBookTypeRequest) ReadField1(iprot thrift.TProtocol) error {
+
+func (p *GetBookTypeRequest) ReadField1(iprot thrift.TProtocol) error {
+
+	var _field *string
+	if v, err := iprot.ReadString(); err != nil {
+		return err
+	} else {
+		_field = &v
+	}
+	p.ISBN = _field
+	return nil
+}
+func (p *GetBookTypeRequest) ReadField2(iprot thrift.TProtocol) error {
+
+	var _field *string
+	if v, err := iprot.ReadString(); err != nil {
+		return err
+	} else {
+		_field = &v
+	}
+	p.Title = _field
+	return nil
+}
+func (p *GetBookTypeRequest) ReadField3(iprot thrift.TProtocol) error {
+
+	var _field *string
+	if v, err := iprot.ReadString(); err != nil {
+		return err
+	} else {
+		_field = &v
+	}
+	p.Author = _field
+	return nil
+}
+func (p *GetBookTypeRequest) ReadField4(iprot thrift.TProtocol) error {
+
+	var _field *string
+	if v, err := iprot.ReadString(); err != nil {
+		return err
+	} else {
+		_field = &v
+	}
+	p.Category = _field
+	return nil
+}
+func (p *GetBookTypeRequest) ReadField5(iprot thrift.TProtocol) error {
+
+	var _field *int64
+	if v, err := iprot.ReadI64(); err != nil {
+		return err
+	} else {
+		_field = &v
+	}
+	p.PageSize = _field
+	return nil
+}
+func (p *GetBookTypeRequest) ReadField6(iprot thrift.TProtocol) error {
+
+	var _field *int64
+	if v, err := iprot.ReadI64(); err != nil {
+		return err
+	} else {
+		_field = &v
+	}
+	p.PageNum = _field
+	return nil
+}
+func (p *GetBookTypeRequest) ReadField7(iprot thrift.TProtocol) error {
 
 	var _field *string
 	if v, err := iprot.ReadString(); err != nil {
@@ -1854,10 +3083,10 @@ func (p *GetBookTypeRequest) ReadField1(iprot thrift.TProtocol) error {
 	} else {
 		_field = &v
 	}
-	p.ISBN = _field
+	p.Cursor = _field
 	return nil
 }
-func (p *GetBookTypeRequest) ReadField2(iprot thrift.TProtocol) error {
+func (p *GetBookTypeRequest) ReadField8(iprot thrift.TProtocol) error {
 
 	var _field *string
 	if v, err := iprot.ReadString(); err != nil {
@@ -1865,21 +3094,32 @@ func (p *GetBookTypeRequest) ReadField2(iprot thrift.TProtocol) error {
 	} else {
 		_field = &v
 	}
-	p.Title = _field
+	p.Sort = _field
 	return nil
 }
-func (p *GetBookTypeRequest) ReadField3(iprot thrift.TProtocol) error {
+func (p *GetBookTypeRequest) ReadField9(iprot thrift.TProtocol) error {
 
-	var _field *string
-	if v, err := iprot.ReadString(); err != nil {
+	var _field *bool
+	if v, err := iprot.ReadBool(); err != nil {
 		return err
 	} else {
 		_field = &v
 	}
-	p.Author = _field
+	p.WithTotal = _field
 	return nil
 }
-func (p *GetBookTypeRequest) ReadField4(iprot thrift.TProtocol) error {
+func (p *GetBookTypeRequest) ReadField10(iprot thrift.TProtocol) error {
+
+	var _field *int64
+	if v, err := iprot.ReadI64(); err != nil {
+		return err
+	} else {
+		_field = &v
+	}
+	p.ContributorID = _field
+	return nil
+}
+func (p *GetBookTypeRequest) ReadField11(iprot thrift.TProtocol) error {
 
 	var _field *string
 	if v, err := iprot.ReadString(); err != nil {
@@ -1887,29 +3127,40 @@ func (p *GetBookTypeRequest) ReadField4(iprot thrift.TProtocol) error {
 	} else {
 		_field = &v
 	}
-	p.Category = _field
+	p.Role = _field
 	return nil
 }
-func (p *GetBookTypeRequest) ReadField5(iprot thrift.TProtocol) error {
+func (p *GetBookTypeRequest) ReadField12(iprot thrift.TProtocol) error {
 
-	var _field int64
+	var _field *int64
 	if v, err := iprot.ReadI64(); err != nil {
 		return err
 	} else {
-		_field = v
+		_field = &v
 	}
-	p.PageSize = _field
+	p.SubjectID = _field
 	return nil
 }
-func (p *GetBookTypeRequest) ReadField6(iprot thrift.TProtocol) error {
+func (p *GetBookTypeRequest) ReadField13(iprot thrift.TProtocol) error {
 
-	var _field int64
+	var _field *int64
 	if v, err := iprot.ReadI64(); err != nil {
 		return err
 	} else {
-		_field = v
+		_field = &v
 	}
-	p.PageNum = _field
+	p.SeriesID = _field
+	return nil
+}
+func (p *GetBookTypeRequest) ReadField14(iprot thrift.TProtocol) error {
+
+	var _field *int64
+	if v, err := iprot.ReadI64(); err != nil {
+		return err
+	} else {
+		_field = &v
+	}
+	p.CategoryID = _field
 	return nil
 }
 
@@ -1943,6 +3194,38 @@ func (p *GetBookTypeRequest) Write(oprot thrift.TProtocol) (err error) {
 			fieldId = 6
 			goto WriteFieldError
 		}
+		if err = p.writeField7(oprot); err != nil {
+			fieldId = 7
+			goto WriteFieldError
+		}
+		if err = p.writeField8(oprot); err != nil {
+			fieldId = 8
+			goto WriteFieldError
+		}
+		if err = p.writeField9(oprot); err != nil {
+			fieldId = 9
+			goto WriteFieldError
+		}
+		if err = p.writeField10(oprot); err != nil {
+			fieldId = 10
+			goto WriteFieldError
+		}
+		if err = p.writeField11(oprot); err != nil {
+			fieldId = 11
+			goto WriteFieldError
+		}
+		if err = p.writeField12(oprot); err != nil {
+			fieldId = 12
+			goto WriteFieldError
+		}
+		if err = p.writeField13(oprot); err != nil {
+			fieldId = 13
+			goto WriteFieldError
+		}
+		if err = p.writeField14(oprot); err != nil {
+			fieldId = 14
+			goto WriteFieldError
+		}
 	}
 	if err = oprot.WriteFieldStop(); err != nil {
 		goto WriteFieldStopError
@@ -2034,14 +3317,16 @@ WriteFieldEndError:
 	return thrift.PrependError(fmt.Sprintf("%T write field 4 end error: ", p), err)
 }
 func (p *GetBookTypeRequest) writeField5(oprot thrift.TProtocol) (err error) {
-	if err = oprot.WriteFieldBegin("page_size", thrift.I64, 5); err != nil {
-		goto WriteFieldBeginError
-	}
-	if err := oprot.WriteI64(p.PageSize); err != nil {
-		return err
-	}
-	if err = oprot.WriteFieldEnd(); err != nil {
-		goto WriteFieldEndError
+	if p.IsSetPageSize() {
+		if err = oprot.WriteFieldBegin("page_size", thrift.I64, 5); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := oprot.WriteI64(*p.PageSize); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
 	}
 	return nil
 WriteFieldBeginError:
@@ -2050,14 +3335,16 @@ WriteFieldEndError:
 	return thrift.PrependError(fmt.Sprintf("%T write field 5 end error: ", p), err)
 }
 func (p *GetBookTypeRequest) writeField6(oprot thrift.TProtocol) (err error) {
-	if err = oprot.WriteFieldBegin("page_num", thrift.I64, 6); err != nil {
-		goto WriteFieldBeginError
-	}
-	if err := oprot.WriteI64(p.PageNum); err != nil {
-		return err
-	}
-	if err = oprot.WriteFieldEnd(); err != nil {
-		goto WriteFieldEndError
+	if p.IsSetPageNum() {
+		if err = oprot.WriteFieldBegin("page_num", thrift.I64, 6); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := oprot.WriteI64(*p.PageNum); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
 	}
 	return nil
 WriteFieldBeginError:
@@ -2065,6 +3352,150 @@ WriteFieldBeginError:
 WriteFieldEndError:
 	return thrift.PrependError(fmt.Sprintf("%T write field 6 end error: ", p), err)
 }
+func (p *GetBookTypeRequest) writeField7(oprot thrift.TProtocol) (err error) {
+	if p.IsSetCursor() {
+		if err = oprot.WriteFieldBegin("cursor", thrift.STRING, 7); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := oprot.WriteString(*p.Cursor); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 7 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 7 end error: ", p), err)
+}
+func (p *GetBookTypeRequest) writeField8(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSort() {
+		if err = oprot.WriteFieldBegin("sort", thrift.STRING, 8); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := oprot.WriteString(*p.Sort); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 8 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 8 end error: ", p), err)
+}
+func (p *GetBookTypeRequest) writeField9(oprot thrift.TProtocol) (err error) {
+	if p.IsSetWithTotal() {
+		if err = oprot.WriteFieldBegin("with_total", thrift.BOOL, 9); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := oprot.WriteBool(*p.WithTotal); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 9 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 9 end error: ", p), err)
+}
+func (p *GetBookTypeRequest) writeField10(oprot thrift.TProtocol) (err error) {
+	if p.IsSetContributorID() {
+		if err = oprot.WriteFieldBegin("contributor_id", thrift.I64, 10); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := oprot.WriteI64(*p.ContributorID); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 10 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 10 end error: ", p), err)
+}
+func (p *GetBookTypeRequest) writeField11(oprot thrift.TProtocol) (err error) {
+	if p.IsSetRole() {
+		if err = oprot.WriteFieldBegin("role", thrift.STRING, 11); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := oprot.WriteString(*p.Role); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 11 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 11 end error: ", p), err)
+}
+func (p *GetBookTypeRequest) writeField12(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSubjectID() {
+		if err = oprot.WriteFieldBegin("subject_id", thrift.I64, 12); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := oprot.WriteI64(*p.SubjectID); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 12 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 12 end error: ", p), err)
+}
+func (p *GetBookTypeRequest) writeField13(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSeriesID() {
+		if err = oprot.WriteFieldBegin("series_id", thrift.I64, 13); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := oprot.WriteI64(*p.SeriesID); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 13 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 13 end error: ", p), err)
+}
+func (p *GetBookTypeRequest) writeField14(oprot thrift.TProtocol) (err error) {
+	if p.IsSetCategoryID() {
+		if err = oprot.WriteFieldBegin("category_id", thrift.I64, 14); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := oprot.WriteI64(*p.CategoryID); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 14 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 14 end error: ", p), err)
+}
 
 func (p *GetBookTypeRequest) String() string {
 	if p == nil {
@@ -2075,9 +3506,10 @@ func (p *GetBookTypeRequest) String() string {
 }
 
 type GetBookTypeResponse struct {
-	Base  *model.BaseResp   `thrift:"base,1" form:"base" json:"base" query:"base"`
-	Data  []*model.BookType `thrift:"data,2,required" form:"data,required" json:"data,required" query:"data,required"`
-	Total int64             `thrift:"total,3,required" form:"total,required" json:"total,required" query:"total,required"`
+	Base       *model.BaseResp   `thrift:"base,1" form:"base" json:"base" query:"base"`
+	Data       []*model.BookType `thrift:"data,2,required" form:"data,required" json:"data,required" query:"data,required"`
+	Total      *int64            `thrift:"total,3,optional" form:"total" json:"total,omitempty" query:"total"`
+	NextCursor *string           `thrift:"next_cursor,4,optional" form:"next_cursor" json:"next_cursor,omitempty" query:"next_cursor"`
 }
 
 func NewGetBookTypeResponse() *GetBookTypeResponse {
@@ -2100,25 +3532,47 @@ func (p *GetBookTypeResponse) GetData() (v []*model.BookType) {
 	return p.Data
 }
 
+var GetBookTypeResponse_Total_DEFAULT int64
+
 func (p *GetBookTypeResponse) GetTotal() (v int64) {
-	return p.Total
+	if !p.IsSetTotal() {
+		return GetBookTypeResponse_Total_DEFAULT
+	}
+	return *p.Total
+}
+
+var GetBookTypeResponse_NextCursor_DEFAULT string
+
+func (p *GetBookTypeResponse) GetNextCursor() (v string) {
+	if !p.IsSetNextCursor() {
+		return GetBookTypeResponse_NextCursor_DEFAULT
+	}
+	return *p.NextCursor
 }
 
 var fieldIDToName_GetBookTypeResponse = map[int16]string{
 	1: "base",
 	2: "data",
 	3: "total",
+	4: "next_cursor",
 }
 
 func (p *GetBookTypeResponse) IsSetBase() bool {
 	return p.Base != nil
 }
 
+func (p *GetBookTypeResponse) IsSetTotal() bool {
+	return p.Total != nil
+}
+
+func (p *GetBookTypeResponse) IsSetNextCursor() bool {
+	return p.NextCursor != nil
+}
+
 func (p *GetBookTypeResponse) Read(iprot thrift.TProtocol) (err error) {
 	var fieldTypeId thrift.TType
 	var fieldId int16
 	var issetData bool = false
-	var issetTotal bool = false
 
 	if _, err = iprot.ReadStructBegin(); err != nil {
 		goto ReadStructBeginError
@@ -2156,7 +3610,14 @@ func (p *GetBookTypeResponse) Read(iprot thrift.TProtocol) (err error) {
 				if err = p.ReadField3(iprot); err != nil {
 					goto ReadFieldError
 				}
-				issetTotal = true
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		case 4:
+			if fieldTypeId == thrift.STRING {
+				if err = p.ReadField4(iprot); err != nil {
+					goto ReadFieldError
+				}
 			} else if err = iprot.Skip(fieldTypeId); err != nil {
 				goto SkipFieldError
 			}
@@ -2177,11 +3638,6 @@ func (p *GetBookTypeResponse) Read(iprot thrift.TProtocol) (err error) {
 		fieldId = 2
 		goto RequiredFieldNotSetError
 	}
-
-	if !issetTotal {
-		fieldId = 3
-		goto RequiredFieldNotSetError
-	}
 	return nil
 ReadStructBeginError:
 	return thrift.PrependError(fmt.Sprintf("%T read struct begin error: ", p), err)
@@ -2233,15 +3689,26 @@ func (p *GetBookTypeResponse) ReadField2(iprot thrift.TProtocol) error {
 }
 func (p *GetBookTypeResponse) ReadField3(iprot thrift.TProtocol) error {
 
-	var _field int64
+	var _field *int64
 	if v, err := iprot.ReadI64(); err != nil {
 		return err
 	} else {
-		_field = v
+		_field = &v
 	}
 	p.Total = _field
 	return nil
 }
+func (p *GetBookTypeResponse) ReadField4(iprot thrift.TProtocol) error {
+
+	var _field *string
+	if v, err := iprot.ReadString(); err != nil {
+		return err
+	} else {
+		_field = &v
+	}
+	p.NextCursor = _field
+	return nil
+}
 
 func (p *GetBookTypeResponse) Write(oprot thrift.TProtocol) (err error) {
 	var fieldId int16
@@ -2261,6 +3728,10 @@ func (p *GetBookTypeResponse) Write(oprot thrift.TProtocol) (err error) {
 			fieldId = 3
 			goto WriteFieldError
 		}
+		if err = p.writeField4(oprot); err != nil {
+			fieldId = 4
+			goto WriteFieldError
+		}
 	}
 	if err = oprot.WriteFieldStop(); err != nil {
 		goto WriteFieldStopError
@@ -2320,14 +3791,16 @@ WriteFieldEndError:
 	return thrift.PrependError(fmt.Sprintf("%T write field 2 end error: ", p), err)
 }
 func (p *GetBookTypeResponse) writeField3(oprot thrift.TProtocol) (err error) {
-	if err = oprot.WriteFieldBegin("total", thrift.I64, 3); err != nil {
-		goto WriteFieldBeginError
-	}
-	if err := oprot.WriteI64(p.Total); err != nil {
-		return err
-	}
-	if err = oprot.WriteFieldEnd(); err != nil {
-		goto WriteFieldEndError
+	if p.IsSetTotal() {
+		if err = oprot.WriteFieldBegin("total", thrift.I64, 3); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := oprot.WriteI64(*p.Total); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
 	}
 	return nil
 WriteFieldBeginError:
@@ -2335,6 +3808,24 @@ WriteFieldBeginError:
 WriteFieldEndError:
 	return thrift.PrependError(fmt.Sprintf("%T write field 3 end error: ", p), err)
 }
+func (p *GetBookTypeResponse) writeField4(oprot thrift.TProtocol) (err error) {
+	if p.IsSetNextCursor() {
+		if err = oprot.WriteFieldBegin("next_cursor", thrift.STRING, 4); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := oprot.WriteString(*p.NextCursor); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 4 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 4 end error: ", p), err)
+}
 
 func (p *GetBookTypeResponse) String() string {
 	if p == nil {
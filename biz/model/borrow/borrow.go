@@ -5,8 +5,10 @@ package borrow
 import (
 	"context"
 	"fmt"
-	"github.com/2451965602/LMS/biz/model/model"
+
 	"github.com/apache/thrift/lib/go/thrift"
+
+	"github.com/2451965602/LMS/biz/model/model"
 )
 
 type BorrowRequest struct {
@@ -1264,50 +1266,51 @@ func (p *RenewResponse) String() string {
 
 }
 
-type GetBorrowRecordRequest struct {
-	UserID   int64 `thrift:"user_id,1,required" form:"user_id,required" json:"user_id,required" query:"user_id,required"`
-	PageSize int64 `thrift:"page_size,2,required" form:"page_size,required" json:"page_size,required" query:"page_size,required"`
-	PageNum  int64 `thrift:"page_num,3,required" form:"page_num,required" json:"page_num,required" query:"page_num,required"`
-	Status   int64 `thrift:"status,4,required" form:"status,required" json:"status,required" query:"status,required"`
+type SettleFineRequest struct {
+	BorrowID int64   `thrift:"borrow_id,1,required" form:"borrow_id,required" json:"borrow_id,required" query:"borrow_id,required"`
+	Action   string  `thrift:"action,2,required" form:"action,required" json:"action,required" query:"action,required"`
+	Note     *string `thrift:"note,3,optional" form:"note" json:"note,omitempty" query:"note"`
 }
 
-func NewGetBorrowRecordRequest() *GetBorrowRecordRequest {
-	return &GetBorrowRecordRequest{}
+func NewSettleFineRequest() *SettleFineRequest {
+	return &SettleFineRequest{}
 }
 
-func (p *GetBorrowRecordRequest) InitDefault() {
+func (p *SettleFineRequest) InitDefault() {
 }
 
-func (p *GetBorrowRecordRequest) GetUserID() (v int64) {
-	return p.UserID
+func (p *SettleFineRequest) GetBorrowID() (v int64) {
+	return p.BorrowID
 }
 
-func (p *GetBorrowRecordRequest) GetPageSize() (v int64) {
-	return p.PageSize
+func (p *SettleFineRequest) GetAction() (v string) {
+	return p.Action
 }
 
-func (p *GetBorrowRecordRequest) GetPageNum() (v int64) {
-	return p.PageNum
+var SettleFineRequest_Note_DEFAULT string
+
+func (p *SettleFineRequest) GetNote() (v string) {
+	if !p.IsSetNote() {
+		return SettleFineRequest_Note_DEFAULT
+	}
+	return *p.Note
 }
 
-func (p *GetBorrowRecordRequest) GetStatus() (v int64) {
-	return p.Status
+var fieldIDToName_SettleFineRequest = map[int16]string{
+	1: "borrow_id",
+	2: "action",
+	3: "note",
 }
 
-var fieldIDToName_GetBorrowRecordRequest = map[int16]string{
-	1: "user_id",
-	2: "page_size",
-	3: "page_num",
-	4: "status",
+func (p *SettleFineRequest) IsSetNote() bool {
+	return p.Note != nil
 }
 
-func (p *GetBorrowRecordRequest) Read(iprot thrift.TProtocol) (err error) {
+func (p *SettleFineRequest) Read(iprot thrift.TProtocol) (err error) {
 	var fieldTypeId thrift.TType
 	var fieldId int16
-	var issetUserID bool = false
-	var issetPageSize bool = false
-	var issetPageNum bool = false
-	var issetStatus bool = false
+	var issetBorrowID bool = false
+	var issetAction bool = false
 
 	if _, err = iprot.ReadStructBegin(); err != nil {
 		goto ReadStructBeginError
@@ -1328,34 +1331,24 @@ func (p *GetBorrowRecordRequest) Read(iprot thrift.TProtocol) (err error) {
 				if err = p.ReadField1(iprot); err != nil {
 					goto ReadFieldError
 				}
-				issetUserID = true
+				issetBorrowID = true
 			} else if err = iprot.Skip(fieldTypeId); err != nil {
 				goto SkipFieldError
 			}
 		case 2:
-			if fieldTypeId == thrift.I64 {
+			if fieldTypeId == thrift.STRING {
 				if err = p.ReadField2(iprot); err != nil {
 					goto ReadFieldError
 				}
-				issetPageSize = true
+				issetAction = true
 			} else if err = iprot.Skip(fieldTypeId); err != nil {
 				goto SkipFieldError
 			}
 		case 3:
-			if fieldTypeId == thrift.I64 {
+			if fieldTypeId == thrift.STRING {
 				if err = p.ReadField3(iprot); err != nil {
 					goto ReadFieldError
 				}
-				issetPageNum = true
-			} else if err = iprot.Skip(fieldTypeId); err != nil {
-				goto SkipFieldError
-			}
-		case 4:
-			if fieldTypeId == thrift.I64 {
-				if err = p.ReadField4(iprot); err != nil {
-					goto ReadFieldError
-				}
-				issetStatus = true
 			} else if err = iprot.Skip(fieldTypeId); err != nil {
 				goto SkipFieldError
 			}
@@ -1372,32 +1365,22 @@ func (p *GetBorrowRecordRequest) Read(iprot thrift.TProtocol) (err error) {
 		goto ReadStructEndError
 	}
 
-	if !issetUserID {
+	if !issetBorrowID {
 		fieldId = 1
 		goto RequiredFieldNotSetError
 	}
 
-	if !issetPageSize {
+	if !issetAction {
 		fieldId = 2
 		goto RequiredFieldNotSetError
 	}
-
-	if !issetPageNum {
-		fieldId = 3
-		goto RequiredFieldNotSetError
-	}
-
-	if !issetStatus {
-		fieldId = 4
-		goto RequiredFieldNotSetError
-	}
 	return nil
 ReadStructBeginError:
 	return thrift.PrependError(fmt.Sprintf("%T read struct begin error: ", p), err)
 ReadFieldBeginError:
 	return thrift.PrependError(fmt.Sprintf("%T read field %d begin error: ", p, fieldId), err)
 ReadFieldError:
-	return thrift.PrependError(fmt.Sprintf("%T read field %d '%s' error: ", p, fieldId, fieldIDToName_GetBorrowRecordRequest[fieldId]), err)
+	return thrift.PrependError(fmt.Sprintf("%T read field %d '%s' error: ", p, fieldId, fieldIDToName_SettleFineRequest[fieldId]), err)
 SkipFieldError:
 	return thrift.PrependError(fmt.Sprintf("%T field %d skip type %d error: ", p, fieldId, fieldTypeId), err)
 
@@ -1406,21 +1389,10 @@ ReadFieldEndError:
 ReadStructEndError:
 	return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 RequiredFieldNotSetError:
-	return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("required field %s is not set", fieldIDToName_GetBorrowRecordRequest[fieldId]))
+	return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("required field %s is not set", fieldIDToName_SettleFineRequest[fieldId]))
 }
 
-func (p *GetBorrowRecordRequest) ReadField1(iprot thrift.TProtocol) error {
-
-	var _field int64
-	if v, err := iprot.ReadI64(); err != nil {
-		return err
-	} else {
-		_field = v
-	}
-	p.UserID = _field
-	return nil
-}
-func (p *GetBorrowRecordRequest) ReadField2(iprot thrift.TProtocol) error {
+func (p *SettleFineRequest) ReadField1(iprot thrift.TProtocol) error {
 
 	var _field int64
 	if v, err := iprot.ReadI64(); err != nil {
@@ -1428,35 +1400,35 @@ func (p *GetBorrowRecordRequest) ReadField2(iprot thrift.TProtocol) error {
 	} else {
 		_field = v
 	}
-	p.PageSize = _field
+	p.BorrowID = _field
 	return nil
 }
-func (p *GetBorrowRecordRequest) ReadField3(iprot thrift.TProtocol) error {
+func (p *SettleFineRequest) ReadField2(iprot thrift.TProtocol) error {
 
-	var _field int64
-	if v, err := iprot.ReadI64(); err != nil {
+	var _field string
+	if v, err := iprot.ReadString(); err != nil {
 		return err
 	} else {
 		_field = v
 	}
-	p.PageNum = _field
+	p.Action = _field
 	return nil
 }
-func (p *GetBorrowRecordRequest) ReadField4(iprot thrift.TProtocol) error {
+func (p *SettleFineRequest) ReadField3(iprot thrift.TProtocol) error {
 
-	var _field int64
-	if v, err := iprot.ReadI64(); err != nil {
+	var _field *string
+	if v, err := iprot.ReadString(); err != nil {
 		return err
 	} else {
-		_field = v
+		_field = &v
 	}
-	p.Status = _field
+	p.Note = _field
 	return nil
 }
 
-func (p *GetBorrowRecordRequest) Write(oprot thrift.TProtocol) (err error) {
+func (p *SettleFineRequest) Write(oprot thrift.TProtocol) (err error) {
 	var fieldId int16
-	if err = oprot.WriteStructBegin("GetBorrowRecordRequest"); err != nil {
+	if err = oprot.WriteStructBegin("SettleFineRequest"); err != nil {
 		goto WriteStructBeginError
 	}
 	if p != nil {
@@ -1472,10 +1444,6 @@ func (p *GetBorrowRecordRequest) Write(oprot thrift.TProtocol) (err error) {
 			fieldId = 3
 			goto WriteFieldError
 		}
-		if err = p.writeField4(oprot); err != nil {
-			fieldId = 4
-			goto WriteFieldError
-		}
 	}
 	if err = oprot.WriteFieldStop(); err != nil {
 		goto WriteFieldStopError
@@ -1494,11 +1462,11 @@ WriteStructEndError:
 	return thrift.PrependError(fmt.Sprintf("%T write struct end error: ", p), err)
 }
 
-func (p *GetBorrowRecordRequest) writeField1(oprot thrift.TProtocol) (err error) {
-	if err = oprot.WriteFieldBegin("user_id", thrift.I64, 1); err != nil {
+func (p *SettleFineRequest) writeField1(oprot thrift.TProtocol) (err error) {
+	if err = oprot.WriteFieldBegin("borrow_id", thrift.I64, 1); err != nil {
 		goto WriteFieldBeginError
 	}
-	if err := oprot.WriteI64(p.UserID); err != nil {
+	if err := oprot.WriteI64(p.BorrowID); err != nil {
 		return err
 	}
 	if err = oprot.WriteFieldEnd(); err != nil {
@@ -1510,11 +1478,11 @@ WriteFieldBeginError:
 WriteFieldEndError:
 	return thrift.PrependError(fmt.Sprintf("%T write field 1 end error: ", p), err)
 }
-func (p *GetBorrowRecordRequest) writeField2(oprot thrift.TProtocol) (err error) {
-	if err = oprot.WriteFieldBegin("page_size", thrift.I64, 2); err != nil {
+func (p *SettleFineRequest) writeField2(oprot thrift.TProtocol) (err error) {
+	if err = oprot.WriteFieldBegin("action", thrift.STRING, 2); err != nil {
 		goto WriteFieldBeginError
 	}
-	if err := oprot.WriteI64(p.PageSize); err != nil {
+	if err := oprot.WriteString(p.Action); err != nil {
 		return err
 	}
 	if err = oprot.WriteFieldEnd(); err != nil {
@@ -1526,15 +1494,17 @@ WriteFieldBeginError:
 WriteFieldEndError:
 	return thrift.PrependError(fmt.Sprintf("%T write field 2 end error: ", p), err)
 }
-func (p *GetBorrowRecordRequest) writeField3(oprot thrift.TProtocol) (err error) {
-	if err = oprot.WriteFieldBegin("page_num", thrift.I64, 3); err != nil {
-		goto WriteFieldBeginError
-	}
-	if err := oprot.WriteI64(p.PageNum); err != nil {
-		return err
-	}
-	if err = oprot.WriteFieldEnd(); err != nil {
-		goto WriteFieldEndError
+func (p *SettleFineRequest) writeField3(oprot thrift.TProtocol) (err error) {
+	if p.IsSetNote() {
+		if err = oprot.WriteFieldBegin("note", thrift.STRING, 3); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := oprot.WriteString(*p.Note); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
 	}
 	return nil
 WriteFieldBeginError:
@@ -1542,76 +1512,62 @@ WriteFieldBeginError:
 WriteFieldEndError:
 	return thrift.PrependError(fmt.Sprintf("%T write field 3 end error: ", p), err)
 }
-func (p *GetBorrowRecordRequest) writeField4(oprot thrift.TProtocol) (err error) {
-	if err = oprot.WriteFieldBegin("status", thrift.I64, 4); err != nil {
-		goto WriteFieldBeginError
-	}
-	if err := oprot.WriteI64(p.Status); err != nil {
-		return err
-	}
-	if err = oprot.WriteFieldEnd(); err != nil {
-		goto WriteFieldEndError
-	}
-	return nil
-WriteFieldBeginError:
-	return thrift.PrependError(fmt.Sprintf("%T write field 4 begin error: ", p), err)
-WriteFieldEndError:
-	return thrift.PrependError(fmt.Sprintf("%T write field 4 end error: ", p), err)
-}
 
-func (p *GetBorrowRecordRequest) String() string {
+func (p *SettleFineRequest) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("GetBorrowRecordRequest(%+v)", *p)
+	return fmt.Sprintf("SettleFineRequest(%+v)", *p)
 
 }
 
-type GetBorrowRecordResponse struct {
-	Base  *model.BaseResp       `thrift:"base,1" form:"base" json:"base" query:"base"`
-	Data  []*model.BorrowRecord `thrift:"data,2,required" form:"data,required" json:"data,required" query:"data,required"`
-	Total int64                 `thrift:"total,3,required" form:"total,required" json:"total,required" query:"total,required"`
+type SettleFineResponse struct {
+	Base *model.BaseResp     `thrift:"base,1" form:"base" json:"base" query:"base"`
+	Data *model.BorrowRecord `thrift:"data,2,required" form:"data,required" json:"data,required" query:"data,required"`
 }
 
-func NewGetBorrowRecordResponse() *GetBorrowRecordResponse {
-	return &GetBorrowRecordResponse{}
+func NewSettleFineResponse() *SettleFineResponse {
+	return &SettleFineResponse{}
 }
 
-func (p *GetBorrowRecordResponse) InitDefault() {
+func (p *SettleFineResponse) InitDefault() {
 }
 
-var GetBorrowRecordResponse_Base_DEFAULT *model.BaseResp
+var SettleFineResponse_Base_DEFAULT *model.BaseResp
 
-func (p *GetBorrowRecordResponse) GetBase() (v *model.BaseResp) {
+func (p *SettleFineResponse) GetBase() (v *model.BaseResp) {
 	if !p.IsSetBase() {
-		return GetBorrowRecordResponse_Base_DEFAULT
+		return SettleFineResponse_Base_DEFAULT
 	}
 	return p.Base
 }
 
-func (p *GetBorrowRecordResponse) GetData() (v []*model.BorrowRecord) {
-	return p.Data
-}
+var SettleFineResponse_Data_DEFAULT *model.BorrowRecord
 
-func (p *GetBorrowRecordResponse) GetTotal() (v int64) {
-	return p.Total
+func (p *SettleFineResponse) GetData() (v *model.BorrowRecord) {
+	if !p.IsSetData() {
+		return SettleFineResponse_Data_DEFAULT
+	}
+	return p.Data
 }
 
-var fieldIDToName_GetBorrowRecordResponse = map[int16]string{
+var fieldIDToName_SettleFineResponse = map[int16]string{
 	1: "base",
 	2: "data",
-	3: "total",
 }
 
-func (p *GetBorrowRecordResponse) IsSetBase() bool {
+func (p *SettleFineResponse) IsSetBase() bool {
 	return p.Base != nil
 }
 
-func (p *GetBorrowRecordResponse) Read(iprot thrift.TProtocol) (err error) {
+func (p *SettleFineResponse) IsSetData() bool {
+	return p.Data != nil
+}
+
+func (p *SettleFineResponse) Read(iprot thrift.TProtocol) (err error) {
 	var fieldTypeId thrift.TType
 	var fieldId int16
 	var issetData bool = false
-	var issetTotal bool = false
 
 	if _, err = iprot.ReadStructBegin(); err != nil {
 		goto ReadStructBeginError
@@ -1636,7 +1592,7 @@ func (p *GetBorrowRecordResponse) Read(iprot thrift.TProtocol) (err error) {
 				goto SkipFieldError
 			}
 		case 2:
-			if fieldTypeId == thrift.LIST {
+			if fieldTypeId == thrift.STRUCT {
 				if err = p.ReadField2(iprot); err != nil {
 					goto ReadFieldError
 				}
@@ -1644,15 +1600,6 @@ func (p *GetBorrowRecordResponse) Read(iprot thrift.TProtocol) (err error) {
 			} else if err = iprot.Skip(fieldTypeId); err != nil {
 				goto SkipFieldError
 			}
-		case 3:
-			if fieldTypeId == thrift.I64 {
-				if err = p.ReadField3(iprot); err != nil {
-					goto ReadFieldError
-				}
-				issetTotal = true
-			} else if err = iprot.Skip(fieldTypeId); err != nil {
-				goto SkipFieldError
-			}
 		default:
 			if err = iprot.Skip(fieldTypeId); err != nil {
 				goto SkipFieldError
@@ -1670,18 +1617,13 @@ func (p *GetBorrowRecordResponse) Read(iprot thrift.TProtocol) (err error) {
 		fieldId = 2
 		goto RequiredFieldNotSetError
 	}
-
-	if !issetTotal {
-		fieldId = 3
-		goto RequiredFieldNotSetError
-	}
 	return nil
 ReadStructBeginError:
 	return thrift.PrependError(fmt.Sprintf("%T read struct begin error: ", p), err)
 ReadFieldBeginError:
 	return thrift.PrependError(fmt.Sprintf("%T read field %d begin error: ", p, fieldId), err)
 ReadFieldError:
-	return thrift.PrependError(fmt.Sprintf("%T read field %d '%s' error: ", p, fieldId, fieldIDToName_GetBorrowRecordResponse[fieldId]), err)
+	return thrift.PrependError(fmt.Sprintf("%T read field %d '%s' error: ", p, fieldId, fieldIDToName_SettleFineResponse[fieldId]), err)
 SkipFieldError:
 	return thrift.PrependError(fmt.Sprintf("%T field %d skip type %d error: ", p, fieldId, fieldTypeId), err)
 
@@ -1690,10 +1632,10 @@ ReadFieldEndError:
 ReadStructEndError:
 	return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 RequiredFieldNotSetError:
-	return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("required field %s is not set", fieldIDToName_GetBorrowRecordResponse[fieldId]))
+	return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("required field %s is not set", fieldIDToName_SettleFineResponse[fieldId]))
 }
 
-func (p *GetBorrowRecordResponse) ReadField1(iprot thrift.TProtocol) error {
+func (p *SettleFineResponse) ReadField1(iprot thrift.TProtocol) error {
 	_field := model.NewBaseResp()
 	if err := _field.Read(iprot); err != nil {
 		return err
@@ -1701,44 +1643,18 @@ func (p *GetBorrowRecordResponse) ReadField1(iprot thrift.TProtocol) error {
 	p.Base = _field
 	return nil
 }
-func (p *GetBorrowRecordResponse) ReadField2(iprot thrift.TProtocol) error {
-	_, size, err := iprot.ReadListBegin()
-	if err != nil {
-		return err
-	}
-	_field := make([]*model.BorrowRecord, 0, size)
-	values := make([]model.BorrowRecord, size)
-	for i := 0; i < size; i++ {
-		_elem := &values[i]
-		_elem.InitDefault()
-
-		if err := _elem.Read(iprot); err != nil {
-			return err
-		}
-
-		_field = append(_field, _elem)
-	}
-	if err := iprot.ReadListEnd(); err != nil {
+func (p *SettleFineResponse) ReadField2(iprot thrift.TProtocol) error {
+	_field := model.NewBorrowRecord()
+	if err := _field.Read(iprot); err != nil {
 		return err
 	}
 	p.Data = _field
 	return nil
 }
-func (p *GetBorrowRecordResponse) ReadField3(iprot thrift.TProtocol) error {
-
-	var _field int64
-	if v, err := iprot.ReadI64(); err != nil {
-		return err
-	} else {
-		_field = v
-	}
-	p.Total = _field
-	return nil
-}
 
-func (p *GetBorrowRecordResponse) Write(oprot thrift.TProtocol) (err error) {
+func (p *SettleFineResponse) Write(oprot thrift.TProtocol) (err error) {
 	var fieldId int16
-	if err = oprot.WriteStructBegin("GetBorrowRecordResponse"); err != nil {
+	if err = oprot.WriteStructBegin("SettleFineResponse"); err != nil {
 		goto WriteStructBeginError
 	}
 	if p != nil {
@@ -1750,10 +1666,6 @@ func (p *GetBorrowRecordResponse) Write(oprot thrift.TProtocol) (err error) {
 			fieldId = 2
 			goto WriteFieldError
 		}
-		if err = p.writeField3(oprot); err != nil {
-			fieldId = 3
-			goto WriteFieldError
-		}
 	}
 	if err = oprot.WriteFieldStop(); err != nil {
 		goto WriteFieldStopError
@@ -1772,7 +1684,7 @@ WriteStructEndError:
 	return thrift.PrependError(fmt.Sprintf("%T write struct end error: ", p), err)
 }
 
-func (p *GetBorrowRecordResponse) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *SettleFineResponse) writeField1(oprot thrift.TProtocol) (err error) {
 	if err = oprot.WriteFieldBegin("base", thrift.STRUCT, 1); err != nil {
 		goto WriteFieldBeginError
 	}
@@ -1788,19 +1700,11 @@ WriteFieldBeginError:
 WriteFieldEndError:
 	return thrift.PrependError(fmt.Sprintf("%T write field 1 end error: ", p), err)
 }
-func (p *GetBorrowRecordResponse) writeField2(oprot thrift.TProtocol) (err error) {
-	if err = oprot.WriteFieldBegin("data", thrift.LIST, 2); err != nil {
+func (p *SettleFineResponse) writeField2(oprot thrift.TProtocol) (err error) {
+	if err = oprot.WriteFieldBegin("data", thrift.STRUCT, 2); err != nil {
 		goto WriteFieldBeginError
 	}
-	if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Data)); err != nil {
-		return err
-	}
-	for _, v := range p.Data {
-		if err := v.Write(oprot); err != nil {
-			return err
-		}
-	}
-	if err := oprot.WriteListEnd(); err != nil {
+	if err := p.Data.Write(oprot); err != nil {
 		return err
 	}
 	if err = oprot.WriteFieldEnd(); err != nil {
@@ -1812,53 +1716,848 @@ WriteFieldBeginError:
 WriteFieldEndError:
 	return thrift.PrependError(fmt.Sprintf("%T write field 2 end error: ", p), err)
 }
-func (p *GetBorrowRecordResponse) writeField3(oprot thrift.TProtocol) (err error) {
-	if err = oprot.WriteFieldBegin("total", thrift.I64, 3); err != nil {
-		goto WriteFieldBeginError
-	}
-	if err := oprot.WriteI64(p.Total); err != nil {
-		return err
-	}
-	if err = oprot.WriteFieldEnd(); err != nil {
-		goto WriteFieldEndError
-	}
-	return nil
-WriteFieldBeginError:
-	return thrift.PrependError(fmt.Sprintf("%T write field 3 begin error: ", p), err)
-WriteFieldEndError:
-	return thrift.PrependError(fmt.Sprintf("%T write field 3 end error: ", p), err)
-}
 
-func (p *GetBorrowRecordResponse) String() string {
+func (p *SettleFineResponse) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("GetBorrowRecordResponse(%+v)", *p)
+	return fmt.Sprintf("SettleFineResponse(%+v)", *p)
 
 }
 
-type BorrowService interface {
-	Borrow(ctx context.Context, req *BorrowRequest) (r *BorrowResponse, err error)
-
-	ReturnBook(ctx context.Context, req *ReturnRequest) (r *ReturnResponse, err error)
+type GetBorrowRecordRequest struct {
+	UserID    int64   `thrift:"user_id,1,required" form:"user_id,required" json:"user_id,required" query:"user_id,required"`
+	PageSize  *int64  `thrift:"page_size,2,optional" form:"page_size" json:"page_size,omitempty" query:"page_size"`
+	PageNum   *int64  `thrift:"page_num,3,optional" form:"page_num" json:"page_num,omitempty" query:"page_num"`
+	Status    int64   `thrift:"status,4,required" form:"status,required" json:"status,required" query:"status,required"`
+	Cursor    *string `thrift:"cursor,5,optional" form:"cursor" json:"cursor,omitempty" query:"cursor"`
+	Sort      *string `thrift:"sort,6,optional" form:"sort" json:"sort,omitempty" query:"sort"`
+	WithTotal *bool   `thrift:"with_total,7,optional" form:"with_total" json:"with_total,omitempty" query:"with_total"`
+}
 
-	Renew(ctx context.Context, req *RenewRequest) (r *RenewResponse, err error)
+func NewGetBorrowRecordRequest() *GetBorrowRecordRequest {
+	return &GetBorrowRecordRequest{}
+}
 
-	GetBorrowRecord(ctx context.Context, req *GetBorrowRecordRequest) (r *GetBorrowRecordResponse, err error)
+func (p *GetBorrowRecordRequest) InitDefault() {
 }
 
-type BorrowServiceClient struct {
-	c thrift.TClient
+func (p *GetBorrowRecordRequest) GetUserID() (v int64) {
+	return p.UserID
 }
 
-func NewBorrowServiceClientFactory(t thrift.TTransport, f thrift.TProtocolFactory) *BorrowServiceClient {
-	return &BorrowServiceClient{
-		c: thrift.NewTStandardClient(f.GetProtocol(t), f.GetProtocol(t)),
+var GetBorrowRecordRequest_PageSize_DEFAULT int64
+
+func (p *GetBorrowRecordRequest) GetPageSize() (v int64) {
+	if !p.IsSetPageSize() {
+		return GetBorrowRecordRequest_PageSize_DEFAULT
 	}
+	return *p.PageSize
 }
 
-func NewBorrowServiceClientProtocol(t thrift.TTransport, iprot thrift.TProtocol, oprot thrift.TProtocol) *BorrowServiceClient {
-	return &BorrowServiceClient{
+var GetBorrowRecordRequest_PageNum_DEFAULT int64
+
+func (p *GetBorrowRecordRequest) GetPageNum() (v int64) {
+	if !p.IsSetPageNum() {
+		return GetBorrowRecordRequest_PageNum_DEFAULT
+	}
+	return *p.PageNum
+}
+
+func (p *GetBorrowRecordRequest) GetStatus() (v int64) {
+	return p.Status
+}
+
+var GetBorrowRecordRequest_Cursor_DEFAULT string
+
+func (p *GetBorrowRecordRequest) GetCursor() (v string) {
+	if !p.IsSetCursor() {
+		return GetBorrowRecordRequest_Cursor_DEFAULT
+	}
+	return *p.Cursor
+}
+
+var GetBorrowRecordRequest_Sort_DEFAULT string
+
+func (p *GetBorrowRecordRequest) GetSort() (v string) {
+	if !p.IsSetSort() {
+		return GetBorrowRecordRequest_Sort_DEFAULT
+	}
+	return *p.Sort
+}
+
+var GetBorrowRecordRequest_WithTotal_DEFAULT bool
+
+func (p *GetBorrowRecordRequest) GetWithTotal() (v bool) {
+	if !p.IsSetWithTotal() {
+		return GetBorrowRecordRequest_WithTotal_DEFAULT
+	}
+	return *p.WithTotal
+}
+
+var fieldIDToName_GetBorrowRecordRequest = map[int16]string{
+	1: "user_id",
+	2: "page_size",
+	3: "page_num",
+	4: "status",
+	5: "cursor",
+	6: "sort",
+	7: "with_total",
+}
+
+func (p *GetBorrowRecordRequest) IsSetPageSize() bool {
+	return p.PageSize != nil
+}
+
+func (p *GetBorrowRecordRequest) IsSetPageNum() bool {
+	return p.PageNum != nil
+}
+
+func (p *GetBorrowRecordRequest) IsSetCursor() bool {
+	return p.Cursor != nil
+}
+
+func (p *GetBorrowRecordRequest) IsSetSort() bool {
+	return p.Sort != nil
+}
+
+func (p *GetBorrowRecordRequest) IsSetWithTotal() bool {
+	return p.WithTotal != nil
+}
+
+func (p *GetBorrowRecordRequest) Read(iprot thrift.TProtocol) (err error) {
+	var fieldTypeId thrift.TType
+	var fieldId int16
+	var issetUserID bool = false
+	var issetStatus bool = false
+
+	if _, err = iprot.ReadStructBegin(); err != nil {
+		goto ReadStructBeginError
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err = iprot.ReadFieldBegin()
+		if err != nil {
+			goto ReadFieldBeginError
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+
+		switch fieldId {
+		case 1:
+			if fieldTypeId == thrift.I64 {
+				if err = p.ReadField1(iprot); err != nil {
+					goto ReadFieldError
+				}
+				issetUserID = true
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		case 2:
+			if fieldTypeId == thrift.I64 {
+				if err = p.ReadField2(iprot); err != nil {
+					goto ReadFieldError
+				}
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		case 3:
+			if fieldTypeId == thrift.I64 {
+				if err = p.ReadField3(iprot); err != nil {
+					goto ReadFieldError
+				}
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		case 4:
+			if fieldTypeId == thrift.I64 {
+				if err = p.ReadField4(iprot); err != nil {
+					goto ReadFieldError
+				}
+				issetStatus = true
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		case 5:
+			if fieldTypeId == thrift.STRING {
+				if err = p.ReadField5(iprot); err != nil {
+					goto ReadFieldError
+				}
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		case 6:
+			if fieldTypeId == thrift.STRING {
+				if err = p.ReadField6(iprot); err != nil {
+					goto ReadFieldError
+				}
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		case 7:
+			if fieldTypeId == thrift.BOOL {
+				if err = p.ReadField7(iprot); err != nil {
+					goto ReadFieldError
+				}
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		default:
+			if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		}
+		if err = iprot.ReadFieldEnd(); err != nil {
+			goto ReadFieldEndError
+		}
+	}
+	if err = iprot.ReadStructEnd(); err != nil {
+		goto ReadStructEndError
+	}
+
+	if !issetUserID {
+		fieldId = 1
+		goto RequiredFieldNotSetError
+	}
+
+	if !issetStatus {
+		fieldId = 4
+		goto RequiredFieldNotSetError
+	}
+	return nil
+ReadStructBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T read struct begin error: ", p), err)
+ReadFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T read field %d begin error: ", p, fieldId), err)
+ReadFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T read field %d '%s' error: ", p, fieldId, fieldIDToName_GetBorrowRecordRequest[fieldId]), err)
+SkipFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T field %d skip type %d error: ", p, fieldId, fieldTypeId), err)
+
+ReadFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T read field end error", p), err)
+ReadStructEndError:
+	return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+RequiredFieldNotSetError:
+	return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("required field %s is not set", fieldIDToName_GetBorrowRecordRequest[fieldId]))
+}
+
+func (p *GetBorrowRecordRequest) ReadField1(iprot thrift.TProtocol) error {
+
+	var _field int64
+	if v, err := iprot.ReadI64(); err != nil {
+		return err
+	} else {
+		_field = v
+	}
+	p.UserID = _field
+	return nil
+}
+func (p *GetBorrowRecordRequest) ReadField2(iprot thrift.TProtocol) error {
+
+	var _field *int64
+	if v, err := iprot.ReadI64(); err != nil {
+		return err
+	} else {
+		_field = &v
+	}
+	p.PageSize = _field
+	return nil
+}
+func (p *GetBorrowRecordRequest) ReadField3(iprot thrift.TProtocol) error {
+
+	var _field *int64
+	if v, err := iprot.ReadI64(); err != nil {
+		return err
+	} else {
+		_field = &v
+	}
+	p.PageNum = _field
+	return nil
+}
+func (p *GetBorrowRecordRequest) ReadField4(iprot thrift.TProtocol) error {
+
+	var _field int64
+	if v, err := iprot.ReadI64(); err != nil {
+		return err
+	} else {
+		_field = v
+	}
+	p.Status = _field
+	return nil
+}
+func (p *GetBorrowRecordRequest) ReadField5(iprot thrift.TProtocol) error {
+
+	var _field *string
+	if v, err := iprot.ReadString(); err != nil {
+		return err
+	} else {
+		_field = &v
+	}
+	p.Cursor = _field
+	return nil
+}
+func (p *GetBorrowRecordRequest) ReadField6(iprot thrift.TProtocol) error {
+
+	var _field *string
+	if v, err := iprot.ReadString(); err != nil {
+		return err
+	} else {
+		_field = &v
+	}
+	p.Sort = _field
+	return nil
+}
+func (p *GetBorrowRecordRequest) ReadField7(iprot thrift.TProtocol) error {
+
+	var _field *bool
+	if v, err := iprot.ReadBool(); err != nil {
+		return err
+	} else {
+		_field = &v
+	}
+	p.WithTotal = _field
+	return nil
+}
+
+func (p *GetBorrowRecordRequest) Write(oprot thrift.TProtocol) (err error) {
+	var fieldId int16
+	if err = oprot.WriteStructBegin("GetBorrowRecordRequest"); err != nil {
+		goto WriteStructBeginError
+	}
+	if p != nil {
+		if err = p.writeField1(oprot); err != nil {
+			fieldId = 1
+			goto WriteFieldError
+		}
+		if err = p.writeField2(oprot); err != nil {
+			fieldId = 2
+			goto WriteFieldError
+		}
+		if err = p.writeField3(oprot); err != nil {
+			fieldId = 3
+			goto WriteFieldError
+		}
+		if err = p.writeField4(oprot); err != nil {
+			fieldId = 4
+			goto WriteFieldError
+		}
+		if err = p.writeField5(oprot); err != nil {
+			fieldId = 5
+			goto WriteFieldError
+		}
+		if err = p.writeField6(oprot); err != nil {
+			fieldId = 6
+			goto WriteFieldError
+		}
+		if err = p.writeField7(oprot); err != nil {
+			fieldId = 7
+			goto WriteFieldError
+		}
+	}
+	if err = oprot.WriteFieldStop(); err != nil {
+		goto WriteFieldStopError
+	}
+	if err = oprot.WriteStructEnd(); err != nil {
+		goto WriteStructEndError
+	}
+	return nil
+WriteStructBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+WriteFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T write field %d error: ", p, fieldId), err)
+WriteFieldStopError:
+	return thrift.PrependError(fmt.Sprintf("%T write field stop error: ", p), err)
+WriteStructEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write struct end error: ", p), err)
+}
+
+func (p *GetBorrowRecordRequest) writeField1(oprot thrift.TProtocol) (err error) {
+	if err = oprot.WriteFieldBegin("user_id", thrift.I64, 1); err != nil {
+		goto WriteFieldBeginError
+	}
+	if err := oprot.WriteI64(p.UserID); err != nil {
+		return err
+	}
+	if err = oprot.WriteFieldEnd(); err != nil {
+		goto WriteFieldEndError
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 1 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 1 end error: ", p), err)
+}
+func (p *GetBorrowRecordRequest) writeField2(oprot thrift.TProtocol) (err error) {
+	if p.IsSetPageSize() {
+		if err = oprot.WriteFieldBegin("page_size", thrift.I64, 2); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := oprot.WriteI64(*p.PageSize); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 2 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 2 end error: ", p), err)
+}
+func (p *GetBorrowRecordRequest) writeField3(oprot thrift.TProtocol) (err error) {
+	if p.IsSetPageNum() {
+		if err = oprot.WriteFieldBegin("page_num", thrift.I64, 3); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := oprot.WriteI64(*p.PageNum); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 3 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 3 end error: ", p), err)
+}
+func (p *GetBorrowRecordRequest) writeField4(oprot thrift.TProtocol) (err error) {
+	if err = oprot.WriteFieldBegin("status", thrift.I64, 4); err != nil {
+		goto WriteFieldBeginError
+	}
+	if err := oprot.WriteI64(p.Status); err != nil {
+		return err
+	}
+	if err = oprot.WriteFieldEnd(); err != nil {
+		goto WriteFieldEndError
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 4 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 4 end error: ", p), err)
+}
+func (p *GetBorrowRecordRequest) writeField5(oprot thrift.TProtocol) (err error) {
+	if p.IsSetCursor() {
+		if err = oprot.WriteFieldBegin("cursor", thrift.STRING, 5); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := oprot.WriteString(*p.Cursor); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 5 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 5 end error: ", p), err)
+}
+func (p *GetBorrowRecordRequest) writeField6(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSort() {
+		if err = oprot.WriteFieldBegin("sort", thrift.STRING, 6); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := oprot.WriteString(*p.Sort); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 6 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 6 end error: ", p), err)
+}
+func (p *GetBorrowRecordRequest) writeField7(oprot thrift.TProtocol) (err error) {
+	if p.IsSetWithTotal() {
+		if err = oprot.WriteFieldBegin("with_total", thrift.BOOL, 7); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := oprot.WriteBool(*p.WithTotal); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 7 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 7 end error: ", p), err)
+}
+
+func (p *GetBorrowRecordRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GetBorrowRecordRequest(%+v)", *p)
+
+}
+
+type GetBorrowRecordResponse struct {
+	Base       *model.BaseResp       `thrift:"base,1" form:"base" json:"base" query:"base"`
+	Data       []*model.BorrowRecord `thrift:"data,2,required" form:"data,required" json:"data,required" query:"data,required"`
+	Total      *int64                `thrift:"total,3,optional" form:"total" json:"total,omitempty" query:"total"`
+	NextCursor *string               `thrift:"next_cursor,4,optional" form:"next_cursor" json:"next_cursor,omitempty" query:"next_cursor"`
+}
+
+func NewGetBorrowRecordResponse() *GetBorrowRecordResponse {
+	return &GetBorrowRecordResponse{}
+}
+
+func (p *GetBorrowRecordResponse) InitDefault() {
+}
+
+var GetBorrowRecordResponse_Base_DEFAULT *model.BaseResp
+
+func (p *GetBorrowRecordResponse) GetBase() (v *model.BaseResp) {
+	if !p.IsSetBase() {
+		return GetBorrowRecordResponse_Base_DEFAULT
+	}
+	return p.Base
+}
+
+func (p *GetBorrowRecordResponse) GetData() (v []*model.BorrowRecord) {
+	return p.Data
+}
+
+var GetBorrowRecordResponse_Total_DEFAULT int64
+
+func (p *GetBorrowRecordResponse) GetTotal() (v int64) {
+	if !p.IsSetTotal() {
+		return GetBorrowRecordResponse_Total_DEFAULT
+	}
+	return *p.Total
+}
+
+var GetBorrowRecordResponse_NextCursor_DEFAULT string
+
+func (p *GetBorrowRecordResponse) GetNextCursor() (v string) {
+	if !p.IsSetNextCursor() {
+		return GetBorrowRecordResponse_NextCursor_DEFAULT
+	}
+	return *p.NextCursor
+}
+
+var fieldIDToName_GetBorrowRecordResponse = map[int16]string{
+	1: "base",
+	2: "data",
+	3: "total",
+	4: "next_cursor",
+}
+
+func (p *GetBorrowRecordResponse) IsSetBase() bool {
+	return p.Base != nil
+}
+
+func (p *GetBorrowRecordResponse) IsSetTotal() bool {
+	return p.Total != nil
+}
+
+func (p *GetBorrowRecordResponse) IsSetNextCursor() bool {
+	return p.NextCursor != nil
+}
+
+func (p *GetBorrowRecordResponse) Read(iprot thrift.TProtocol) (err error) {
+	var fieldTypeId thrift.TType
+	var fieldId int16
+	var issetData bool = false
+
+	if _, err = iprot.ReadStructBegin(); err != nil {
+		goto ReadStructBeginError
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err = iprot.ReadFieldBegin()
+		if err != nil {
+			goto ReadFieldBeginError
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+
+		switch fieldId {
+		case 1:
+			if fieldTypeId == thrift.STRUCT {
+				if err = p.ReadField1(iprot); err != nil {
+					goto ReadFieldError
+				}
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		case 2:
+			if fieldTypeId == thrift.LIST {
+				if err = p.ReadField2(iprot); err != nil {
+					goto ReadFieldError
+				}
+				issetData = true
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		case 3:
+			if fieldTypeId == thrift.I64 {
+				if err = p.ReadField3(iprot); err != nil {
+					goto ReadFieldError
+				}
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		case 4:
+			if fieldTypeId == thrift.STRING {
+				if err = p.ReadField4(iprot); err != nil {
+					goto ReadFieldError
+				}
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		default:
+			if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		}
+		if err = iprot.ReadFieldEnd(); err != nil {
+			goto ReadFieldEndError
+		}
+	}
+	if err = iprot.ReadStructEnd(); err != nil {
+		goto ReadStructEndError
+	}
+
+	if !issetData {
+		fieldId = 2
+		goto RequiredFieldNotSetError
+	}
+	return nil
+ReadStructBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T read struct begin error: ", p), err)
+ReadFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T read field %d begin error: ", p, fieldId), err)
+ReadFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T read field %d '%s' error: ", p, fieldId, fieldIDToName_GetBorrowRecordResponse[fieldId]), err)
+SkipFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T field %d skip type %d error: ", p, fieldId, fieldTypeId), err)
+
+ReadFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T read field end error", p), err)
+ReadStructEndError:
+	return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+RequiredFieldNotSetError:
+	return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("required field %s is not set", fieldIDToName_GetBorrowRecordResponse[fieldId]))
+}
+
+func (p *GetBorrowRecordResponse) ReadField1(iprot thrift.TProtocol) error {
+	_field := model.NewBaseResp()
+	if err := _field.Read(iprot); err != nil {
+		return err
+	}
+	p.Base = _field
+	return nil
+}
+func (p *GetBorrowRecordResponse) ReadField2(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return err
+	}
+	_field := make([]*model.BorrowRecord, 0, size)
+	values := make([]model.BorrowRecord, size)
+	for i := 0; i < size; i++ {
+		_elem := &values[i]
+		_elem.InitDefault()
+
+		if err := _elem.Read(iprot); err != nil {
+			return err
+		}
+
+		_field = append(_field, _elem)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return err
+	}
+	p.Data = _field
+	return nil
+}
+func (p *GetBorrowRecordResponse) ReadField3(iprot thrift.TProtocol) error {
+
+	var _field *int64
+	if v, err := iprot.ReadI64(); err != nil {
+		return err
+	} else {
+		_field = &v
+	}
+	p.Total = _field
+	return nil
+}
+func (p *GetBorrowRecordResponse) ReadField4(iprot thrift.TProtocol) error {
+
+	var _field *string
+	if v, err := iprot.ReadString(); err != nil {
+		return err
+	} else {
+		_field = &v
+	}
+	p.NextCursor = _field
+	return nil
+}
+
+func (p *GetBorrowRecordResponse) Write(oprot thrift.TProtocol) (err error) {
+	var fieldId int16
+	if err = oprot.WriteStructBegin("GetBorrowRecordResponse"); err != nil {
+		goto WriteStructBeginError
+	}
+	if p != nil {
+		if err = p.writeField1(oprot); err != nil {
+			fieldId = 1
+			goto WriteFieldError
+		}
+		if err = p.writeField2(oprot); err != nil {
+			fieldId = 2
+			goto WriteFieldError
+		}
+		if err = p.writeField3(oprot); err != nil {
+			fieldId = 3
+			goto WriteFieldError
+		}
+		if err = p.writeField4(oprot); err != nil {
+			fieldId = 4
+			goto WriteFieldError
+		}
+	}
+	if err = oprot.WriteFieldStop(); err != nil {
+		goto WriteFieldStopError
+	}
+	if err = oprot.WriteStructEnd(); err != nil {
+		goto WriteStructEndError
+	}
+	return nil
+WriteStructBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+WriteFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T write field %d error: ", p, fieldId), err)
+WriteFieldStopError:
+	return thrift.PrependError(fmt.Sprintf("%T write field stop error: ", p), err)
+WriteStructEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write struct end error: ", p), err)
+}
+
+func (p *GetBorrowRecordResponse) writeField1(oprot thrift.TProtocol) (err error) {
+	if err = oprot.WriteFieldBegin("base", thrift.STRUCT, 1); err != nil {
+		goto WriteFieldBeginError
+	}
+	if err := p.Base.Write(oprot); err != nil {
+		return err
+	}
+	if err = oprot.WriteFieldEnd(); err != nil {
+		goto WriteFieldEndError
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 1 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 1 end error: ", p), err)
+}
+func (p *GetBorrowRecordResponse) writeField2(oprot thrift.TProtocol) (err error) {
+	if err = oprot.WriteFieldBegin("data", thrift.LIST, 2); err != nil {
+		goto WriteFieldBeginError
+	}
+	if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Data)); err != nil {
+		return err
+	}
+	for _, v := range p.Data {
+		if err := v.Write(oprot); err != nil {
+			return err
+		}
+	}
+	if err := oprot.WriteListEnd(); err != nil {
+		return err
+	}
+	if err = oprot.WriteFieldEnd(); err != nil {
+		goto WriteFieldEndError
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 2 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 2 end error: ", p), err)
+}
+func (p *GetBorrowRecordResponse) writeField3(oprot thrift.TProtocol) (err error) {
+	if p.IsSetTotal() {
+		if err = oprot.WriteFieldBegin("total", thrift.I64, 3); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := oprot.WriteI64(*p.Total); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 3 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 3 end error: ", p), err)
+}
+func (p *GetBorrowRecordResponse) writeField4(oprot thrift.TProtocol) (err error) {
+	if p.IsSetNextCursor() {
+		if err = oprot.WriteFieldBegin("next_cursor", thrift.STRING, 4); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := oprot.WriteString(*p.NextCursor); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 4 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 4 end error: ", p), err)
+}
+
+func (p *GetBorrowRecordResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GetBorrowRecordResponse(%+v)", *p)
+
+}
+
+type BorrowService interface {
+	Borrow(ctx context.Context, req *BorrowRequest) (r *BorrowResponse, err error)
+
+	ReturnBook(ctx context.Context, req *ReturnRequest) (r *ReturnResponse, err error)
+
+	Renew(ctx context.Context, req *RenewRequest) (r *RenewResponse, err error)
+
+	SettleFine(ctx context.Context, req *SettleFineRequest) (r *SettleFineResponse, err error)
+
+	GetBorrowRecord(ctx context.Context, req *GetBorrowRecordRequest) (r *GetBorrowRecordResponse, err error)
+}
+
+type BorrowServiceClient struct {
+	c thrift.TClient
+}
+
+func NewBorrowServiceClientFactory(t thrift.TTransport, f thrift.TProtocolFactory) *BorrowServiceClient {
+	return &BorrowServiceClient{
+		c: thrift.NewTStandardClient(f.GetProtocol(t), f.GetProtocol(t)),
+	}
+}
+
+func NewBorrowServiceClientProtocol(t thrift.TTransport, iprot thrift.TProtocol, oprot thrift.TProtocol) *BorrowServiceClient {
+	return &BorrowServiceClient{
 		c: thrift.NewTStandardClient(iprot, oprot),
 	}
 }
@@ -1900,6 +2599,15 @@ func (p *BorrowServiceClient) Renew(ctx context.Context, req *RenewRequest) (r *
 	}
 	return _result.GetSuccess(), nil
 }
+func (p *BorrowServiceClient) SettleFine(ctx context.Context, req *SettleFineRequest) (r *SettleFineResponse, err error) {
+	var _args BorrowServiceSettleFineArgs
+	_args.Req = req
+	var _result BorrowServiceSettleFineResult
+	if err = p.Client_().Call(ctx, "settleFine", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
 func (p *BorrowServiceClient) GetBorrowRecord(ctx context.Context, req *GetBorrowRecordRequest) (r *GetBorrowRecordResponse, err error) {
 	var _args BorrowServiceGetBorrowRecordArgs
 	_args.Req = req
@@ -1933,6 +2641,7 @@ func NewBorrowServiceProcessor(handler BorrowService) *BorrowServiceProcessor {
 	self.AddToProcessorMap("borrow", &borrowServiceProcessorBorrow{handler: handler})
 	self.AddToProcessorMap("returnBook", &borrowServiceProcessorReturnBook{handler: handler})
 	self.AddToProcessorMap("renew", &borrowServiceProcessorRenew{handler: handler})
+	self.AddToProcessorMap("settleFine", &borrowServiceProcessorSettleFine{handler: handler})
 	self.AddToProcessorMap("getBorrowRecord", &borrowServiceProcessorGetBorrowRecord{handler: handler})
 	return self
 }
@@ -2080,7 +2789,55 @@ func (p *borrowServiceProcessorRenew) Process(ctx context.Context, seqId int32,
 	} else {
 		result.Success = retval
 	}
-	if err2 = oprot.WriteMessageBegin("renew", thrift.REPLY, seqId); err2 != nil {
+	if err2 = oprot.WriteMessageBegin("renew", thrift.REPLY, seqId); err2 != nil {
+		err = err2
+	}
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.Flush(ctx); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type borrowServiceProcessorSettleFine struct {
+	handler BorrowService
+}
+
+func (p *borrowServiceProcessorSettleFine) Process(ctx context.Context, seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := BorrowServiceSettleFineArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("settleFine", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush(ctx)
+		return false, err
+	}
+
+	iprot.ReadMessageEnd()
+	var err2 error
+	result := BorrowServiceSettleFineResult{}
+	var retval *SettleFineResponse
+	if retval, err2 = p.handler.SettleFine(ctx, args.Req); err2 != nil {
+		x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing settleFine: "+err2.Error())
+		oprot.WriteMessageBegin("settleFine", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush(ctx)
+		return true, err2
+	} else {
+		result.Success = retval
+	}
+	if err2 = oprot.WriteMessageBegin("settleFine", thrift.REPLY, seqId); err2 != nil {
 		err = err2
 	}
 	if err2 = result.Write(oprot); err == nil && err2 != nil {
@@ -3022,6 +3779,298 @@ func (p *BorrowServiceRenewResult) String() string {
 
 }
 
+type BorrowServiceSettleFineArgs struct {
+	Req *SettleFineRequest `thrift:"req,1"`
+}
+
+func NewBorrowServiceSettleFineArgs() *BorrowServiceSettleFineArgs {
+	return &BorrowServiceSettleFineArgs{}
+}
+
+func (p *BorrowServiceSettleFineArgs) InitDefault() {
+}
+
+var BorrowServiceSettleFineArgs_Req_DEFAULT *SettleFineRequest
+
+func (p *BorrowServiceSettleFineArgs) GetReq() (v *SettleFineRequest) {
+	if !p.IsSetReq() {
+		return BorrowServiceSettleFineArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+
+var fieldIDToName_BorrowServiceSettleFineArgs = map[int16]string{
+	1: "req",
+}
+
+func (p *BorrowServiceSettleFineArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *BorrowServiceSettleFineArgs) Read(iprot thrift.TProtocol) (err error) {
+	var fieldTypeId thrift.TType
+	var fieldId int16
+
+	if _, err = iprot.ReadStructBegin(); err != nil {
+		goto ReadStructBeginError
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err = iprot.ReadFieldBegin()
+		if err != nil {
+			goto ReadFieldBeginError
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+
+		switch fieldId {
+		case 1:
+			if fieldTypeId == thrift.STRUCT {
+				if err = p.ReadField1(iprot); err != nil {
+					goto ReadFieldError
+				}
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		default:
+			if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		}
+		if err = iprot.ReadFieldEnd(); err != nil {
+			goto ReadFieldEndError
+		}
+	}
+	if err = iprot.ReadStructEnd(); err != nil {
+		goto ReadStructEndError
+	}
+
+	return nil
+ReadStructBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T read struct begin error: ", p), err)
+ReadFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T read field %d begin error: ", p, fieldId), err)
+ReadFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T read field %d '%s' error: ", p, fieldId, fieldIDToName_BorrowServiceSettleFineArgs[fieldId]), err)
+SkipFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T field %d skip type %d error: ", p, fieldId, fieldTypeId), err)
+
+ReadFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T read field end error", p), err)
+ReadStructEndError:
+	return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+}
+
+func (p *BorrowServiceSettleFineArgs) ReadField1(iprot thrift.TProtocol) error {
+	_field := NewSettleFineRequest()
+	if err := _field.Read(iprot); err != nil {
+		return err
+	}
+	p.Req = _field
+	return nil
+}
+
+func (p *BorrowServiceSettleFineArgs) Write(oprot thrift.TProtocol) (err error) {
+	var fieldId int16
+	if err = oprot.WriteStructBegin("settleFine_args"); err != nil {
+		goto WriteStructBeginError
+	}
+	if p != nil {
+		if err = p.writeField1(oprot); err != nil {
+			fieldId = 1
+			goto WriteFieldError
+		}
+	}
+	if err = oprot.WriteFieldStop(); err != nil {
+		goto WriteFieldStopError
+	}
+	if err = oprot.WriteStructEnd(); err != nil {
+		goto WriteStructEndError
+	}
+	return nil
+WriteStructBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+WriteFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T write field %d error: ", p, fieldId), err)
+WriteFieldStopError:
+	return thrift.PrependError(fmt.Sprintf("%T write field stop error: ", p), err)
+WriteStructEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write struct end error: ", p), err)
+}
+
+func (p *BorrowServiceSettleFineArgs) writeField1(oprot thrift.TProtocol) (err error) {
+	if err = oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
+		goto WriteFieldBeginError
+	}
+	if err := p.Req.Write(oprot); err != nil {
+		return err
+	}
+	if err = oprot.WriteFieldEnd(); err != nil {
+		goto WriteFieldEndError
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 1 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 1 end error: ", p), err)
+}
+
+func (p *BorrowServiceSettleFineArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("BorrowServiceSettleFineArgs(%+v)", *p)
+
+}
+
+type BorrowServiceSettleFineResult struct {
+	Success *SettleFineResponse `thrift:"success,0,optional"`
+}
+
+func NewBorrowServiceSettleFineResult() *BorrowServiceSettleFineResult {
+	return &BorrowServiceSettleFineResult{}
+}
+
+func (p *BorrowServiceSettleFineResult) InitDefault() {
+}
+
+var BorrowServiceSettleFineResult_Success_DEFAULT *SettleFineResponse
+
+func (p *BorrowServiceSettleFineResult) GetSuccess() (v *SettleFineResponse) {
+	if !p.IsSetSuccess() {
+		return BorrowServiceSettleFineResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+var fieldIDToName_BorrowServiceSettleFineResult = map[int16]string{
+	0: "success",
+}
+
+func (p *BorrowServiceSettleFineResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *BorrowServiceSettleFineResult) Read(iprot thrift.TProtocol) (err error) {
+	var fieldTypeId thrift.TType
+	var fieldId int16
+
+	if _, err = iprot.ReadStructBegin(); err != nil {
+		goto ReadStructBeginError
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err = iprot.ReadFieldBegin()
+		if err != nil {
+			goto ReadFieldBeginError
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+
+		switch fieldId {
+		case 0:
+			if fieldTypeId == thrift.STRUCT {
+				if err = p.ReadField0(iprot); err != nil {
+					goto ReadFieldError
+				}
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		default:
+			if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		}
+		if err = iprot.ReadFieldEnd(); err != nil {
+			goto ReadFieldEndError
+		}
+	}
+	if err = iprot.ReadStructEnd(); err != nil {
+		goto ReadStructEndError
+	}
+
+	return nil
+ReadStructBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T read struct begin error: ", p), err)
+ReadFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T read field %d begin error: ", p, fieldId), err)
+ReadFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T read field %d '%s' error: ", p, fieldId, fieldIDToName_BorrowServiceSettleFineResult[fieldId]), err)
+SkipFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T field %d skip type %d error: ", p, fieldId, fieldTypeId), err)
+
+ReadFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T read field end error", p), err)
+ReadStructEndError:
+	return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+}
+
+func (p *BorrowServiceSettleFineResult) ReadField0(iprot thrift.TProtocol) error {
+	_field := NewSettleFineResponse()
+	if err := _field.Read(iprot); err != nil {
+		return err
+	}
+	p.Success = _field
+	return nil
+}
+
+func (p *BorrowServiceSettleFineResult) Write(oprot thrift.TProtocol) (err error) {
+	var fieldId int16
+	if err = oprot.WriteStructBegin("settleFine_result"); err != nil {
+		goto WriteStructBeginError
+	}
+	if p != nil {
+		if err = p.writeField0(oprot); err != nil {
+			fieldId = 0
+			goto WriteFieldError
+		}
+	}
+	if err = oprot.WriteFieldStop(); err != nil {
+		goto WriteFieldStopError
+	}
+	if err = oprot.WriteStructEnd(); err != nil {
+		goto WriteStructEndError
+	}
+	return nil
+WriteStructBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+WriteFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T write field %d error: ", p, fieldId), err)
+WriteFieldStopError:
+	return thrift.PrependError(fmt.Sprintf("%T write field stop error: ", p), err)
+WriteStructEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write struct end error: ", p), err)
+}
+
+func (p *BorrowServiceSettleFineResult) writeField0(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSuccess() {
+		if err = oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := p.Success.Write(oprot); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 0 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 0 end error: ", p), err)
+}
+
+func (p *BorrowServiceSettleFineResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("BorrowServiceSettleFineResult(%+v)", *p)
+
+}
+
 type BorrowServiceGetBorrowRecordArgs struct {
 	Req *GetBorrowRecordRequest `thrift:"req,1"`
 }
@@ -0,0 +1,56 @@
+package pack
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/2451965602/LMS/biz/dal/db"
+	"github.com/2451965602/LMS/biz/model/model"
+	"github.com/2451965602/LMS/config"
+	"github.com/2451965602/LMS/pkg/constants"
+)
+
+// BuildAttachmentResp 构建附件信息，附件内容通过 url 和 thumbnail_url 访问
+func BuildAttachmentResp(info *db.Attachment) *model.Attachment {
+	if info == nil {
+		return nil
+	}
+	result := &model.Attachment{
+		ID:          info.ID,
+		ISBN:        info.ISBN,
+		Kind:        info.Kind,
+		Filename:    info.Filename,
+		ContentType: info.ContentType,
+		Size:        info.Size,
+		URL:         buildAttachmentURL(info.ID, info.StorageKey, false),
+		CreatedAt:   info.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+	if info.ThumbnailKey != nil {
+		thumbnailURL := buildAttachmentURL(info.ID, *info.ThumbnailKey, true)
+		result.ThumbnailURL = &thumbnailURL
+	}
+	return result
+}
+
+func BuildAttachmentListResp(infos []*db.Attachment) []*model.Attachment {
+	if infos == nil {
+		return nil
+	}
+	resp := make([]*model.Attachment, 0, len(infos))
+	for _, info := range infos {
+		resp = append(resp, BuildAttachmentResp(info))
+	}
+	return resp
+}
+
+// buildAttachmentURL 配置了公开访问地址时直接指向存储中的对象，否则经由下载接口读取
+func buildAttachmentURL(id int64, key string, thumbnail bool) string {
+	if base := config.Attachment.PublicBaseURL; base != "" {
+		return strings.TrimRight(base, "/") + "/" + key
+	}
+	url := fmt.Sprintf("%s?id=%d", constants.AttachmentDownloadPath, id)
+	if thumbnail {
+		url += "&thumbnail=true"
+	}
+	return url
+}
@@ -0,0 +1,78 @@
+package pack
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/cloudwego/hertz/pkg/app"
+
+	"github.com/2451965602/LMS/pkg/errno"
+	"github.com/2451965602/LMS/pkg/validate"
+)
+
+var (
+	// requiredParamRe 匹配 Hertz 缺少必填参数时的错误，第一组为参数名
+	requiredParamRe = regexp.MustCompile(`'([^']+)' field is a 'required' parameter`)
+	// decodeParamRe 匹配 Hertz 参数类型转换失败时的错误，第一组为参数值，第二组为目标类型
+	decodeParamRe = regexp.MustCompile(`unable to decode '([^']*)' as (\S+?):`)
+)
+
+// BuildBindError 将 c.BindAndValidate 返回的错误转换为带有字段错误详情的 errno.ErrNo
+// 缺少必填参数时错误码为 ParamMissingErrorCode，其余绑定和校验错误为 ParamVerifyErrorCode。
+// Hertz 类型转换失败的错误中只有参数值，字段名从请求中取值相同的参数得到。
+func BuildBindError(c *app.RequestContext, err error) error {
+	if err == nil {
+		return nil
+	}
+	// vd 校验表达式的错误由 ValidateErrorFactory 生成，已经带有字段错误详情
+	var no errno.ErrNo
+	if errors.As(err, &no) {
+		return no
+	}
+
+	msg := err.Error()
+	var details validate.Errors
+	switch {
+	case requiredParamRe.MatchString(msg):
+		field := requiredParamRe.FindStringSubmatch(msg)[1]
+		details.Add(field, validate.RuleRequired, errno.ParamMissingErrorCode, "%s is required", field)
+		return errno.Errorf(errno.ParamMissingErrorCode, "missing parameter: %s", field).WithDetails(details...)
+	case decodeParamRe.MatchString(msg):
+		m := decodeParamRe.FindStringSubmatch(msg)
+		details.Add(paramWithValue(c, m[1]), validate.RuleType, errno.ParamVerifyErrorCode, "'%s' is not a valid %s", m[1], m[2])
+	case strings.HasPrefix(msg, "bind body failed"):
+		details.Add("body", validate.RuleType, errno.ParamVerifyErrorCode, "request body is not valid JSON for this request")
+	default:
+		return errno.Errorf(errno.ParamVerifyErrorCode, "invalid parameter: %s", msg)
+	}
+	return errno.Errorf(errno.ParamVerifyErrorCode, "invalid parameter: %s", msg).WithDetails(details...)
+}
+
+// paramWithValue 返回请求中取值为 value 的参数名，依次查找路径参数、查询参数和表单参数，找不到时返回空字符串
+func paramWithValue(c *app.RequestContext, value string) string {
+	for _, p := range c.Params {
+		if p.Value == value {
+			return p.Key
+		}
+	}
+	var name string
+	visit := func(key, val []byte) {
+		if name == "" && string(val) == value {
+			name = string(key)
+		}
+	}
+	c.QueryArgs().VisitAll(visit)
+	if name == "" {
+		c.PostArgs().VisitAll(visit)
+	}
+	return name
+}
+
+// ValidateErrorFactory 生成 vd 校验表达式的错误，通过 binding.ValidateConfig 注册到 Hertz
+func ValidateErrorFactory(failPath, msg string) error {
+	if msg == "" {
+		msg = "invalid parameter: " + failPath
+	}
+	return validate.NewFieldError(failPath, validate.RuleValidate, errno.ParamVerifyErrorCode, nil, "%s", msg)
+}
@@ -16,6 +16,7 @@ func BuildBookResp(info *db.Book) *model.Book {
 		Status:        info.Status,
 		PurchasePrice: info.PurchasePrice,
 		PurchaseDate:  info.PurchaseDate.Format("2006-01-02 15:04:05"),
+		Version:       info.Version,
 	}
 	if info.LastCheckout != nil {
 		result.LastCheckout = info.LastCheckout.Format("2006-01-02 15:04:05")
@@ -36,3 +37,25 @@ func BuildBookListResp(infos []*db.Book) []*model.Book {
 	}
 	return resp
 }
+
+// BuildBookDetailResp 将书籍及其书名、作者转换为响应中的图书
+func BuildBookDetailResp(info *db.BookDetail) *model.Book {
+	if info == nil {
+		return nil
+	}
+	result := BuildBookResp(&info.Book)
+	result.Title = info.Title
+	result.Author = info.Author
+	return result
+}
+
+func BuildBookDetailListResp(infos []*db.BookDetail) []*model.Book {
+	if infos == nil {
+		return nil
+	}
+	resp := make([]*model.Book, 0, len(infos))
+	for _, info := range infos {
+		resp = append(resp, BuildBookDetailResp(info))
+	}
+	return resp
+}
@@ -9,7 +9,7 @@ func BuildBookTypeResp(info *db.BookType) *model.BookType {
 	if info == nil {
 		return nil
 	}
-	return &model.BookType{
+	result := &model.BookType{
 		ISBN:            info.ISBN,
 		Title:           info.Title,
 		Author:          info.Author,
@@ -19,7 +19,22 @@ func BuildBookTypeResp(info *db.BookType) *model.BookType {
 		Description:     info.Description,
 		TotalCopies:     info.TotalCopies,
 		AvailableCopies: info.AvailableCopies,
+		Version:         info.Version,
+		Contributors:    buildBookTypeContributorsResp(info.Contributors),
+		Subjects:        buildBookTypeSubjectsResp(info.Subjects),
+		Series:          buildBookTypeSeriesResp(info.Series),
+		CategoryID:      info.CategoryID,
+		Isbn10:          info.ISBN10,
 	}
+	if info.Cover != nil {
+		coverURL := buildAttachmentURL(info.Cover.ID, info.Cover.StorageKey, false)
+		result.CoverURL = &coverURL
+		if info.Cover.ThumbnailKey != nil {
+			thumbnailURL := buildAttachmentURL(info.Cover.ID, *info.Cover.ThumbnailKey, true)
+			result.CoverThumbnailURL = &thumbnailURL
+		}
+	}
+	return result
 }
 
 func BuildBookTypeListResp(infos []*db.BookType) []*model.BookType {
@@ -3,6 +3,7 @@ package pack
 import (
 	"github.com/2451965602/LMS/biz/dal/db"
 	"github.com/2451965602/LMS/biz/model/model"
+	"github.com/2451965602/LMS/pkg/constants"
 )
 
 func BuildBorrowRecordResp(info *db.BorrowRecord) *model.BorrowRecord {
@@ -11,7 +12,6 @@ func BuildBorrowRecordResp(info *db.BorrowRecord) *model.BorrowRecord {
 	}
 	result := &model.BorrowRecord{
 		ID:           info.ID,
-		UserID:       info.UserID,
 		BookID:       info.BookID,
 		Title:        info.Title,
 		CheckoutDate: info.CheckoutDate.Format("2006-01-02 15:04:05"),
@@ -19,6 +19,10 @@ func BuildBorrowRecordResp(info *db.BorrowRecord) *model.BorrowRecord {
 		Status:       info.Status,
 		RenewalCount: info.RenewalCount,
 		LateFee:      info.LateFee,
+		FeeStatus:    feeStatus(info),
+	}
+	if info.UserID != nil {
+		result.UserID = *info.UserID
 	}
 	if info.ReturnDate != nil {
 		result.ReturnDate = info.ReturnDate.Format("2006-01-02 15:04:05")
@@ -28,6 +32,20 @@ func BuildBorrowRecordResp(info *db.BorrowRecord) *model.BorrowRecord {
 	return result
 }
 
+// feeStatus 返回借阅记录逾期费用的结算状态
+func feeStatus(info *db.BorrowRecord) string {
+	switch {
+	case info.LateFee <= 0:
+		return constants.FeeStatusNone
+	case info.FeePaid:
+		return constants.FeeStatusPaid
+	case info.FeeWaived:
+		return constants.FeeStatusWaived
+	default:
+		return constants.FeeStatusUnpaid
+	}
+}
+
 func BuildBorrowRecordListResp(infos []*db.BorrowRecord) []*model.BorrowRecord {
 	if infos == nil {
 		return nil
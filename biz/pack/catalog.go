@@ -0,0 +1,72 @@
+package pack
+
+import (
+	"github.com/2451965602/LMS/biz/dal/db"
+	"github.com/2451965602/LMS/biz/model/model"
+)
+
+// BuildCatalogEntryResp 构建责任者、主题或丛书
+func BuildCatalogEntryResp(info *db.CatalogEntry) *model.CatalogEntry {
+	if info == nil {
+		return nil
+	}
+	return &model.CatalogEntry{
+		ID:   info.ID,
+		Name: info.Name,
+	}
+}
+
+func BuildCatalogEntryListResp(infos []*db.CatalogEntry) []*model.CatalogEntry {
+	if infos == nil {
+		return nil
+	}
+	resp := make([]*model.CatalogEntry, 0, len(infos))
+	for _, info := range infos {
+		resp = append(resp, BuildCatalogEntryResp(info))
+	}
+	return resp
+}
+
+// buildBookTypeContributorsResp 构建书籍类型的责任者列表，未查询关联时返回 nil
+func buildBookTypeContributorsResp(infos []db.BookTypeContributorDetail) []*model.BookTypeContributor {
+	if infos == nil {
+		return nil
+	}
+	resp := make([]*model.BookTypeContributor, 0, len(infos))
+	for _, info := range infos {
+		resp = append(resp, &model.BookTypeContributor{
+			ContributorID: info.ContributorID,
+			Name:          info.Name,
+			Role:          info.Role,
+		})
+	}
+	return resp
+}
+
+// buildBookTypeSubjectsResp 构建书籍类型的主题列表，未查询关联时返回 nil
+func buildBookTypeSubjectsResp(infos []db.CatalogEntry) []*model.CatalogEntry {
+	if infos == nil {
+		return nil
+	}
+	resp := make([]*model.CatalogEntry, 0, len(infos))
+	for i := range infos {
+		resp = append(resp, BuildCatalogEntryResp(&infos[i]))
+	}
+	return resp
+}
+
+// buildBookTypeSeriesResp 构建书籍类型所属的丛书列表，未查询关联时返回 nil
+func buildBookTypeSeriesResp(infos []db.BookTypeSeriesDetail) []*model.BookTypeSeries {
+	if infos == nil {
+		return nil
+	}
+	resp := make([]*model.BookTypeSeries, 0, len(infos))
+	for _, info := range infos {
+		resp = append(resp, &model.BookTypeSeries{
+			SeriesID: info.SeriesID,
+			Name:     info.Name,
+			Volume:   info.Volume,
+		})
+	}
+	return resp
+}
@@ -0,0 +1,30 @@
+package pack
+
+import (
+	"github.com/2451965602/LMS/biz/dal/db"
+	"github.com/2451965602/LMS/biz/model/model"
+)
+
+// BuildCategoryResp 构建分类节点
+func BuildCategoryResp(info *db.CategoryNode) *model.Category {
+	if info == nil {
+		return nil
+	}
+	return &model.Category{
+		ID:       info.ID,
+		Code:     info.Code,
+		Name:     info.Name,
+		ParentID: info.ParentID,
+	}
+}
+
+func BuildCategoryListResp(infos []*db.CategoryNode) []*model.Category {
+	if infos == nil {
+		return nil
+	}
+	resp := make([]*model.Category, 0, len(infos))
+	for _, info := range infos {
+		resp = append(resp, BuildCategoryResp(info))
+	}
+	return resp
+}
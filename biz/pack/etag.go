@@ -0,0 +1,13 @@
+package pack
+
+import (
+	"github.com/cloudwego/hertz/pkg/app"
+
+	"github.com/2451965602/LMS/pkg/constants"
+	"github.com/2451965602/LMS/pkg/etag"
+)
+
+// SetETag 在响应头中返回数据的版本，客户端更新时通过 If-Match 带回
+func SetETag(c *app.RequestContext, version int64) {
+	c.Header(constants.ETagHeader, etag.Format(version))
+}
@@ -0,0 +1,104 @@
+package pack
+
+import (
+	"math"
+	"time"
+
+	"github.com/2451965602/LMS/biz/dal/db"
+	"github.com/2451965602/LMS/biz/model/model"
+)
+
+func BuildLoanResp(info *db.BorrowDetail) *model.Loan {
+	if info == nil {
+		return nil
+	}
+	now := time.Now()
+	return &model.Loan{
+		BorrowID:      info.ID,
+		BookID:        info.BookID,
+		Isbn:          info.ISBN,
+		Title:         info.Title,
+		Author:        info.Author,
+		Location:      info.Location,
+		CheckoutDate:  info.CheckoutDate.Format("2006-01-02 15:04:05"),
+		DueDate:       info.DueDate.Format("2006-01-02 15:04:05"),
+		DaysRemaining: int64(math.Ceil(info.DueDate.Sub(now).Hours() / 24)),
+		RenewalCount:  info.RenewalCount,
+		Overdue:       info.Status == "overdue" || now.After(info.DueDate),
+	}
+}
+
+func BuildLoanListResp(infos []*db.BorrowDetail) []*model.Loan {
+	if infos == nil {
+		return nil
+	}
+	resp := make([]*model.Loan, 0, len(infos))
+	for _, info := range infos {
+		resp = append(resp, BuildLoanResp(info))
+	}
+	return resp
+}
+
+func BuildHoldResp(info *db.HoldDetail) *model.Hold {
+	if info == nil {
+		return nil
+	}
+	result := &model.Hold{
+		ID:          info.ID,
+		Isbn:        info.ISBN,
+		Title:       info.Title,
+		Author:      info.Author,
+		Status:      info.Status,
+		ReserveDate: info.ReserveDate.Format("2006-01-02 15:04:05"),
+	}
+	if info.ExpireDate != nil {
+		result.ExpireDate = info.ExpireDate.Format("2006-01-02 15:04:05")
+	} else {
+		result.ExpireDate = ""
+	}
+	return result
+}
+
+func BuildHoldListResp(infos []*db.HoldDetail) []*model.Hold {
+	if infos == nil {
+		return nil
+	}
+	resp := make([]*model.Hold, 0, len(infos))
+	for _, info := range infos {
+		resp = append(resp, BuildHoldResp(info))
+	}
+	return resp
+}
+
+func BuildHistoryItemResp(info *db.BorrowDetail) *model.HistoryItem {
+	if info == nil {
+		return nil
+	}
+	result := &model.HistoryItem{
+		BorrowID:     info.ID,
+		BookID:       info.BookID,
+		Isbn:         info.ISBN,
+		Title:        info.Title,
+		Author:       info.Author,
+		CheckoutDate: info.CheckoutDate.Format("2006-01-02 15:04:05"),
+		Status:       info.Status,
+		LateFee:      info.LateFee,
+	}
+	if info.ReturnDate != nil {
+		result.ReturnDate = info.ReturnDate.Format("2006-01-02 15:04:05")
+	} else {
+		result.ReturnDate = ""
+	}
+	return result
+}
+
+func BuildHistoryListResp(infos []*db.BorrowDetail) []*model.HistoryItem {
+	if infos == nil {
+		return nil
+	}
+	resp := make([]*model.HistoryItem, 0, len(infos))
+	for _, info := range infos {
+		resp = append(resp, BuildHistoryItemResp(info))
+	}
+	return resp
+}
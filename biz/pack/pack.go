@@ -1,11 +1,16 @@
 package pack
 
 import (
+	"bytes"
+	"encoding/csv"
+
 	"github.com/cloudwego/hertz/pkg/app"
 	"github.com/cloudwego/hertz/pkg/protocol/consts"
 
 	"github.com/2451965602/LMS/biz/model/model"
+	"github.com/2451965602/LMS/pkg/constants"
 	"github.com/2451965602/LMS/pkg/errno"
+	"github.com/2451965602/LMS/pkg/i18n"
 )
 
 func BuildBaseResp(err error) *model.BaseResp {
@@ -16,18 +21,87 @@ func BuildBaseResp(err error) *model.BaseResp {
 		}
 	}
 	Errno := errno.ConvertErr(err)
-	return &model.BaseResp{
+	if errno.IsInternal(Errno.ErrorCode) {
+		// 内部错误只返回固定的说明，实际原因由 SendFailResponse 交给追踪中间件写入日志
+		return &model.BaseResp{
+			Code: Errno.ErrorCode,
+			Msg:  errno.PublicMessage(Errno.ErrorCode),
+		}
+	}
+	resp := &model.BaseResp{
 		Code: Errno.ErrorCode,
 		Msg:  Errno.ErrorMsg,
 	}
+	for _, d := range Errno.Details {
+		resp.Details = append(resp.Details, &model.FieldError{
+			Field:  d.Field,
+			Code:   d.ErrorCode,
+			Msg:    d.ErrorMsg,
+			Rule:   d.Rule,
+			Params: d.Params,
+		})
+	}
+	return resp
 }
 
+// SendFailResponse 返回错误响应
+// 内部错误的响应只包含错误码和请求ID，实际原因和调用栈记录在请求上下文中，由追踪中间件写入日志。
 func SendFailResponse(c *app.RequestContext, err error) {
 	resp := new(model.ErrorResp)
-	resp.Base = BuildBaseResp(err)
+	resp.Base = BuildFailBaseResp(c, err)
 	SendResponse(c, resp)
 }
 
+// BuildFailBaseResp 构建错误响应的 BaseResp，并在请求上下文中记录错误
+// 错误响应需要同时返回数据时使用，如版本冲突时返回当前的数据；其余情况使用 SendFailResponse。
+func BuildFailBaseResp(c *app.RequestContext, err error) *model.BaseResp {
+	base := BuildBaseResp(err)
+	Errno := errno.ConvertErr(err)
+	c.Set(constants.ErrnoKey, base.Code)                // 记录业务错误码，供监控中间件使用
+	c.Set(constants.ErrmsgKey, Errno.InternalMessage()) // 记录错误信息，供链路追踪中间件使用
+	c.Set(constants.ErrorKey, Errno)                    // 记录完整的错误，供链路追踪中间件输出调用栈
+	if errno.IsInternal(base.Code) {
+		if id := c.GetString(constants.RequestIDKey); id != "" {
+			base.RequestID = &id
+		}
+	}
+	return base
+}
+
+// SendResponse 返回 JSON 响应
+// 响应带有 BaseResp 时按 Accept-Language 本地化其中的消息，并按错误码设置 HTTP 状态码。
 func SendResponse(c *app.RequestContext, data interface{}) {
-	c.JSON(consts.StatusOK, data)
+	status := consts.StatusOK
+	if r, ok := data.(interface{ GetBase() *model.BaseResp }); ok && r.GetBase() != nil {
+		base := r.GetBase()
+		localize(i18n.FromAcceptLanguage(string(c.GetHeader("Accept-Language"))), base)
+		status = httpStatus(base.Code)
+	}
+	c.JSON(status, data)
+}
+
+// SendCSVResponse 以 CSV 附件的形式返回数据，rows 的第一行为表头
+func SendCSVResponse(c *app.RequestContext, filename string, rows [][]string) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.WriteAll(rows); err != nil {
+		SendFailResponse(c, errno.NewErrNo(errno.InternalServiceErrorCode, "write csv failed"))
+		return
+	}
+	c.Header("Content-Disposition", "attachment; filename=\""+filename+"\"")
+	c.Data(consts.StatusOK, "text/csv; charset=utf-8", buf.Bytes())
+}
+
+// localize 将 BaseResp 中的消息和字段错误说明转换为指定语言
+func localize(lang i18n.Lang, base *model.BaseResp) {
+	base.Msg = i18n.Message(lang, base.Code, base.Msg)
+	for _, d := range base.Details {
+		d.Msg = i18n.FieldMessage(lang, errno.FieldError{
+			Field:     d.Field,
+			Rule:      d.Rule,
+			ErrorCode: d.Code,
+			ErrorMsg:  d.Msg,
+			Params:    d.Params,
+		})
+	}
 }
@@ -0,0 +1,102 @@
+package pack
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/app/server"
+	"github.com/cloudwego/hertz/pkg/app/server/binding"
+	"github.com/cloudwego/hertz/pkg/common/config"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/cloudwego/hertz/pkg/route"
+
+	"github.com/2451965602/LMS/biz/model/model"
+	"github.com/2451965602/LMS/pkg/errno"
+	"github.com/2451965602/LMS/pkg/validate"
+)
+
+// bindTestRequest 用于触发绑定和校验失败的请求
+type bindTestRequest struct {
+	Name string `query:"name,required"`
+	Age  int64  `query:"age" vd:"$>=0"`
+	Size *int64 `query:"size"`
+}
+
+// newBindTestEngine 创建与 main 中相同校验配置的路由，绑定失败时按接口的方式返回错误
+func newBindTestEngine() *route.Engine {
+	vc := binding.NewValidateConfig()
+	vc.SetValidatorErrorFactory(ValidateErrorFactory)
+	engine := route.NewEngine(config.NewOptions([]config.Option{server.WithValidateConfig(vc)}))
+	engine.GET("/bind", func(ctx context.Context, c *app.RequestContext) {
+		var req bindTestRequest
+		if err := c.BindAndValidate(&req); err != nil {
+			SendFailResponse(c, BuildBindError(c, err))
+			return
+		}
+		SendResponse(c, &model.ErrorResp{Base: BuildBaseResp(nil)})
+	})
+	engine.GET("/enum", func(ctx context.Context, c *app.RequestContext) {
+		SendFailResponse(c, validate.NewFieldError("kind", validate.RuleEnum, errno.ParamVerifyErrorCode,
+			map[string]string{"allowed": "cover, document"}, "kind must be one of cover, document"))
+	})
+	return engine
+}
+
+func performBind(t *testing.T, url, lang string) (int, *model.BaseResp) {
+	t.Helper()
+	w := ut.PerformRequest(newBindTestEngine(), http.MethodGet, url, nil, ut.Header{Key: "Accept-Language", Value: lang})
+	resp := w.Result()
+	var body model.ErrorResp
+	if err := json.Unmarshal(resp.Body(), &body); err != nil {
+		t.Fatalf("unmarshal %s: %v", resp.Body(), err)
+	}
+	if body.Base == nil {
+		t.Fatalf("response has no base: %s", resp.Body())
+	}
+	return resp.StatusCode(), body.Base
+}
+
+func TestFieldErrorDetails(t *testing.T) {
+	cases := []struct {
+		name   string
+		url    string
+		lang   string
+		status int
+		code   int64
+		field  string
+		rule   string
+		msg    string
+		params map[string]string
+	}{
+		{"missing zh", "/bind", "zh-CN", http.StatusBadRequest, errno.ParamMissingErrorCode, "name", validate.RuleRequired, "此项为必填项", nil},
+		{"missing en", "/bind", "en", http.StatusBadRequest, errno.ParamMissingErrorCode, "name", validate.RuleRequired, "name is required", nil},
+		{"validate zh", "/bind?name=a&age=-1", "zh-CN", http.StatusBadRequest, errno.ParamVerifyErrorCode, "Age", validate.RuleValidate, "取值不符合要求", nil},
+		{"type zh", "/bind?name=a&age=x", "zh-CN", http.StatusBadRequest, errno.ParamVerifyErrorCode, "age", validate.RuleType, "类型不正确", nil},
+		{"pointer type zh", "/bind?name=a&size=x", "zh-CN", http.StatusBadRequest, errno.ParamVerifyErrorCode, "size", validate.RuleType, "类型不正确", nil},
+		{"enum zh", "/enum", "zh-CN", http.StatusBadRequest, errno.ParamVerifyErrorCode, "kind", validate.RuleEnum,
+			"取值应为以下之一：cover, document", map[string]string{"allowed": "cover, document"}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			status, base := performBind(t, c.url, c.lang)
+			if status != c.status || base.Code != c.code {
+				t.Fatalf("status %d code %d, want %d %d", status, base.Code, c.status, c.code)
+			}
+			if len(base.Details) != 1 {
+				t.Fatalf("got %d details, want 1", len(base.Details))
+			}
+			d := base.Details[0]
+			if d.Field != c.field || d.Rule != c.rule || d.Msg != c.msg {
+				t.Errorf("detail = {field %q rule %q msg %q}, want {field %q rule %q msg %q}", d.Field, d.Rule, d.Msg, c.field, c.rule, c.msg)
+			}
+			for k, v := range c.params {
+				if d.Params[k] != v {
+					t.Errorf("params[%q] = %q, want %q", k, d.Params[k], v)
+				}
+			}
+		})
+	}
+}
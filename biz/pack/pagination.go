@@ -0,0 +1,28 @@
+package pack
+
+import (
+	"strconv"
+
+	"github.com/cloudwego/hertz/pkg/app"
+
+	"github.com/2451965602/LMS/pkg/constants"
+	"github.com/2451965602/LMS/pkg/pagination"
+)
+
+// BuildNextCursor 返回响应中的 next_cursor，没有下一页时不返回该字段
+func BuildNextCursor(result *pagination.Result) *string {
+	if result == nil || result.NextCursor == "" {
+		return nil
+	}
+	return &result.NextCursor
+}
+
+// SetPageHeaders 在响应头中返回下一页的游标和总数，用于 CSV 等没有 JSON 包装的响应
+func SetPageHeaders(c *app.RequestContext, result *pagination.Result) {
+	if cursor := BuildNextCursor(result); cursor != nil {
+		c.Header(constants.NextCursorHeader, *cursor)
+	}
+	if result != nil && result.Total != nil {
+		c.Header(constants.TotalCountHeader, strconv.FormatInt(*result.Total, 10))
+	}
+}
@@ -0,0 +1,258 @@
+package pack
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/2451965602/LMS/biz/dal/db"
+	"github.com/2451965602/LMS/biz/model/report"
+	"github.com/2451965602/LMS/pkg/constants"
+)
+
+// IsCSVFormat 判断报表请求是否要求 CSV 输出
+func IsCSVFormat(format *string) bool {
+	return format != nil && *format == constants.ReportFormatCSV
+}
+
+func BuildPeriodCountListResp(infos []*db.PeriodCount) []*report.PeriodCount {
+	resp := make([]*report.PeriodCount, 0, len(infos))
+	for _, info := range infos {
+		resp = append(resp, &report.PeriodCount{
+			Period: info.Period,
+			Count:  info.Count,
+		})
+	}
+	return resp
+}
+
+func BuildPeriodCountCSV(infos []*db.PeriodCount) [][]string {
+	rows := [][]string{{"period", "count"}}
+	for _, info := range infos {
+		rows = append(rows, []string{info.Period, strconv.FormatInt(info.Count, 10)})
+	}
+	return rows
+}
+
+func BuildRankItemListResp(infos []*db.RankItem) []*report.RankItem {
+	resp := make([]*report.RankItem, 0, len(infos))
+	for _, info := range infos {
+		resp = append(resp, &report.RankItem{
+			Name:  info.Name,
+			Isbn:  info.ISBN,
+			Count: info.Count,
+		})
+	}
+	return resp
+}
+
+func BuildRankItemCSV(infos []*db.RankItem) [][]string {
+	rows := [][]string{{"name", "isbn", "count"}}
+	for _, info := range infos {
+		rows = append(rows, []string{info.Name, info.ISBN, strconv.FormatInt(info.Count, 10)})
+	}
+	return rows
+}
+
+func BuildCategoryTurnoverListResp(infos []*db.CategoryTurnover) []*report.CategoryTurnover {
+	resp := make([]*report.CategoryTurnover, 0, len(infos))
+	for _, info := range infos {
+		resp = append(resp, &report.CategoryTurnover{
+			Category:   info.Category,
+			Copies:     info.Copies,
+			Loans:      info.Loans,
+			Turnover:   ratio(info.Loans, info.Copies),
+			CategoryID: info.CategoryID,
+		})
+	}
+	return resp
+}
+
+func BuildCategoryTurnoverCSV(infos []*db.CategoryTurnover) [][]string {
+	rows := [][]string{{"category_id", "category", "copies", "loans", "turnover"}}
+	for _, info := range infos {
+		rows = append(rows, []string{
+			formatOptionalID(info.CategoryID),
+			info.Category,
+			strconv.FormatInt(info.Copies, 10),
+			strconv.FormatInt(info.Loans, 10),
+			strconv.FormatFloat(ratio(info.Loans, info.Copies), 'f', 4, 64),
+		})
+	}
+	return rows
+}
+
+func BuildIdleCopyListResp(infos []*db.IdleCopy) []*report.IdleCopy {
+	resp := make([]*report.IdleCopy, 0, len(infos))
+	for _, info := range infos {
+		resp = append(resp, &report.IdleCopy{
+			BookID:       info.BookID,
+			Isbn:         info.ISBN,
+			Title:        info.Title,
+			Category:     info.Category,
+			Location:     info.Location,
+			PurchaseDate: info.PurchaseDate.Format("2006-01-02 15:04:05"),
+			CategoryID:   info.CategoryID,
+		})
+	}
+	return resp
+}
+
+func BuildIdleCopyCSV(infos []*db.IdleCopy) [][]string {
+	rows := [][]string{{"book_id", "isbn", "title", "category_id", "category", "location", "purchase_date"}}
+	for _, info := range infos {
+		rows = append(rows, []string{
+			strconv.FormatInt(info.BookID, 10),
+			info.ISBN,
+			info.Title,
+			formatOptionalID(info.CategoryID),
+			info.Category,
+			info.Location,
+			info.PurchaseDate.Format("2006-01-02 15:04:05"),
+		})
+	}
+	return rows
+}
+
+func BuildWeedingGroupListResp(infos []*db.WeedingGroup) []*report.WeedingGroup {
+	resp := make([]*report.WeedingGroup, 0, len(infos))
+	for _, info := range infos {
+		resp = append(resp, &report.WeedingGroup{
+			Category:   info.Category,
+			Location:   info.Location,
+			Copies:     info.Copies,
+			CategoryID: info.CategoryID,
+		})
+	}
+	return resp
+}
+
+func BuildWeedingCandidateListResp(infos []*db.WeedingCandidate) []*report.WeedingCandidate {
+	resp := make([]*report.WeedingCandidate, 0, len(infos))
+	for _, info := range infos {
+		resp = append(resp, &report.WeedingCandidate{
+			BookID:       info.BookID,
+			Isbn:         info.ISBN,
+			Title:        info.Title,
+			Category:     info.Category,
+			Location:     info.Location,
+			Status:       info.Status,
+			PurchaseDate: info.PurchaseDate.Format("2006-01-02 15:04:05"),
+			LastCheckout: formatOptionalTime(info.LastCheckout),
+			CategoryID:   info.CategoryID,
+		})
+	}
+	return resp
+}
+
+func BuildWeedingCandidateCSV(infos []*db.WeedingCandidate) [][]string {
+	rows := [][]string{{"book_id", "isbn", "title", "category_id", "category", "location", "status", "purchase_date", "last_checkout"}}
+	for _, info := range infos {
+		rows = append(rows, []string{
+			strconv.FormatInt(info.BookID, 10),
+			info.ISBN,
+			info.Title,
+			formatOptionalID(info.CategoryID),
+			info.Category,
+			info.Location,
+			info.Status,
+			info.PurchaseDate.Format("2006-01-02 15:04:05"),
+			formatOptionalTime(info.LastCheckout),
+		})
+	}
+	return rows
+}
+
+func BuildRoleOverdueListResp(infos []*db.RoleOverdue) []*report.RoleOverdue {
+	resp := make([]*report.RoleOverdue, 0, len(infos))
+	for _, info := range infos {
+		resp = append(resp, &report.RoleOverdue{
+			Role:    info.Role,
+			Loans:   info.Loans,
+			Overdue: info.Overdue,
+			Rate:    ratio(info.Overdue, info.Loans),
+		})
+	}
+	return resp
+}
+
+func BuildRoleOverdueCSV(infos []*db.RoleOverdue) [][]string {
+	rows := [][]string{{"role", "loans", "overdue", "rate"}}
+	for _, info := range infos {
+		rows = append(rows, []string{
+			info.Role,
+			strconv.FormatInt(info.Loans, 10),
+			strconv.FormatInt(info.Overdue, 10),
+			strconv.FormatFloat(ratio(info.Overdue, info.Loans), 'f', 4, 64),
+		})
+	}
+	return rows
+}
+
+func BuildPeriodAmountListResp(infos []*db.PeriodAmount) []*report.PeriodAmount {
+	resp := make([]*report.PeriodAmount, 0, len(infos))
+	for _, info := range infos {
+		resp = append(resp, &report.PeriodAmount{
+			Period: info.Period,
+			Amount: info.Amount,
+		})
+	}
+	return resp
+}
+
+func BuildPeriodAmountCSV(infos []*db.PeriodAmount) [][]string {
+	rows := [][]string{{"period", "amount"}}
+	for _, info := range infos {
+		rows = append(rows, []string{info.Period, strconv.FormatFloat(info.Amount, 'f', 2, 64)})
+	}
+	return rows
+}
+
+func BuildCategoryValueListResp(infos []*db.CategoryValue) []*report.CategoryValue {
+	resp := make([]*report.CategoryValue, 0, len(infos))
+	for _, info := range infos {
+		resp = append(resp, &report.CategoryValue{
+			Category:   info.Category,
+			Copies:     info.Copies,
+			Value:      info.Value,
+			CategoryID: info.CategoryID,
+		})
+	}
+	return resp
+}
+
+func BuildCategoryValueCSV(infos []*db.CategoryValue) [][]string {
+	rows := [][]string{{"category_id", "category", "copies", "value"}}
+	for _, info := range infos {
+		rows = append(rows, []string{
+			formatOptionalID(info.CategoryID),
+			info.Category,
+			strconv.FormatInt(info.Copies, 10),
+			strconv.FormatFloat(info.Value, 'f', 2, 64),
+		})
+	}
+	return rows
+}
+
+// ratio 计算 numerator / denominator，分母为 0 时返回 0
+func ratio(numerator, denominator int64) float64 {
+	if denominator == 0 {
+		return 0
+	}
+	return float64(numerator) / float64(denominator)
+}
+
+// formatOptionalID 格式化可能为空的 ID，为空时返回空字符串
+func formatOptionalID(id *int64) string {
+	if id == nil {
+		return ""
+	}
+	return strconv.FormatInt(*id, 10)
+}
+
+// formatOptionalTime 格式化可能为空的时间，为空时返回空字符串
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format("2006-01-02 15:04:05")
+}
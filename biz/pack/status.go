@@ -0,0 +1,66 @@
+package pack
+
+import (
+	"github.com/cloudwego/hertz/pkg/protocol/consts"
+
+	"github.com/2451965602/LMS/pkg/errno"
+)
+
+// httpStatus 错误码对应的 HTTP 状态码
+// 客户端仍应以 BaseResp 中的错误码区分具体错误，状态码只用于网关、监控和通用客户端的粗略分类。
+func httpStatus(code int64) int {
+	switch code {
+	case errno.SuccessCode:
+		return consts.StatusOK
+	case errno.AuthNoOperatePermissionCode,
+		errno.ServicePermissionDenied,
+		errno.ServicePasswordChangeRequired,
+		errno.ServiceTwoFactorRequired:
+		return consts.StatusForbidden
+	case errno.IllegalOperatorCode:
+		return consts.StatusBadRequest
+	case errno.ServiceUserNotExist:
+		// 登录时用户名或密码错误也使用此错误码，不能以 404 暴露用户是否存在
+		return consts.StatusBadRequest
+	case errno.ServiceBookTypeNotFound,
+		errno.ServiceBookTypeNotExist,
+		errno.ServiceBookNotExist,
+		errno.ServiceBorrowRecordNotExist,
+		errno.ServiceSessionNotExist,
+		errno.ServiceContributorNotExist,
+		errno.ServiceSubjectNotExist,
+		errno.ServiceSeriesNotExist,
+		errno.ServiceCategoryNotExist,
+		errno.ServiceAttachmentNotExist:
+		return consts.StatusNotFound
+	case errno.ServiceUserExist,
+		errno.ServiceBookTypeExist,
+		errno.ServiceBookTypeInUse,
+		errno.ServiceIdentityLinked,
+		errno.ServiceIllegalStatusTransition,
+		errno.ServiceIdempotencyKeyReused,
+		errno.ServiceVersionConflict,
+		errno.ServiceCatalogEntryExist,
+		errno.ServiceCatalogEntryInUse,
+		errno.ServiceCategoryExist,
+		errno.ServiceCategoryInUse:
+		return consts.StatusConflict
+	case errno.ServiceAttachmentTooLarge:
+		return consts.StatusRequestEntityTooLarge
+	case errno.ServiceAttachmentTypeNotAllowed:
+		return consts.StatusUnsupportedMediaType
+	case errno.ServiceLoginThrottled,
+		errno.ServiceLoginLocked,
+		errno.ServiceResetCodeTooFrequent:
+		return consts.StatusTooManyRequests
+	}
+
+	switch {
+	case code >= errno.AuthInvalidCode && code < errno.AuthInvalidCode+10000:
+		return consts.StatusUnauthorized
+	case code >= errno.InternalServiceErrorCode && code < errno.InternalServiceErrorCode+10000:
+		return consts.StatusInternalServerError
+	default:
+		return consts.StatusBadRequest
+	}
+}
@@ -5,16 +5,73 @@ import (
 	"github.com/2451965602/LMS/biz/model/model"
 )
 
+// BuildUserResp 构建本人或工作人员可见的完整用户信息
+// 其他没有工作人员权限的用户只能看到 BuildPublicUserResp 构建的公开信息，其中不含手机号和邮箱。
 func BuildUserResp(info *db.User) *model.User {
 	if info == nil {
 		return nil
 	}
 	return &model.User{
+		ID:                 info.ID,
+		Username:           info.Name,
+		Phone:              info.Phone,
+		Status:             info.Status,
+		Permissions:        info.Permission,
+		RegisterDate:       info.RegisterDate.Format("2006-01-02 15:04:05"),
+		KeepHistory:        info.KeepHistory,
+		MustChangePassword: info.MustChangePassword,
+		TwoFactorEnabled:   info.TOTPEnabled,
+		Email:              info.Email,
+		PatronType:         info.PatronType,
+		Version:            info.Version,
+	}
+}
+
+// BuildPublicUserResp 构建对其他用户公开的用户信息
+func BuildPublicUserResp(info *db.User) *model.PublicUser {
+	if info == nil {
+		return nil
+	}
+	return &model.PublicUser{
 		ID:           info.ID,
 		Username:     info.Name,
-		Phone:        info.Phone,
-		Status:       info.Status,
-		Permissions:  info.Permission,
 		RegisterDate: info.RegisterDate.Format("2006-01-02 15:04:05"),
 	}
 }
+
+func BuildSessionResp(info *db.Session, currentId int64) *model.Session {
+	if info == nil {
+		return nil
+	}
+	return &model.Session{
+		ID:         info.ID,
+		Device:     info.Device,
+		IP:         info.IP,
+		CreatedAt:  info.CreatedAt.Format("2006-01-02 15:04:05"),
+		LastUsedAt: info.LastUsedAt.Format("2006-01-02 15:04:05"),
+		ExpiresAt:  info.ExpiresAt.Format("2006-01-02 15:04:05"),
+		Current:    info.ID == currentId,
+	}
+}
+
+func BuildSessionListResp(infos []*db.Session, currentId int64) []*model.Session {
+	if infos == nil {
+		return nil
+	}
+	resp := make([]*model.Session, 0, len(infos))
+	for _, info := range infos {
+		resp = append(resp, BuildSessionResp(info, currentId))
+	}
+	return resp
+}
+
+func BuildTwoFactorPolicyListResp(policies []*db.TwoFactorPolicy) []*model.TwoFactorPolicy {
+	resp := make([]*model.TwoFactorPolicy, 0, len(policies))
+	for _, p := range policies {
+		resp = append(resp, &model.TwoFactorPolicy{
+			Role:     p.Role,
+			Required: p.Required,
+		})
+	}
+	return resp
+}
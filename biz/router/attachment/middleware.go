@@ -0,0 +1,46 @@
+// Code generated by hertz generator.
+
+package attachment
+
+import (
+	"github.com/cloudwego/hertz/pkg/app"
+
+	"github.com/2451965602/LMS/biz/router/auth"
+)
+
+func rootMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _attachmentMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _deleteattachmentMw() []app.HandlerFunc {
+	// your code...
+	return append(make([]app.HandlerFunc, 0),
+		auth.AccessTokenAuth(),
+	)
+}
+
+func _downloadattachmentMw() []app.HandlerFunc {
+	// your code...
+	// 下载不需要登录，封面地址可以直接用于 <img> 标签
+	return nil
+}
+
+func _getattachmentMw() []app.HandlerFunc {
+	// your code...
+	return append(make([]app.HandlerFunc, 0),
+		auth.AccessTokenAuth(),
+	)
+}
+
+func _uploadattachmentMw() []app.HandlerFunc {
+	// your code...
+	return append(make([]app.HandlerFunc, 0),
+		auth.AccessTokenAuth(),
+	)
+}
@@ -4,18 +4,41 @@ import (
 	"context"
 
 	"github.com/cloudwego/hertz/pkg/app"
+	"go.opentelemetry.io/otel/attribute"
 
 	mw "github.com/2451965602/LMS/biz/middleware"
 	"github.com/2451965602/LMS/biz/pack"
 	metainfoContext "github.com/2451965602/LMS/pkg/base/context"
+	"github.com/2451965602/LMS/pkg/constants"
 	"github.com/2451965602/LMS/pkg/errno"
+	"github.com/2451965602/LMS/pkg/tracing"
 )
 
+// twoFactorSetupAllowedPaths 所属角色要求两步验证但尚未启用的用户仍可访问的接口
+var twoFactorSetupAllowedPaths = map[string]bool{
+	"/user/2fa/setup":  true,
+	"/user/2fa/enable": true,
+	"/user/info":       true,
+	"/user/logout":     true,
+	"/user/logout/all": true,
+}
+
+// passwordChangeAllowedPaths 必须修改密码的用户仍可访问的接口
+var passwordChangeAllowedPaths = map[string]bool{
+	"/user/password":   true,
+	"/user/update":     true,
+	"/user/info":       true,
+	"/user/logout":     true,
+	"/user/logout/all": true,
+}
+
+// AccessTokenAuth 校验 Access Token，并按账号状态限制可访问的接口
+// 校验过程（包括查询会话）记录在 auth.AccessTokenAuth span 中，该 span 在调用后续处理函数前结束，不作为接口内 span 的父节点。
 func AccessTokenAuth() app.HandlerFunc {
 	return func(ctx context.Context, c *app.RequestContext) {
-		valid, userID := mw.IsAccessTokenAvailable(ctx, c)
-		if !valid {
-			pack.SendFailResponse(c, errno.Errorf(errno.AuthAccessExpiredCode, "access token expired"))
+		userID, err := checkAccessToken(ctx, c)
+		if err != nil {
+			pack.SendFailResponse(c, err)
 			c.Abort()
 			return
 		}
@@ -24,6 +47,31 @@ func AccessTokenAuth() app.HandlerFunc {
 	}
 }
 
+// checkAccessToken 校验 Access Token 和账号状态，返回用户ID
+func checkAccessToken(ctx context.Context, c *app.RequestContext) (int64, error) {
+	ctx, span := tracing.Start(ctx, "auth.AccessTokenAuth")
+	defer span.End()
+
+	valid, userID := mw.IsAccessTokenAvailable(ctx, c)
+	if !valid {
+		err := errno.Errorf(errno.AuthAccessExpiredCode, "access token expired")
+		tracing.RecordError(span, err)
+		return 0, err
+	}
+	span.SetAttributes(attribute.Int64("lms.user_id", userID))
+	if c.GetBool(constants.MustChangePasswordKey) && !passwordChangeAllowedPaths[c.FullPath()] {
+		err := errno.Errorf(errno.ServicePasswordChangeRequired, "password must be changed before continuing")
+		tracing.RecordError(span, err)
+		return 0, err
+	}
+	if c.GetBool(constants.TwoFactorSetupRequiredKey) && !twoFactorSetupAllowedPaths[c.FullPath()] {
+		err := errno.Errorf(errno.ServiceTwoFactorRequired, "two-factor authentication must be enabled before continuing")
+		tracing.RecordError(span, err)
+		return 0, err
+	}
+	return userID, nil
+}
+
 func RefreshTokenAuth() app.HandlerFunc {
 	return func(ctx context.Context, c *app.RequestContext) {
 		valid, userID := mw.IsRefreshTokenAvailable(ctx, c)
@@ -0,0 +1,82 @@
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+	"net"
+	"net/netip"
+	"os"
+	"strings"
+
+	"github.com/cloudwego/hertz/pkg/app"
+
+	"github.com/2451965602/LMS/biz/pack"
+	"github.com/2451965602/LMS/config"
+	"github.com/2451965602/LMS/pkg/errno"
+)
+
+// MetricsAuth 限制指标接口的访问，来源地址在 allowedIPs 中或携带正确的 Bearer Token 时才放行
+// 来源地址取连接的对端地址，不使用可以伪造的 X-Forwarded-For。
+func MetricsAuth() app.HandlerFunc {
+	return func(ctx context.Context, c *app.RequestContext) {
+		cfg := config.Metrics
+		if cfg != nil && (metricsIPAllowed(c.RemoteAddr(), cfg.AllowedIPs) || metricsTokenValid(string(c.GetHeader("Authorization")), metricsToken())) {
+			c.Next(ctx)
+			return
+		}
+		pack.SendFailResponse(c, errno.Errorf(errno.AuthNoOperatePermissionCode, "metrics access denied"))
+		c.Abort()
+	}
+}
+
+// metricsToken 返回配置的 Token，环境变量优先
+func metricsToken() string {
+	cfg := config.Metrics
+	if cfg.TokenEnv != "" {
+		if token := os.Getenv(cfg.TokenEnv); token != "" {
+			return token
+		}
+	}
+	return cfg.Token
+}
+
+// metricsIPAllowed 检查对端地址是否在允许的 IP 或 CIDR 中
+func metricsIPAllowed(addr net.Addr, allowed []string) bool {
+	if addr == nil || len(allowed) == 0 {
+		return false
+	}
+	host, _, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		host = addr.String()
+	}
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return false
+	}
+	ip = ip.Unmap()
+	for _, entry := range allowed {
+		entry = strings.TrimSpace(entry)
+		if prefix, err := netip.ParsePrefix(entry); err == nil {
+			if prefix.Contains(ip) {
+				return true
+			}
+			continue
+		}
+		if a, err := netip.ParseAddr(entry); err == nil && a.Unmap() == ip {
+			return true
+		}
+	}
+	return false
+}
+
+// metricsTokenValid 检查 Authorization 请求头中的 Bearer Token，未配置 Token 时总是不通过
+func metricsTokenValid(header, token string) bool {
+	if token == "" {
+		return false
+	}
+	const prefix = "Bearer "
+	if len(header) <= len(prefix) || !strings.EqualFold(header[:len(prefix)], prefix) {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(strings.TrimSpace(header[len(prefix):])), []byte(token)) == 1
+}
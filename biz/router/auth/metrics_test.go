@@ -0,0 +1,48 @@
+package auth
+
+import (
+	"net"
+	"testing"
+)
+
+func TestMetricsIPAllowed(t *testing.T) {
+	allowed := []string{"127.0.0.1", "::1", "10.0.0.0/8"}
+	cases := []struct {
+		addr net.Addr
+		want bool
+	}{
+		{&net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 5000}, true},
+		{&net.TCPAddr{IP: net.ParseIP("::1"), Port: 5000}, true},
+		{&net.TCPAddr{IP: net.ParseIP("::ffff:127.0.0.1"), Port: 5000}, true},
+		{&net.TCPAddr{IP: net.ParseIP("10.1.2.3"), Port: 5000}, true},
+		{&net.TCPAddr{IP: net.ParseIP("192.168.1.10"), Port: 5000}, false},
+		{nil, false},
+	}
+	for _, c := range cases {
+		if got := metricsIPAllowed(c.addr, allowed); got != c.want {
+			t.Errorf("metricsIPAllowed(%v) = %v, want %v", c.addr, got, c.want)
+		}
+	}
+	if metricsIPAllowed(&net.TCPAddr{IP: net.ParseIP("127.0.0.1")}, nil) {
+		t.Error("empty allow-list must not allow any address")
+	}
+}
+
+func TestMetricsTokenValid(t *testing.T) {
+	cases := []struct {
+		header, token string
+		want          bool
+	}{
+		{"Bearer secret", "secret", true},
+		{"bearer secret", "secret", true},
+		{"Bearer wrong", "secret", false},
+		{"secret", "secret", false},
+		{"Bearer ", "", false},
+		{"", "", false},
+	}
+	for _, c := range cases {
+		if got := metricsTokenValid(c.header, c.token); got != c.want {
+			t.Errorf("metricsTokenValid(%q, %q) = %v, want %v", c.header, c.token, got, c.want)
+		}
+	}
+}
@@ -3,8 +3,9 @@
 package book
 
 import (
-	book "github.com/2451965602/LMS/biz/handler/book"
 	"github.com/cloudwego/hertz/pkg/app/server"
+
+	book "github.com/2451965602/LMS/biz/handler/book"
 )
 
 /*
@@ -23,5 +24,6 @@ func Register(r *server.Hertz) {
 		_book.DELETE("/delete", append(_deletebookMw(), book.DeleteBook)...)
 		_book.GET("/search", append(_getbookMw(), book.GetBook)...)
 		_book.PUT("/update", append(_updatebookMw(), book.UpdateBook)...)
+		_book.POST("/withdraw", append(_withdrawbookMw(), book.WithdrawBook)...)
 	}
 }
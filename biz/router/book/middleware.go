@@ -39,3 +39,8 @@ func _updatebookMw() []app.HandlerFunc {
 	// your code...
 	return nil
 }
+
+func _withdrawbookMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
@@ -3,8 +3,9 @@
 package borrow
 
 import (
-	borrow "github.com/2451965602/LMS/biz/handler/borrow"
 	"github.com/cloudwego/hertz/pkg/app/server"
+
+	borrow "github.com/2451965602/LMS/biz/handler/borrow"
 )
 
 /*
@@ -23,5 +24,9 @@ func Register(r *server.Hertz) {
 		_book.GET("/record", append(_getborrowrecordMw(), borrow.GetBorrowRecord)...)
 		_book.POST("/renew", append(_renewMw(), borrow.Renew)...)
 		_book.POST("/return", append(_returnbookMw(), borrow.ReturnBook)...)
+		{
+			_fine := _book.Group("/fine", _fineMw()...)
+			_fine.POST("/settle", append(_settlefineMw(), borrow.SettleFine)...)
+		}
 	}
 }
@@ -39,3 +39,13 @@ func _returnbookMw() []app.HandlerFunc {
 	// your code...
 	return nil
 }
+
+func _fineMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _settlefineMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
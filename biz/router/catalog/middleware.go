@@ -0,0 +1,91 @@
+// Code generated by hertz generator.
+
+package catalog
+
+import (
+	"github.com/cloudwego/hertz/pkg/app"
+
+	"github.com/2451965602/LMS/biz/router/auth"
+)
+
+func rootMw() []app.HandlerFunc {
+	// your code...
+	return append(make([]app.HandlerFunc, 0),
+		auth.AccessTokenAuth(),
+	)
+}
+
+func _contributorMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _addcontributorMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _deletecontributorMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _getcontributorMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _updatecontributorMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _seriesMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _addseriesMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _deleteseriesMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _getseriesMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _updateseriesMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _subjectMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _addsubjectMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _deletesubjectMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _getsubjectMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _updatesubjectMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
@@ -0,0 +1,46 @@
+// Code generated by hertz generator.
+
+package category
+
+import (
+	"github.com/cloudwego/hertz/pkg/app"
+
+	"github.com/2451965602/LMS/biz/router/auth"
+)
+
+func rootMw() []app.HandlerFunc {
+	// your code...
+	return append(make([]app.HandlerFunc, 0),
+		auth.AccessTokenAuth(),
+	)
+}
+
+func _categoryMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _addcategoryMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _deletecategoryMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _getcategoryMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _getcategorypathMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _updatecategoryMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
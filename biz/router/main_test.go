@@ -0,0 +1,53 @@
+package router
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/app/server"
+	"github.com/cloudwego/hertz/pkg/app/server/binding"
+	"github.com/cloudwego/hertz/pkg/route"
+
+	"github.com/2451965602/LMS/biz/dal"
+	mw "github.com/2451965602/LMS/biz/middleware"
+	"github.com/2451965602/LMS/biz/pack"
+	"github.com/2451965602/LMS/pkg/testmysql"
+)
+
+// testDBErr 临时数据库的初始化结果，为 nil 时测试可以使用数据访问层
+var testDBErr error
+
+func TestMain(m *testing.M) {
+	cleanup, err := testmysql.Open()
+	if err == nil {
+		mw.Init()
+		if err = dal.Init(); err != nil {
+			err = fmt.Errorf("dal.Init: %w", err)
+		}
+	}
+	testDBErr = err
+	code := m.Run()
+	if cleanup != nil {
+		cleanup()
+	}
+	os.Exit(code)
+}
+
+// newTestEngine 返回注册了全部接口的路由，临时数据库未配置时跳过测试
+func newTestEngine(t *testing.T) *route.Engine {
+	t.Helper()
+	if errors.Is(testDBErr, testmysql.ErrNotConfigured) {
+		t.Skip(testDBErr)
+	}
+	if testDBErr != nil {
+		t.Fatal(testDBErr)
+	}
+
+	vc := binding.NewValidateConfig()
+	vc.SetValidatorErrorFactory(pack.ValidateErrorFactory)
+	h := server.New(server.WithValidateConfig(vc))
+	GeneratedRegister(h)
+	return h.Engine
+}
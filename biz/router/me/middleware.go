@@ -0,0 +1,36 @@
+// Code generated by hertz generator.
+
+package me
+
+import (
+	"github.com/cloudwego/hertz/pkg/app"
+
+	"github.com/2451965602/LMS/biz/router/auth"
+)
+
+func rootMw() []app.HandlerFunc {
+	// your code...
+	return append(make([]app.HandlerFunc, 0),
+		auth.AccessTokenAuth(),
+	)
+}
+
+func _meMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _getsummaryMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _exportdataMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _updateprivacyMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
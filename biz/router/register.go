@@ -5,16 +5,31 @@ package router
 import (
 	"github.com/cloudwego/hertz/pkg/app/server"
 
+	attachment "github.com/2451965602/LMS/biz/router/attachment"
 	book "github.com/2451965602/LMS/biz/router/book"
 	booktype "github.com/2451965602/LMS/biz/router/booktype"
 	borrow "github.com/2451965602/LMS/biz/router/borrow"
+	catalog "github.com/2451965602/LMS/biz/router/catalog"
+	category "github.com/2451965602/LMS/biz/router/category"
+	me "github.com/2451965602/LMS/biz/router/me"
 	model "github.com/2451965602/LMS/biz/router/model"
+	report "github.com/2451965602/LMS/biz/router/report"
 	user "github.com/2451965602/LMS/biz/router/user"
 )
 
 // GeneratedRegister registers routers generated by IDL.
 func GeneratedRegister(r *server.Hertz) {
 	//INSERT_POINT: DO NOT DELETE THIS LINE!
+	attachment.Register(r)
+
+	category.Register(r)
+
+	catalog.Register(r)
+
+	report.Register(r)
+
+	me.Register(r)
+
 	booktype.Register(r)
 
 	book.Register(r)
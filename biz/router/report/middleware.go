@@ -0,0 +1,61 @@
+// Code generated by hertz generator.
+
+package report
+
+import (
+	"github.com/cloudwego/hertz/pkg/app"
+
+	"github.com/2451965602/LMS/biz/router/auth"
+)
+
+func rootMw() []app.HandlerFunc {
+	// your code...
+	return append(make([]app.HandlerFunc, 0),
+		auth.AccessTokenAuth(),
+	)
+}
+
+func _reportMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _finerevenueMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _idlecopiesMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _loanstatsMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _overduerateMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _topborrowedMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _turnoverMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _collectionvalueMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _weedingMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
@@ -67,6 +67,167 @@ func _refreshtokenMw() []app.HandlerFunc {
 }
 
 func _getuserinfoMw() []app.HandlerFunc {
+	// your code...
+	return append(make([]app.HandlerFunc, 0),
+		auth.AccessTokenAuth(),
+	)
+}
+
+func _logoutMw() []app.HandlerFunc {
+	// your code...
+	return append(make([]app.HandlerFunc, 0),
+		auth.AccessTokenAuth(),
+	)
+}
+
+func _logout0Mw() []app.HandlerFunc {
+	// your code...
+	return append(make([]app.HandlerFunc, 0),
+		auth.AccessTokenAuth(),
+	)
+}
+
+func _logoutallMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _sessionMw() []app.HandlerFunc {
+	// your code...
+	return append(make([]app.HandlerFunc, 0),
+		auth.AccessTokenAuth(),
+	)
+}
+
+func _listsessionsMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _revokesessionMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _passwordMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _changepasswordMw() []app.HandlerFunc {
+	// your code...
+	return append(make([]app.HandlerFunc, 0),
+		auth.AccessTokenAuth(),
+	)
+}
+
+func _resetpasswordMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _resetMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _sendresetcodeMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _login0Mw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _logintwofactorMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func __faMw() []app.HandlerFunc {
+	// your code...
+	return append(make([]app.HandlerFunc, 0),
+		auth.AccessTokenAuth(),
+	)
+}
+
+func _disabletwofactorMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _enabletwofactorMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _regeneraterecoverycodesMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _setuptwofactorMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func __fa0Mw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _listtwofactorpolicyMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _settwofactorpolicyMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _ssoMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _ldapMw() []app.HandlerFunc {
 	// your code...
 	return nil
 }
+
+func _linkldapMw() []app.HandlerFunc {
+	// your code...
+	return append(make([]app.HandlerFunc, 0),
+		auth.AccessTokenAuth(),
+	)
+}
+
+func _ldaploginMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _oidcMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _oidcauthorizeMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _oidccallbackMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _linkoidcMw() []app.HandlerFunc {
+	// your code...
+	return append(make([]app.HandlerFunc, 0),
+		auth.AccessTokenAuth(),
+	)
+}
@@ -22,14 +22,57 @@ func Register(r *server.Hertz) {
 		_user := root.Group("/user", _userMw()...)
 		_user.DELETE("/delete", append(_deleteuserMw(), user.DeleteUser)...)
 		_user.GET("/info", append(_getuserinfoMw(), user.GetUserInfo)...)
-		_user.POST("/login", append(_loginMw(), user.Login)...)
+		_user.POST("/login", append(_login0Mw(), user.Login)...)
+		_login := _user.Group("/login", _loginMw()...)
+		_login.POST("/2fa", append(_logintwofactorMw(), user.LoginTwoFactor)...)
+		_user.POST("/logout", append(_logout0Mw(), user.Logout)...)
+		_logout := _user.Group("/logout", _logoutMw()...)
+		_logout.POST("/all", append(_logoutallMw(), user.LogoutAll)...)
+		_user.PUT("/password", append(_changepasswordMw(), user.ChangePassword)...)
+		_password := _user.Group("/password", _passwordMw()...)
+		_password.POST("/reset", append(_resetpasswordMw(), user.ResetPassword)...)
+		{
+			_reset := _password.Group("/reset", _resetMw()...)
+			_reset.POST("/code", append(_sendresetcodeMw(), user.SendResetCode)...)
+		}
 		_user.POST("/refresh", append(_refreshtokenMw(), user.RefreshToken)...)
 		_user.POST("/register", append(_registerMw(), user.Register)...)
 		_user.PUT("/update", append(_updateuserMw(), user.UpdateUser)...)
+		{
+			__fa := _user.Group("/2fa", __faMw()...)
+			__fa.POST("/disable", append(_disabletwofactorMw(), user.DisableTwoFactor)...)
+			__fa.POST("/enable", append(_enabletwofactorMw(), user.EnableTwoFactor)...)
+			__fa.POST("/recovery", append(_regeneraterecoverycodesMw(), user.RegenerateRecoveryCodes)...)
+			__fa.POST("/setup", append(_setuptwofactorMw(), user.SetupTwoFactor)...)
+		}
 		{
 			_admin := _user.Group("/admin", _adminMw()...)
 			_admin.DELETE("/delete", append(_admindeleteuserMw(), user.AdminDeleteUser)...)
 			_admin.PUT("/update", append(_adminupdateuserMw(), user.AdminUpdateUser)...)
+			{
+				__fa0 := _admin.Group("/2fa", __fa0Mw()...)
+				__fa0.GET("/policy", append(_listtwofactorpolicyMw(), user.ListTwoFactorPolicy)...)
+				__fa0.PUT("/policy", append(_settwofactorpolicyMw(), user.SetTwoFactorPolicy)...)
+			}
+		}
+		{
+			_session := _user.Group("/session", _sessionMw()...)
+			_session.GET("/list", append(_listsessionsMw(), user.ListSessions)...)
+			_session.DELETE("/revoke", append(_revokesessionMw(), user.RevokeSession)...)
+		}
+		{
+			_sso := _user.Group("/sso", _ssoMw()...)
+			{
+				_ldap := _sso.Group("/ldap", _ldapMw()...)
+				_ldap.POST("/link", append(_linkldapMw(), user.LinkLDAP)...)
+				_ldap.POST("/login", append(_ldaploginMw(), user.LdapLogin)...)
+			}
+			{
+				_oidc := _sso.Group("/oidc", _oidcMw()...)
+				_oidc.GET("/authorize", append(_oidcauthorizeMw(), user.OidcAuthorize)...)
+				_oidc.GET("/callback", append(_oidccallbackMw(), user.OidcCallback)...)
+				_oidc.GET("/link", append(_linkoidcMw(), user.LinkOIDC)...)
+			}
 		}
 	}
 }
@@ -0,0 +1,354 @@
+package router
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/cloudwego/hertz/pkg/protocol"
+	"github.com/cloudwego/hertz/pkg/route"
+	"github.com/golang-jwt/jwt/v4"
+
+	"github.com/2451965602/LMS/config"
+	"github.com/2451965602/LMS/pkg/constants"
+	"github.com/2451965602/LMS/pkg/errno"
+	"github.com/2451965602/LMS/pkg/totp"
+)
+
+// testClient 按接口的方式发送请求并检查响应
+type testClient struct {
+	engine *route.Engine
+}
+
+// do 发送请求，body 不为 nil 时以 JSON 发送，token 不为空时带上 Access Token
+func (tc *testClient) do(t *testing.T, method, path string, body interface{}, token string, headers ...ut.Header) *protocol.Response {
+	t.Helper()
+	var reqBody *ut.Body
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		reqBody = &ut.Body{Body: bytes.NewReader(b), Len: len(b)}
+		headers = append(headers, ut.Header{Key: "Content-Type", Value: "application/json"})
+	}
+	if token != "" {
+		headers = append(headers, ut.Header{Key: "Authorization", Value: token})
+	}
+	return ut.PerformRequest(tc.engine, method, path, reqBody, headers...).Result()
+}
+
+// call 发送请求，要求接口返回成功并且响应中没有密码，返回解析后的响应
+func (tc *testClient) call(t *testing.T, method, path string, body interface{}, token string, headers ...ut.Header) (*protocol.Response, map[string]interface{}) {
+	t.Helper()
+	resp := tc.do(t, method, path, body, token, headers...)
+	data := tc.checkNoPassword(t, method+" "+path, resp)
+	base, _ := data["base"].(map[string]interface{})
+	if code, _ := base["code"].(float64); int64(code) != errno.SuccessCode {
+		t.Fatalf("%s %s: %s", method, path, resp.Body())
+	}
+	return resp, data
+}
+
+// checkNoPassword 检查响应中没有 password 字段，也没有 bcrypt 哈希
+func (tc *testClient) checkNoPassword(t *testing.T, name string, resp *protocol.Response) map[string]interface{} {
+	t.Helper()
+	body := resp.Body()
+	if bytes.Contains(body, []byte("$2a$")) {
+		t.Errorf("%s: response contains a bcrypt hash: %s", name, body)
+	}
+	var data map[string]interface{}
+	if err := json.Unmarshal(body, &data); err != nil {
+		t.Fatalf("%s: unmarshal %s: %v", name, body, err)
+	}
+	if path, ok := findKey(data, "password", "$"); ok {
+		t.Errorf("%s: response has a password field at %s: %s", name, path, body)
+	}
+	return data
+}
+
+// findKey 在 JSON 中查找名称为 key 的字段，忽略大小写，返回其路径
+func findKey(v interface{}, key, path string) (string, bool) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, child := range v {
+			if strings.EqualFold(k, key) {
+				return path + "." + k, true
+			}
+			if p, ok := findKey(child, key, path+"."+k); ok {
+				return p, true
+			}
+		}
+	case []interface{}:
+		for i, child := range v {
+			if p, ok := findKey(child, key, fmt.Sprintf("%s[%d]", path, i)); ok {
+				return p, true
+			}
+		}
+	}
+	return "", false
+}
+
+// register 注册用户并登录，返回用户ID和 Access Token
+func (tc *testClient) register(t *testing.T, username, password, phone string) (int64, string) {
+	t.Helper()
+	_, data := tc.call(t, http.MethodPost, "/user/register", map[string]string{
+		"username": username, "password": password, "phone": phone,
+	}, "")
+	userId := int64(data["user_id"].(float64))
+	resp, _ := tc.call(t, http.MethodPost, "/user/login", map[string]string{"username": username, "password": password}, "")
+	return userId, string(resp.Header.Peek("Access-Token"))
+}
+
+// TestUserResponsesHidePassword 返回用户信息的接口都不能带出密码哈希
+func TestUserResponsesHidePassword(t *testing.T) {
+	tc := &testClient{engine: newTestEngine(t)}
+	idp := newTestOIDCProvider(t)
+	config.SSO.OIDC.Enabled = true
+	config.SSO.OIDC.Issuer = idp.URL
+	config.SSO.OIDC.ClientID = testOIDCClientID
+	config.SSO.OIDC.ClientSecret = "secret"
+
+	const password = "Passw0rd!"
+	userId, token := tc.register(t, "202400001", password, "13800138001")
+
+	t.Run("login", func(t *testing.T) {
+		tc.call(t, http.MethodPost, "/user/login", map[string]string{"username": "202400001", "password": password}, "")
+	})
+	t.Run("user info", func(t *testing.T) {
+		_, data := tc.call(t, http.MethodGet, fmt.Sprintf("/user/info?user_id=%d", userId), nil, token)
+		// 本人看到完整的手机号
+		if info, _ := data["data"].(map[string]interface{}); info == nil || info["phone"] != "+8613800138001" {
+			t.Errorf("owner got %v, want full profile with phone +8613800138001", data)
+		}
+	})
+	t.Run("user info of another user", func(t *testing.T) {
+		_, otherToken := tc.register(t, "202400004", password, "13800138004")
+		_, data := tc.call(t, http.MethodGet, fmt.Sprintf("/user/info?user_id=%d", userId), nil, otherToken)
+		if data["data"] != nil {
+			t.Errorf("another reader got the full profile: %v", data["data"])
+		}
+		profile, _ := data["profile"].(map[string]interface{})
+		if profile == nil {
+			t.Fatalf("another reader got no public profile: %v", data)
+		}
+		for key := range profile {
+			if key != "id" && key != "username" && key != "register_date" {
+				t.Errorf("public profile has field %s: %v", key, profile)
+			}
+		}
+	})
+	t.Run("user update", func(t *testing.T) {
+		tc.call(t, http.MethodPut, "/user/update", map[string]string{"email": "reader@example.com"}, token)
+	})
+	t.Run("me privacy", func(t *testing.T) {
+		tc.call(t, http.MethodPut, "/me/privacy", map[string]bool{"keep_history": true}, token)
+	})
+	t.Run("me summary", func(t *testing.T) {
+		tc.call(t, http.MethodGet, "/me/summary?page_size=10&page_num=1", nil, token)
+	})
+	t.Run("me export", func(t *testing.T) {
+		tc.call(t, http.MethodGet, "/me/export", nil, token)
+	})
+
+	t.Run("admin update", func(t *testing.T) {
+		// 默认管理员首次登录后必须修改密码
+		resp, _ := tc.call(t, http.MethodPost, "/user/login", map[string]string{"username": "admin", "password": "admin"}, "")
+		tc.call(t, http.MethodPut, "/user/password", map[string]string{"old_password": "admin", "new_password": password},
+			string(resp.Header.Peek("Access-Token")))
+		resp, _ = tc.call(t, http.MethodPost, "/user/login", map[string]string{"username": "admin", "password": password}, "")
+		adminToken := string(resp.Header.Peek("Access-Token"))
+
+		update := map[string]interface{}{"user_id": userId, "email": "admin-set@example.com"}
+		_, data := tc.call(t, http.MethodPut, "/user/admin/update", update, adminToken)
+		// 工作人员看到完整的手机号
+		if info, _ := data["data"].(map[string]interface{}); info == nil || info["phone"] != "+8613800138001" {
+			t.Errorf("admin got %v, want full profile with phone +8613800138001", data)
+		}
+		// 版本冲突时同样返回当前的数据
+		stale := ut.Header{Key: "If-Match", Value: `"0"`}
+		resp = tc.do(t, http.MethodPut, "/user/admin/update", update, adminToken, stale)
+		if resp.StatusCode() == http.StatusOK {
+			t.Fatalf("stale If-Match accepted: %s", resp.Body())
+		}
+		if data := tc.checkNoPassword(t, "PUT /user/admin/update conflict", resp); data["data"] == nil {
+			t.Errorf("conflict response has no current data: %s", resp.Body())
+		}
+	})
+
+	t.Run("two-factor login", func(t *testing.T) {
+		_, token := tc.register(t, "202400002", password, "13800138002")
+		_, data := tc.call(t, http.MethodPost, "/user/2fa/setup", nil, token)
+		secret := data["secret"].(string)
+		counter := totp.Counter(time.Now())
+		code, err := totp.Code(secret, counter)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tc.call(t, http.MethodPost, "/user/2fa/enable", map[string]string{"code": code}, token)
+
+		_, data = tc.call(t, http.MethodPost, "/user/login", map[string]string{"username": "202400002", "password": password}, "")
+		if data["data"] != nil {
+			t.Fatalf("login returned user before two-factor verification: %v", data)
+		}
+		// 同一时间步的验证码不能再次使用，使用允许偏差内的下一个时间步
+		if code, err = totp.Code(secret, counter+1); err != nil {
+			t.Fatal(err)
+		}
+		tc.call(t, http.MethodPost, "/user/login/2fa", map[string]string{"challenge_token": data["challenge_token"].(string), "code": code}, "")
+	})
+
+	t.Run("oidc callback", func(t *testing.T) {
+		resp, data := tc.call(t, http.MethodGet, "/user/sso/oidc/authorize", nil, "")
+		callback := idp.authorize(t, data["authorization_url"].(string), "oidc-subject-1", "202400003")
+		_, data = tc.call(t, http.MethodGet, callback, nil, "", nonceCookie(t, resp))
+		if data["data"] == nil {
+			t.Errorf("oidc login returned no user: %v", data)
+		}
+	})
+
+	t.Run("oidc link callback", func(t *testing.T) {
+		resp, data := tc.call(t, http.MethodGet, "/user/sso/oidc/link", nil, token)
+		callback := idp.authorize(t, data["authorization_url"].(string), "oidc-subject-2", "202400001")
+		_, data = tc.call(t, http.MethodGet, callback, nil, "", nonceCookie(t, resp))
+		if data["data"] == nil {
+			t.Errorf("oidc link returned no user: %v", data)
+		}
+	})
+
+	t.Run("oidc link callback from another browser", func(t *testing.T) {
+		// 攻击者发起关联，诱导其他用户的浏览器完成回调
+		_, attackerToken := tc.register(t, "202400005", password, "13800138005")
+		_, data := tc.call(t, http.MethodGet, "/user/sso/oidc/link", nil, attackerToken)
+		callback := idp.authorize(t, data["authorization_url"].(string), "oidc-subject-3", "202400006")
+		victim, _ := tc.call(t, http.MethodGet, "/user/sso/oidc/authorize", nil, "")
+		for name, headers := range map[string][]ut.Header{
+			"without cookie":      nil,
+			"with another cookie": {nonceCookie(t, victim)},
+		} {
+			resp := tc.do(t, http.MethodGet, callback, nil, "", headers...)
+			data := tc.checkNoPassword(t, name, resp)
+			base, _ := data["base"].(map[string]interface{})
+			if code, _ := base["code"].(float64); int64(code) != errno.AuthInvalidCode {
+				t.Errorf("callback %s: %s, want AuthInvalidCode", name, resp.Body())
+			}
+		}
+	})
+}
+
+// nonceCookie 返回把响应中设置的 OIDC nonce Cookie 带回服务端的请求头
+func nonceCookie(t *testing.T, resp *protocol.Response) ut.Header {
+	t.Helper()
+	var cookie protocol.Cookie
+	cookie.SetKey(constants.OIDCNonceCookie)
+	if !resp.Header.Cookie(&cookie) {
+		t.Fatalf("response sets no %s cookie", constants.OIDCNonceCookie)
+	}
+	if string(cookie.Path()) != constants.OIDCCookiePath || !cookie.HTTPOnly() || cookie.SameSite() != protocol.CookieSameSiteLaxMode {
+		t.Errorf("%s cookie: %s", constants.OIDCNonceCookie, cookie.String())
+	}
+	return ut.Header{Key: "Cookie", Value: constants.OIDCNonceCookie + "=" + string(cookie.Value())}
+}
+
+const testOIDCClientID = "lms"
+
+// testOIDCProvider 模拟 OIDC 身份提供方，授权码即登录页地址中的 nonce
+type testOIDCProvider struct {
+	*httptest.Server
+	key    *rsa.PrivateKey
+	claims map[string]jwt.MapClaims // nonce 对应的 ID Token 声明
+}
+
+func newTestOIDCProvider(t *testing.T) *testOIDCProvider {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := &testOIDCProvider{key: key, claims: make(map[string]jwt.MapClaims)}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]interface{}{
+			"issuer":                                p.URL,
+			"authorization_endpoint":                p.URL + "/authorize",
+			"token_endpoint":                        p.URL + "/token",
+			"jwks_uri":                              p.URL + "/jwks",
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]interface{}{"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "test",
+			"alg": "RS256",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := p.claims[r.FormValue("code")]
+		if !ok {
+			http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+			return
+		}
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+		token.Header["kid"] = "test"
+		idToken, err := token.SignedString(key)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, map[string]interface{}{
+			"access_token": "access",
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+			"id_token":     idToken,
+		})
+	})
+	p.Server = httptest.NewServer(mux)
+	t.Cleanup(p.Close)
+	return p
+}
+
+// authorize 模拟用户在登录页完成登录，返回身份提供方跳转回的回调接口路径及参数
+// 回调路径取自登录页地址中的 redirect_uri，与配置中的回调地址一致。
+func (p *testOIDCProvider) authorize(t *testing.T, authorizationURL, subject, username string) string {
+	t.Helper()
+	u, err := url.Parse(authorizationURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	nonce := u.Query().Get("nonce")
+	now := time.Now()
+	p.claims[nonce] = jwt.MapClaims{
+		"iss":                p.URL,
+		"aud":                testOIDCClientID,
+		"sub":                subject,
+		"iat":                now.Unix(),
+		"exp":                now.Add(time.Hour).Unix(),
+		"nonce":              nonce,
+		"preferred_username": username,
+	}
+	redirect, err := url.Parse(u.Query().Get("redirect_uri"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return redirect.Path + "?" + url.Values{"code": {nonce}, "state": {u.Query().Get("state")}}.Encode()
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
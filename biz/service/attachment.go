@@ -0,0 +1,342 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+
+	"github.com/2451965602/LMS/biz/dal/db"
+	"github.com/2451965602/LMS/biz/model/attachment"
+	"github.com/2451965602/LMS/config"
+	contextLogin "github.com/2451965602/LMS/pkg/base/context"
+	"github.com/2451965602/LMS/pkg/blob"
+	"github.com/2451965602/LMS/pkg/constants"
+	"github.com/2451965602/LMS/pkg/errno"
+	"github.com/2451965602/LMS/pkg/thumbnail"
+	"github.com/2451965602/LMS/pkg/tracing"
+	"github.com/2451965602/LMS/pkg/validate"
+)
+
+// AttachmentService 用于管理书籍类型的封面和目录等附件，附件内容保存在可配置的附件存储中。
+type AttachmentService struct {
+	ctx context.Context     // 上下文，用于传递请求相关的元数据
+	c   *app.RequestContext // Hertz框架的请求上下文，用于处理HTTP请求
+}
+
+// NewAttachmentService 创建一个新的AttachmentService实例，初始化上下文和请求上下文。
+func NewAttachmentService(ctx context.Context, c *app.RequestContext) *AttachmentService {
+	return &AttachmentService{
+		ctx: ctx,
+		c:   c,
+	}
+}
+
+// attachmentExtensions 各内容类型的附件保存时使用的扩展名
+var attachmentExtensions = map[string]string{
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"image/gif":       ".gif",
+	"application/pdf": ".pdf",
+}
+
+// UploadAttachment 上传封面或附件，仅馆员和管理员可以操作，文件通过 multipart 表单的 file 字段上传
+// 参数：
+//   - ctx: 上下文
+//   - req: 上传请求，包含ISBN和附件类型（cover 或 document）
+//
+// 返回值：
+//   - *db.Attachment: 上传成功的附件记录
+//   - error: 错误信息，文件超过大小上限时返回 errno.ServiceAttachmentTooLarge，类型不允许时返回 errno.ServiceAttachmentTypeNotAllowed
+func (s *AttachmentService) UploadAttachment(ctx context.Context, req attachment.UploadAttachmentRequest) (*db.Attachment, error) {
+	ctx, span := tracing.Start(ctx, "AttachmentService.UploadAttachment")
+	defer span.End()
+
+	if err := s.checkLibrarian(ctx); err != nil {
+		return nil, err
+	}
+	// 检查ISBN格式是否正确，ISBN-10 统一为 ISBN-13
+	if err := normalizeISBN(&req.ISBN); err != nil {
+		return nil, err
+	}
+	if err := attachmentKindCheck(req.Kind); err != nil {
+		return nil, err
+	}
+
+	// 按文件内容检测类型，不信任文件名和客户端声明的类型
+	filename, data, err := s.readUpload()
+	if err != nil {
+		return nil, err
+	}
+	contentType, _, _ := mime.ParseMediaType(http.DetectContentType(data))
+	if !slices.Contains(constants.AttachmentContentTypes[req.Kind], contentType) {
+		return nil, errno.Errorf(errno.ServiceAttachmentTypeNotAllowed, "%s attachment cannot be %s", req.Kind, contentType)
+	}
+
+	var thumb []byte
+	if strings.HasPrefix(contentType, "image/") {
+		thumb, err = thumbnail.Make(data, config.Attachment.ThumbnailSize, constants.AttachmentMaxPixels)
+		if errors.Is(err, thumbnail.ErrTooLarge) {
+			return nil, errno.Errorf(errno.ServiceAttachmentTooLarge, "image exceeds %d pixels", constants.AttachmentMaxPixels)
+		}
+		if err != nil {
+			return nil, errno.Errorf(errno.ServiceAttachmentTypeNotAllowed, "invalid image: %v", err)
+		}
+	}
+
+	store, err := attachmentStore()
+	if err != nil {
+		return nil, errno.Errorf(errno.InternalServiceErrorCode, "create attachment store failed: %v", err)
+	}
+	suffix := make([]byte, 16)
+	if _, err = rand.Read(suffix); err != nil {
+		return nil, errno.Errorf(errno.InternalServiceErrorCode, "generate attachment key failed: %v", err)
+	}
+	att := &db.Attachment{
+		ISBN:        req.ISBN,
+		Kind:        req.Kind,
+		Filename:    filename,
+		ContentType: contentType,
+		Size:        int64(len(data)),
+		StorageKey:  constants.AttachmentKeyPrefix + req.ISBN + "/" + hex.EncodeToString(suffix) + attachmentExtensions[contentType],
+		CreatedAt:   time.Now(),
+	}
+	if uploader, err := contextLogin.GetLoginData(ctx); err == nil {
+		att.UploadedBy = &uploader
+	}
+
+	// 先写入存储再插入记录，插入失败时删除已写入的内容
+	if err = store.Put(ctx, att.StorageKey, data, contentType); err != nil {
+		return nil, errno.Errorf(errno.InternalServiceErrorCode, "save attachment failed: %v", err)
+	}
+	if thumb != nil {
+		thumbKey := att.StorageKey + constants.AttachmentThumbnailExt
+		if err = store.Put(ctx, thumbKey, thumb, "image/jpeg"); err != nil {
+			removeAttachmentBlobs(ctx, store, []*db.Attachment{att})
+			return nil, errno.Errorf(errno.InternalServiceErrorCode, "save thumbnail failed: %v", err)
+		}
+		att.ThumbnailKey = &thumbKey
+	}
+
+	replaced, err := db.AddAttachment(ctx, att)
+	if err != nil {
+		removeAttachmentBlobs(ctx, store, []*db.Attachment{att})
+		return nil, err
+	}
+	removeAttachmentBlobs(ctx, store, replaced)
+	return att, nil
+}
+
+// DeleteAttachment 删除附件，仅馆员和管理员可以操作
+// 参数：
+//   - ctx: 上下文
+//   - req: 删除请求，包含附件ID
+//
+// 返回值：
+//   - error: 错误信息，附件不存在时返回 errno.ServiceAttachmentNotExist
+func (s *AttachmentService) DeleteAttachment(ctx context.Context, req attachment.DeleteAttachmentRequest) error {
+	ctx, span := tracing.Start(ctx, "AttachmentService.DeleteAttachment")
+	defer span.End()
+
+	if err := s.checkLibrarian(ctx); err != nil {
+		return err
+	}
+	att, err := db.DeleteAttachment(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	RemoveAttachmentContent(ctx, []*db.Attachment{att})
+	return nil
+}
+
+// GetAttachments 获取书籍类型的封面和附件
+// 参数：
+//   - ctx: 上下文
+//   - req: 查询请求，包含ISBN，可以按附件类型过滤
+//
+// 返回值：
+//   - []*db.Attachment: 附件列表，封面在前
+//   - error: 错误信息，如果查询失败会返回错误
+func (s *AttachmentService) GetAttachments(ctx context.Context, req attachment.GetAttachmentRequest) ([]*db.Attachment, error) {
+	ctx, span := tracing.Start(ctx, "AttachmentService.GetAttachments")
+	defer span.End()
+
+	// 检查ISBN格式是否正确，ISBN-10 统一为 ISBN-13
+	if err := normalizeISBN(&req.ISBN); err != nil {
+		return nil, err
+	}
+	if req.Kind != nil && *req.Kind != "" {
+		if err := attachmentKindCheck(*req.Kind); err != nil {
+			return nil, err
+		}
+	}
+	return db.GetAttachmentsByISBN(ctx, req.ISBN, req.Kind)
+}
+
+// DownloadAttachment 读取附件或其缩略图的内容
+// 参数：
+//   - ctx: 上下文
+//   - req: 下载请求，包含附件ID，thumbnail 为 true 时读取缩略图
+//
+// 返回值：
+//   - *db.Attachment: 附件记录
+//   - []byte: 附件或缩略图的内容
+//   - string: 内容类型
+//   - error: 错误信息，附件或缩略图不存在时返回 errno.ServiceAttachmentNotExist
+func (s *AttachmentService) DownloadAttachment(ctx context.Context, req attachment.DownloadAttachmentRequest) (*db.Attachment, []byte, string, error) {
+	ctx, span := tracing.Start(ctx, "AttachmentService.DownloadAttachment")
+	defer span.End()
+
+	att, err := db.GetAttachment(ctx, req.ID)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	key, contentType := att.StorageKey, att.ContentType
+	if req.Thumbnail != nil && *req.Thumbnail {
+		if att.ThumbnailKey == nil {
+			return nil, nil, "", errno.Errorf(errno.ServiceAttachmentNotExist, "attachment (id: %d) has no thumbnail", att.ID)
+		}
+		key, contentType = *att.ThumbnailKey, "image/jpeg"
+	}
+
+	store, err := attachmentStore()
+	if err != nil {
+		return nil, nil, "", errno.Errorf(errno.InternalServiceErrorCode, "create attachment store failed: %v", err)
+	}
+	body, err := store.Get(ctx, key)
+	if err != nil {
+		if errors.Is(err, blob.ErrNotFound) {
+			return nil, nil, "", errno.Errorf(errno.ServiceAttachmentNotExist, "content of attachment (id: %d) not found in store", att.ID)
+		}
+		return nil, nil, "", errno.Errorf(errno.InternalServiceErrorCode, "read attachment (id: %d) failed: %v", att.ID, err)
+	}
+	defer body.Close()
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, nil, "", errno.Errorf(errno.InternalServiceErrorCode, "read attachment (id: %d) failed: %v", att.ID, err)
+	}
+	return att, data, contentType, nil
+}
+
+// readUpload 读取上传的文件，返回清理后的文件名和文件内容
+func (s *AttachmentService) readUpload() (string, []byte, error) {
+	maxSize := config.Attachment.MaxSize
+	file, err := s.c.FormFile(constants.AttachmentFormField)
+	if err != nil {
+		return "", nil, validate.NewFieldError(constants.AttachmentFormField, validate.RuleRequired, errno.ParamMissingErrorCode, nil,
+			"%s is required", constants.AttachmentFormField)
+	}
+	if file.Size > maxSize {
+		return "", nil, errno.Errorf(errno.ServiceAttachmentTooLarge, "attachment exceeds %d bytes", maxSize)
+	}
+
+	f, err := file.Open()
+	if err != nil {
+		return "", nil, errno.Errorf(errno.InternalServiceErrorCode, "open uploaded file failed: %v", err)
+	}
+	defer f.Close()
+	data, err := io.ReadAll(io.LimitReader(f, maxSize+1))
+	if err != nil {
+		return "", nil, errno.Errorf(errno.InternalServiceErrorCode, "read uploaded file failed: %v", err)
+	}
+	if int64(len(data)) > maxSize {
+		return "", nil, errno.Errorf(errno.ServiceAttachmentTooLarge, "attachment exceeds %d bytes", maxSize)
+	}
+	if len(data) == 0 {
+		return "", nil, validate.NewFieldError(constants.AttachmentFormField, validate.RuleRequired, errno.ParamMissingErrorCode, nil,
+			"%s is empty", constants.AttachmentFormField)
+	}
+
+	// 只保留文件名本身，去掉客户端可能带上的路径，超长时截断
+	filename := filepath.Base(strings.ReplaceAll(file.Filename, "\\", "/"))
+	if filename == "." || filename == "/" {
+		filename = ""
+	}
+	return truncateRunes(filename, constants.AttachmentFilenameMax), data, nil
+}
+
+// checkLibrarian 检查当前登录用户是否为馆员或管理员
+func (s *AttachmentService) checkLibrarian(ctx context.Context) error {
+	currentUserID, err := contextLogin.GetLoginData(ctx)
+	if err != nil {
+		return err
+	}
+	ok, err := db.IsPermission(ctx, currentUserID, "librarian")
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errno.Errorf(errno.ServicePermissionDenied, "permission denied")
+	}
+	return nil
+}
+
+// attachmentKindCheck 检查附件类型
+func attachmentKindCheck(kind string) error {
+	if _, ok := constants.AttachmentContentTypes[kind]; ok {
+		return nil
+	}
+	allowed := []string{constants.AttachmentKindCover, constants.AttachmentKindDocument}
+	return validate.NewFieldError("kind", validate.RuleEnum, errno.ParamVerifyErrorCode,
+		map[string]string{"allowed": strings.Join(allowed, ", ")}, "kind must be one of %s", strings.Join(allowed, ", "))
+}
+
+// attachmentStore 根据配置创建附件存储，S3 访问密钥优先从环境变量读取
+func attachmentStore() (blob.Store, error) {
+	cfg := config.Attachment
+	secret := cfg.S3.SecretAccessKey
+	if cfg.S3.SecretAccessKeyEnv != "" && os.Getenv(cfg.S3.SecretAccessKeyEnv) != "" {
+		secret = os.Getenv(cfg.S3.SecretAccessKeyEnv)
+	}
+	return blob.New(cfg.Store, blob.Options{
+		LocalDir:        cfg.LocalDir,
+		Endpoint:        cfg.S3.Endpoint,
+		Region:          cfg.S3.Region,
+		Bucket:          cfg.S3.Bucket,
+		AccessKeyID:     cfg.S3.AccessKeyID,
+		SecretAccessKey: secret,
+		PathStyle:       cfg.S3.PathStyle,
+		Timeout:         time.Duration(cfg.S3.Timeout) * time.Second,
+	})
+}
+
+// RemoveAttachmentContent 删除已删除的附件记录在存储中的内容，供删除书籍类型和合并书籍类型时使用
+// 记录已经删除，存储中的内容不再被引用，创建附件存储失败时只记录日志。
+func RemoveAttachmentContent(ctx context.Context, atts []*db.Attachment) {
+	if len(atts) == 0 {
+		return
+	}
+	store, err := attachmentStore()
+	if err != nil {
+		hlog.CtxErrorf(ctx, "RemoveAttachmentContent: create attachment store failed: %v", err)
+		return
+	}
+	removeAttachmentBlobs(ctx, store, atts)
+}
+
+// removeAttachmentBlobs 删除附件及其缩略图在存储中的内容
+// 记录已经删除或从未插入，删除内容失败只会留下不再被引用的对象，因此只记录日志，不影响请求结果。
+func removeAttachmentBlobs(ctx context.Context, store blob.Store, atts []*db.Attachment) {
+	for _, att := range atts {
+		keys := []string{att.StorageKey}
+		if att.ThumbnailKey != nil {
+			keys = append(keys, *att.ThumbnailKey)
+		}
+		for _, key := range keys {
+			if err := store.Delete(ctx, key); err != nil {
+				hlog.CtxWarnf(ctx, "removeAttachmentBlobs: delete %s failed: %v", key, err)
+			}
+		}
+	}
+}
@@ -2,13 +2,23 @@ package service
 
 import (
 	"context"
-	"github.com/2451965602/LMS/pkg/errno"
+	"fmt"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/hlog"
 
 	"github.com/2451965602/LMS/biz/dal/db"
 	"github.com/2451965602/LMS/biz/model/book"
+	contextLogin "github.com/2451965602/LMS/pkg/base/context"
+	"github.com/2451965602/LMS/pkg/constants"
+	"github.com/2451965602/LMS/pkg/errno"
+	"github.com/2451965602/LMS/pkg/etag"
+	"github.com/2451965602/LMS/pkg/pagination"
+	"github.com/2451965602/LMS/pkg/tracing"
+	"github.com/2451965602/LMS/pkg/validate"
 )
 
 // BookService 用于管理图书相关的业务逻辑，封装了添加、更新、删除和查询图书的操作。
@@ -34,10 +44,18 @@ func NewBookService(ctx context.Context, c *app.RequestContext) *BookService {
 //   - int64: 添加成功的图书ID
 //   - error: 错误信息，如果添加失败会返回错误
 func (s *BookService) AddBook(ctx context.Context, req book.AddBookRequest) (int64, error) {
-	// 检查ISBN格式是否正确
-	req.ISBN = strings.Replace(req.ISBN, "-", "", -1)
-	if !IsValidISBN(req.ISBN) {
-		return 0, errno.Errorf(errno.ServiceInvalidISBN, "invalid ISBN format") // 如果ISBN格式不正确，返回错误
+	ctx, span := tracing.Start(ctx, "BookService.AddBook")
+	defer span.End()
+
+	// 检查ISBN格式是否正确，ISBN-10 统一为 ISBN-13
+	if err := normalizeISBN(&req.ISBN); err != nil {
+		return 0, err
+	}
+	// 新入库的副本只能在架或修补中，其余状态只能由状态变更或借还产生
+	if !slices.Contains(bookInitialStatuses, req.Status) {
+		allowed := strings.Join(bookInitialStatuses, ", ")
+		return 0, validate.NewFieldError("status", validate.RuleEnum, errno.ServiceIllegalStatusTransition,
+			map[string]string{"allowed": allowed}, "initial status must be one of %s", allowed)
 	}
 	bookId, err := db.AddBook(ctx, req) // 调用数据库操作函数添加图书
 	if err != nil {
@@ -48,19 +66,27 @@ func (s *BookService) AddBook(ctx context.Context, req book.AddBookRequest) (int
 }
 
 // UpdateBook 更新图书信息
+// 副本状态按状态变更规则变更，并同步调整书籍类型的副本数；借出和归还只能通过借还流程完成。
 // 参数：
 //   - ctx: 上下文
 //   - req: 更新图书请求，包含图书信息
 //
 // 返回值：
 //   - *db.Book: 更新成功的图书信息
-//   - error: 错误信息，如果更新失败会返回错误
+//   - error: 错误信息，如果更新失败会返回错误，不允许的状态变更返回 errno.ServiceIllegalStatusTransition，
+//     If-Match 中的版本已过期时返回 errno.ServiceVersionConflict，此时同时返回当前的图书信息
 func (s *BookService) UpdateBook(ctx context.Context, req book.UpdateBookRequest) (*db.Book, error) {
+	ctx, span := tracing.Start(ctx, "BookService.UpdateBook")
+	defer span.End()
 
-	bk, err := db.UpdateBook(ctx, req) // 调用数据库操作函数更新图书
+	version, err := etag.ParseIfMatch(string(s.c.GetHeader(constants.IfMatchHeader)))
 	if err != nil {
 		return nil, err
 	}
+	bk, err := db.UpdateBook(ctx, req, version) // 调用数据库操作函数更新图书
+	if err != nil {
+		return bk, err
+	}
 	return bk, nil
 }
 
@@ -72,6 +98,9 @@ func (s *BookService) UpdateBook(ctx context.Context, req book.UpdateBookRequest
 // 返回值：
 //   - error: 错误信息，如果删除失败会返回错误
 func (s *BookService) DeleteBook(ctx context.Context, req book.DeleteBookRequest) error {
+	ctx, span := tracing.Start(ctx, "BookService.DeleteBook")
+	defer span.End()
+
 	err := db.DeleteBook(ctx, req.BookID) // 调用数据库操作函数删除图书
 	if err != nil {
 		return err
@@ -82,26 +111,95 @@ func (s *BookService) DeleteBook(ctx context.Context, req book.DeleteBookRequest
 // SearchBook 搜索图书
 // 参数：
 //   - ctx: 上下文
-//   - req: 搜索图书请求，包含搜索条件和分页信息
+//   - req: 搜索图书请求，包含过滤条件和分页信息
 //
 // 返回值：
-//   - []*db.Book: 搜索结果的图书列表
-//   - int64: 总记录数
+//   - []*db.BookDetail: 搜索结果的图书列表，请求关联书籍类型时包含书名和作者
+//   - *pagination.Result: 下一页的游标，以及请求需要时的总记录数
 //   - error: 错误信息，如果搜索失败会返回错误
-func (s *BookService) SearchBook(ctx context.Context, req book.GetBookRequest) ([]*db.Book, int64, error) {
+func (s *BookService) SearchBook(ctx context.Context, req book.GetBookRequest) ([]*db.BookDetail, *pagination.Result, error) {
+	ctx, span := tracing.Start(ctx, "BookService.SearchBook")
+	defer span.End()
 
 	if req.ISBN != nil {
-		*req.ISBN = strings.Replace(*req.ISBN, "-", "", -1)
-		if !IsValidISBN(*req.ISBN) { // 检查ISBN格式是否正确
-			return nil, 0, errno.Errorf(errno.ServiceInvalidISBN, "invalid ISBN format") // 如果ISBN格式不正确，返回错误
+		// 检查ISBN格式是否正确，ISBN-10 统一为 ISBN-13
+		if err := normalizeISBN(req.ISBN); err != nil {
+			return nil, nil, err
 		}
 	}
-	books, total, err := db.SearchBook(ctx, req) // 调用数据库操作函数搜索图书
+	statuses, err := SearchBookCheck(&req)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Status = statuses
+
+	page, err := pagination.Parse(db.BookPageSpec, pagination.Request{
+		Cursor: req.Cursor, PageSize: req.PageSize, PageNum: req.PageNum, Sort: req.Sort, WithTotal: req.WithTotal,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	books, result, err := db.SearchBook(ctx, req, page) // 调用数据库操作函数搜索图书
 	if err != nil {
-		return nil, 0, err
+		return nil, nil, err
 	}
 
-	return books, total, nil
+	return books, result, nil
+}
+
+// WithdrawBooks 批量剔除副本，仅馆员和管理员可以操作
+// 参数：
+//   - ctx: 上下文
+//   - req: 剔除请求，包含副本ID列表和剔除原因
+//
+// 返回值：
+//   - []int64: 已剔除的副本ID
+//   - []int64: 跳过的副本ID，包括不存在、借出中和已剔除的副本
+//   - error: 错误信息，如果剔除失败会返回错误
+func (s *BookService) WithdrawBooks(ctx context.Context, req book.WithdrawBookRequest) ([]int64, []int64, error) {
+	ctx, span := tracing.Start(ctx, "BookService.WithdrawBooks")
+	defer span.End()
+
+	currentUserID, err := contextLogin.GetLoginData(ctx) // 获取当前登录用户ID
+	if err != nil {
+		return nil, nil, err
+	}
+	ok, err := db.IsPermission(ctx, currentUserID, "librarian") // 检查当前用户是否有馆员权限
+	if err != nil {
+		return nil, nil, err
+	}
+	if !ok {
+		return nil, nil, errno.Errorf(errno.ServicePermissionDenied, "permission denied")
+	}
+	if len(req.BookIds) == 0 || len(req.BookIds) > constants.WithdrawMaxBatch {
+		return nil, nil, validate.NewFieldError("book_ids", validate.RuleRange, errno.ParamVerifyErrorCode,
+			map[string]string{"min": "1", "max": strconv.Itoa(constants.WithdrawMaxBatch)},
+			"book_ids must contain 1 to %d ids", constants.WithdrawMaxBatch)
+	}
+	seen := make(map[int64]bool, len(req.BookIds))
+	for _, id := range req.BookIds {
+		if seen[id] {
+			return nil, nil, validate.NewFieldError("book_ids", validate.RuleUnique, errno.ParamVerifyErrorCode,
+				map[string]string{"value": strconv.FormatInt(id, 10)}, "book_ids contains duplicate id %d", id)
+		}
+		seen[id] = true
+	}
+
+	withdrawn, skipped, err := db.WithdrawBooks(ctx, req.BookIds) // 调用数据库操作函数剔除副本
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(withdrawn) > 0 {
+		detail := fmt.Sprintf("book ids: %v", withdrawn)
+		if req.Reason != nil && *req.Reason != "" {
+			detail += ", reason: " + *req.Reason
+		}
+		if err = db.CreateAuditLog(ctx, constants.AuditActionBookWithdraw, strconv.FormatInt(currentUserID, 10), s.c.ClientIP(), detail); err != nil {
+			hlog.CtxErrorf(ctx, "BookService.WithdrawBooks: write audit log failed: %v", err) // 剔除已完成，审计日志写入失败不影响结果
+		}
+	}
+	return withdrawn, skipped, nil
 }
 
 // GetBookById 根据图书ID获取图书信息
@@ -113,6 +211,9 @@ func (s *BookService) SearchBook(ctx context.Context, req book.GetBookRequest) (
 //   - *db.Book: 获取的图书信息
 //   - error: 错误信息，如果获取失败会返回错误
 func (s *BookService) GetBookById(ctx context.Context, bookId int64) (*db.Book, error) {
+	ctx, span := tracing.Start(ctx, "BookService.GetBookById")
+	defer span.End()
+
 	bk, err := db.GetBookById(ctx, bookId) // 调用数据库操作函数根据ID获取图书信息
 	if err != nil {
 		return nil, err
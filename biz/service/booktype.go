@@ -2,13 +2,19 @@ package service
 
 import (
 	"context"
+	"slices"
 	"strings"
 
 	"github.com/cloudwego/hertz/pkg/app"
 
 	"github.com/2451965602/LMS/biz/dal/db"
 	"github.com/2451965602/LMS/biz/model/booktype"
+	"github.com/2451965602/LMS/pkg/constants"
 	"github.com/2451965602/LMS/pkg/errno"
+	"github.com/2451965602/LMS/pkg/etag"
+	"github.com/2451965602/LMS/pkg/pagination"
+	"github.com/2451965602/LMS/pkg/tracing"
+	"github.com/2451965602/LMS/pkg/validate"
 )
 
 // BookTypeService 用于管理图书类型相关的业务逻辑，封装了添加、更新、删除和查询图书类型的操作。
@@ -34,15 +40,20 @@ func NewBookTypeService(ctx context.Context, c *app.RequestContext) *BookTypeSer
 //   - *db.BookType: 添加成功的图书类型信息
 //   - error: 错误信息，如果添加失败会返回错误
 func (s *BookTypeService) AddBookType(ctx context.Context, req booktype.AddBookTypeRequest) (*db.BookType, error) {
+	ctx, span := tracing.Start(ctx, "BookTypeService.AddBookType")
+	defer span.End()
 
-	// 检查ISBN格式是否正确
-	req.ISBN = strings.Replace(req.ISBN, "-", "", -1)
-	if !IsValidISBN(req.ISBN) {
-		return nil, errno.Errorf(errno.ServiceInvalidISBN, "invalid ISBN format") // 如果ISBN格式不正确，返回错误
+	// 检查ISBN格式是否正确，ISBN-10 统一为 ISBN-13
+	if err := normalizeISBN(&req.ISBN); err != nil {
+		return nil, err
 	}
 
-	if !CheckAuthor(req.Author) {
-		return nil, errno.Errorf(errno.ServiceInvalidAuthor, "invalid author format") // 如果作者格式不正确，返回错误
+	if req.Author != nil {
+		author, err := BookTypeAuthorCheck(*req.Author) // 检查著者说明的长度
+		if err != nil {
+			return nil, err
+		}
+		req.Author = &author
 	}
 
 	exit, err := db.IsBookTypeExist(ctx, req.ISBN) // 检查图书类型是否已存在
@@ -53,7 +64,12 @@ func (s *BookTypeService) AddBookType(ctx context.Context, req booktype.AddBookT
 		return nil, errno.Errorf(errno.ServiceBookTypeExist, "book type already exist") // 如果图书类型已存在，返回错误
 	}
 
-	bt, err := db.AddBookType(ctx, req) // 调用数据库操作函数添加图书类型
+	links, err := BookTypeLinksCheck(req.Contributors, req.SubjectIds, req.Series) // 校验关联的责任者、主题和丛书
+	if err != nil {
+		return nil, err
+	}
+
+	bt, err := db.AddBookType(ctx, req, links) // 调用数据库操作函数添加图书类型
 	if err != nil {
 		return nil, err
 	}
@@ -67,23 +83,34 @@ func (s *BookTypeService) AddBookType(ctx context.Context, req booktype.AddBookT
 //
 // 返回值：
 //   - *db.BookType: 更新成功的图书类型信息
-//   - error: 错误信息，如果更新失败会返回错误
+//   - error: 错误信息，如果更新失败会返回错误，If-Match 中的版本已过期时返回 errno.ServiceVersionConflict，此时同时返回当前的图书类型信息
 func (s *BookTypeService) UpdateBookType(ctx context.Context, req booktype.UpdateBookTypeRequest) (*db.BookType, error) {
+	ctx, span := tracing.Start(ctx, "BookTypeService.UpdateBookType")
+	defer span.End()
 
-	// 检查ISBN格式是否正确
-	req.ISBN = strings.Replace(req.ISBN, "-", "", -1)
-	if !IsValidISBN(req.ISBN) {
-		return nil, errno.Errorf(errno.ServiceInvalidISBN, "invalid ISBN format") // 如果ISBN格式不正确，返回错误
+	// 检查ISBN格式是否正确，ISBN-10 统一为 ISBN-13
+	if err := normalizeISBN(&req.ISBN); err != nil {
+		return nil, err
 	}
 	if req.Author != nil {
-		if !CheckAuthor(*req.Author) {
-			return nil, errno.Errorf(errno.ServiceInvalidAuthor, "invalid author format") // 如果作者格式不正确，返回错误
+		author, err := BookTypeAuthorCheck(*req.Author) // 检查著者说明的长度
+		if err != nil {
+			return nil, err
 		}
+		req.Author = &author
+	}
+	links, err := BookTypeLinksCheck(req.Contributors, req.SubjectIds, req.Series) // 校验关联的责任者、主题和丛书
+	if err != nil {
+		return nil, err
 	}
-	bt, err := db.UpdateBookType(ctx, req) // 调用数据库操作函数更新图书类型
+	version, err := etag.ParseIfMatch(string(s.c.GetHeader(constants.IfMatchHeader)))
 	if err != nil {
 		return nil, err
 	}
+	bt, err := db.UpdateBookType(ctx, req, links, version) // 调用数据库操作函数更新图书类型
+	if err != nil {
+		return bt, err
+	}
 	return bt, nil
 }
 
@@ -95,10 +122,12 @@ func (s *BookTypeService) UpdateBookType(ctx context.Context, req booktype.Updat
 // 返回值：
 //   - error: 错误信息，如果删除失败会返回错误
 func (s *BookTypeService) DeleteBookType(ctx context.Context, req booktype.DeleteBookTypeRequest) error {
-	// 检查ISBN格式是否正确
-	req.ISBN = strings.Replace(req.ISBN, "-", "", -1)
-	if !IsValidISBN(req.ISBN) {
-		return errno.Errorf(errno.ServiceInvalidISBN, "invalid ISBN format") // 如果ISBN格式不正确，返回错误
+	ctx, span := tracing.Start(ctx, "BookTypeService.DeleteBookType")
+	defer span.End()
+
+	// 检查ISBN格式是否正确，ISBN-10 统一为 ISBN-13
+	if err := normalizeISBN(&req.ISBN); err != nil {
+		return err
 	}
 
 	exist, err := db.IsBookTypeExist(ctx, req.ISBN) // 检查图书类型是否存在
@@ -117,38 +146,55 @@ func (s *BookTypeService) DeleteBookType(ctx context.Context, req booktype.Delet
 		return errno.Errorf(errno.ServiceBookTypeInUse, "book type cannot be deleted, existing books of this type still exist") // 如果有书籍使用该类型，返回错误
 	}
 
+	atts, err := db.GetAttachmentsByISBN(ctx, req.ISBN, nil) // 附件记录随图书类型一起删除，先取出以便删除存储中的内容
+	if err != nil {
+		return err
+	}
 	err = db.DeleteBookType(ctx, req.ISBN) // 调用数据库操作函数删除图书类型
 	if err != nil {
 		return err
 	}
+	RemoveAttachmentContent(ctx, atts)
 	return nil
 }
 
-// SearchBookType 搜索图书类型
+// SearchBookType 搜索图书类型，可以按责任者、主题或丛书浏览
 // 参数：
 //   - ctx: 上下文
 //   - req: 搜索图书类型请求，包含搜索条件和分页信息
 //
 // 返回值：
-//   - []*db.BookType: 搜索结果的图书类型列表
-//   - int64: 总记录数
+//   - []*db.BookType: 搜索结果的图书类型列表，包含责任者、主题和丛书
+//   - *pagination.Result: 下一页的游标，以及请求需要时的总记录数
 //   - error: 错误信息，如果搜索失败会返回错误
-func (s *BookTypeService) SearchBookType(ctx context.Context, req booktype.GetBookTypeRequest) ([]*db.BookType, int64, error) {
+func (s *BookTypeService) SearchBookType(ctx context.Context, req booktype.GetBookTypeRequest) ([]*db.BookType, *pagination.Result, error) {
+	ctx, span := tracing.Start(ctx, "BookTypeService.SearchBookType")
+	defer span.End()
 
 	if req.ISBN != nil {
-		// 检查ISBN格式是否正确
-		*req.ISBN = strings.Replace(*req.ISBN, "-", "", -1)
-		if !IsValidISBN(*req.ISBN) {
-			return nil, 0, errno.Errorf(errno.ServiceInvalidISBN, "invalid ISBN format") // 如果ISBN格式不正确，返回错误
+		// 检查ISBN格式是否正确，ISBN-10 统一为 ISBN-13
+		if err := normalizeISBN(req.ISBN); err != nil {
+			return nil, nil, err
 		}
 	}
+	if req.Role != nil && *req.Role != "" && !slices.Contains(contributorRoles, *req.Role) {
+		allowed := strings.Join(contributorRoles, ", ")
+		return nil, nil, validate.NewFieldError("role", validate.RuleEnum, errno.ParamVerifyErrorCode,
+			map[string]string{"allowed": allowed}, "role must be one of %s", allowed)
+	}
+	page, err := pagination.Parse(db.BookTypePageSpec, pagination.Request{
+		Cursor: req.Cursor, PageSize: req.PageSize, PageNum: req.PageNum, Sort: req.Sort, WithTotal: req.WithTotal,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
 
-	bookTypes, total, err := db.SearchBookType(ctx, req.Title, req.Author, req.ISBN, req.Category, req.PageNum, req.PageSize) // 调用数据库操作函数搜索图书类型
+	bookTypes, result, err := db.SearchBookType(ctx, req, page) // 调用数据库操作函数搜索图书类型
 	if err != nil {
-		return nil, 0, err
+		return nil, nil, err
 	}
 
-	return bookTypes, total, nil
+	return bookTypes, result, nil
 }
 
 // GetBookTypeByISBN 根据ISBN获取图书类型
@@ -160,10 +206,12 @@ func (s *BookTypeService) SearchBookType(ctx context.Context, req booktype.GetBo
 //   - *db.BookType: 获取的图书类型信息
 //   - error: 错误信息，如果获取失败会返回错误
 func (s *BookTypeService) GetBookTypeByISBN(ctx context.Context, isbn string) (*db.BookType, error) {
-	// 检查ISBN格式是否正确
-	isbn = strings.Replace(isbn, "-", "", -1)
-	if !IsValidISBN(isbn) {
-		return nil, errno.Errorf(errno.ServiceInvalidISBN, "invalid ISBN format") // 如果ISBN格式不正确，返回错误
+	ctx, span := tracing.Start(ctx, "BookTypeService.GetBookTypeByISBN")
+	defer span.End()
+
+	// 检查ISBN格式是否正确，ISBN-10 统一为 ISBN-13
+	if err := normalizeISBN(&isbn); err != nil {
+		return nil, err
 	}
 	bt, err := db.GetBookTypeByISBN(ctx, isbn) // 调用数据库操作函数根据ISBN获取图书类型
 	if err != nil {
@@ -2,13 +2,23 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/cloudwego/hertz/pkg/app"
+
 	"github.com/2451965602/LMS/config"
+	"github.com/2451965602/LMS/pkg/constants"
 	"github.com/2451965602/LMS/pkg/errno"
-	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/2451965602/LMS/pkg/pagination"
+	"github.com/2451965602/LMS/pkg/validate"
 
 	"github.com/2451965602/LMS/biz/dal/db"
 	"github.com/2451965602/LMS/biz/model/borrow"
 	contextLogin "github.com/2451965602/LMS/pkg/base/context"
+	"github.com/2451965602/LMS/pkg/tracing"
 )
 
 // BorrowService 用于管理图书借阅相关的业务逻辑，封装了借书、还书、续借和获取借阅记录等操作。
@@ -26,6 +36,7 @@ func NewBorrowService(ctx context.Context, c *app.RequestContext) *BorrowService
 }
 
 // BookBorrow 借书操作
+// 请求头带有 Idempotency-Key 时，同一用户以相同的幂等键重试会返回原借阅记录，不会重复借出。
 // 参数：
 //   - ctx: 上下文
 //   - req: 借书请求，包含书籍ID
@@ -34,19 +45,20 @@ func NewBorrowService(ctx context.Context, c *app.RequestContext) *BorrowService
 //   - int64: 借阅记录ID
 //   - error: 错误信息，如果借书失败会返回错误
 func (s *BorrowService) BookBorrow(ctx context.Context, req borrow.BorrowRequest) (int64, error) {
+	ctx, span := tracing.Start(ctx, "BorrowService.BookBorrow")
+	defer span.End()
+
 	userId, err := contextLogin.GetLoginData(ctx) // 从上下文中获取当前登录用户ID
 	if err != nil {
 		return -1, err
 	}
-	_, count, err := db.GetCurrentBorrowRecord(ctx, userId, 1, 1, 1)
-	if err != nil {
+	key := strings.TrimSpace(string(s.c.GetHeader(constants.IdempotencyKeyHeader)))
+	if err = IdempotencyKeyCheck(key); err != nil {
 		return -1, err
 	}
-	if count >= config.MaxBorrowNum.Num {
-		return -1, errno.Errorf(errno.ServiceBorrowNumOver, "can't borrow more")
-	}
 
-	borrowId, err := db.BookBorrow(ctx, userId, req.BookID) // 调用数据库操作函数记录借书信息
+	// 借阅数量在借书的事务中检查，并发借书时不会超过限制
+	borrowId, err := db.BookBorrow(ctx, userId, req.BookID, config.MaxBorrowNum.Num, key) // 调用数据库操作函数记录借书信息
 	if err != nil {
 		return -1, err
 	}
@@ -62,6 +74,9 @@ func (s *BorrowService) BookBorrow(ctx context.Context, req borrow.BorrowRequest
 //   - *db.BorrowRecord: 还书后的借阅记录信息
 //   - error: 错误信息，如果还书失败会返回错误
 func (s *BorrowService) BookReturn(ctx context.Context, req borrow.ReturnRequest) (*db.BorrowRecord, error) {
+	ctx, span := tracing.Start(ctx, "BorrowService.BookReturn")
+	defer span.End()
+
 	userId, err := contextLogin.GetLoginData(ctx) // 从上下文中获取当前登录用户ID
 	if err != nil {
 		return nil, err
@@ -82,6 +97,9 @@ func (s *BorrowService) BookReturn(ctx context.Context, req borrow.ReturnRequest
 //   - *db.BorrowRecord: 续借后的借阅记录信息
 //   - error: 错误信息，如果续借失败会返回错误
 func (s *BorrowService) BookRenew(ctx context.Context, req borrow.RenewRequest) (*db.BorrowRecord, error) {
+	ctx, span := tracing.Start(ctx, "BorrowService.BookRenew")
+	defer span.End()
+
 	userId, err := contextLogin.GetLoginData(ctx) // 从上下文中获取当前登录用户ID
 	if err != nil {
 		return nil, err
@@ -93,6 +111,58 @@ func (s *BorrowService) BookRenew(ctx context.Context, req borrow.RenewRequest)
 	return borrowRecord, nil
 }
 
+// SettleFine 结清借阅记录的逾期费用，需要馆员权限
+// 读者缴纳后登记为已缴纳，计入逾期费用收入；馆员减免的费用不计入收入。两种方式都会写入审计日志。
+// 参数：
+//   - ctx: 上下文
+//   - req: 结清请求，包含借阅记录ID、结清方式（pay 或 waive）和备注
+//
+// 返回值：
+//   - *db.BorrowRecord: 结清后的借阅记录信息
+//   - error: 错误信息，如果没有权限、没有逾期费用或已经结清会返回错误
+func (s *BorrowService) SettleFine(ctx context.Context, req borrow.SettleFineRequest) (*db.BorrowRecord, error) {
+	ctx, span := tracing.Start(ctx, "BorrowService.SettleFine")
+	defer span.End()
+
+	currentUserID, err := contextLogin.GetLoginData(ctx) // 获取当前登录用户ID
+	if err != nil {
+		return nil, err
+	}
+	ok, err := db.IsPermission(ctx, currentUserID, "librarian") // 检查当前用户是否有馆员权限
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errno.Errorf(errno.ServicePermissionDenied, "permission denied")
+	}
+
+	var action string
+	switch req.Action {
+	case constants.FineActionPay:
+		action = constants.AuditActionFinePay
+	case constants.FineActionWaive:
+		action = constants.AuditActionFineWaive
+	default:
+		allowed := constants.FineActionPay + ", " + constants.FineActionWaive
+		return nil, validate.NewFieldError("action", validate.RuleEnum, errno.ParamVerifyErrorCode,
+			map[string]string{"allowed": allowed}, "action must be one of %s", allowed)
+	}
+	detail := fmt.Sprintf("borrow id: %d", req.BorrowID)
+	if req.Note != nil {
+		note := strings.TrimSpace(*req.Note)
+		if utf8.RuneCountInString(note) > constants.FineNoteMaxLength {
+			return nil, validate.NewFieldError("note", validate.RuleValidate, errno.ParamVerifyErrorCode, nil,
+				"note must be at most %d characters", constants.FineNoteMaxLength)
+		}
+		if note != "" {
+			detail += ", note: " + note
+		}
+	}
+
+	audit := &db.AuditLog{Action: action, Subject: strconv.FormatInt(currentUserID, 10), IP: s.c.ClientIP(), Detail: detail}
+	return db.SettleFine(ctx, req.BorrowID, req.Action == constants.FineActionWaive, audit)
+}
+
 // GetCurrentBorrowRecord 获取当前用户的借阅记录
 // 参数：
 //   - ctx: 上下文
@@ -100,22 +170,31 @@ func (s *BorrowService) BookRenew(ctx context.Context, req borrow.RenewRequest)
 //
 // 返回值：
 //   - []*db.BorrowRecord: 借阅记录列表
-//   - int64: 总记录数
+//   - *pagination.Result: 下一页的游标，以及请求需要时的总记录数
 //   - error: 错误信息，如果获取失败会返回错误
-func (s *BorrowService) GetCurrentBorrowRecord(ctx context.Context, req borrow.GetBorrowRecordRequest) ([]*db.BorrowRecord, int64, error) {
+func (s *BorrowService) GetCurrentBorrowRecord(ctx context.Context, req borrow.GetBorrowRecordRequest) ([]*db.BorrowRecord, *pagination.Result, error) {
+	ctx, span := tracing.Start(ctx, "BorrowService.GetCurrentBorrowRecord")
+	defer span.End()
+
 	userId, err := contextLogin.GetLoginData(ctx) // 从上下文中获取当前登录用户ID
 	if err != nil {
-		return nil, 0, err
+		return nil, nil, err
+	}
+	page, err := pagination.Parse(db.BorrowRecordPageSpec, pagination.Request{
+		Cursor: req.Cursor, PageSize: req.PageSize, PageNum: req.PageNum, Sort: req.Sort, WithTotal: req.WithTotal,
+	})
+	if err != nil {
+		return nil, nil, err
 	}
 
-	records, total, err := db.GetCurrentBorrowRecord(ctx, userId, req.PageNum, req.PageSize, req.Status) // 调用数据库操作函数获取借阅记录
+	records, result, err := db.GetCurrentBorrowRecord(ctx, userId, req.Status, page) // 调用数据库操作函数获取借阅记录
 	if err != nil {
-		return nil, 0, err
+		return nil, nil, err
 	}
 
-	var resultRecords []*db.BorrowRecord
+	resultRecords := make([]*db.BorrowRecord, 0, len(records))
 	for i := range records {
 		resultRecords = append(resultRecords, &records[i]) // 将借阅记录转换为指针列表
 	}
-	return resultRecords, total, nil
+	return resultRecords, result, nil
 }
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+
+	"github.com/cloudwego/hertz/pkg/app"
+
+	"github.com/2451965602/LMS/biz/dal/db"
+	"github.com/2451965602/LMS/biz/model/catalog"
+	contextLogin "github.com/2451965602/LMS/pkg/base/context"
+	"github.com/2451965602/LMS/pkg/errno"
+	"github.com/2451965602/LMS/pkg/pagination"
+	"github.com/2451965602/LMS/pkg/tracing"
+)
+
+// CatalogService 用于管理责任者、主题和丛书等规范化的编目条目，三者的增删改查逻辑相同，通过 db.CatalogKind 区分。
+type CatalogService struct {
+	ctx context.Context     // 上下文，用于传递请求相关的元数据
+	c   *app.RequestContext // Hertz框架的请求上下文，用于处理HTTP请求
+}
+
+// NewCatalogService 创建一个新的CatalogService实例，初始化上下文和请求上下文。
+func NewCatalogService(ctx context.Context, c *app.RequestContext) *CatalogService {
+	return &CatalogService{
+		ctx: ctx,
+		c:   c,
+	}
+}
+
+// AddEntry 添加责任者、主题或丛书，仅馆员和管理员可以操作
+// 参数：
+//   - ctx: 上下文
+//   - kind: 条目类型
+//   - req: 添加请求，包含名称
+//
+// 返回值：
+//   - *db.CatalogEntry: 添加成功的条目，名称可以与已有条目相同
+//   - error: 错误信息，如果添加失败会返回错误
+func (s *CatalogService) AddEntry(ctx context.Context, kind db.CatalogKind, req catalog.AddCatalogEntryRequest) (*db.CatalogEntry, error) {
+	ctx, span := tracing.Start(ctx, "CatalogService.AddEntry")
+	defer span.End()
+
+	if err := s.checkLibrarian(ctx); err != nil {
+		return nil, err
+	}
+	name, err := CatalogNameCheck(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	return db.AddCatalogEntry(ctx, kind, name)
+}
+
+// UpdateEntry 修改责任者、主题或丛书的名称，仅馆员和管理员可以操作
+// 参数：
+//   - ctx: 上下文
+//   - kind: 条目类型
+//   - req: 修改请求，包含条目ID和新名称
+//
+// 返回值：
+//   - *db.CatalogEntry: 修改后的条目
+//   - error: 错误信息，条目不存在时返回对应的错误码
+func (s *CatalogService) UpdateEntry(ctx context.Context, kind db.CatalogKind, req catalog.UpdateCatalogEntryRequest) (*db.CatalogEntry, error) {
+	ctx, span := tracing.Start(ctx, "CatalogService.UpdateEntry")
+	defer span.End()
+
+	if err := s.checkLibrarian(ctx); err != nil {
+		return nil, err
+	}
+	name, err := CatalogNameCheck(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	return db.UpdateCatalogEntry(ctx, kind, req.ID, name)
+}
+
+// DeleteEntry 删除责任者、主题或丛书，仅馆员和管理员可以操作
+// 参数：
+//   - ctx: 上下文
+//   - kind: 条目类型
+//   - req: 删除请求，包含条目ID
+//
+// 返回值：
+//   - error: 错误信息，仍有书籍类型关联时返回 errno.ServiceCatalogEntryInUse
+func (s *CatalogService) DeleteEntry(ctx context.Context, kind db.CatalogKind, req catalog.DeleteCatalogEntryRequest) error {
+	ctx, span := tracing.Start(ctx, "CatalogService.DeleteEntry")
+	defer span.End()
+
+	if err := s.checkLibrarian(ctx); err != nil {
+		return err
+	}
+	return db.DeleteCatalogEntry(ctx, kind, req.ID)
+}
+
+// SearchEntry 按名称搜索责任者、主题或丛书
+// 参数：
+//   - ctx: 上下文
+//   - kind: 条目类型
+//   - req: 搜索请求，包含名称和分页信息
+//
+// 返回值：
+//   - []*db.CatalogEntry: 一页条目
+//   - *pagination.Result: 下一页的游标，以及请求需要时的总记录数
+//   - error: 错误信息，如果搜索失败会返回错误
+func (s *CatalogService) SearchEntry(ctx context.Context, kind db.CatalogKind, req catalog.GetCatalogEntryRequest) ([]*db.CatalogEntry, *pagination.Result, error) {
+	ctx, span := tracing.Start(ctx, "CatalogService.SearchEntry")
+	defer span.End()
+
+	page, err := pagination.Parse(db.CatalogEntryPageSpec, pagination.Request{
+		Cursor: req.Cursor, PageSize: req.PageSize, PageNum: req.PageNum, Sort: req.Sort, WithTotal: req.WithTotal,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return db.SearchCatalogEntry(ctx, kind, req.Name, page)
+}
+
+// checkLibrarian 检查当前登录用户是否为馆员或管理员
+func (s *CatalogService) checkLibrarian(ctx context.Context) error {
+	currentUserID, err := contextLogin.GetLoginData(ctx)
+	if err != nil {
+		return err
+	}
+	ok, err := db.IsPermission(ctx, currentUserID, "librarian")
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errno.Errorf(errno.ServicePermissionDenied, "permission denied")
+	}
+	return nil
+}
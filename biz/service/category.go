@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+
+	"github.com/cloudwego/hertz/pkg/app"
+
+	"github.com/2451965602/LMS/biz/dal/db"
+	"github.com/2451965602/LMS/biz/model/category"
+	contextLogin "github.com/2451965602/LMS/pkg/base/context"
+	"github.com/2451965602/LMS/pkg/errno"
+	"github.com/2451965602/LMS/pkg/pagination"
+	"github.com/2451965602/LMS/pkg/tracing"
+)
+
+// CategoryService 用于管理分类法（如中图法）的分类节点，节点之间构成层级，书籍类型引用其中的一个节点。
+type CategoryService struct {
+	ctx context.Context     // 上下文，用于传递请求相关的元数据
+	c   *app.RequestContext // Hertz框架的请求上下文，用于处理HTTP请求
+}
+
+// NewCategoryService 创建一个新的CategoryService实例，初始化上下文和请求上下文。
+func NewCategoryService(ctx context.Context, c *app.RequestContext) *CategoryService {
+	return &CategoryService{
+		ctx: ctx,
+		c:   c,
+	}
+}
+
+// AddCategory 添加分类节点，仅管理员可以操作
+// 参数：
+//   - ctx: 上下文
+//   - req: 添加请求，包含分类号、名称和上级节点ID，未指定上级节点时添加为顶级类目
+//
+// 返回值：
+//   - *db.CategoryNode: 添加成功的分类节点
+//   - error: 错误信息，分类号已存在时返回 errno.ServiceCategoryExist，上级节点不存在时返回 errno.ServiceCategoryNotExist
+func (s *CategoryService) AddCategory(ctx context.Context, req category.AddCategoryRequest) (*db.CategoryNode, error) {
+	ctx, span := tracing.Start(ctx, "CategoryService.AddCategory")
+	defer span.End()
+
+	if err := s.checkAdmin(ctx); err != nil {
+		return nil, err
+	}
+	code, err := CategoryCodeCheck(req.Code)
+	if err != nil {
+		return nil, err
+	}
+	name, err := CategoryNameCheck(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	return db.AddCategory(ctx, code, name, req.ParentID)
+}
+
+// UpdateCategory 修改分类节点，仅管理员可以操作
+// 参数：
+//   - ctx: 上下文
+//   - req: 修改请求，包含节点ID，以及要修改的分类号、名称或上级节点ID，上级节点ID为 0 表示移动为顶级类目
+//
+// 返回值：
+//   - *db.CategoryNode: 修改后的分类节点
+//   - error: 错误信息，移动到自身或其下级节点之下时返回 errno.ServiceCategoryCycle
+func (s *CategoryService) UpdateCategory(ctx context.Context, req category.UpdateCategoryRequest) (*db.CategoryNode, error) {
+	ctx, span := tracing.Start(ctx, "CategoryService.UpdateCategory")
+	defer span.End()
+
+	if err := s.checkAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if req.Code == nil && req.Name == nil && req.ParentID == nil {
+		return nil, errno.Errorf(errno.ParamMissingErrorCode, "no fields to update")
+	}
+	if req.Code != nil {
+		code, err := CategoryCodeCheck(*req.Code)
+		if err != nil {
+			return nil, err
+		}
+		req.Code = &code
+	}
+	if req.Name != nil {
+		name, err := CategoryNameCheck(*req.Name)
+		if err != nil {
+			return nil, err
+		}
+		req.Name = &name
+	}
+	return db.UpdateCategory(ctx, req.ID, req.Code, req.Name, req.ParentID)
+}
+
+// DeleteCategory 删除分类节点，仅管理员可以操作
+// 参数：
+//   - ctx: 上下文
+//   - req: 删除请求，包含节点ID
+//
+// 返回值：
+//   - error: 错误信息，节点仍有下级节点或仍被书籍类型引用时返回 errno.ServiceCategoryInUse
+func (s *CategoryService) DeleteCategory(ctx context.Context, req category.DeleteCategoryRequest) error {
+	ctx, span := tracing.Start(ctx, "CategoryService.DeleteCategory")
+	defer span.End()
+
+	if err := s.checkAdmin(ctx); err != nil {
+		return err
+	}
+	return db.DeleteCategory(ctx, req.ID)
+}
+
+// SearchCategory 浏览或搜索分类节点
+// 参数：
+//   - ctx: 上下文
+//   - req: 查询请求，指定上级节点时返回其直接下级节点，指定分类号或名称时在整个分类法中查找，都未指定时返回顶级类目
+//
+// 返回值：
+//   - []*db.CategoryNode: 一页分类节点
+//   - *pagination.Result: 下一页的游标，以及请求需要时的总记录数
+//   - error: 错误信息，如果查询失败会返回错误
+func (s *CategoryService) SearchCategory(ctx context.Context, req category.GetCategoryRequest) ([]*db.CategoryNode, *pagination.Result, error) {
+	ctx, span := tracing.Start(ctx, "CategoryService.SearchCategory")
+	defer span.End()
+
+	page, err := pagination.Parse(db.CategoryPageSpec, pagination.Request{
+		Cursor: req.Cursor, PageSize: req.PageSize, PageNum: req.PageNum, Sort: req.Sort, WithTotal: req.WithTotal,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return db.SearchCategory(ctx, req.ParentID, req.Code, req.Name, page)
+}
+
+// GetCategoryPath 获取从顶级类目到指定节点的各级节点
+// 参数：
+//   - ctx: 上下文
+//   - req: 查询请求，包含节点ID
+//
+// 返回值：
+//   - []*db.CategoryNode: 从顶级类目开始的各级节点，最后一个为指定的节点
+//   - error: 错误信息，节点不存在时返回 errno.ServiceCategoryNotExist
+func (s *CategoryService) GetCategoryPath(ctx context.Context, req category.GetCategoryPathRequest) ([]*db.CategoryNode, error) {
+	ctx, span := tracing.Start(ctx, "CategoryService.GetCategoryPath")
+	defer span.End()
+
+	return db.GetCategoryPath(ctx, req.ID)
+}
+
+// checkAdmin 检查当前登录用户是否为管理员
+func (s *CategoryService) checkAdmin(ctx context.Context) error {
+	currentUserID, err := contextLogin.GetLoginData(ctx)
+	if err != nil {
+		return err
+	}
+	ok, err := db.IsPermission(ctx, currentUserID, "admin")
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errno.Errorf(errno.ServicePermissionDenied, "permission denied")
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+
+	"github.com/2451965602/LMS/biz/dal/db"
+	"github.com/2451965602/LMS/config"
+	"github.com/2451965602/LMS/pkg/constants"
+	"github.com/2451965602/LMS/pkg/errno"
+	"github.com/2451965602/LMS/pkg/loginguard"
+	"github.com/2451965602/LMS/pkg/tracing"
+)
+
+var (
+	loginStoreMu   sync.Mutex
+	loginStore     loginguard.Store
+	loginStoreKind string // 生成 loginStore 时使用的存储类型，配置热更新后会重新创建
+)
+
+// loginAttemptStore 返回当前配置对应的登录失败记录存储
+func loginAttemptStore() (loginguard.Store, error) {
+	loginStoreMu.Lock()
+	defer loginStoreMu.Unlock()
+
+	kind := config.LoginProtection.Store
+	if loginStore != nil && loginStoreKind == kind {
+		return loginStore, nil
+	}
+	switch kind {
+	case constants.LoginAttemptStoreMemory, "":
+		loginStore = loginguard.NewMemoryStore()
+	case constants.LoginAttemptStoreMySQL:
+		loginStore = db.NewLoginAttemptStore()
+	default:
+		return nil, errno.Errorf(errno.InternalServiceErrorCode, "unknown login attempt store %q", kind)
+	}
+	loginStoreKind = kind
+	return loginStore, nil
+}
+
+// userLoginLimits 按用户名统计的限制，每次失败后等待时间指数增长，连续失败过多时锁定
+func userLoginLimits() loginguard.Limits {
+	cfg := config.LoginProtection
+	return loginguard.Limits{
+		MaxFailures: cfg.MaxUserFailures,
+		BaseDelay:   time.Duration(cfg.BaseDelay) * time.Second,
+		MaxDelay:    time.Duration(cfg.MaxDelay) * time.Second,
+		Lockout:     time.Duration(cfg.LockoutDuration) * time.Minute,
+		Window:      time.Duration(cfg.Window) * time.Minute,
+	}
+}
+
+// ipLoginLimits 按 IP 统计的限制
+// 同一出口 IP 后可能有很多正常用户，因此只在失败次数达到上限时锁定，不做逐次的等待。
+func ipLoginLimits() loginguard.Limits {
+	cfg := config.LoginProtection
+	return loginguard.Limits{
+		MaxFailures: cfg.MaxIPFailures,
+		Lockout:     time.Duration(cfg.LockoutDuration) * time.Minute,
+		Window:      time.Duration(cfg.Window) * time.Minute,
+	}
+}
+
+// loginCheck 一个需要统计登录失败次数的键及其限制
+type loginCheck struct {
+	key    string
+	limits loginguard.Limits
+}
+
+// loginChecks 返回一次登录需要检查的键：用户名和客户端IP
+func loginChecks(username, ip string) []loginCheck {
+	return []loginCheck{
+		{constants.LoginAttemptUserPrefix + username, userLoginLimits()},
+		{constants.LoginAttemptIPPrefix + ip, ipLoginLimits()},
+	}
+}
+
+// checkLoginAllowed 检查用户名和 IP 当前是否允许尝试登录
+// 参数：
+//   - ctx: 上下文
+//   - store: 登录失败记录存储
+//   - username: 用户名
+//   - ip: 客户端IP
+//
+// 返回值：
+//   - error: 处于锁定或等待期时返回错误，错误信息中包含需要等待的秒数
+func checkLoginAllowed(ctx context.Context, store loginguard.Store, username, ip string) error {
+	now := time.Now()
+	for _, check := range loginChecks(username, ip) {
+		a, err := store.Get(ctx, check.key)
+		if err != nil {
+			return err
+		}
+		wait, locked := check.limits.Wait(a, now)
+		if locked {
+			return errno.Errorf(errno.ServiceLoginLocked, "too many failed login attempts, locked for %d seconds", seconds(wait))
+		}
+		if wait > 0 {
+			return errno.Errorf(errno.ServiceLoginThrottled, "too many failed login attempts, retry after %d seconds", seconds(wait))
+		}
+	}
+	return nil
+}
+
+// recordLoginFailure 记录一次登录失败，达到上限时锁定并写入审计日志
+// 记录失败本身出错时只输出日志，不影响返回给用户的登录错误。
+// 参数：
+//   - ctx: 上下文
+//   - store: 登录失败记录存储
+//   - username: 用户名
+//   - ip: 客户端IP
+func recordLoginFailure(ctx context.Context, store loginguard.Store, username, ip string) {
+	now := time.Now()
+	for _, check := range loginChecks(username, ip) {
+		a, err := store.Fail(ctx, check.key, now, check.limits.Window)
+		if err != nil {
+			hlog.CtxErrorf(ctx, "service.recordLoginFailure: %v", err)
+			continue
+		}
+		if !check.limits.ShouldLock(a) {
+			continue
+		}
+
+		until := now.Add(check.limits.Lockout)
+		if err = store.Lock(ctx, check.key, until); err != nil {
+			hlog.CtxErrorf(ctx, "service.recordLoginFailure: %v", err)
+			continue
+		}
+		detail := fmt.Sprintf("%d failed login attempts, locked until %s", a.Failures, until.Format("2006-01-02 15:04:05"))
+		if err = db.CreateAuditLog(ctx, constants.AuditActionLoginLockout, check.key, ip, detail); err != nil {
+			hlog.CtxErrorf(ctx, "service.recordLoginFailure: %v", err)
+		}
+		hlog.CtxWarnf(ctx, "service.recordLoginFailure: %s locked: %s", check.key, detail)
+	}
+}
+
+// RunLoginAttemptCleanup 删除共享存储中已过期的登录失败记录，进程内存储会自行清理
+func RunLoginAttemptCleanup(ctx context.Context) {
+	if config.LoginProtection.Store != constants.LoginAttemptStoreMySQL {
+		return
+	}
+
+	ctx, span := tracing.Start(ctx, "service.RunLoginAttemptCleanup")
+	defer span.End()
+
+	now := time.Now()
+	window := time.Duration(config.LoginProtection.Window) * time.Minute
+	count, err := db.DeleteStaleLoginAttempts(ctx, now, now.Add(-window))
+	if err != nil {
+		tracing.RecordError(span, err)
+		hlog.CtxErrorf(ctx, "service.RunLoginAttemptCleanup: %v", err)
+		return
+	}
+	if count > 0 {
+		hlog.CtxInfof(ctx, "service.RunLoginAttemptCleanup: deleted %d stale login attempts", count)
+	}
+}
+
+// seconds 将等待时间向上取整为秒
+func seconds(d time.Duration) int64 {
+	return int64(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/app"
+
+	"github.com/2451965602/LMS/biz/dal/db"
+	"github.com/2451965602/LMS/config"
+	"github.com/2451965602/LMS/pkg/constants"
+	"github.com/2451965602/LMS/pkg/errno"
+	"github.com/2451965602/LMS/pkg/loginguard"
+)
+
+const testLoginPassword = "Passw0rd!"
+
+// newLoginGuardTest 注册一个用户，并使用新的进程内存储和以下限制：同一用户名失败 3 次锁定，不做逐次等待，不按 IP 锁定
+func newLoginGuardTest(t *testing.T, username string) (*UserService, loginguard.Store) {
+	t.Helper()
+	initTestDB(t)
+	if _, err := db.RegisterUser(context.Background(), username, testLoginPassword, config.Registration.DefaultPatronType, nil, nil); err != nil {
+		t.Fatalf("register %s: %v", username, err)
+	}
+
+	saved := *config.LoginProtection
+	t.Cleanup(func() {
+		*config.LoginProtection = saved
+		loginStore = nil
+	})
+	config.LoginProtection.MaxUserFailures = 3
+	config.LoginProtection.MaxIPFailures = 0
+	config.LoginProtection.BaseDelay = 0
+	config.LoginProtection.Store = constants.LoginAttemptStoreMemory
+	loginStore = nil
+
+	store, err := loginAttemptStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewUserService(context.Background(), app.NewContext(0)), store
+}
+
+// login 登录并检查错误码，want 为 0 时要求登录成功
+func login(t *testing.T, s *UserService, username, password string, want int64) {
+	t.Helper()
+	_, err := s.Login(context.Background(), username, password)
+	switch {
+	case want == 0 && err != nil:
+		t.Fatalf("login %s: %v, want success", username, err)
+	case want != 0 && (err == nil || errno.ConvertErr(err).ErrorCode != want):
+		t.Fatalf("login %s: err = %v, want code %d", username, err, want)
+	}
+}
+
+// TestLoginLockoutThreshold 连续失败次数达到上限后锁定，正确的密码也不能登录
+func TestLoginLockoutThreshold(t *testing.T) {
+	const username = "202410001"
+	s, _ := newLoginGuardTest(t, username)
+
+	login(t, s, username, "wrong", errno.ServiceUserNotExist)
+	login(t, s, username, "wrong", errno.ServiceUserNotExist)
+	login(t, s, username, "wrong", errno.ServiceUserNotExist) // 第 3 次失败后锁定
+	login(t, s, username, testLoginPassword, errno.ServiceLoginLocked)
+	login(t, s, username, "wrong", errno.ServiceLoginLocked)
+}
+
+// TestLoginLockoutExpiry 锁定到期后可以登录，超出统计窗口的失败不再计数
+func TestLoginLockoutExpiry(t *testing.T) {
+	const username = "202410002"
+	s, store := newLoginGuardTest(t, username)
+	ctx := context.Background()
+	key := constants.LoginAttemptUserPrefix + username
+
+	for i := 0; i < 3; i++ {
+		login(t, s, username, "wrong", errno.ServiceUserNotExist)
+	}
+	login(t, s, username, testLoginPassword, errno.ServiceLoginLocked)
+
+	// 将锁定截止时间改为已过去，模拟锁定到期
+	if err := store.Lock(ctx, key, time.Now().Add(-time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	login(t, s, username, testLoginPassword, 0)
+
+	// 统计窗口之前的两次失败不计入，之后再失败两次仍未达到上限
+	window := time.Duration(config.LoginProtection.Window) * time.Minute
+	for i := 0; i < 2; i++ {
+		if _, err := store.Fail(ctx, key, time.Now().Add(-2*window), window); err != nil {
+			t.Fatal(err)
+		}
+	}
+	login(t, s, username, "wrong", errno.ServiceUserNotExist)
+	login(t, s, username, "wrong", errno.ServiceUserNotExist)
+	login(t, s, username, testLoginPassword, 0)
+}
+
+// TestLoginSuccessResetsFailures 登录成功后清除该用户名的失败次数
+func TestLoginSuccessResetsFailures(t *testing.T) {
+	const username = "202410003"
+	s, store := newLoginGuardTest(t, username)
+
+	login(t, s, username, "wrong", errno.ServiceUserNotExist)
+	login(t, s, username, "wrong", errno.ServiceUserNotExist)
+	login(t, s, username, testLoginPassword, 0)
+
+	a, err := store.Get(context.Background(), constants.LoginAttemptUserPrefix+username)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Failures != 0 {
+		t.Errorf("failures after successful login = %d, want 0", a.Failures)
+	}
+	// 不清除时第 3 次失败就会锁定
+	login(t, s, username, "wrong", errno.ServiceUserNotExist)
+	login(t, s, username, "wrong", errno.ServiceUserNotExist)
+	login(t, s, username, testLoginPassword, 0)
+}
@@ -0,0 +1,40 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/2451965602/LMS/biz/dal/db"
+	"github.com/2451965602/LMS/pkg/testmysql"
+)
+
+// testDBErr 临时数据库的初始化结果，为 nil 时测试可以使用数据访问层
+var testDBErr error
+
+func TestMain(m *testing.M) {
+	cleanup, err := testmysql.Open()
+	if err == nil {
+		if err = db.Init(); err != nil {
+			err = fmt.Errorf("db.Init: %w", err)
+		}
+	}
+	testDBErr = err
+	code := m.Run()
+	if cleanup != nil {
+		cleanup()
+	}
+	os.Exit(code)
+}
+
+// initTestDB 确认临时数据库可用，未配置时跳过测试
+func initTestDB(t *testing.T) {
+	t.Helper()
+	if errors.Is(testDBErr, testmysql.ErrNotConfigured) {
+		t.Skip(testDBErr)
+	}
+	if testDBErr != nil {
+		t.Fatal(testDBErr)
+	}
+}
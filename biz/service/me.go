@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+
+	"github.com/cloudwego/hertz/pkg/app"
+
+	"github.com/2451965602/LMS/biz/dal/db"
+	"github.com/2451965602/LMS/biz/model/me"
+	contextLogin "github.com/2451965602/LMS/pkg/base/context"
+	"github.com/2451965602/LMS/pkg/pagination"
+	"github.com/2451965602/LMS/pkg/tracing"
+)
+
+// MeService 用于管理当前登录用户的自助服务，汇总借阅、罚款、预约和借阅历史。
+type MeService struct {
+	ctx context.Context     // 上下文，用于传递请求相关的元数据
+	c   *app.RequestContext // Hertz框架的请求上下文，用于处理HTTP请求
+}
+
+// NewMeService 创建一个新的MeService实例，初始化上下文和请求上下文。
+func NewMeService(ctx context.Context, c *app.RequestContext) *MeService {
+	return &MeService{
+		ctx: ctx,
+		c:   c,
+	}
+}
+
+// Summary 当前用户的借阅概览
+type Summary struct {
+	Loans        []*db.BorrowDetail // 当前未归还的借阅
+	OverdueCount int64              // 逾期借阅数量
+	FineBalance  float64            // 未缴清的逾期费用
+	Holds        []*db.HoldDetail   // 有效的预约
+	History      []*db.BorrowDetail // 借阅历史（当前页）
+	HistoryTotal int64              // 借阅历史总数
+}
+
+// GetSummary 获取当前用户的借阅概览
+// 参数：
+//   - ctx: 上下文
+//   - req: 获取概览请求，包含借阅历史的分页信息
+//
+// 返回值：
+//   - *Summary: 当前用户的借阅概览
+//   - error: 错误信息，如果获取失败会返回错误
+func (s *MeService) GetSummary(ctx context.Context, req me.GetSummaryRequest) (*Summary, error) {
+	ctx, span := tracing.Start(ctx, "MeService.GetSummary")
+	defer span.End()
+
+	userId, err := contextLogin.GetLoginData(ctx) // 从上下文中获取当前登录用户ID
+	if err != nil {
+		return nil, err
+	}
+
+	size, offset, err := pagination.ParseOffset(&req.PageSize, req.PageNum) // 借阅历史的每页数量不超过列表接口的上限
+	if err != nil {
+		return nil, err
+	}
+
+	summary := new(Summary)
+
+	summary.Loans, err = db.GetCurrentLoans(ctx, userId) // 获取当前未归还的借阅
+	if err != nil {
+		return nil, err
+	}
+
+	summary.OverdueCount, err = db.CountOverdueLoans(ctx, userId) // 统计逾期借阅数量
+	if err != nil {
+		return nil, err
+	}
+
+	summary.FineBalance, err = db.GetFineBalance(ctx, userId) // 获取未缴清的逾期费用
+	if err != nil {
+		return nil, err
+	}
+
+	summary.Holds, err = db.GetActiveHolds(ctx, userId) // 获取有效的预约
+	if err != nil {
+		return nil, err
+	}
+
+	summary.History, summary.HistoryTotal, err = db.GetReadingHistory(ctx, userId, offset, size) // 分页获取借阅历史
+	if err != nil {
+		return nil, err
+	}
+
+	return summary, nil
+}
+
+// UpdatePrivacy 更新当前用户的借阅历史隐私设置
+// 参数：
+//   - ctx: 上下文
+//   - req: 更新隐私设置请求，包含是否保留借阅历史
+//
+// 返回值：
+//   - *db.User: 更新后的用户信息
+//   - error: 错误信息，如果更新失败会返回错误
+func (s *MeService) UpdatePrivacy(ctx context.Context, req me.UpdatePrivacyRequest) (*db.User, error) {
+	ctx, span := tracing.Start(ctx, "MeService.UpdatePrivacy")
+	defer span.End()
+
+	userId, err := contextLogin.GetLoginData(ctx) // 从上下文中获取当前登录用户ID
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := db.UpdateKeepHistory(ctx, userId, req.KeepHistory) // 调用数据库操作函数更新隐私设置
+	if err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// ExportData 当前用户的个人数据
+type ExportData struct {
+	User          *db.User           // 用户信息
+	BorrowRecords []*db.BorrowRecord // 全部借阅记录
+	Holds         []*db.HoldDetail   // 全部预约记录
+}
+
+// ExportData 导出当前用户的个人数据
+// 参数：
+//   - ctx: 上下文
+//
+// 返回值：
+//   - *ExportData: 当前用户的个人数据
+//   - error: 错误信息，如果导出失败会返回错误
+func (s *MeService) ExportData(ctx context.Context) (*ExportData, error) {
+	ctx, span := tracing.Start(ctx, "MeService.ExportData")
+	defer span.End()
+
+	userId, err := contextLogin.GetLoginData(ctx) // 从上下文中获取当前登录用户ID
+	if err != nil {
+		return nil, err
+	}
+
+	data := new(ExportData)
+
+	data.User, err = db.GetUserById(ctx, userId) // 获取用户信息
+	if err != nil {
+		return nil, err
+	}
+
+	data.BorrowRecords, err = db.GetAllBorrowRecords(ctx, userId) // 获取全部借阅记录
+	if err != nil {
+		return nil, err
+	}
+
+	data.Holds, err = db.GetAllHolds(ctx, userId) // 获取全部预约记录
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
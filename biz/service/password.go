@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+
+	"github.com/2451965602/LMS/biz/dal/db"
+	"github.com/2451965602/LMS/config"
+	contextLogin "github.com/2451965602/LMS/pkg/base/context"
+	"github.com/2451965602/LMS/pkg/constants"
+	"github.com/2451965602/LMS/pkg/crypt"
+	"github.com/2451965602/LMS/pkg/errno"
+	"github.com/2451965602/LMS/pkg/password"
+	"github.com/2451965602/LMS/pkg/sms"
+	"github.com/2451965602/LMS/pkg/tracing"
+)
+
+// ChangePassword 修改当前登录用户的密码
+// 修改成功后之前签发的 Token 全部失效，需要重新登录。
+// 参数：
+//   - ctx: 上下文
+//   - oldPassword: 当前密码
+//   - newPassword: 新密码
+//
+// 返回值：
+//   - error: 错误信息，如果当前密码错误、新密码不符合策略或修改失败会返回错误
+func (s *UserService) ChangePassword(ctx context.Context, oldPassword, newPassword string) error {
+	ctx, span := tracing.Start(ctx, "UserService.ChangePassword")
+	defer span.End()
+
+	userId, err := contextLogin.GetLoginData(ctx) // 从上下文中获取当前登录用户ID
+	if err != nil {
+		return err
+	}
+	info, err := db.GetUserById(ctx, userId)
+	if err != nil {
+		return err
+	}
+
+	if oldPassword == newPassword {
+		return errno.Errorf(errno.ServiceWeakPassword, "new password must be different from the current password")
+	}
+	if err = CheckPassword(newPassword, info.Name); err != nil {
+		return err
+	}
+	return db.ChangePassword(ctx, userId, oldPassword, newPassword)
+}
+
+// SendResetCode 向用户绑定的手机号发送找回密码验证码
+// 用户不存在、未绑定手机号或发送过于频繁时同样返回成功，只有存在的用户才会触发频率限制，返回错误会暴露用户名是否存在。
+// 参数：
+//   - ctx: 上下文
+//   - username: 用户名
+//
+// 返回值：
+//   - error: 错误信息，如果发送失败会返回错误
+func (s *UserService) SendResetCode(ctx context.Context, username string) error {
+	ctx, span := tracing.Start(ctx, "UserService.SendResetCode")
+	defer span.End()
+
+	info, err := db.GetUserByName(ctx, username)
+	if err != nil {
+		if errno.ConvertErr(err).ErrorCode == errno.ServiceUserNotExist {
+			return nil
+		}
+		return err
+	}
+	if info.Phone == nil || *info.Phone == "" {
+		hlog.CtxInfof(ctx, "UserService.SendResetCode: user (id: %d) has no phone", info.ID)
+		return nil
+	}
+
+	cfg := config.PasswordReset
+	code, err := crypt.RandomDigits(cfg.CodeLength)
+	if err != nil {
+		return err
+	}
+	ttl := time.Duration(cfg.CodeTTL) * time.Minute
+	err = db.CreatePasswordResetCode(ctx, info.ID, crypt.TokenHash(code), ttl,
+		time.Duration(cfg.ResendInterval)*time.Second, cfg.MaxDailySends)
+	if err != nil {
+		if errno.ConvertErr(err).ErrorCode == errno.ServiceResetCodeTooFrequent { // 不发送短信，但返回与正常发送相同的结果
+			hlog.CtxInfof(ctx, "UserService.SendResetCode: user (id: %d): %v", info.ID, err)
+			return nil
+		}
+		return err
+	}
+
+	sender, err := smsSender()
+	if err != nil {
+		return errno.Errorf(errno.InternalServiceErrorCode, "create sms sender failed: %v", err)
+	}
+	if err = sender.Send(ctx, *info.Phone, fmt.Sprintf(constants.PasswordResetCodeMessage, code, cfg.CodeTTL)); err != nil {
+		return errno.Errorf(errno.InternalServiceErrorCode, "send reset code failed: %v", err)
+	}
+	return nil
+}
+
+// ResetPassword 使用短信验证码重置密码
+// 重置成功后之前签发的 Token 全部失效。
+// 参数：
+//   - ctx: 上下文
+//   - username: 用户名
+//   - code: 短信验证码
+//   - newPassword: 新密码
+//
+// 返回值：
+//   - error: 错误信息，如果验证码错误或过期、新密码不符合策略会返回错误
+func (s *UserService) ResetPassword(ctx context.Context, username, code, newPassword string) error {
+	ctx, span := tracing.Start(ctx, "UserService.ResetPassword")
+	defer span.End()
+
+	info, err := db.GetUserByName(ctx, username)
+	if err != nil {
+		if errno.ConvertErr(err).ErrorCode == errno.ServiceUserNotExist {
+			return errno.Errorf(errno.ServiceResetCodeInvalid, "reset code invalid or expired")
+		}
+		return err
+	}
+	if err = CheckPassword(newPassword, info.Name); err != nil {
+		return err
+	}
+	return db.ResetPasswordWithCode(ctx, info.ID, crypt.TokenHash(code), newPassword, config.PasswordReset.MaxAttempts)
+}
+
+// CheckPassword 按配置的密码策略检查密码强度
+func CheckPassword(pwd, username string) error {
+	cfg := config.PasswordPolicy
+	policy := password.Policy{
+		MinLength:  cfg.MinLength,
+		MaxLength:  cfg.MaxLength,
+		MinClasses: cfg.MinClasses,
+	}
+	return policy.Check(pwd, username)
+}
+
+// smsSender 根据配置创建短信发送器，访问令牌优先从环境变量读取
+func smsSender() (sms.Sender, error) {
+	cfg := config.SMS
+	token := cfg.Token
+	if cfg.TokenEnv != "" && os.Getenv(cfg.TokenEnv) != "" {
+		token = os.Getenv(cfg.TokenEnv)
+	}
+	return sms.New(cfg.Sender, sms.Options{
+		Endpoint: cfg.Endpoint,
+		Token:    token,
+		Timeout:  time.Duration(cfg.Timeout) * time.Second,
+	})
+}
+
+// RunPasswordResetCleanup 删除超出发送次数统计窗口的找回密码验证码
+func RunPasswordResetCleanup(ctx context.Context) {
+	ctx, span := tracing.Start(ctx, "service.RunPasswordResetCleanup")
+	defer span.End()
+
+	count, err := db.DeleteExpiredPasswordResetCodes(ctx, time.Now().Add(-constants.PasswordResetSendWindow))
+	if err != nil {
+		tracing.RecordError(span, err)
+		hlog.CtxErrorf(ctx, "service.RunPasswordResetCleanup: %v", err)
+		return
+	}
+	if count > 0 {
+		hlog.CtxInfof(ctx, "service.RunPasswordResetCleanup: deleted %d expired password reset codes", count)
+	}
+}
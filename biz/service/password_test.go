@@ -0,0 +1,30 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/app"
+
+	"github.com/2451965602/LMS/biz/dal/db"
+	"github.com/2451965602/LMS/config"
+)
+
+// TestSendResetCodeSameResponse 用户存在、不存在以及发送过于频繁时返回相同的结果
+func TestSendResetCodeSameResponse(t *testing.T) {
+	initTestDB(t)
+	ctx := context.Background()
+	const username = "202410004"
+	phone := "+8613800138010"
+	if _, err := db.RegisterUser(ctx, username, testLoginPassword, config.Registration.DefaultPatronType, &phone, nil); err != nil {
+		t.Fatalf("register %s: %v", username, err)
+	}
+	s := NewUserService(ctx, app.NewContext(0))
+
+	// 第二次请求在重发间隔内，不会再发送，但结果与第一次相同
+	for i, name := range []string{username, username, "202419999"} {
+		if err := s.SendResetCode(ctx, name); err != nil {
+			t.Errorf("request %d for %s: %v, want success", i+1, name, err)
+		}
+	}
+}
@@ -0,0 +1,309 @@
+package service
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/app"
+
+	"github.com/2451965602/LMS/biz/dal/db"
+	"github.com/2451965602/LMS/biz/model/report"
+	contextLogin "github.com/2451965602/LMS/pkg/base/context"
+	"github.com/2451965602/LMS/pkg/constants"
+	"github.com/2451965602/LMS/pkg/errno"
+	"github.com/2451965602/LMS/pkg/pagination"
+	"github.com/2451965602/LMS/pkg/tracing"
+	"github.com/2451965602/LMS/pkg/validate"
+)
+
+// ReportService 用于生成流通与馆藏统计报表，所有报表仅对管理员开放。
+type ReportService struct {
+	ctx context.Context     // 上下文，用于传递请求相关的元数据
+	c   *app.RequestContext // Hertz框架的请求上下文，用于处理HTTP请求
+}
+
+// NewReportService 创建一个新的ReportService实例，初始化上下文和请求上下文。
+func NewReportService(ctx context.Context, c *app.RequestContext) *ReportService {
+	return &ReportService{
+		ctx: ctx,
+		c:   c,
+	}
+}
+
+// LoanStats 按天、周或月统计借阅次数
+// 参数：
+//   - ctx: 上下文
+//   - req: 借阅统计请求，包含统计周期和时间范围
+//
+// 返回值：
+//   - []*db.PeriodCount: 每个周期的借阅次数
+//   - error: 错误信息，如果统计失败会返回错误
+func (s *ReportService) LoanStats(ctx context.Context, req report.LoanStatsRequest) ([]*db.PeriodCount, error) {
+	ctx, span := tracing.Start(ctx, "ReportService.LoanStats")
+	defer span.End()
+
+	if err := s.checkReportRequest(ctx, req.Format); err != nil {
+		return nil, err
+	}
+	dateFormat, err := periodFormat(req.Period)
+	if err != nil {
+		return nil, err
+	}
+
+	start, end := timeRange(req.StartTime, req.EndTime)
+	return db.CountLoansByPeriod(ctx, dateFormat, start, end)
+}
+
+// TopBorrowed 统计借阅次数最多的书名或作者
+// 参数：
+//   - ctx: 上下文
+//   - req: 排行榜请求，包含排行依据、条数和时间范围
+//
+// 返回值：
+//   - []*db.RankItem: 排行榜条目
+//   - error: 错误信息，如果统计失败会返回错误
+func (s *ReportService) TopBorrowed(ctx context.Context, req report.TopBorrowedRequest) ([]*db.RankItem, error) {
+	ctx, span := tracing.Start(ctx, "ReportService.TopBorrowed")
+	defer span.End()
+
+	if err := s.checkReportRequest(ctx, req.Format); err != nil {
+		return nil, err
+	}
+
+	limit := constants.ReportDefaultTopLimit
+	if req.Limit != nil {
+		if *req.Limit <= 0 || *req.Limit > constants.ReportMaxTopLimit {
+			return nil, errno.Errorf(errno.ParamVerifyErrorCode, "limit must be between 1 and %d", constants.ReportMaxTopLimit)
+		}
+		limit = int(*req.Limit)
+	}
+
+	start, end := timeRange(req.StartTime, req.EndTime)
+	switch req.By {
+	case constants.ReportRankByTitle:
+		return db.TopBorrowedTitles(ctx, limit, start, end)
+	case constants.ReportRankByAuthor:
+		return db.TopBorrowedAuthors(ctx, limit, start, end)
+	default:
+		return nil, errno.Errorf(errno.ParamVerifyErrorCode, "invalid rank field: %s", req.By)
+	}
+}
+
+// Turnover 统计每个分类的副本数和借阅次数
+// 参数：
+//   - ctx: 上下文
+//   - req: 周转率请求，包含时间范围，指定分类节点时统计其下的分类
+//
+// 返回值：
+//   - []*db.CategoryTurnover: 每个顶级类目或指定节点的直接下级分类的副本数和借阅次数
+//   - error: 错误信息，如果统计失败会返回错误
+func (s *ReportService) Turnover(ctx context.Context, req report.TurnoverRequest) ([]*db.CategoryTurnover, error) {
+	ctx, span := tracing.Start(ctx, "ReportService.Turnover")
+	defer span.End()
+
+	if err := s.checkReportRequest(ctx, req.Format); err != nil {
+		return nil, err
+	}
+
+	parent, err := reportCategory(ctx, req.CategoryID)
+	if err != nil {
+		return nil, err
+	}
+	start, end := timeRange(req.StartTime, req.EndTime)
+	return db.CategoryTurnoverStats(ctx, parent, start, end)
+}
+
+// IdleCopies 获取自购入以来从未被借阅的副本
+// 参数：
+//   - ctx: 上下文
+//   - req: 闲置副本请求，可按分类节点或分类名称过滤，包含分页信息
+//
+// 返回值：
+//   - []*db.IdleCopy: 一页从未被借阅的副本
+//   - *pagination.Result: 下一页的游标，以及请求需要时的总记录数
+//   - error: 错误信息，如果查询失败会返回错误
+func (s *ReportService) IdleCopies(ctx context.Context, req report.IdleCopiesRequest) ([]*db.IdleCopy, *pagination.Result, error) {
+	ctx, span := tracing.Start(ctx, "ReportService.IdleCopies")
+	defer span.End()
+
+	if err := s.checkReportRequest(ctx, req.Format); err != nil {
+		return nil, nil, err
+	}
+
+	page, err := pagination.Parse(db.IdleCopyPageSpec, pagination.Request{
+		Cursor: req.Cursor, PageSize: req.PageSize, PageNum: req.PageNum, Sort: req.Sort, WithTotal: req.WithTotal,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	node, err := reportCategory(ctx, req.CategoryID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return db.GetIdleCopies(ctx, node, req.Category, page)
+}
+
+// Weeding 获取长期未被借出的副本，用于剔旧
+// 参数：
+//   - ctx: 上下文
+//   - req: 剔旧报表请求，包含未借出的月数，可按分类节点、分类名称和馆藏位置过滤，包含分页信息
+//
+// 返回值：
+//   - []*db.WeedingGroup: 按顶级类目或指定节点的直接下级分类和馆藏位置汇总的全部候选副本数
+//   - []*db.WeedingCandidate: 一页候选副本明细
+//   - *pagination.Result: 下一页的游标，以及请求需要时的总记录数
+//   - error: 错误信息，如果查询失败会返回错误
+func (s *ReportService) Weeding(ctx context.Context, req report.WeedingRequest) ([]*db.WeedingGroup, []*db.WeedingCandidate, *pagination.Result, error) {
+	ctx, span := tracing.Start(ctx, "ReportService.Weeding")
+	defer span.End()
+
+	if err := s.checkReportRequest(ctx, req.Format); err != nil {
+		return nil, nil, nil, err
+	}
+	if req.Months <= 0 || req.Months > constants.WeedingMaxMonths {
+		return nil, nil, nil, validate.NewFieldError("months", validate.RuleRange, errno.ParamVerifyErrorCode,
+			map[string]string{"min": "1", "max": strconv.Itoa(constants.WeedingMaxMonths)},
+			"months must be between 1 and %d", constants.WeedingMaxMonths)
+	}
+	page, err := pagination.Parse(db.WeedingCandidatePageSpec, pagination.Request{
+		Cursor: req.Cursor, PageSize: req.PageSize, PageNum: req.PageNum, Sort: req.Sort, WithTotal: req.WithTotal,
+	})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	node, err := reportCategory(ctx, req.CategoryID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	before := time.Now().AddDate(0, -int(req.Months), 0)
+	groups, err := db.GetWeedingGroups(ctx, before, node, req.Category, req.Location)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	candidates, result, err := db.GetWeedingCandidates(ctx, before, node, req.Category, req.Location, page)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return groups, candidates, result, nil
+}
+
+// OverdueRate 按用户角色统计逾期率
+// 参数：
+//   - ctx: 上下文
+//   - req: 逾期率请求，包含时间范围
+//
+// 返回值：
+//   - []*db.RoleOverdue: 每个角色的借阅次数和逾期次数
+//   - error: 错误信息，如果统计失败会返回错误
+func (s *ReportService) OverdueRate(ctx context.Context, req report.OverdueRateRequest) ([]*db.RoleOverdue, error) {
+	ctx, span := tracing.Start(ctx, "ReportService.OverdueRate")
+	defer span.End()
+
+	if err := s.checkReportRequest(ctx, req.Format); err != nil {
+		return nil, err
+	}
+
+	start, end := timeRange(req.StartTime, req.EndTime)
+	return db.OverdueStatsByRole(ctx, start, end)
+}
+
+// FineRevenue 按天、周或月统计逾期费用收入
+// 参数：
+//   - ctx: 上下文
+//   - req: 罚款收入请求，包含统计周期和时间范围
+//
+// 返回值：
+//   - []*db.PeriodAmount: 每个周期的罚款收入
+//   - error: 错误信息，如果统计失败会返回错误
+func (s *ReportService) FineRevenue(ctx context.Context, req report.FineRevenueRequest) ([]*db.PeriodAmount, error) {
+	ctx, span := tracing.Start(ctx, "ReportService.FineRevenue")
+	defer span.End()
+
+	if err := s.checkReportRequest(ctx, req.Format); err != nil {
+		return nil, err
+	}
+	dateFormat, err := periodFormat(req.Period)
+	if err != nil {
+		return nil, err
+	}
+
+	start, end := timeRange(req.StartTime, req.EndTime)
+	return db.SumFineRevenueByPeriod(ctx, dateFormat, start, end)
+}
+
+// CollectionValue 按分类统计馆藏价值
+// 参数：
+//   - ctx: 上下文
+//   - req: 馆藏价值请求，指定分类节点时统计其下的分类
+//
+// 返回值：
+//   - []*db.CategoryValue: 每个顶级类目或指定节点的直接下级分类的副本数和购入价格总和
+//   - error: 错误信息，如果统计失败会返回错误
+func (s *ReportService) CollectionValue(ctx context.Context, req report.CollectionValueRequest) ([]*db.CategoryValue, error) {
+	ctx, span := tracing.Start(ctx, "ReportService.CollectionValue")
+	defer span.End()
+
+	if err := s.checkReportRequest(ctx, req.Format); err != nil {
+		return nil, err
+	}
+
+	parent, err := reportCategory(ctx, req.CategoryID)
+	if err != nil {
+		return nil, err
+	}
+	return db.CollectionValueByCategory(ctx, parent)
+}
+
+// checkReportRequest 检查当前用户是否为管理员，以及输出格式是否合法
+func (s *ReportService) checkReportRequest(ctx context.Context, format *string) error {
+	currentUserID, err := contextLogin.GetLoginData(ctx) // 获取当前登录用户ID
+	if err != nil {
+		return err
+	}
+	ok, err := db.IsPermission(ctx, currentUserID, "admin") // 检查当前用户是否有管理员权限
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errno.Errorf(errno.ServicePermissionDenied, "permission denied") // 如果没有权限，返回错误
+	}
+
+	if format != nil && *format != constants.ReportFormatJSON && *format != constants.ReportFormatCSV {
+		return errno.Errorf(errno.ParamVerifyErrorCode, "invalid report format: %s", *format)
+	}
+	return nil
+}
+
+// reportCategory 获取报表请求指定的分类节点，未指定或为 0 时返回 nil，即统计全部分类并汇总到顶级类目
+func reportCategory(ctx context.Context, categoryId *int64) (*db.CategoryNode, error) {
+	if categoryId == nil || *categoryId == 0 {
+		return nil, nil
+	}
+	return db.GetCategory(ctx, *categoryId)
+}
+
+// periodFormat 获取统计周期对应的日期格式
+func periodFormat(period string) (string, error) {
+	dateFormat, ok := constants.ReportPeriodFormats[period]
+	if !ok {
+		return "", errno.Errorf(errno.ParamVerifyErrorCode, "invalid report period: %s", period)
+	}
+	return dateFormat, nil
+}
+
+// timeRange 将请求中的 Unix 时间戳转换为时间范围，未指定的边界返回 nil
+func timeRange(startTime, endTime *int64) (*time.Time, *time.Time) {
+	var start, end *time.Time
+	if startTime != nil {
+		t := time.Unix(*startTime, 0)
+		start = &t
+	}
+	if endTime != nil {
+		t := time.Unix(*endTime, 0)
+		end = &t
+	}
+	return start, end
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+
+	"github.com/2451965602/LMS/biz/dal/db"
+	"github.com/2451965602/LMS/config"
+	"github.com/2451965602/LMS/pkg/tracing"
+)
+
+// StartHistoryRetention 启动借阅历史匿名化任务
+// 按 config.Privacy.CheckInterval 的间隔定期执行 RunHistoryRetention，每次执行前重新读取配置以支持热更新。
+func StartHistoryRetention() {
+	go func() {
+		for {
+			RunHistoryRetention(context.Background())
+			time.Sleep(retentionInterval())
+		}
+	}()
+}
+
+// RunHistoryRetention 执行一次借阅历史匿名化
+// 将归还时间早于保留期、逾期费用已缴清且用户未选择保留历史的借阅记录去除用户关联。
+func RunHistoryRetention(ctx context.Context) {
+	if config.Privacy == nil || config.Privacy.RetentionDays <= 0 {
+		return
+	}
+
+	ctx, span := tracing.Start(ctx, "service.RunHistoryRetention")
+	defer span.End()
+
+	before := time.Now().AddDate(0, 0, -int(config.Privacy.RetentionDays))
+	count, err := db.AnonymizeBorrowRecords(ctx, before)
+	if err != nil {
+		tracing.RecordError(span, err)
+		hlog.CtxErrorf(ctx, "service.RunHistoryRetention: %v", err)
+		return
+	}
+	if count > 0 {
+		hlog.CtxInfof(ctx, "service.RunHistoryRetention: anonymized %d borrow records returned before %s", count, before.Format("2006-01-02 15:04:05"))
+	}
+}
+
+// retentionInterval 获取匿名化任务的执行间隔，未配置时默认为一小时
+func retentionInterval() time.Duration {
+	if config.Privacy == nil || config.Privacy.CheckInterval <= 0 {
+		return time.Hour
+	}
+	return time.Duration(config.Privacy.CheckInterval) * time.Minute
+}
@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+
+	"github.com/2451965602/LMS/biz/dal/db"
+	contextLogin "github.com/2451965602/LMS/pkg/base/context"
+	"github.com/2451965602/LMS/pkg/constants"
+	"github.com/2451965602/LMS/pkg/crypt"
+	"github.com/2451965602/LMS/pkg/tracing"
+)
+
+// SessionService 用于管理用户的登录会话，封装了会话的创建、Refresh Token 轮换、查询和吊销操作。
+type SessionService struct {
+	ctx context.Context     // 上下文，用于传递请求相关的元数据
+	c   *app.RequestContext // Hertz框架的请求上下文，用于处理HTTP请求
+}
+
+// NewSessionService 创建一个新的SessionService实例，初始化上下文和请求上下文。
+func NewSessionService(ctx context.Context, c *app.RequestContext) *SessionService {
+	return &SessionService{
+		ctx: ctx,
+		c:   c,
+	}
+}
+
+// Create 为登录成功的用户创建会话
+// 设备信息取自请求的 User-Agent，IP 取自客户端地址。
+// 参数：
+//   - ctx: 上下文
+//   - userId: 用户ID
+//
+// 返回值：
+//   - *db.Session: 创建的会话
+//   - string: 该会话第一个 Refresh Token 的 jti
+//   - error: 错误信息，如果创建失败会返回错误
+func (s *SessionService) Create(ctx context.Context, userId int64) (*db.Session, string, error) {
+	ctx, span := tracing.Start(ctx, "SessionService.Create")
+	defer span.End()
+
+	jti, err := crypt.RandomToken(constants.TokenIDLength)
+	if err != nil {
+		return nil, "", err
+	}
+
+	session, err := db.CreateSession(ctx, userId, s.device(), s.c.ClientIP(), jti)
+	if err != nil {
+		return nil, "", err
+	}
+	return session, jti, nil
+}
+
+// Refresh 轮换会话的 Refresh Token
+// 旧的 Refresh Token 使用后立即失效；已使用过的 Refresh Token 再次出现时吊销整个会话。
+// 参数：
+//   - ctx: 上下文
+//   - sessionId: 会话ID
+//   - jti: 本次请求携带的 Refresh Token 的 jti
+//
+// 返回值：
+//   - string: 新 Refresh Token 的 jti
+//   - error: 错误信息，如果轮换失败或检测到重复使用会返回错误
+func (s *SessionService) Refresh(ctx context.Context, sessionId int64, jti string) (string, error) {
+	ctx, span := tracing.Start(ctx, "SessionService.Refresh")
+	defer span.End()
+
+	newJTI, err := crypt.RandomToken(constants.TokenIDLength)
+	if err != nil {
+		return "", err
+	}
+
+	_, err = db.RotateRefreshToken(ctx, sessionId, jti, newJTI, s.c.ClientIP())
+	if err != nil {
+		return "", err
+	}
+	return newJTI, nil
+}
+
+// ListSessions 获取当前用户所有有效的会话
+// 参数：
+//   - ctx: 上下文
+//
+// 返回值：
+//   - []*db.Session: 会话列表，按最后使用时间倒序
+//   - error: 错误信息，如果获取失败会返回错误
+func (s *SessionService) ListSessions(ctx context.Context) ([]*db.Session, error) {
+	ctx, span := tracing.Start(ctx, "SessionService.ListSessions")
+	defer span.End()
+
+	userId, err := contextLogin.GetLoginData(ctx) // 获取当前登录用户ID
+	if err != nil {
+		return nil, err
+	}
+	return db.GetActiveSessions(ctx, userId)
+}
+
+// RevokeSession 吊销当前用户的指定会话，该会话签发的所有 Token 立即失效
+// 参数：
+//   - ctx: 上下文
+//   - sessionId: 会话ID
+//
+// 返回值：
+//   - error: 错误信息，如果会话不存在或吊销失败会返回错误
+func (s *SessionService) RevokeSession(ctx context.Context, sessionId int64) error {
+	ctx, span := tracing.Start(ctx, "SessionService.RevokeSession")
+	defer span.End()
+
+	userId, err := contextLogin.GetLoginData(ctx) // 获取当前登录用户ID
+	if err != nil {
+		return err
+	}
+	return db.RevokeSession(ctx, userId, sessionId)
+}
+
+// device 获取请求的设备信息，超出长度的部分按字符截断
+// User-Agent 由客户端提供，可能含有无效的 UTF-8 字节，写入 utf8mb4 列前先替换掉，截断时也不能拆开多字节字符。
+func (s *SessionService) device() string {
+	device := strings.ToValidUTF8(string(s.c.UserAgent()), "")
+	return truncateRunes(device, constants.SessionDeviceMaxLength)
+}
+
+// StartSessionCleanup 启动过期会话的清理任务
+// 按 constants.SessionCleanupInterval 的间隔定期执行 RunSessionCleanup、RunLoginAttemptCleanup 和 RunPasswordResetCleanup。
+func StartSessionCleanup() {
+	go func() {
+		for {
+			RunSessionCleanup(context.Background())
+			RunLoginAttemptCleanup(context.Background())
+			RunPasswordResetCleanup(context.Background())
+			time.Sleep(constants.SessionCleanupInterval)
+		}
+	}()
+}
+
+// RunSessionCleanup 执行一次过期会话的清理
+func RunSessionCleanup(ctx context.Context) {
+	ctx, span := tracing.Start(ctx, "service.RunSessionCleanup")
+	defer span.End()
+
+	count, err := db.DeleteExpiredSessions(ctx, time.Now())
+	if err != nil {
+		tracing.RecordError(span, err)
+		hlog.CtxErrorf(ctx, "service.RunSessionCleanup: %v", err)
+		return
+	}
+	if count > 0 {
+		hlog.CtxInfof(ctx, "service.RunSessionCleanup: deleted %d expired sessions", count)
+	}
+}
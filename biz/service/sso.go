@@ -0,0 +1,322 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+
+	"github.com/2451965602/LMS/biz/dal/db"
+	"github.com/2451965602/LMS/config"
+	contextLogin "github.com/2451965602/LMS/pkg/base/context"
+	"github.com/2451965602/LMS/pkg/constants"
+	"github.com/2451965602/LMS/pkg/errno"
+	"github.com/2451965602/LMS/pkg/sso"
+	"github.com/2451965602/LMS/pkg/tracing"
+	"github.com/2451965602/LMS/pkg/validate"
+)
+
+var (
+	oidcProviderMu   sync.Mutex
+	oidcProvider     *sso.OIDCProvider
+	oidcProviderOpts sso.OIDCOptions // 生成 oidcProvider 时使用的参数，配置热更新后会重新创建
+)
+
+// permissionRank 权限从低到高的顺序，用户属于多个外部组时取最高权限
+var permissionRank = map[string]int{
+	"member":    1,
+	"librarian": 2,
+	"admin":     3,
+}
+
+// SSOService 用于处理单点登录，封装了 LDAP、OIDC 登录和外部账号关联操作。
+// 外部账号首次登录时自动创建本地用户，之后按外部账号标识找到同一用户。
+type SSOService struct {
+	ctx context.Context     // 上下文，用于传递请求相关的元数据
+	c   *app.RequestContext // Hertz框架的请求上下文，用于处理HTTP请求
+}
+
+// NewSSOService 创建一个新的SSOService实例，初始化上下文和请求上下文。
+func NewSSOService(ctx context.Context, c *app.RequestContext) *SSOService {
+	return &SSOService{
+		ctx: ctx,
+		c:   c,
+	}
+}
+
+// LDAPLogin 使用 LDAP 账号登录
+// 与密码登录共用失败次数限制。
+// 参数：
+//   - ctx: 上下文
+//   - username: LDAP 用户名
+//   - password: LDAP 密码
+//
+// 返回值：
+//   - *db.User: 登录成功返回对应的本地用户
+//   - error: 错误信息，如果未启用 LDAP 登录、用户名或密码错误、处于锁定期或同名本地账号未关联会返回错误
+func (s *SSOService) LDAPLogin(ctx context.Context, username, password string) (*db.User, error) {
+	ctx, span := tracing.Start(ctx, "SSOService.LDAPLogin")
+	defer span.End()
+
+	identity, err := s.authenticateLDAP(ctx, username, password)
+	if err != nil {
+		return nil, err
+	}
+	return s.resolveIdentity(ctx, identity)
+}
+
+// LinkLDAP 将 LDAP 账号关联到当前登录用户
+// 参数：
+//   - ctx: 上下文
+//   - username: LDAP 用户名
+//   - password: LDAP 密码
+//
+// 返回值：
+//   - error: 错误信息，如果用户名或密码错误、该 LDAP 账号已关联其他用户会返回错误
+func (s *SSOService) LinkLDAP(ctx context.Context, username, password string) error {
+	ctx, span := tracing.Start(ctx, "SSOService.LinkLDAP")
+	defer span.End()
+
+	userId, err := contextLogin.GetLoginData(ctx) // 从上下文中获取当前登录用户ID
+	if err != nil {
+		return err
+	}
+	identity, err := s.authenticateLDAP(ctx, username, password)
+	if err != nil {
+		return err
+	}
+	return db.LinkExternalIdentity(ctx, userId, identity.Provider, identity.Subject)
+}
+
+// OIDCAuthorizeURL 生成跳转到身份提供方登录页的地址
+// 参数：
+//   - ctx: 上下文
+//   - state: 回调时原样返回的 state 参数
+//   - nonce: 写入 ID Token 的随机值
+//
+// 返回值：
+//   - string: 登录页地址
+//   - error: 错误信息，如果未启用 OIDC 登录或获取身份提供方元数据失败会返回错误
+func (s *SSOService) OIDCAuthorizeURL(ctx context.Context, state, nonce string) (string, error) {
+	ctx, span := tracing.Start(ctx, "SSOService.OIDCAuthorizeURL")
+	defer span.End()
+
+	provider, err := s.oidcProvider()
+	if err != nil {
+		return "", err
+	}
+	url, err := provider.AuthCodeURL(ctx, state, nonce)
+	if err != nil {
+		return "", errno.Errorf(errno.InternalServiceErrorCode, "build oidc authorization url failed: %v", err)
+	}
+	return url, nil
+}
+
+// OIDCLogin 使用 OIDC 回调中的授权码登录
+// 参数：
+//   - ctx: 上下文
+//   - code: 回调中的授权码
+//   - nonce: state 参数中的 nonce
+//
+// 返回值：
+//   - *db.User: 登录成功返回对应的本地用户
+//   - error: 错误信息，如果授权码无效、ID Token 校验失败或同名本地账号未关联会返回错误
+func (s *SSOService) OIDCLogin(ctx context.Context, code, nonce string) (*db.User, error) {
+	ctx, span := tracing.Start(ctx, "SSOService.OIDCLogin")
+	defer span.End()
+
+	identity, err := s.exchangeOIDC(ctx, code, nonce)
+	if err != nil {
+		return nil, err
+	}
+	return s.resolveIdentity(ctx, identity)
+}
+
+// LinkOIDC 将 OIDC 账号关联到发起关联的用户
+// 回调请求来自浏览器跳转，不携带 Access Token，用户ID和Token版本取自签名的 state 参数。
+// 参数：
+//   - ctx: 上下文
+//   - userId: 发起关联的用户ID
+//   - version: 发起关联时用户的Token版本，之后修改过密码或退出全部设备时关联失败
+//   - code: 回调中的授权码
+//   - nonce: state 参数中的 nonce
+//
+// 返回值：
+//   - *db.User: 关联成功返回该用户信息
+//   - error: 错误信息，如果 state 已失效、授权码无效或该 OIDC 账号已关联其他用户会返回错误
+func (s *SSOService) LinkOIDC(ctx context.Context, userId, version int64, code, nonce string) (*db.User, error) {
+	ctx, span := tracing.Start(ctx, "SSOService.LinkOIDC")
+	defer span.End()
+
+	info, err := db.GetUserById(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	if info.TokenVersion != version {
+		return nil, errno.Errorf(errno.AuthInvalidCode, "link request no longer valid")
+	}
+	identity, err := s.exchangeOIDC(ctx, code, nonce)
+	if err != nil {
+		return nil, err
+	}
+	if err = db.LinkExternalIdentity(ctx, userId, identity.Provider, identity.Subject); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// authenticateLDAP 校验 LDAP 用户名和密码
+func (s *SSOService) authenticateLDAP(ctx context.Context, username, password string) (*sso.Identity, error) {
+	cfg := config.SSO.LDAP
+	if !cfg.Enabled {
+		return nil, errno.Errorf(errno.ServiceSSODisabled, "ldap login disabled")
+	}
+
+	bindPassword := cfg.BindPassword
+	if cfg.BindPasswordEnv != "" && os.Getenv(cfg.BindPasswordEnv) != "" {
+		bindPassword = os.Getenv(cfg.BindPasswordEnv)
+	}
+	provider := sso.NewLDAPProvider(sso.LDAPOptions{
+		URL:                cfg.URL,
+		StartTLS:           cfg.StartTLS,
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+		BindDN:             cfg.BindDN,
+		BindPassword:       bindPassword,
+		BaseDN:             cfg.BaseDN,
+		UserFilter:         cfg.UserFilter,
+		UsernameAttribute:  cfg.UsernameAttribute,
+		SubjectAttribute:   cfg.SubjectAttribute,
+		PhoneAttribute:     cfg.PhoneAttribute,
+		GroupAttribute:     cfg.GroupAttribute,
+		Timeout:            time.Duration(cfg.Timeout) * time.Second,
+	})
+	return s.authenticatePassword(ctx, sso.ProviderLDAP, provider, username, password)
+}
+
+// authenticatePassword 使用用户名和密码向身份提供方认证，失败时计入该用户名和客户端IP的失败次数
+// 与本地密码登录共用失败记录，认证前同样检查是否处于等待期或锁定期。
+func (s *SSOService) authenticatePassword(ctx context.Context, name string, provider sso.PasswordAuthenticator, username, password string) (*sso.Identity, error) {
+	store, err := loginAttemptStore()
+	if err != nil {
+		return nil, err
+	}
+	ip := s.c.ClientIP()
+	if err = checkLoginAllowed(ctx, store, username, ip); err != nil {
+		return nil, err
+	}
+
+	identity, err := provider.Authenticate(ctx, username, password)
+	if err != nil {
+		if errors.Is(err, sso.ErrInvalidCredentials) {
+			recordLoginFailure(ctx, store, username, ip)
+			return nil, errno.Errorf(errno.ServiceUserNotExist, "user not found or invalid credentials (%s username: %s)", name, username)
+		}
+		return nil, errno.Errorf(errno.InternalServiceErrorCode, "%s authenticate failed: %v", name, err)
+	}
+
+	if err = store.Reset(ctx, constants.LoginAttemptUserPrefix+username); err != nil {
+		hlog.CtxErrorf(ctx, "SSOService.authenticatePassword: %v", err)
+	}
+	return identity, nil
+}
+
+// exchangeOIDC 使用授权码换取并校验 ID Token
+func (s *SSOService) exchangeOIDC(ctx context.Context, code, nonce string) (*sso.Identity, error) {
+	provider, err := s.oidcProvider()
+	if err != nil {
+		return nil, err
+	}
+	identity, err := provider.Exchange(ctx, code, nonce)
+	if err != nil {
+		return nil, errno.Errorf(errno.AuthInvalidCode, "oidc login failed: %v", err)
+	}
+	return identity, nil
+}
+
+// oidcProvider 返回当前配置对应的 OIDC 身份提供方，配置不变时复用已获取的元数据
+func (s *SSOService) oidcProvider() (*sso.OIDCProvider, error) {
+	cfg := config.SSO.OIDC
+	if !cfg.Enabled {
+		return nil, errno.Errorf(errno.ServiceSSODisabled, "oidc login disabled")
+	}
+
+	clientSecret := cfg.ClientSecret
+	if cfg.ClientSecretEnv != "" && os.Getenv(cfg.ClientSecretEnv) != "" {
+		clientSecret = os.Getenv(cfg.ClientSecretEnv)
+	}
+	opts := sso.OIDCOptions{
+		Issuer:        cfg.Issuer,
+		ClientID:      cfg.ClientID,
+		ClientSecret:  clientSecret,
+		RedirectURL:   cfg.RedirectURL,
+		Scopes:        cfg.Scopes,
+		UsernameClaim: cfg.UsernameClaim,
+		PhoneClaim:    cfg.PhoneClaim,
+		GroupsClaim:   cfg.GroupsClaim,
+	}
+
+	oidcProviderMu.Lock()
+	defer oidcProviderMu.Unlock()
+	if oidcProvider == nil || !reflect.DeepEqual(oidcProviderOpts, opts) {
+		oidcProvider = sso.NewOIDCProvider(opts)
+		oidcProviderOpts = opts
+	}
+	return oidcProvider, nil
+}
+
+// resolveIdentity 找到外部账号对应的本地用户
+// 已关联时同步权限并返回该用户；未关联时按外部账号信息自动创建用户。
+func (s *SSOService) resolveIdentity(ctx context.Context, identity *sso.Identity) (*db.User, error) {
+	permission := mapPermission(identity.Groups)
+
+	linked, info, err := db.GetUserByExternalIdentity(ctx, identity.Provider, identity.Subject)
+	if err == nil {
+		return db.SyncExternalLogin(ctx, linked, info, permission)
+	}
+	if errno.ConvertErr(err).ErrorCode != errno.ServiceUserNotExist {
+		return nil, err
+	}
+
+	var phone *string
+	if identity.Phone != "" {
+		if p, err := validate.Phone(identity.Phone, config.Registration.PhoneRegion); err == nil { // 身份提供方的手机号格式不正确时不保存
+			phone = &p
+		}
+	}
+	info, err = db.ProvisionExternalUser(ctx, identity.Provider, identity.Subject, identity.Username,
+		config.Registration.DefaultPatronType, phone, permission)
+	if err != nil {
+		return nil, err
+	}
+	hlog.CtxInfof(ctx, "SSOService.resolveIdentity: provisioned user (id: %d) for %s account %s", info.ID, identity.Provider, identity.Subject)
+	return info, nil
+}
+
+// mapPermission 按配置的组映射计算外部账号对应的本地权限
+// 组名比较时忽略大小写；属于多个组时取最高权限，没有匹配的组时使用默认权限。
+func mapPermission(groups []string) string {
+	cfg := config.SSO
+	permission := cfg.DefaultPermission
+	if _, ok := permissionRank[permission]; !ok {
+		permission = "member"
+	}
+	for _, mapping := range cfg.GroupMappings {
+		rank, ok := permissionRank[mapping.Permission]
+		if !ok || rank <= permissionRank[permission] {
+			continue
+		}
+		for _, group := range groups {
+			if strings.EqualFold(group, mapping.Group) {
+				permission = mapping.Permission
+				break
+			}
+		}
+	}
+	return permission
+}
@@ -0,0 +1,322 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+
+	"github.com/2451965602/LMS/biz/dal/db"
+	"github.com/2451965602/LMS/config"
+	contextLogin "github.com/2451965602/LMS/pkg/base/context"
+	"github.com/2451965602/LMS/pkg/constants"
+	"github.com/2451965602/LMS/pkg/crypt"
+	"github.com/2451965602/LMS/pkg/errno"
+	"github.com/2451965602/LMS/pkg/totp"
+	"github.com/2451965602/LMS/pkg/tracing"
+)
+
+// TwoFactorService 用于管理两步验证，封装了 TOTP 的设置、启用、关闭、恢复码和登录第二步校验。
+type TwoFactorService struct {
+	ctx context.Context     // 上下文，用于传递请求相关的元数据
+	c   *app.RequestContext // Hertz框架的请求上下文，用于处理HTTP请求
+}
+
+// NewTwoFactorService 创建一个新的TwoFactorService实例，初始化上下文和请求上下文。
+func NewTwoFactorService(ctx context.Context, c *app.RequestContext) *TwoFactorService {
+	return &TwoFactorService{
+		ctx: ctx,
+		c:   c,
+	}
+}
+
+// Setup 为当前登录用户生成新的 TOTP 密钥
+// 密钥在调用 Enable 并通过校验之前不会生效。
+// 参数：
+//   - ctx: 上下文
+//
+// 返回值：
+//   - string: Base32 编码的密钥，供无法扫码时手动输入
+//   - string: otpauth 格式的配置 URI，客户端将其渲染为二维码
+//   - error: 错误信息，如果已启用两步验证或保存失败会返回错误
+func (s *TwoFactorService) Setup(ctx context.Context) (string, string, error) {
+	ctx, span := tracing.Start(ctx, "TwoFactorService.Setup")
+	defer span.End()
+
+	info, err := s.currentUser(ctx)
+	if err != nil {
+		return "", "", err
+	}
+	if info.TOTPEnabled {
+		return "", "", errno.Errorf(errno.ServiceTwoFactorAlreadyEnabled, "two-factor authentication already enabled")
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return "", "", errno.Errorf(errno.InternalServiceErrorCode, "generate totp secret failed: %v", err)
+	}
+	if err = db.SetTOTPSecret(ctx, info.ID, secret); err != nil {
+		return "", "", err
+	}
+	return secret, totp.ProvisioningURI(config.TwoFactor.Issuer, info.Name, secret), nil
+}
+
+// Enable 校验验证器应用生成的验证码并启用两步验证
+// 参数：
+//   - ctx: 上下文
+//   - code: 验证器应用当前显示的验证码
+//
+// 返回值：
+//   - []string: 恢复码明文，只在此时返回一次
+//   - error: 错误信息，如果未设置密钥、验证码错误或保存失败会返回错误
+func (s *TwoFactorService) Enable(ctx context.Context, code string) ([]string, error) {
+	ctx, span := tracing.Start(ctx, "TwoFactorService.Enable")
+	defer span.End()
+
+	info, err := s.currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if info.TOTPEnabled {
+		return nil, errno.Errorf(errno.ServiceTwoFactorAlreadyEnabled, "two-factor authentication already enabled")
+	}
+	if info.TOTPSecret == "" {
+		return nil, errno.Errorf(errno.ServiceTwoFactorNotEnabled, "two-factor authentication not set up")
+	}
+
+	counter, ok := totp.Validate(info.TOTPSecret, code, time.Now(), config.TwoFactor.Skew)
+	if !ok {
+		return nil, errno.Errorf(errno.ServiceTwoFactorInvalid, "invalid two-factor code")
+	}
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err = db.EnableTOTP(ctx, info.ID, counter, hashes); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// Disable 关闭当前登录用户的两步验证
+// 需要同时提供密码和验证码；所属角色要求两步验证时不能关闭。
+// 参数：
+//   - ctx: 上下文
+//   - password: 当前密码
+//   - code: 验证器应用生成的验证码或恢复码
+//
+// 返回值：
+//   - error: 错误信息，如果密码或验证码错误、角色要求两步验证会返回错误
+func (s *TwoFactorService) Disable(ctx context.Context, password, code string) error {
+	ctx, span := tracing.Start(ctx, "TwoFactorService.Disable")
+	defer span.End()
+
+	info, err := s.currentUser(ctx)
+	if err != nil {
+		return err
+	}
+	if !info.TOTPEnabled {
+		return errno.Errorf(errno.ServiceTwoFactorNotEnabled, "two-factor authentication not enabled")
+	}
+	required, err := db.IsTwoFactorRequired(ctx, info.Permission)
+	if err != nil {
+		return err
+	}
+	if required {
+		return errno.Errorf(errno.ServiceTwoFactorRequired, "two-factor authentication is required for role %s", info.Permission)
+	}
+
+	if !crypt.PasswordVerify(password, info.Password) {
+		return errno.Errorf(errno.ServicePasswordMismatch, "current password is incorrect")
+	}
+	if err = s.verifyCode(ctx, info, code, true); err != nil {
+		return err
+	}
+	return db.DisableTOTP(ctx, info.ID)
+}
+
+// RegenerateRecoveryCodes 重新生成恢复码，之前的恢复码全部失效
+// 参数：
+//   - ctx: 上下文
+//   - code: 验证器应用生成的验证码，不接受恢复码
+//
+// 返回值：
+//   - []string: 新的恢复码明文，只在此时返回一次
+//   - error: 错误信息，如果未启用两步验证或验证码错误会返回错误
+func (s *TwoFactorService) RegenerateRecoveryCodes(ctx context.Context, code string) ([]string, error) {
+	ctx, span := tracing.Start(ctx, "TwoFactorService.RegenerateRecoveryCodes")
+	defer span.End()
+
+	info, err := s.currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !info.TOTPEnabled {
+		return nil, errno.Errorf(errno.ServiceTwoFactorNotEnabled, "two-factor authentication not enabled")
+	}
+	if err = s.verifyCode(ctx, info, code, false); err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err = db.ReplaceRecoveryCodes(ctx, info.ID, hashes); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// VerifyLogin 完成两步登录的第二步
+// 与密码登录共用失败次数限制，验证码错误会计入该用户名和客户端IP的失败次数。
+// 参数：
+//   - ctx: 上下文
+//   - userId: 挑战Token中的用户ID
+//   - version: 挑战Token签发时用户的Token版本，修改密码后之前的挑战Token失效
+//   - code: 验证器应用生成的验证码或恢复码
+//
+// 返回值：
+//   - *db.User: 校验通过的用户信息
+//   - error: 错误信息，如果挑战Token已失效、验证码错误或处于锁定期会返回错误
+func (s *TwoFactorService) VerifyLogin(ctx context.Context, userId, version int64, code string) (*db.User, error) {
+	ctx, span := tracing.Start(ctx, "TwoFactorService.VerifyLogin")
+	defer span.End()
+
+	info, err := db.GetUserById(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	if info.TokenVersion != version || !info.TOTPEnabled {
+		return nil, errno.Errorf(errno.AuthInvalidCode, "challenge token no longer valid")
+	}
+
+	store, err := loginAttemptStore()
+	if err != nil {
+		return nil, err
+	}
+	ip := s.c.ClientIP()
+	if err = checkLoginAllowed(ctx, store, info.Name, ip); err != nil {
+		return nil, err
+	}
+
+	if err = s.verifyCode(ctx, info, code, true); err != nil {
+		if errno.ConvertErr(err).ErrorCode == errno.ServiceTwoFactorInvalid {
+			recordLoginFailure(ctx, store, info.Name, ip)
+		}
+		return nil, err
+	}
+
+	if err = store.Reset(ctx, constants.LoginAttemptUserPrefix+info.Name); err != nil {
+		hlog.CtxErrorf(ctx, "TwoFactorService.VerifyLogin: %v", err)
+	}
+	return info, nil
+}
+
+// SetPolicy 设置角色是否必须启用两步验证
+// 参数：
+//   - ctx: 上下文
+//   - role: 角色，admin、librarian 或 member
+//   - required: 是否必须启用
+//
+// 返回值：
+//   - error: 错误信息，如果当前用户不是管理员或角色无效会返回错误
+func (s *TwoFactorService) SetPolicy(ctx context.Context, role string, required bool) error {
+	ctx, span := tracing.Start(ctx, "TwoFactorService.SetPolicy")
+	defer span.End()
+
+	if err := s.checkAdmin(ctx); err != nil {
+		return err
+	}
+	switch role {
+	case "admin", "librarian", "member":
+	default:
+		return errno.Errorf(errno.ParamVerifyErrorCode, "invalid role %q", role)
+	}
+	return db.SetTwoFactorPolicy(ctx, role, required)
+}
+
+// ListPolicies 获取所有角色的两步验证策略
+// 参数：
+//   - ctx: 上下文
+//
+// 返回值：
+//   - []*db.TwoFactorPolicy: 已设置的策略，未设置的角色不要求两步验证
+//   - error: 错误信息，如果当前用户不是管理员会返回错误
+func (s *TwoFactorService) ListPolicies(ctx context.Context) ([]*db.TwoFactorPolicy, error) {
+	ctx, span := tracing.Start(ctx, "TwoFactorService.ListPolicies")
+	defer span.End()
+
+	if err := s.checkAdmin(ctx); err != nil {
+		return nil, err
+	}
+	return db.GetTwoFactorPolicies(ctx)
+}
+
+// currentUser 获取当前登录用户的完整信息
+func (s *TwoFactorService) currentUser(ctx context.Context) (*db.User, error) {
+	userId, err := contextLogin.GetLoginData(ctx) // 从上下文中获取当前登录用户ID
+	if err != nil {
+		return nil, err
+	}
+	return db.GetUserById(ctx, userId)
+}
+
+// checkAdmin 检查当前登录用户是否为管理员
+func (s *TwoFactorService) checkAdmin(ctx context.Context) error {
+	currentUserID, err := contextLogin.GetLoginData(ctx) // 获取当前登录用户ID
+	if err != nil {
+		return err
+	}
+	ok, err := db.IsPermission(ctx, currentUserID, "admin") // 检查当前用户是否有管理员权限
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errno.Errorf(errno.ServicePermissionDenied, "permission denied") // 如果没有权限，返回错误
+	}
+	return nil
+}
+
+// verifyCode 校验验证码，allowRecovery 为真时也接受恢复码
+// 每个时间步的验证码和每个恢复码都只能使用一次。
+func (s *TwoFactorService) verifyCode(ctx context.Context, info *db.User, code string, allowRecovery bool) error {
+	code = strings.TrimSpace(code)
+	if counter, ok := totp.Validate(info.TOTPSecret, code, time.Now(), config.TwoFactor.Skew); ok {
+		used, err := db.UseTOTPCounter(ctx, info.ID, counter)
+		if err != nil {
+			return err
+		}
+		if used {
+			return nil
+		}
+	} else if allowRecovery {
+		used, err := db.UseRecoveryCode(ctx, info.ID, crypt.TokenHash(strings.ToLower(code)))
+		if err != nil {
+			return err
+		}
+		if used {
+			hlog.CtxInfof(ctx, "TwoFactorService.verifyCode: user (id: %d) used a recovery code", info.ID)
+			return nil
+		}
+	}
+	return errno.Errorf(errno.ServiceTwoFactorInvalid, "invalid two-factor code")
+}
+
+// newRecoveryCodes 生成一组恢复码，返回明文和用于保存的摘要
+func newRecoveryCodes() ([]string, []string, error) {
+	n := config.TwoFactor.RecoveryCodes
+	codes := make([]string, 0, n)
+	hashes := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		code, err := crypt.RandomToken(constants.RecoveryCodeBytes)
+		if err != nil {
+			return nil, nil, err
+		}
+		codes = append(codes, code)
+		hashes = append(hashes, crypt.TokenHash(code))
+	}
+	return codes, hashes, nil
+}
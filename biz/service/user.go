@@ -2,12 +2,19 @@ package service
 
 import (
 	"context"
+
 	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/hlog"
 
 	"github.com/2451965602/LMS/biz/dal/db"
 	"github.com/2451965602/LMS/biz/model/user"
+	"github.com/2451965602/LMS/config"
 	contextLogin "github.com/2451965602/LMS/pkg/base/context"
+	"github.com/2451965602/LMS/pkg/constants"
 	"github.com/2451965602/LMS/pkg/errno"
+	"github.com/2451965602/LMS/pkg/etag"
+	"github.com/2451965602/LMS/pkg/tracing"
+	"github.com/2451965602/LMS/pkg/validate"
 )
 
 // UserService 用于管理用户相关的业务逻辑，封装了用户注册、登录、更新、删除等操作。
@@ -25,22 +32,27 @@ func NewUserService(ctx context.Context, c *app.RequestContext) *UserService {
 }
 
 // Register 注册新用户
+// 用户名、手机号和邮箱按配置的注册规则校验，手机号以 E.164 格式保存。
 // 参数：
 //   - ctx: 上下文
-//   - username: 用户名
-//   - password: 密码
+//   - req: 注册请求，包含用户名、密码、读者类型和联系方式
 //
 // 返回值：
 //   - int64: 注册成功用户的ID
-//   - error: 错误信息，如果用户已存在或注册失败会返回错误
-func (s *UserService) Register(ctx context.Context, username, password, phone string) (int64, error) {
+//   - error: 错误信息，如果校验失败、用户已存在或注册失败会返回错误
+func (s *UserService) Register(ctx context.Context, req user.RegisterRequest) (int64, error) {
+	ctx, span := tracing.Start(ctx, "UserService.Register")
+	defer span.End()
 
-	err := RegisterCheck(username, phone)
+	fields, err := RegisterCheck(req.Username, req.PatronType, req.Phone, req.Email)
 	if err != nil {
 		return 0, err
 	}
+	if err = CheckPassword(req.Password, req.Username); err != nil {
+		return 0, err
+	}
 
-	exit, err := db.IsUserExist(ctx, username) // 检查用户是否已存在
+	exit, err := db.IsUserExist(ctx, req.Username) // 检查用户是否已存在
 	if err != nil {
 		return 0, err
 	}
@@ -48,7 +60,7 @@ func (s *UserService) Register(ctx context.Context, username, password, phone st
 		return 0, errno.Errorf(errno.ServiceUserExist, "user already exist") // 如果用户已存在，返回错误
 	}
 
-	id, err := db.RegisterUser(ctx, username, password, phone) // 调用数据库操作函数注册用户
+	id, err := db.RegisterUser(ctx, req.Username, req.Password, fields.PatronType, fields.Phone, fields.Email) // 调用数据库操作函数注册用户
 	if err != nil {
 		return 0, err
 	}
@@ -56,6 +68,7 @@ func (s *UserService) Register(ctx context.Context, username, password, phone st
 }
 
 // Login 用户登录
+// 登录前检查用户名和客户端IP是否处于等待期或锁定期，密码错误时记录失败次数，登录成功后清除该用户名的失败记录。
 // 参数：
 //   - ctx: 上下文
 //   - username: 用户名
@@ -65,10 +78,30 @@ func (s *UserService) Register(ctx context.Context, username, password, phone st
 //   - *db.User: 登录成功返回用户信息
 //   - error: 错误信息，如果登录失败会返回错误
 func (s *UserService) Login(ctx context.Context, username, password string) (*db.User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.Login")
+	defer span.End()
+
+	store, err := loginAttemptStore()
+	if err != nil {
+		return nil, err
+	}
+	ip := s.c.ClientIP()
+	if err = checkLoginAllowed(ctx, store, username, ip); err != nil { // 检查是否因多次失败需要等待或已被锁定
+		return nil, err
+	}
+
 	info, err := db.LoginUser(ctx, username, password) // 调用数据库操作函数进行用户登录验证
 	if err != nil {
+		if errno.ConvertErr(err).ErrorCode == errno.ServiceUserNotExist { // 用户名或密码错误时记录失败次数
+			recordLoginFailure(ctx, store, username, ip)
+		}
 		return nil, err
 	}
+
+	// IP 的失败记录不在此清除，避免攻击者用自己的账号登录来重置计数
+	if err = store.Reset(ctx, constants.LoginAttemptUserPrefix+username); err != nil {
+		hlog.CtxErrorf(ctx, "UserService.Login: %v", err)
+	}
 	return info, nil
 }
 
@@ -81,10 +114,17 @@ func (s *UserService) Login(ctx context.Context, username, password string) (*db
 //   - *db.User: 更新成功返回用户信息
 //   - error: 错误信息，如果更新失败会返回错误
 func (s *UserService) UpdateUser(ctx context.Context, req user.UpdateUserRequest) (*db.User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.UpdateUser")
+	defer span.End()
+
 	userId, err := contextLogin.GetLoginData(ctx) // 从上下文中获取当前登录用户ID
 	if err != nil {
 		return nil, err
 	}
+	req.Phone, req.Email, err = contactUpdateCheck(req.Phone, req.Email) // 校验并规范化手机号和邮箱
+	if err != nil {
+		return nil, err
+	}
 
 	info, err := db.UpdateUser(ctx, userId, req) // 调用数据库操作函数更新用户信息
 	if err != nil {
@@ -101,6 +141,9 @@ func (s *UserService) UpdateUser(ctx context.Context, req user.UpdateUserRequest
 // 返回值：
 //   - error: 错误信息，如果删除失败会返回错误
 func (s *UserService) DeleteUser(ctx context.Context, username string) error {
+	ctx, span := tracing.Start(ctx, "UserService.DeleteUser")
+	defer span.End()
+
 	currentUserID, err := contextLogin.GetLoginData(ctx) // 获取当前登录用户ID
 	if err != nil {
 		return err
@@ -113,20 +156,38 @@ func (s *UserService) DeleteUser(ctx context.Context, username string) error {
 	return nil
 }
 
-// GetUserById 根据用户ID获取用户信息
+// GetUserInfo 获取用户信息，并判断当前登录用户能否查看完整信息
+// 只有用户本人和工作人员（管理员、图书管理员）可以查看完整信息，其他用户只能查看公开信息。
 // 参数：
 //   - ctx: 上下文
-//   - userId: 用户ID
+//   - userId: 要查看的用户ID
 //
 // 返回值：
-//   - *db.User: 获取成功返回用户信息
-//   - error: 错误信息，如果获取失败会返回错误
-func (s *UserService) GetUserById(ctx context.Context, userId int64) (*db.User, error) {
-	info, err := db.GetUserById(ctx, userId) // 调用数据库操作函数根据ID获取用户信息
+//   - *db.User: 用户信息
+//   - bool: 当前登录用户能否查看完整信息
+//   - error: 错误信息，如果用户不存在或获取失败会返回错误
+func (s *UserService) GetUserInfo(ctx context.Context, userId int64) (*db.User, bool, error) {
+	ctx, span := tracing.Start(ctx, "UserService.GetUserInfo")
+	defer span.End()
+
+	currentUserID, err := contextLogin.GetLoginData(ctx) // 获取当前登录用户ID
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
-	return info, nil
+
+	info, err := db.GetUserById(ctx, userId)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if currentUserID == userId {
+		return info, true, nil
+	}
+	full, err := db.IsPermission(ctx, currentUserID, "librarian") // 管理员和图书管理员可以查看完整信息
+	if err != nil {
+		return nil, false, err
+	}
+	return info, full, nil
 }
 
 // GetUserByName 根据用户名获取用户信息
@@ -138,6 +199,9 @@ func (s *UserService) GetUserById(ctx context.Context, userId int64) (*db.User,
 //   - *db.User: 获取成功返回用户信息
 //   - error: 错误信息，如果获取失败会返回错误
 func (s *UserService) GetUserByName(ctx context.Context, username string) (*db.User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.GetUserByName")
+	defer span.End()
+
 	info, err := db.GetUserByName(ctx, username) // 调用数据库操作函数根据用户名获取用户信息
 	if err != nil {
 		return nil, err
@@ -152,8 +216,11 @@ func (s *UserService) GetUserByName(ctx context.Context, username string) (*db.U
 //
 // 返回值：
 //   - *db.User: 更新成功返回用户信息
-//   - error: 错误信息，如果更新失败会返回错误
+//   - error: 错误信息，如果更新失败会返回错误，If-Match 中的版本已过期时返回 errno.ServiceVersionConflict，此时同时返回当前的用户信息
 func (s *UserService) AdminUpdateUser(ctx context.Context, req user.AdminUpdateUserRequest) (*db.User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.AdminUpdateUser")
+	defer span.End()
+
 	currentUserID, err := contextLogin.GetLoginData(ctx) // 获取当前登录用户ID
 	if err != nil {
 		return nil, err
@@ -165,11 +232,23 @@ func (s *UserService) AdminUpdateUser(ctx context.Context, req user.AdminUpdateU
 	if !ok {
 		return nil, errno.Errorf(errno.ServicePermissionDenied, "permission denied") // 如果没有权限，返回错误
 	}
+	if req.Password != nil && *req.Password != "" {
+		if err = CheckPassword(*req.Password, ""); err != nil { // 管理员设置的临时密码同样需要符合密码策略
+			return nil, err
+		}
+	}
+	if err = s.adminUpdateCheck(ctx, &req); err != nil {
+		return nil, err
+	}
 
-	info, err := db.AdminUpdateUser(ctx, req) // 调用数据库操作函数进行管理员更新用户操作
+	version, err := etag.ParseIfMatch(string(s.c.GetHeader(constants.IfMatchHeader)))
 	if err != nil {
 		return nil, err
 	}
+	info, err := db.AdminUpdateUser(ctx, req, version) // 调用数据库操作函数进行管理员更新用户操作
+	if err != nil {
+		return info, err
+	}
 	return info, nil
 }
 
@@ -181,6 +260,9 @@ func (s *UserService) AdminUpdateUser(ctx context.Context, req user.AdminUpdateU
 // 返回值：
 //   - error: 错误信息，如果删除失败会返回错误
 func (s *UserService) AdminDeleteUser(ctx context.Context, req user.AdminDeleteUserRequest) error {
+	ctx, span := tracing.Start(ctx, "UserService.AdminDeleteUser")
+	defer span.End()
+
 	currentUserID, err := contextLogin.GetLoginData(ctx) // 获取当前登录用户ID
 	if err != nil {
 		return err
@@ -199,3 +281,84 @@ func (s *UserService) AdminDeleteUser(ctx context.Context, req user.AdminDeleteU
 	}
 	return nil
 }
+
+// LogoutAll 注销当前用户的所有登录
+// 增加用户的 Token 版本，之前签发的 Access Token 和 Refresh Token 全部失效。
+// 参数：
+//   - ctx: 上下文
+//
+// 返回值：
+//   - error: 错误信息，如果注销失败会返回错误
+func (s *UserService) LogoutAll(ctx context.Context) error {
+	ctx, span := tracing.Start(ctx, "UserService.LogoutAll")
+	defer span.End()
+
+	userId, err := contextLogin.GetLoginData(ctx) // 获取当前登录用户ID
+	if err != nil {
+		return err
+	}
+
+	return db.IncreaseTokenVersion(ctx, userId)
+}
+
+// adminUpdateCheck 校验管理员修改的用户名、读者类型和联系方式
+// 修改用户名或读者类型时，用户名需符合修改后的读者类型对应的规则。
+func (s *UserService) adminUpdateCheck(ctx context.Context, req *user.AdminUpdateUserRequest) error {
+	var errs validate.Errors
+	if (req.Username != nil && *req.Username != "") || (req.PatronType != nil && *req.PatronType != "") {
+		info, err := db.GetUserById(ctx, req.UserID)
+		if err != nil {
+			return err
+		}
+		username, patronType := info.Name, info.PatronType
+		if req.Username != nil && *req.Username != "" {
+			username = *req.Username
+		}
+		field := "username"
+		if req.PatronType != nil && *req.PatronType != "" {
+			patronType = *req.PatronType
+			field = "patron_type"
+		}
+		if _, err = usernameRule(patronType); err != nil {
+			errs.AddErr("patron_type", validate.RuleEnum, err)
+		} else if err = UsernameCheck(username, patronType); err != nil {
+			errs.AddErr(field, validate.RulePattern, err)
+		}
+	}
+	phone, email, err := contactUpdateCheck(req.Phone, req.Email)
+	if err != nil {
+		errs = append(errs, errno.ConvertErr(err).Details...)
+	}
+	if err = errs.Err(); err != nil {
+		return err
+	}
+	req.Phone, req.Email = phone, email
+	return nil
+}
+
+// contactUpdateCheck 校验修改资料时提交的手机号和邮箱，返回规范化后的值
+// 未提交的字段返回 nil；提交空字符串表示清除该字段，配置要求必填的字段不能清除。
+func contactUpdateCheck(phone, email *string) (*string, *string, error) {
+	cfg := config.Registration
+	var errs validate.Errors
+	if phone != nil && *phone == "" && cfg.PhoneRequired {
+		errs.Add("phone", validate.RuleRequired, errno.ParamMissingErrorCode, "phone is required")
+	}
+	if email != nil && *email == "" && cfg.EmailRequired {
+		errs.Add("email", validate.RuleRequired, errno.ParamMissingErrorCode, "email is required")
+	}
+	normalizedPhone, normalizedEmail, err := ContactCheck(phone, email)
+	if err != nil {
+		errs = append(errs, errno.ConvertErr(err).Details...)
+	}
+	if err = errs.Err(); err != nil {
+		return nil, nil, err
+	}
+	if normalizedPhone != nil {
+		phone = normalizedPhone
+	}
+	if normalizedEmail != nil {
+		email = normalizedEmail
+	}
+	return phone, email, nil
+}
@@ -1,86 +1,355 @@
 package service
 
 import (
-	"github.com/2451965602/LMS/pkg/errno"
+	"fmt"
 	"regexp"
-	"strconv"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/2451965602/LMS/biz/dal/db"
+	"github.com/2451965602/LMS/biz/model/book"
+	"github.com/2451965602/LMS/biz/model/booktype"
+	"github.com/2451965602/LMS/config"
+	"github.com/2451965602/LMS/pkg/constants"
+	"github.com/2451965602/LMS/pkg/errno"
+	"github.com/2451965602/LMS/pkg/isbn"
+	"github.com/2451965602/LMS/pkg/validate"
 )
 
-func CheckMobile(phone string) bool {
-	// 匹配规则
-	// ^1第一位为一
-	// [345789]{1} 后接一位345789 的数字
-	// \\d \d的转义 表示数字 {9} 接9位
-	// $ 结束符
-	regRuler := "^1[345789]{1}\\d{9}$"
+// bookStatuses 图书副本的全部状态
+var bookStatuses = []string{
+	constants.BookStatusAvailable,
+	constants.BookStatusCheckedOut,
+	constants.BookStatusLost,
+	constants.BookStatusDamaged,
+	constants.BookStatusInRepair,
+	constants.BookStatusWithdrawn,
+}
+
+// bookInitialStatuses 新入库副本允许的初始状态，其余状态只能由状态变更或借还产生
+var bookInitialStatuses = []string{
+	constants.BookStatusAvailable,
+	constants.BookStatusInRepair,
+}
+
+// RegisterFields 通过校验并规范化后的注册信息
+type RegisterFields struct {
+	PatronType string  // 读者类型
+	Phone      *string // E.164 格式的手机号，未填写时为 nil
+	Email      *string // 邮箱，未填写时为 nil
+}
 
-	// 正则调用规则
-	reg := regexp.MustCompile(regRuler)
+// RegisterCheck 按配置的注册规则校验注册信息
+// 所有字段都检查完后一次返回，错误详情中列出每个出错的字段。
+// 参数：
+//   - username: 用户名，需符合读者类型对应的规则
+//   - patronType: 读者类型，为空时使用配置的默认类型
+//   - phone: 手机号，配置要求时必填
+//   - email: 邮箱，配置要求时必填
+//
+// 返回值：
+//   - *RegisterFields: 规范化后的读者类型、手机号和邮箱
+//   - error: 错误信息，任一字段不符合规则时返回错误
+func RegisterCheck(username string, patronType, phone, email *string) (*RegisterFields, error) {
+	cfg := config.Registration
+	var errs validate.Errors
+
+	fields := &RegisterFields{PatronType: cfg.DefaultPatronType}
+	if patronType != nil && *patronType != "" {
+		fields.PatronType = *patronType
+	}
+	if rule, err := usernameRule(fields.PatronType); err != nil {
+		errs.AddErr("patron_type", validate.RuleEnum, err)
+	} else {
+		errs.AddErr("username", validate.RulePattern, rule.Check(username))
+	}
 
-	// 返回 MatchString 是否匹配
-	return reg.MatchString(phone)
+	if phone == nil || *phone == "" {
+		if cfg.PhoneRequired {
+			errs.Add("phone", validate.RuleRequired, errno.ParamMissingErrorCode, "phone is required")
+		}
+	}
+	if email == nil || *email == "" {
+		if cfg.EmailRequired {
+			errs.Add("email", validate.RuleRequired, errno.ParamMissingErrorCode, "email is required")
+		}
+	}
+	var err error
+	fields.Phone, fields.Email, err = ContactCheck(phone, email)
+	if err != nil {
+		errs = append(errs, errno.ConvertErr(err).Details...)
+	}
 
+	if err = errs.Err(); err != nil {
+		return nil, err
+	}
+	return fields, nil
 }
 
-func isValidISBN10(isbn string) bool {
-	sum := 0
-	for i, char := range isbn {
-		var digit int
-		if i == 9 && char == 'X' {
-			digit = 10
+// ContactCheck 校验手机号和邮箱，未填写的字段不检查
+// 参数：
+//   - phone: 手机号，不带国际区号时按配置的默认地区解析
+//   - email: 邮箱
+//
+// 返回值：
+//   - *string: E.164 格式的手机号，未填写时为 nil
+//   - *string: 规范化后的邮箱，未填写时为 nil
+//   - error: 错误信息，任一字段格式不正确时返回错误
+func ContactCheck(phone, email *string) (*string, *string, error) {
+	var errs validate.Errors
+	var normalizedPhone, normalizedEmail *string
+	if phone != nil && *phone != "" {
+		if p, err := validate.Phone(*phone, config.Registration.PhoneRegion); err != nil {
+			errs.AddErr("phone", validate.RulePhone, err)
+		} else {
+			normalizedPhone = &p
+		}
+	}
+	if email != nil && *email != "" {
+		if e, err := validate.Email(*email); err != nil {
+			errs.AddErr("email", validate.RuleEmail, err)
 		} else {
-			digit, _ = strconv.Atoi(string(char))
-			if digit < 0 || digit > 9 {
-				return false
+			normalizedEmail = &e
+		}
+	}
+	if err := errs.Err(); err != nil {
+		return nil, nil, err
+	}
+	return normalizedPhone, normalizedEmail, nil
+}
+
+// SearchBookCheck 校验图书搜索的过滤条件
+// 参数：
+//   - req: 搜索图书请求，status 可以重复传入，也可以用逗号分隔多个状态
+//
+// 返回值：
+//   - []string: 去重后的状态列表
+//   - error: 错误信息，状态不存在或范围的下限大于上限时返回错误
+func SearchBookCheck(req *book.GetBookRequest) ([]string, error) {
+	var errs validate.Errors
+
+	var statuses []string
+	seen := make(map[string]bool)
+	for _, item := range req.Status {
+		for _, status := range strings.Split(item, ",") {
+			status = strings.TrimSpace(status)
+			if status == "" || seen[status] {
+				continue
+			}
+			if !slices.Contains(bookStatuses, status) {
+				allowed := strings.Join(bookStatuses, ", ")
+				errs.AddErr("status", validate.RuleEnum, validate.NewFieldError("status", validate.RuleEnum, errno.ParamVerifyErrorCode,
+					map[string]string{"allowed": allowed}, "status must be one of %s", allowed))
+				break
 			}
+			seen[status] = true
+			statuses = append(statuses, status)
 		}
-		sum += digit * (10 - i)
 	}
-	return sum%11 == 0
+
+	if req.PurchasedAfter != nil && req.PurchasedBefore != nil && *req.PurchasedAfter > *req.PurchasedBefore {
+		errs.Add("purchased_after", validate.RuleValidate, errno.ParamVerifyErrorCode, "purchased_after must not be later than purchased_before")
+	}
+	if req.MinPrice != nil && req.MaxPrice != nil && *req.MinPrice > *req.MaxPrice {
+		errs.Add("min_price", validate.RuleValidate, errno.ParamVerifyErrorCode, "min_price must not be greater than max_price")
+	}
+
+	if err := errs.Err(); err != nil {
+		return nil, err
+	}
+	return statuses, nil
+}
+
+// UsernameCheck 按读者类型对应的规则校验用户名
+func UsernameCheck(username, patronType string) error {
+	rule, err := usernameRule(patronType)
+	if err != nil {
+		return err
+	}
+	return rule.Check(username)
 }
 
-func isValidISBN13(isbn string) bool {
-	sum := 0
-	for i, char := range isbn {
-		digit, _ := strconv.Atoi(string(char))
-		if digit < 0 || digit > 9 {
-			return false
+// usernameRule 查找读者类型对应的用户名规则
+func usernameRule(patronType string) (validate.UsernameRule, error) {
+	names := make([]string, 0, len(config.Registration.PatronTypes))
+	for _, t := range config.Registration.PatronTypes {
+		if t.Name == patronType {
+			return validate.UsernameRule{
+				PatronType:  t.Name,
+				Pattern:     t.UsernamePattern,
+				Description: t.Description,
+			}, nil
 		}
-		if i%2 == 0 {
-			sum += digit
-		} else {
-			sum += digit * 3
+		names = append(names, t.Name)
+	}
+	allowed := strings.Join(names, ", ")
+	return validate.UsernameRule{}, validate.NewFieldError("patron_type", validate.RuleEnum, errno.ServiceInvalidPatronType,
+		map[string]string{"allowed": allowed}, "invalid patron type %q, must be one of: %s", patronType, allowed)
+}
+
+// contributorRoles 责任者在书籍类型中可以承担的角色
+var contributorRoles = []string{
+	constants.ContributorRoleAuthor,
+	constants.ContributorRoleEditor,
+	constants.ContributorRoleTranslator,
+	constants.ContributorRoleIllustrator,
+}
+
+// BookTypeLinksCheck 校验书籍类型关联的责任者、主题和丛书，转换为数据库层的关联
+// 请求中未提供的列表保持为 nil，表示不修改该类关联；重复的主题只保留一次。
+func BookTypeLinksCheck(contributors []*booktype.ContributorLink, subjectIds []int64, series []*booktype.SeriesLink) (db.BookTypeLinks, error) {
+	var links db.BookTypeLinks
+	var errs validate.Errors
+
+	if contributors != nil {
+		links.Contributors = make([]db.BookTypeContributor, 0, len(contributors))
+		seen := make(map[db.BookTypeContributor]bool, len(contributors))
+		for _, c := range contributors {
+			if !slices.Contains(contributorRoles, c.Role) {
+				allowed := strings.Join(contributorRoles, ", ")
+				errs.AddErr("contributors", validate.RuleEnum, validate.NewFieldError("role", validate.RuleEnum, errno.ParamVerifyErrorCode,
+					map[string]string{"allowed": allowed}, "contributor role must be one of %s", allowed))
+				break
+			}
+			link := db.BookTypeContributor{ContributorID: c.ContributorID, Role: c.Role}
+			if seen[link] {
+				continue
+			}
+			seen[link] = true
+			links.Contributors = append(links.Contributors, link)
+		}
+	}
+
+	if subjectIds != nil {
+		links.SubjectIDs = make([]int64, 0, len(subjectIds))
+		for _, id := range subjectIds {
+			if !slices.Contains(links.SubjectIDs, id) {
+				links.SubjectIDs = append(links.SubjectIDs, id)
+			}
+		}
+	}
+
+	if series != nil {
+		links.Series = make([]db.BookTypeSeries, 0, len(series))
+		for _, sr := range series {
+			if sr.Volume != nil && utf8.RuneCountInString(*sr.Volume) > constants.SeriesVolumeMaxLength {
+				errs.Add("series", validate.RuleValidate, errno.ParamVerifyErrorCode, "series volume must be at most %d characters", constants.SeriesVolumeMaxLength)
+				break
+			}
+			if slices.ContainsFunc(links.Series, func(l db.BookTypeSeries) bool { return l.SeriesID == sr.SeriesID }) {
+				errs.Add("series", validate.RuleValidate, errno.ParamVerifyErrorCode, "series %d listed more than once", sr.SeriesID)
+				break
+			}
+			links.Series = append(links.Series, db.BookTypeSeries{SeriesID: sr.SeriesID, Volume: sr.Volume})
 		}
 	}
-	return sum%10 == 0
+
+	if err := errs.Err(); err != nil {
+		return db.BookTypeLinks{}, err
+	}
+	return links, nil
 }
 
-func RegisterCheck(username, phone string) error {
-	if len(username) != 9 {
-		return errno.Errorf(errno.ServiceInvalidUsername, "username must be between 3 and 20 characters") // 用户名长度不符合要求，返回错误
+// BookTypeAuthorCheck 校验书籍类型的著者说明，返回去除首尾空白后的著者说明
+// 著者说明是展示用的自由文本，可以包含多位责任者、国籍和角色，如“张三、李四 著”，只限制长度；为空时由责任者生成。
+func BookTypeAuthorCheck(author string) (string, error) {
+	author = strings.TrimSpace(author)
+	if utf8.RuneCountInString(author) > constants.BookTypeAuthorMaxLength {
+		return "", validate.NewFieldError("author", validate.RuleValidate, errno.ParamVerifyErrorCode, nil,
+			"author must be at most %d characters", constants.BookTypeAuthorMaxLength)
 	}
-	if !CheckMobile(phone) {
-		return errno.Errorf(errno.ServiceInvalidPhone, "invalid phone number") // 手机号码格式不正确，返回错误
+	return author, nil
+}
+
+// CatalogNameCheck 校验责任者、主题或丛书的名称，返回去除首尾空白后的名称
+func CatalogNameCheck(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", validate.NewFieldError("name", validate.RuleRequired, errno.ParamMissingErrorCode, nil, "name is required")
 	}
-	return nil
+	if utf8.RuneCountInString(name) > constants.CatalogNameMaxLength {
+		return "", validate.NewFieldError("name", validate.RuleValidate, errno.ParamVerifyErrorCode, nil,
+			"name must be at most %d characters", constants.CatalogNameMaxLength)
+	}
+	return name, nil
 }
 
-func IsValidISBN(isbn string) bool {
-	length := len(isbn)
+// categoryCodePattern 分类号的格式，可以包含中图法的字母、数字和 . - / ，或杜威十进分类法的数字和小数点
+var categoryCodePattern = regexp.MustCompile(`^[A-Za-z0-9./\-]+$`)
 
-	switch length {
-	case 10:
-		return isValidISBN10(isbn)
-	case 13:
-		return isValidISBN13(isbn)
-	default:
-		return false
+// CategoryCodeCheck 校验分类号，返回去除首尾空白后的分类号
+func CategoryCodeCheck(code string) (string, error) {
+	code = strings.TrimSpace(code)
+	if code == "" {
+		return "", validate.NewFieldError("code", validate.RuleRequired, errno.ParamMissingErrorCode, nil, "code is required")
 	}
+	description := fmt.Sprintf("at most %d letters, digits or ./-", constants.CategoryCodeMaxLength)
+	if len(code) > constants.CategoryCodeMaxLength || !categoryCodePattern.MatchString(code) {
+		return "", validate.NewFieldError("code", validate.RulePattern, errno.ParamVerifyErrorCode,
+			map[string]string{"description": description}, "code must be %s", description)
+	}
+	return code, nil
 }
 
-func CheckAuthor(author string) bool {
-	regRuler := `^(?:\[\p{Han}+\]\s+)?[\p{Han}\p{Latin}\s·]+$`
-	reg := regexp.MustCompile(regRuler)
+// CategoryNameCheck 校验分类名称，返回去除首尾空白后的名称
+func CategoryNameCheck(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", validate.NewFieldError("name", validate.RuleRequired, errno.ParamMissingErrorCode, nil, "name is required")
+	}
+	if utf8.RuneCountInString(name) > constants.CategoryNameMaxLength {
+		return "", validate.NewFieldError("name", validate.RuleValidate, errno.ParamVerifyErrorCode, nil,
+			"name must be at most %d characters", constants.CategoryNameMaxLength)
+	}
+	return name, nil
+}
 
-	return reg.MatchString(author)
+// IdempotencyKeyCheck 检查借书请求的幂等键
+// 幂等键可以为空，不为空时只接受字母、数字和 -_. ，长度不超过 constants.IdempotencyKeyMaxLength，UUID 等常见格式均可使用。
+func IdempotencyKeyCheck(key string) error {
+	if key == "" {
+		return nil
+	}
+	description := fmt.Sprintf("at most %d letters, digits or -_.", constants.IdempotencyKeyMaxLength)
+	invalid := func() error {
+		return validate.NewFieldError(constants.IdempotencyKeyHeader, validate.RulePattern, errno.ParamVerifyErrorCode,
+			map[string]string{"description": description}, "%s must be %s", constants.IdempotencyKeyHeader, description)
+	}
+	if len(key) > constants.IdempotencyKeyMaxLength {
+		return invalid()
+	}
+	for _, r := range key {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+		default:
+			return invalid()
+		}
+	}
+	return nil
+}
+
+// normalizeISBN 校验 ISBN-10 或 ISBN-13 并就地改为规范的 ISBN-13，同一版本的两种形式对应同一个书籍类型
+func normalizeISBN(s *string) error {
+	normalized, ok := isbn.Normalize(*s)
+	if !ok {
+		return errno.Errorf(errno.ServiceInvalidISBN, "invalid ISBN format")
+	}
+	*s = normalized
+	return nil
+}
+
+// truncateRunes 把字符串截断为最多 max 个字符，不会拆开多字节字符
+func truncateRunes(s string, max int) string {
+	if utf8.RuneCountInString(s) <= max {
+		return s
+	}
+	n := 0
+	for i := range s {
+		if n == max {
+			return s[:i]
+		}
+		n++
+	}
+	return s
 }
@@ -0,0 +1,49 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestTruncateRunes(t *testing.T) {
+	cases := []struct {
+		in   string
+		max  int
+		want string
+	}{
+		{"Mozilla/5.0", 255, "Mozilla/5.0"},
+		{"abcdef", 3, "abc"},
+		{"图书馆管理系统", 3, "图书馆"},
+		{"ab图书", 3, "ab图"},
+		{"", 3, ""},
+	}
+	for _, c := range cases {
+		if got := truncateRunes(c.in, c.max); got != c.want {
+			t.Errorf("truncateRunes(%q, %d) = %q, want %q", c.in, c.max, got, c.want)
+		}
+	}
+
+	// 255 个字节处正好落在多字节字符中间时，结果仍是有效的 UTF-8
+	ua := strings.Repeat("a", 254) + strings.Repeat("浏", 10)
+	got := truncateRunes(ua, 255)
+	if !utf8.ValidString(got) || utf8.RuneCountInString(got) != 255 {
+		t.Errorf("truncateRunes produced %d runes, valid UTF-8: %v", utf8.RuneCountInString(got), utf8.ValidString(got))
+	}
+}
+
+func TestBookTypeAuthorCheck(t *testing.T) {
+	for _, author := range []string{
+		"张三、李四",
+		"[英] 乔治·奥威尔/董乐山 译",
+		"John Smith, Jane Doe",
+		"",
+	} {
+		if got, err := BookTypeAuthorCheck(" " + author + " "); err != nil || got != author {
+			t.Errorf("BookTypeAuthorCheck(%q) = %q, %v, want %q", author, got, err, author)
+		}
+	}
+	if _, err := BookTypeAuthorCheck(strings.Repeat("著", 256)); err == nil {
+		t.Error("BookTypeAuthorCheck accepted 256 characters")
+	}
+}
@@ -0,0 +1,20 @@
+package main
+
+import (
+	"context"
+
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+
+	"github.com/2451965602/LMS/biz/dal/db"
+)
+
+// backfillLastCheckout 根据借阅记录回填书籍的最后借出时间
+// 上线借出时记录最后借出时间之前，该字段一直为空，剔旧报表依赖此字段。
+func backfillLastCheckout(ctx context.Context, _ []string) error {
+	updated, err := db.BackfillLastCheckout(ctx)
+	if err != nil {
+		return err
+	}
+	hlog.Infof("backfill-last-checkout: %d books updated", updated)
+	return nil
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+
+	"github.com/2451965602/LMS/biz/dal/db"
+)
+
+// categorySchemes 可以初始化的分类法，只包括基本大类和常用的二级类目，更细的类目由管理员按需添加
+var categorySchemes = map[string][]db.CategorySeed{
+	// 中国图书馆分类法
+	"clc": {
+		{Code: "A", Name: "马克思主义、列宁主义、毛泽东思想、邓小平理论"},
+		{Code: "B", Name: "哲学、宗教"},
+		{Code: "C", Name: "社会科学总论"},
+		{Code: "D", Name: "政治、法律"},
+		{Code: "E", Name: "军事"},
+		{Code: "F", Name: "经济"},
+		{Code: "G", Name: "文化、科学、教育、体育"},
+		{Code: "H", Name: "语言、文字"},
+		{Code: "I", Name: "文学"},
+		{Code: "J", Name: "艺术"},
+		{Code: "K", Name: "历史、地理"},
+		{Code: "N", Name: "自然科学总论"},
+		{Code: "O", Name: "数理科学和化学"},
+		{Code: "P", Name: "天文学、地球科学"},
+		{Code: "Q", Name: "生物科学"},
+		{Code: "R", Name: "医药、卫生"},
+		{Code: "S", Name: "农业科学"},
+		{Code: "T", Name: "工业技术"},
+		{Code: "U", Name: "交通运输"},
+		{Code: "V", Name: "航空、航天"},
+		{Code: "X", Name: "环境科学、安全科学"},
+		{Code: "Z", Name: "综合性图书"},
+
+		{Code: "F0", Name: "经济学", ParentCode: "F"},
+		{Code: "F2", Name: "经济管理", ParentCode: "F"},
+		{Code: "F8", Name: "财政、金融", ParentCode: "F"},
+		{Code: "G2", Name: "信息与知识传播", ParentCode: "G"},
+		{Code: "G4", Name: "教育", ParentCode: "G"},
+		{Code: "G8", Name: "体育", ParentCode: "G"},
+		{Code: "H1", Name: "汉语", ParentCode: "H"},
+		{Code: "H3", Name: "常用外国语", ParentCode: "H"},
+		{Code: "I0", Name: "文学理论", ParentCode: "I"},
+		{Code: "I1", Name: "世界文学", ParentCode: "I"},
+		{Code: "I2", Name: "中国文学", ParentCode: "I"},
+		{Code: "I3/7", Name: "各国文学", ParentCode: "I"},
+		{Code: "K1", Name: "世界史", ParentCode: "K"},
+		{Code: "K2", Name: "中国史", ParentCode: "K"},
+		{Code: "K9", Name: "地理", ParentCode: "K"},
+		{Code: "O1", Name: "数学", ParentCode: "O"},
+		{Code: "O4", Name: "物理学", ParentCode: "O"},
+		{Code: "O6", Name: "化学", ParentCode: "O"},
+		{Code: "TB", Name: "一般工业技术", ParentCode: "T"},
+		{Code: "TN", Name: "无线电电子学、电信技术", ParentCode: "T"},
+		{Code: "TP", Name: "自动化技术、计算机技术", ParentCode: "T"},
+		{Code: "TP3", Name: "计算技术、计算机技术", ParentCode: "TP"},
+		{Code: "TU", Name: "建筑科学", ParentCode: "T"},
+	},
+	// 杜威十进分类法
+	"ddc": {
+		{Code: "000", Name: "Computer science, information & general works"},
+		{Code: "100", Name: "Philosophy & psychology"},
+		{Code: "200", Name: "Religion"},
+		{Code: "300", Name: "Social sciences"},
+		{Code: "400", Name: "Language"},
+		{Code: "500", Name: "Science"},
+		{Code: "600", Name: "Technology"},
+		{Code: "700", Name: "Arts & recreation"},
+		{Code: "800", Name: "Literature"},
+		{Code: "900", Name: "History & geography"},
+	},
+}
+
+// seedCategories 初始化分类法的基本类目，已存在的分类号跳过，可以重复执行
+func seedCategories(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("seed-categories", flag.ContinueOnError)
+	scheme := fs.String("scheme", "clc", "分类法：clc（中国图书馆分类法）或 ddc（杜威十进分类法）")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	seeds, ok := categorySchemes[*scheme]
+	if !ok {
+		fs.Usage()
+		return errors.New("scheme must be clc or ddc")
+	}
+
+	created, err := db.SeedCategories(ctx, seeds)
+	if err != nil {
+		return fmt.Errorf("seeded %d categories before failing: %w", created, err)
+	}
+	hlog.Infof("seed-categories: %d of %d %s categories created", created, len(seeds), *scheme)
+	return nil
+}
@@ -0,0 +1,89 @@
+// lmsctl 运维命令行工具，用于执行一次性的数据回填、迁移和检查任务
+// 需在项目根目录下运行，与服务使用相同的配置文件和数据库。
+//
+// 用法：
+//
+//	go run ./cmd/lmsctl <command> [args]
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+
+	"github.com/2451965602/LMS/biz/dal"
+	"github.com/2451965602/LMS/config"
+)
+
+// command 一个子命令
+type command struct {
+	usage string                                         // 子命令说明
+	run   func(ctx context.Context, args []string) error // 执行子命令，args 为子命令之后的参数
+}
+
+// commands 所有子命令
+var commands = map[string]command{
+	"backfill-last-checkout": {
+		usage: "根据借阅记录回填书籍的最后借出时间，可以重复执行",
+		run:   backfillLastCheckout,
+	},
+	"normalize-isbn": {
+		usage: "把书籍类型的 ISBN 统一为 ISBN-13 并合并同一版本的重复记录，加 -dry-run 只输出将要进行的操作",
+		run:   normalizeISBNs,
+	},
+	"seed-categories": {
+		usage: "初始化中图法（-scheme clc）或杜威十进分类法（-scheme ddc）的基本类目，可以重复执行",
+		run:   seedCategories,
+	},
+	"map-categories": {
+		usage: "把书籍类型的分类名称映射到分类节点并输出复核报表，加 -apply 关联确定的匹配",
+		run:   mapCategories,
+	},
+	"split-authors": {
+		usage: "把书籍类型的作者字符串拆分为责任者并建立关联，加 -dry-run 只输出拆分结果",
+		run:   splitAuthors,
+	},
+	"stress-borrow": {
+		usage: "并发借书并检查借阅数据是否一致，借出的副本不会归还，只能在测试库上运行",
+		run:   stressBorrow,
+	},
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		printUsage()
+		os.Exit(2)
+	}
+	cmd, ok := commands[os.Args[1]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n", os.Args[1])
+		printUsage()
+		os.Exit(2)
+	}
+
+	config.Init()
+	if err := dal.Init(); err != nil {
+		hlog.Fatalf("dal.Init: %v", err)
+	}
+	if err := cmd.run(context.Background(), os.Args[2:]); err != nil {
+		hlog.Fatalf("%s: %v", os.Args[1], err)
+	}
+}
+
+// printUsage 输出所有子命令的说明
+func printUsage() {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(os.Stderr, "usage: lmsctl <command> [args]")
+	fmt.Fprintln(os.Stderr, "\ncommands:")
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-28s %s\n", name, commands[name].usage)
+	}
+}
@@ -0,0 +1,281 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+
+	"github.com/2451965602/LMS/biz/dal/db"
+)
+
+// 分类名称与分类节点的匹配方式
+const (
+	categoryMatchMapping   = "mapping"   // 人工对照表中指定的分类号
+	categoryMatchExact     = "exact"     // 与唯一一个节点的名称相同
+	categoryMatchFuzzy     = "fuzzy"     // 与节点名称中的一项互相包含，取层级最深的唯一节点
+	categoryMatchAmbiguous = "ambiguous" // 有多个同样可能的节点，需人工确认
+	categoryMatchNone      = "unmatched" // 没有可能的节点，需人工确认
+)
+
+// categoryMatch 一个旧分类名称的匹配结果
+type categoryMatch struct {
+	category   string             // 旧的分类名称
+	bookTypes  []*db.BookType     // 使用该分类名称的书籍类型
+	match      string             // 匹配方式
+	candidates []*db.CategoryNode // 匹配到的节点，匹配唯一时只有一个
+	applied    int                // 已关联到节点的书籍类型数
+}
+
+// mapCategories 把书籍类型的旧分类名称映射到分类节点，并输出供人工复核的报表
+// 默认只输出报表；-apply 时关联人工对照表和名称完全相同的匹配，再加 -fuzzy 时同时关联唯一的模糊匹配。
+// 复核时在报表中为 ambiguous、unmatched 和有误的行填写唯一的分类号，通过 -mapping 传入后重新执行。
+// 已关联分类节点的书籍类型不再处理，因此可以重复执行。
+func mapCategories(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("map-categories", flag.ContinueOnError)
+	mapping := fs.String("mapping", "", "人工对照表 CSV，每行为“分类名称,分类号”")
+	report := fs.String("report", "", "复核报表的输出路径，为空时输出到标准输出")
+	apply := fs.Bool("apply", false, "关联人工对照表和名称完全相同的匹配，不加时只输出报表")
+	fuzzy := fs.Bool("fuzzy", false, "与 -apply 一起使用时，同时关联唯一的模糊匹配")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *fuzzy && !*apply {
+		return errors.New("-fuzzy only takes effect with -apply")
+	}
+
+	nodes, err := db.ListCategories(ctx)
+	if err != nil {
+		return err
+	}
+	if len(nodes) == 0 {
+		return errors.New("no categories yet, run seed-categories first")
+	}
+	byCode := make(map[string]*db.CategoryNode, len(nodes))
+	byID := make(map[int64]*db.CategoryNode, len(nodes))
+	for _, n := range nodes {
+		byCode[n.Code] = n
+		byID[n.ID] = n
+	}
+
+	manual := map[string]string{}
+	if *mapping != "" {
+		if manual, err = readCategoryMapping(*mapping); err != nil {
+			return err
+		}
+		for category, code := range manual {
+			if byCode[code] == nil {
+				return fmt.Errorf("mapping %q -> %q: category code not exist", category, code)
+			}
+		}
+	}
+
+	bookTypes, err := db.GetBookTypesWithoutCategory(ctx)
+	if err != nil {
+		return err
+	}
+	var matches []*categoryMatch
+	byCategory := make(map[string]*categoryMatch)
+	for _, bt := range bookTypes {
+		m := byCategory[bt.Category]
+		if m == nil {
+			m = matchCategory(bt.Category, nodes, manual, byCode)
+			byCategory[bt.Category] = m
+			matches = append(matches, m)
+		}
+		m.bookTypes = append(m.bookTypes, bt)
+	}
+
+	if *apply {
+		for _, m := range matches {
+			if m.match != categoryMatchMapping && m.match != categoryMatchExact && (m.match != categoryMatchFuzzy || !*fuzzy) {
+				continue
+			}
+			for _, bt := range m.bookTypes {
+				updated, err := db.SetBookTypeCategory(ctx, bt.ISBN, m.candidates[0])
+				if err != nil {
+					return err
+				}
+				if updated {
+					m.applied++
+				}
+			}
+		}
+	}
+
+	out := io.Writer(os.Stdout)
+	if *report != "" {
+		f, err := os.Create(*report)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = f
+	}
+	if err = writeCategoryReport(out, matches, byID); err != nil {
+		return err
+	}
+
+	counts := make(map[string]int)
+	applied := 0
+	for _, m := range matches {
+		counts[m.match] += len(m.bookTypes)
+		applied += m.applied
+	}
+	hlog.Infof("map-categories: %d book types in %d categories, %d mapping, %d exact, %d fuzzy, %d ambiguous, %d unmatched, %d linked",
+		len(bookTypes), len(matches), counts[categoryMatchMapping], counts[categoryMatchExact], counts[categoryMatchFuzzy],
+		counts[categoryMatchAmbiguous], counts[categoryMatchNone], applied)
+	return nil
+}
+
+// matchCategory 为一个旧分类名称查找分类节点
+// 1. 人工对照表中有该名称时以对照表为准。
+// 2. 与节点名称完全相同时为精确匹配，多个节点同名时需人工确认。
+// 3. 否则与节点名称中以“、”分隔的各项比较，互相包含即为可能的节点，如“计算机”可能是“计算技术、计算机技术”；
+// 取层级最深的节点，最深一层有多个节点时需人工确认。
+func matchCategory(category string, nodes []*db.CategoryNode, manual map[string]string, byCode map[string]*db.CategoryNode) *categoryMatch {
+	m := &categoryMatch{category: category}
+	text := normalizeCategory(category)
+	if code, ok := manual[category]; ok {
+		m.match = categoryMatchMapping
+		m.candidates = []*db.CategoryNode{byCode[code]}
+		return m
+	}
+
+	for _, n := range nodes {
+		if normalizeCategory(n.Name) == text {
+			m.candidates = append(m.candidates, n)
+		}
+	}
+	switch len(m.candidates) {
+	case 1:
+		m.match = categoryMatchExact
+		return m
+	case 0:
+	default:
+		m.match = categoryMatchAmbiguous
+		return m
+	}
+
+	depth := 0
+	for _, n := range nodes {
+		if text == "" || !fuzzyCategoryMatch(text, n.Name) {
+			continue
+		}
+		d := strings.Count(n.Path, "/")
+		switch {
+		case d > depth:
+			depth = d
+			m.candidates = []*db.CategoryNode{n}
+		case d == depth:
+			m.candidates = append(m.candidates, n)
+		}
+	}
+	switch len(m.candidates) {
+	case 0:
+		m.match = categoryMatchNone
+	case 1:
+		m.match = categoryMatchFuzzy
+	default:
+		m.match = categoryMatchAmbiguous
+	}
+	return m
+}
+
+// fuzzyCategoryMatch 旧分类名称与节点名称中以“、”等分隔的某一项互相包含
+func fuzzyCategoryMatch(text, name string) bool {
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool { return r == '、' || r == ',' || r == '&' }) {
+		part = normalizeCategory(part)
+		if part != "" && (strings.Contains(part, text) || strings.Contains(text, part)) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeCategory 去除空白并统一大小写，便于比较分类名称
+func normalizeCategory(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), ""))
+}
+
+// readCategoryMapping 读取人工对照表，每行为“分类名称,分类号”
+// 有表头时按 category 和 code 列读取，因此复核报表修改 code 列后可以直接作为对照表；分类号为空或仍有多个候选的行跳过。
+func readCategoryMapping(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = -1
+	rows, err := r.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("read mapping %s: %w", path, err)
+	}
+	categoryCol, codeCol := 0, 1
+	if len(rows) > 0 && slices.Contains(rows[0], "category") && slices.Contains(rows[0], "code") {
+		categoryCol, codeCol = slices.Index(rows[0], "category"), slices.Index(rows[0], "code")
+		rows = rows[1:]
+	}
+
+	mapping := make(map[string]string, len(rows))
+	for i, row := range rows {
+		if len(row) <= max(categoryCol, codeCol) {
+			return nil, fmt.Errorf("mapping %s row %d: want category and code", path, i+1)
+		}
+		code := strings.TrimSpace(row[codeCol])
+		if code == "" || strings.Contains(code, "|") {
+			continue
+		}
+		mapping[row[categoryCol]] = code
+	}
+	return mapping, nil
+}
+
+// writeCategoryReport 输出复核报表，每个旧分类名称一行，候选节点以 | 分隔，并附上节点在分类法中的层级
+func writeCategoryReport(out io.Writer, matches []*categoryMatch, byID map[int64]*db.CategoryNode) error {
+	w := csv.NewWriter(out)
+	_ = w.Write([]string{"category", "book_types", "match", "code", "name", "path", "linked", "sample_isbn"})
+	for _, m := range matches {
+		var codes, names, paths []string
+		for _, n := range m.candidates {
+			codes = append(codes, n.Code)
+			names = append(names, n.Name)
+			paths = append(paths, categoryBreadcrumb(n, byID))
+		}
+		_ = w.Write([]string{
+			m.category,
+			strconv.Itoa(len(m.bookTypes)),
+			m.match,
+			strings.Join(codes, "|"),
+			strings.Join(names, "|"),
+			strings.Join(paths, "|"),
+			strconv.Itoa(m.applied),
+			m.bookTypes[0].ISBN,
+		})
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// categoryBreadcrumb 以“上级 > 下级”的形式显示节点在分类法中的层级
+func categoryBreadcrumb(node *db.CategoryNode, byID map[int64]*db.CategoryNode) string {
+	var names []string
+	for _, s := range strings.Split(strings.Trim(node.Path, "/"), "/") {
+		id, err := strconv.ParseInt(s, 10, 64)
+		if err != nil || byID[id] == nil {
+			continue
+		}
+		names = append(names, byID[id].Code+" "+byID[id].Name)
+	}
+	return strings.Join(names, " > ")
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"flag"
+
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+
+	"github.com/2451965602/LMS/biz/dal/db"
+	"github.com/2451965602/LMS/biz/service"
+	"github.com/2451965602/LMS/pkg/isbn"
+)
+
+// normalizeISBNs 把以 ISBN-10 保存的书籍类型改为规范的 ISBN-13，同一版本的两条记录合并为一条
+// 合并时副本、预约、责任者、主题、丛书和附件都转移到 ISBN-13 的记录上，副本数累加；
+// 978 开头的 ISBN-13 同时回填 ISBN-10。ISBN 无效的书籍类型只输出警告，需人工处理。可以重复执行；-dry-run 时只输出将要进行的操作。
+func normalizeISBNs(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("normalize-isbn", flag.ContinueOnError)
+	dryRun := fs.Bool("dry-run", false, "只输出将要进行的操作，不写入数据库")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	bookTypes, err := db.GetBookTypeISBNs(ctx)
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]bool, len(bookTypes))
+	for _, bt := range bookTypes {
+		existing[bt.ISBN] = true
+	}
+
+	var renamed, merged, backfilled, invalid int
+	for _, bt := range bookTypes {
+		canonical, ok := isbn.Normalize(bt.ISBN)
+		if !ok {
+			hlog.Warnf("normalize-isbn: %s: invalid ISBN, skipped", bt.ISBN)
+			invalid++
+			continue
+		}
+		var isbn10 *string
+		if s, ok := isbn.To10(canonical); ok {
+			isbn10 = &s
+		}
+
+		if canonical == bt.ISBN {
+			if bt.ISBN10 != nil || isbn10 == nil {
+				continue
+			}
+			if !*dryRun {
+				if err = db.SetBookTypeISBN10(ctx, canonical, *isbn10); err != nil {
+					return err
+				}
+			}
+			backfilled++
+			continue
+		}
+
+		if *dryRun {
+			if existing[canonical] {
+				hlog.Infof("normalize-isbn: %s: merge into %s", bt.ISBN, canonical)
+				merged++
+			} else {
+				hlog.Infof("normalize-isbn: %s: rename to %s", bt.ISBN, canonical)
+				renamed++
+			}
+			existing[canonical] = true
+			continue
+		}
+		result, err := db.MergeBookType(ctx, bt.ISBN, canonical, isbn10)
+		if err != nil {
+			return err
+		}
+		if result.Renamed {
+			hlog.Infof("normalize-isbn: %s: renamed to %s", bt.ISBN, canonical)
+			renamed++
+		} else {
+			hlog.Infof("normalize-isbn: %s: merged into %s, %d books and %d reservations moved, %d covers dropped",
+				bt.ISBN, canonical, result.Books, result.Reservations, len(result.DroppedCovers))
+			merged++
+		}
+		existing[canonical] = true
+		service.RemoveAttachmentContent(ctx, result.DroppedCovers)
+	}
+	hlog.Infof("normalize-isbn: %d renamed, %d merged, %d ISBN-10 backfilled, %d invalid, dry run: %v",
+		renamed, merged, backfilled, invalid, *dryRun)
+	return nil
+}
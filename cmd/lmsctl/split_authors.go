@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"regexp"
+	"strings"
+
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+
+	"github.com/2451965602/LMS/biz/dal/db"
+	"github.com/2451965602/LMS/pkg/constants"
+)
+
+var (
+	// authorSeparators 旧作者字符串中分隔多位责任者的符号
+	authorSeparators = regexp.MustCompile(`[,，、;；/&]`)
+	// authorNationality 责任者名称前的国籍标记，如 [美]、（英）
+	authorNationality = regexp.MustCompile(`^\s*(?:\[[^\]]*\]|【[^】]*】|\([^)]*\)|（[^）]*）)\s*`)
+)
+
+// authorRoleSuffixes 责任者名称后表示角色的后缀，较长的后缀在前，避免“编著”被识别为“著”
+var authorRoleSuffixes = []struct {
+	suffix string
+	role   string
+}{
+	{"编著", constants.ContributorRoleAuthor},
+	{"主编", constants.ContributorRoleEditor},
+	{"著", constants.ContributorRoleAuthor},
+	{"编", constants.ContributorRoleEditor},
+	{"译", constants.ContributorRoleTranslator},
+	{"绘", constants.ContributorRoleIllustrator},
+}
+
+// splitAuthors 把旧的作者字符串拆分为责任者，关联到对应的书籍类型
+// 同名的责任者可能是不同的人，无法自动区分，每个书籍类型的责任者都新建条目，不按名称合并。
+// 已关联责任者的书籍类型会被跳过，因此可以重复执行；-dry-run 时只输出拆分结果，不写入数据库。
+func splitAuthors(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("split-authors", flag.ContinueOnError)
+	dryRun := fs.Bool("dry-run", false, "只输出拆分结果，不写入数据库")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	bookTypes, err := db.GetBookTypesWithoutContributors(ctx)
+	if err != nil {
+		return err
+	}
+
+	var linked, skipped int
+	for _, bt := range bookTypes {
+		parsed := parseAuthors(bt.Author)
+		if len(parsed) == 0 {
+			hlog.Warnf("split-authors: %s: cannot split author %q, skipped", bt.ISBN, bt.Author)
+			skipped++
+			continue
+		}
+		if *dryRun {
+			for _, p := range parsed {
+				hlog.Infof("split-authors: %s: %q -> %s (%s)", bt.ISBN, bt.Author, p.name, p.role)
+			}
+			linked++
+			continue
+		}
+
+		contributors := make([]db.BookTypeContributor, 0, len(parsed))
+		for _, p := range parsed {
+			entry, err := db.AddCatalogEntry(ctx, db.ContributorKind, p.name)
+			if err != nil {
+				return err
+			}
+			contributors = append(contributors, db.BookTypeContributor{ContributorID: entry.ID, Role: p.role})
+		}
+		if err = db.SetBookTypeContributors(ctx, bt.ISBN, contributors); err != nil {
+			return err
+		}
+		linked++
+	}
+	hlog.Infof("split-authors: %d book types linked, %d skipped, dry run: %v", linked, skipped, *dryRun)
+	return nil
+}
+
+// parsedAuthor 从作者字符串中拆分出的一位责任者
+type parsedAuthor struct {
+	name string
+	role string
+}
+
+// parseAuthors 拆分作者字符串
+//  1. 按分隔符拆分为多段，去掉每段开头的国籍标记和末尾的“等”。
+//  2. 段末的角色后缀同时作用于前面尚未确定角色的各段，如“张三、李四 译”中两人都是译者；
+//     但前面带国籍标记的段通常是原著者，不随“译”成为译者，如“[英] 乔治·奥威尔/董乐山 译”。最后仍未确定角色的视为著者。
+//  3. 同一责任者以同一角色出现多次时只保留一次。
+func parseAuthors(author string) []parsedAuthor {
+	var results []parsedAuthor
+	var pending []struct {
+		name    string
+		foreign bool
+	}
+	seen := make(map[parsedAuthor]bool)
+	flush := func(role string) {
+		for _, pa := range pending {
+			p := parsedAuthor{name: pa.name, role: role}
+			if pa.foreign && role == constants.ContributorRoleTranslator {
+				p.role = constants.ContributorRoleAuthor
+			}
+			if !seen[p] {
+				seen[p] = true
+				results = append(results, p)
+			}
+		}
+		pending = pending[:0]
+	}
+
+	for _, part := range authorSeparators.Split(author, -1) {
+		foreign := authorNationality.MatchString(part)
+		name := strings.TrimSpace(authorNationality.ReplaceAllString(part, ""))
+		role := ""
+		for _, s := range authorRoleSuffixes {
+			if strings.HasSuffix(name, s.suffix) {
+				name = strings.TrimSpace(strings.TrimSuffix(name, s.suffix))
+				role = s.role
+				break
+			}
+		}
+		name = strings.TrimSpace(strings.TrimSuffix(name, "等"))
+		if name != "" && len([]rune(name)) <= constants.CatalogNameMaxLength {
+			pending = append(pending, struct {
+				name    string
+				foreign bool
+			}{name, foreign && role == ""}) // 自带角色后缀的段按后缀确定角色
+		}
+		if role != "" {
+			flush(role)
+		}
+	}
+	flush(constants.ContributorRoleAuthor)
+	return results
+}
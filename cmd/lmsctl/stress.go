@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+
+	"github.com/2451965602/LMS/biz/dal/db"
+	"github.com/2451965602/LMS/config"
+	"github.com/2451965602/LMS/pkg/errno"
+)
+
+// stressBorrow 并发借书，检查并发下借阅数据仍然一致
+// 每两个并发请求为一组，使用相同的用户、副本和幂等键，模拟客户端重试；同一用户的多组请求同时借不同的副本，检查借阅数量限制。
+// 借出的副本不会归还，只能在测试库上运行。
+func stressBorrow(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("stress-borrow", flag.ContinueOnError)
+	isbn := fs.String("isbn", "", "借阅的书籍类型 ISBN，使用其下全部在架副本")
+	users := fs.String("users", "", "借书的用户 ID，以逗号分隔")
+	groups := fs.Int("groups", 20, "并发的请求组数，每组两个使用相同幂等键的请求")
+	yes := fs.Bool("yes", false, "确认在测试库上运行，借出的副本不会归还")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *isbn == "" || *users == "" || *groups <= 0 {
+		fs.Usage()
+		return errors.New("isbn, users and a positive groups are required")
+	}
+	if !*yes {
+		return errors.New("stress-borrow checks out books without returning them, pass -yes to run it on a test database")
+	}
+
+	var userIds []int64
+	for _, s := range strings.Split(*users, ",") {
+		id, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid user id %q", s)
+		}
+		userIds = append(userIds, id)
+	}
+	bookIds, err := db.GetAvailableBookIds(ctx, *isbn)
+	if err != nil {
+		return err
+	}
+	if len(bookIds) == 0 {
+		return fmt.Errorf("no available copies for ISBN %s", *isbn)
+	}
+
+	// 运行前的数据不一致会干扰结果判断
+	problems, err := db.CheckCirculation(ctx, *isbn)
+	if err != nil {
+		return err
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("circulation already inconsistent before the run: %s", strings.Join(problems, "; "))
+	}
+
+	type outcome struct {
+		borrowId int64
+		code     int64
+	}
+	nonce := time.Now().UnixNano()
+	results := make([][2]outcome, *groups)
+	var wg sync.WaitGroup
+	for g := 0; g < *groups; g++ {
+		for twin := 0; twin < 2; twin++ {
+			wg.Add(1)
+			go func(g, twin int) {
+				defer wg.Done()
+				key := fmt.Sprintf("stress-%d-%d", nonce, g)
+				id, err := db.BookBorrow(ctx, userIds[g%len(userIds)], bookIds[g%len(bookIds)], config.MaxBorrowNum.Num, key)
+				results[g][twin] = outcome{borrowId: id, code: errno.ConvertErr(err).ErrorCode}
+			}(g, twin)
+		}
+	}
+	wg.Wait()
+
+	codes := make(map[int64]int)
+	for g, r := range results {
+		codes[r[0].code]++
+		codes[r[1].code]++
+		if r[0].code == errno.SuccessCode && r[1].code == errno.SuccessCode && r[0].borrowId != r[1].borrowId {
+			problems = append(problems, fmt.Sprintf("group %d borrowed twice with one idempotency key: records %d and %d", g, r[0].borrowId, r[1].borrowId))
+		}
+		if r[0].code != r[1].code {
+			problems = append(problems, fmt.Sprintf("group %d got different results with one idempotency key: %d and %d", g, r[0].code, r[1].code))
+		}
+	}
+
+	circulation, err := db.CheckCirculation(ctx, *isbn)
+	if err != nil {
+		return err
+	}
+	problems = append(problems, circulation...)
+	for _, id := range userIds {
+		count, err := db.CountCheckedOutRecord(ctx, id)
+		if err != nil {
+			return err
+		}
+		if count > config.MaxBorrowNum.Num {
+			problems = append(problems, fmt.Sprintf("user %d has %d books checked out, limit is %d", id, count, config.MaxBorrowNum.Num))
+		}
+	}
+
+	for code, n := range codes {
+		hlog.Infof("stress-borrow: %d requests finished with code %d", n, code)
+	}
+	if len(problems) > 0 {
+		for _, p := range problems {
+			hlog.Errorf("stress-borrow: %s", p)
+		}
+		return fmt.Errorf("%d invariant violations", len(problems))
+	}
+	hlog.Infof("stress-borrow: %d requests, all invariants hold", 2*len(results))
+	return nil
+}
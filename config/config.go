@@ -10,10 +10,23 @@ import (
 )
 
 var (
-	Server       *server // 服务器配置的全局变量
-	Mysql        *mySQL  // MySQL数据库配置的全局变量
-	MaxBorrowNum *maxBorrowNum
-	runtimeViper *viper.Viper // Viper实例，用于管理配置文件
+	Server          *server // 服务器配置的全局变量
+	Mysql           *mySQL  // MySQL数据库配置的全局变量
+	MaxBorrowNum    *maxBorrowNum
+	Privacy         *privacy         // 借阅历史隐私配置的全局变量
+	Tracing         *tracing         // 链路追踪配置的全局变量
+	JWT             *jwtConfig       // JWT密钥配置的全局变量
+	LoginProtection *loginProtection // 登录防暴力破解配置的全局变量
+	PasswordPolicy  *passwordPolicy  // 密码强度策略的全局变量
+	SMS             *sms             // 短信发送配置的全局变量
+	PasswordReset   *passwordReset   // 找回密码验证码配置的全局变量
+	TwoFactor       *twoFactor       // 两步验证配置的全局变量
+	SSO             *sso             // 单点登录配置的全局变量
+	Registration    *registration    // 注册校验规则的全局变量
+	Pagination      *pagination      // 列表接口分页配置的全局变量
+	Attachment      *attachment      // 封面和附件配置的全局变量
+	Metrics         *metrics         // 指标接口访问配置的全局变量
+	runtimeViper    *viper.Viper     // Viper实例，用于管理配置文件
 )
 
 // Init 初始化配置模块
@@ -67,8 +80,111 @@ func createDefaultConfig(configPath string) error {
 			Password: "root",           // 默认数据库密码
 			Charset:  "utf8mb4",        // 默认数据库字符集
 		},
-		maxBorrowNum: maxBorrowNum{
-			Num: 5,
+		MaxBorrowNum: maxBorrowNum{
+			Num: 5, // 默认最多同时借阅 5 本
+		},
+		Privacy: privacy{
+			RetentionDays: 180, // 默认保留 180 天
+			CheckInterval: 60,  // 默认每 60 分钟执行一次
+		},
+		Tracing: tracing{
+			Exporter:    "none",           // 默认不导出追踪数据，仅生成追踪ID
+			Endpoint:    "127.0.0.1:4318", // 默认 OTLP/HTTP 接收端地址
+			Insecure:    true,             // 默认使用明文连接
+			ServiceName: "LMS",            // 默认服务名称
+			SampleRatio: 1,                // 默认全部采样
+		},
+		JWT: jwtConfig{
+			ActiveKid: "default", // 默认使用 default 密钥签发
+			Keys: []jwtKey{
+				{
+					Kid:       "default",        // 默认密钥ID
+					Algorithm: "HS256",          // 默认使用 HMAC-SHA256
+					SecretEnv: "LMS_JWT_SECRET", // 默认从环境变量读取密钥
+				},
+			},
+		},
+		LoginProtection: loginProtection{
+			MaxUserFailures: 5,        // 默认同一用户名连续失败 5 次后锁定
+			MaxIPFailures:   20,       // 默认同一 IP 连续失败 20 次后锁定
+			BaseDelay:       1,        // 默认第一次失败后等待 1 秒
+			MaxDelay:        60,       // 默认最长等待 60 秒
+			LockoutDuration: 15,       // 默认锁定 15 分钟
+			Window:          15,       // 默认统计最近 15 分钟内的失败
+			Store:           "memory", // 默认使用进程内存储
+		},
+		PasswordPolicy: passwordPolicy{
+			MinLength:  8,  // 默认至少 8 个字符
+			MaxLength:  72, // 默认不超过 bcrypt 的 72 字节上限
+			MinClasses: 3,  // 默认至少包含三类字符
+		},
+		SMS: sms{
+			Sender:   "log",           // 默认只输出日志，不真正发送
+			TokenEnv: "LMS_SMS_TOKEN", // 默认从环境变量读取访问令牌
+			Timeout:  5,               // 默认 5 秒超时
+		},
+		PasswordReset: passwordReset{
+			CodeLength:     6,  // 默认 6 位验证码
+			CodeTTL:        10, // 默认 10 分钟内有效
+			ResendInterval: 60, // 默认 60 秒内不能重复发送
+			MaxDailySends:  5,  // 默认每天最多发送 5 次
+			MaxAttempts:    5,  // 默认每个验证码最多输错 5 次
+		},
+		TwoFactor: twoFactor{
+			Issuer:        "LMS", // 默认签发方名称
+			Skew:          1,     // 默认允许前后各 30 秒的偏差
+			RecoveryCodes: 10,    // 默认生成 10 个恢复码
+		},
+		SSO: sso{
+			LDAP: ldapSSO{
+				UserFilter:        "(uid=%s)", // 默认按 uid 搜索用户
+				UsernameAttribute: "uid",
+				SubjectAttribute:  "entryUUID",
+				PhoneAttribute:    "mobile",
+				GroupAttribute:    "memberOf",
+				BindPasswordEnv:   "LMS_LDAP_BIND_PASSWORD", // 默认从环境变量读取服务账号密码
+				Timeout:           5,                        // 默认 5 秒超时
+			},
+			OIDC: oidcSSO{
+				ClientSecretEnv: "LMS_OIDC_CLIENT_SECRET",                       // 默认从环境变量读取客户端密钥
+				RedirectURL:     "http://localhost:8080/user/sso/oidc/callback", // 默认本机的回调接口
+				Scopes:          []string{"profile", "phone"},
+				UsernameClaim:   "preferred_username",
+				PhoneClaim:      "phone_number",
+				GroupsClaim:     "groups",
+			},
+			DefaultPermission: "member", // 默认新用户为普通读者
+		},
+		Registration: registration{
+			PatronTypes: []patronType{
+				{Name: "student", UsernamePattern: `^\d{9}$`, Description: "学号，9 位数字"},
+				{Name: "staff", UsernamePattern: `^[A-Za-z]{1,3}\d{4,8}$`, Description: "工号，1 到 3 位字母加 4 到 8 位数字"},
+				{Name: "visitor", UsernamePattern: `^[A-Za-z][A-Za-z0-9_.-]{2,19}$`, Description: "3 到 20 位字母、数字、下划线、点或连字符，以字母开头"},
+			},
+			DefaultPatronType: "student", // 默认按学号校验
+			PhoneRegion:       "CN",      // 默认按中国大陆号码解析不带国际区号的手机号
+			PhoneRequired:     true,      // 默认注册时必须填写手机号
+		},
+		Pagination: pagination{
+			DefaultPageSize: 20,  // 默认每页 20 条
+			MaxPageSize:     100, // 默认每页最多 100 条
+		},
+		Attachment: attachment{
+			Store:    "local",              // 默认保存在本地目录
+			LocalDir: "./data/attachments", // 默认保存在项目目录下
+			S3: s3Attachment{
+				Region:             "us-east-1",
+				SecretAccessKeyEnv: "LMS_S3_SECRET_ACCESS_KEY", // 默认从环境变量读取访问密钥
+				PathStyle:          true,
+				Timeout:            10, // 默认 10 秒超时
+			},
+			MaxSize:       10 << 20, // 默认单个附件不超过 10 MB
+			ThumbnailSize: 256,      // 默认缩略图最大边长 256 像素
+		},
+		Metrics: metrics{
+			ListenAddr: "127.0.0.1:9100",             // 默认只在本机的单独端口暴露指标
+			TokenEnv:   "LMS_METRICS_TOKEN",          // 默认从环境变量读取 Token
+			AllowedIPs: []string{"127.0.0.1", "::1"}, // 默认只允许本机直接访问
 		},
 	}
 
@@ -76,7 +192,20 @@ func createDefaultConfig(configPath string) error {
 	v := viper.New()
 	v.Set("server", defaultConfig.Server)
 	v.Set("mysql", defaultConfig.MySQL)
-	v.Set("maxBorrowNum", defaultConfig.maxBorrowNum.Num)
+	v.Set("maxBorrowNum", defaultConfig.MaxBorrowNum)
+	v.Set("privacy", defaultConfig.Privacy)
+	v.Set("tracing", defaultConfig.Tracing)
+	v.Set("jwt", defaultConfig.JWT)
+	v.Set("loginProtection", defaultConfig.LoginProtection)
+	v.Set("passwordPolicy", defaultConfig.PasswordPolicy)
+	v.Set("sms", defaultConfig.SMS)
+	v.Set("passwordReset", defaultConfig.PasswordReset)
+	v.Set("twoFactor", defaultConfig.TwoFactor)
+	v.Set("sso", defaultConfig.SSO)
+	v.Set("registration", defaultConfig.Registration)
+	v.Set("pagination", defaultConfig.Pagination)
+	v.Set("attachment", defaultConfig.Attachment)
+	v.Set("metrics", defaultConfig.Metrics)
 
 	return v.WriteConfigAs(configPath) // 将默认配置写入指定路径
 }
@@ -92,4 +221,17 @@ func configMapping() {
 	Server = &c.Server
 	Mysql = &c.MySQL
 	MaxBorrowNum = &c.MaxBorrowNum
+	Privacy = &c.Privacy
+	Tracing = &c.Tracing
+	JWT = &c.JWT
+	LoginProtection = &c.LoginProtection
+	PasswordPolicy = &c.PasswordPolicy
+	SMS = &c.SMS
+	PasswordReset = &c.PasswordReset
+	TwoFactor = &c.TwoFactor
+	SSO = &c.SSO
+	Registration = &c.Registration
+	Pagination = &c.Pagination
+	Attachment = &c.Attachment
+	Metrics = &c.Metrics
 }
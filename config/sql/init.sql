@@ -6,20 +6,43 @@ CREATE TABLE Users (
                            permission ENUM('admin', 'librarian', 'member') NOT NULL DEFAULT 'member',
                            phone VARCHAR(20),
                            register_date TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-                           status ENUM('active', 'suspended', 'inactive') DEFAULT 'active'
+                           status ENUM('active', 'suspended', 'inactive') DEFAULT 'active',
+                           keep_history BOOLEAN NOT NULL DEFAULT FALSE,
+                           token_version INT NOT NULL DEFAULT 0,
+                           must_change_password BOOLEAN NOT NULL DEFAULT FALSE,
+                           totp_secret VARCHAR(64) NOT NULL DEFAULT '',
+                           totp_enabled BOOLEAN NOT NULL DEFAULT FALSE,
+                           totp_last_counter BIGINT NOT NULL DEFAULT 0,
+                           email VARCHAR(254),
+                           patron_type VARCHAR(20) NOT NULL DEFAULT 'student',
+                           version INT NOT NULL DEFAULT 0
 ) COMMENT '系统用户信息表';
 
+-- 分类法节点表，以物化路径记录层级，path 为从根节点到自身的 ID，如 /1/5/
+CREATE TABLE Categories (
+                            id INT AUTO_INCREMENT PRIMARY KEY,
+                            code VARCHAR(20) NOT NULL UNIQUE,
+                            name VARCHAR(50) NOT NULL,
+                            parent_id INT,
+                            path VARCHAR(255) NOT NULL DEFAULT '',
+                            FOREIGN KEY (parent_id) REFERENCES Categories(id) ON UPDATE CASCADE ON DELETE RESTRICT
+) COMMENT '分类法节点表';
+
 -- 图书类型表（元数据）
 CREATE TABLE BookTypes (
                                ISBN VARCHAR(20) PRIMARY KEY,
+                               isbn10 VARCHAR(10),
                                title VARCHAR(100) NOT NULL,
-                               author VARCHAR(50) NOT NULL,
+                               author VARCHAR(255) NOT NULL DEFAULT '',
                                category VARCHAR(50) NOT NULL,
+                               category_id INT,
                                publisher VARCHAR(50) NOT NULL,
                                publish_year INT NOT NULL,
                                description TEXT,
                                total_copies INT NOT NULL DEFAULT 0,
-                               available_copies INT NOT NULL DEFAULT 0
+                               available_copies INT NOT NULL DEFAULT 0,
+                               version INT NOT NULL DEFAULT 0,
+                               FOREIGN KEY (category_id) REFERENCES Categories(id) ON UPDATE CASCADE ON DELETE RESTRICT
 ) COMMENT '图书元数据信息表';
 
 -- 图书实体表（具体副本）
@@ -27,17 +50,18 @@ CREATE TABLE Books (
                            id INT AUTO_INCREMENT PRIMARY KEY,
                            ISBN VARCHAR(20) NOT NULL,
                            location VARCHAR(50) NOT NULL,
-                           status ENUM('available', 'checked_out', 'lost', 'damaged') DEFAULT 'available',
+                           status ENUM('available', 'checked_out', 'lost', 'damaged', 'in_repair', 'withdrawn') DEFAULT 'available',
                            purchase_date TIMESTAMP NOT NULL,
                            purchase_price DECIMAL(10,2) NOT NULL,
                            last_checkout TIMESTAMP,
+                           version INT NOT NULL DEFAULT 0,
                            FOREIGN KEY (ISBN) REFERENCES BookTypes(ISBN) ON UPDATE CASCADE ON DELETE RESTRICT
 ) COMMENT '图书实体副本表';
 
 -- 借阅记录表
 CREATE TABLE BorrowRecords (
                                    id INT AUTO_INCREMENT PRIMARY KEY,
-                                   user_id INT NOT NULL,
+                                   user_id INT,
                                    book_id INT NOT NULL,
                                    title VARCHAR(100) NOT NULL,
                                    checkout_date TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
@@ -46,15 +70,202 @@ CREATE TABLE BorrowRecords (
                                    return_date TIMESTAMP,
                                    status ENUM('checked_out', 'returned', 'overdue', 'lost') DEFAULT 'checked_out',
                                    late_fee DECIMAL(10,2) DEFAULT 0.00,
+                                   fee_paid BOOLEAN NOT NULL DEFAULT FALSE,
+                                   fee_waived BOOLEAN NOT NULL DEFAULT FALSE,
+                                   fee_settled_at TIMESTAMP NULL,
+                                   idempotency_key VARCHAR(64),
+                                   UNIQUE KEY idx_borrowrecords_user_idempotency_key (user_id, idempotency_key),
                                    FOREIGN KEY (user_id) REFERENCES Users(id) ON UPDATE CASCADE ON DELETE RESTRICT,
                                    FOREIGN KEY (book_id) REFERENCES Books(id) ON UPDATE CASCADE ON DELETE RESTRICT
 ) COMMENT '图书借阅记录表';
 
+-- 预约记录表
+CREATE TABLE Reservations (
+                                  id INT AUTO_INCREMENT PRIMARY KEY,
+                                  user_id INT NOT NULL,
+                                  ISBN VARCHAR(20) NOT NULL,
+                                  reserve_date TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                                  expire_date TIMESTAMP NULL,
+                                  status ENUM('pending', 'ready', 'fulfilled', 'cancelled', 'expired') DEFAULT 'pending',
+                                  FOREIGN KEY (user_id) REFERENCES Users(id) ON UPDATE CASCADE ON DELETE RESTRICT,
+                                  FOREIGN KEY (ISBN) REFERENCES BookTypes(ISBN) ON UPDATE CASCADE ON DELETE RESTRICT
+) COMMENT '图书预约记录表';
+
+-- 登录会话表
+CREATE TABLE Sessions (
+                              id INT AUTO_INCREMENT PRIMARY KEY,
+                              user_id INT NOT NULL,
+                              device VARCHAR(255) NOT NULL,
+                              ip VARCHAR(64) NOT NULL,
+                              created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                              last_used_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                              expires_at TIMESTAMP NOT NULL,
+                              revoked_at TIMESTAMP NULL,
+                              FOREIGN KEY (user_id) REFERENCES Users(id) ON UPDATE CASCADE ON DELETE CASCADE
+) COMMENT '登录会话表';
+
+-- Refresh Token表，记录每个会话签发过的 Refresh Token，用于识别重复使用
+CREATE TABLE RefreshTokens (
+                                   jti VARCHAR(64) PRIMARY KEY,
+                                   session_id INT NOT NULL,
+                                   issued_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                                   used_at TIMESTAMP NULL,
+                                   FOREIGN KEY (session_id) REFERENCES Sessions(id) ON UPDATE CASCADE ON DELETE CASCADE
+) COMMENT 'Refresh Token表';
+
+-- 登录失败记录表，多实例部署时用于共享用户名和 IP 的失败次数
+CREATE TABLE LoginAttempts (
+                                   attempt_key VARCHAR(128) PRIMARY KEY,
+                                   failures INT NOT NULL DEFAULT 0,
+                                   last_failure TIMESTAMP NULL,
+                                   locked_until TIMESTAMP NULL
+) COMMENT '登录失败记录表';
+
+-- 审计日志表
+CREATE TABLE AuditLogs (
+                                   id INT AUTO_INCREMENT PRIMARY KEY,
+                                   action VARCHAR(50) NOT NULL,
+                                   subject VARCHAR(128) NOT NULL,
+                                   ip VARCHAR(64) NOT NULL,
+                                   detail TEXT,
+                                   created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+) COMMENT '审计日志表';
+
+-- 找回密码验证码表，只保存验证码的摘要
+CREATE TABLE PasswordResetCodes (
+                                   id INT AUTO_INCREMENT PRIMARY KEY,
+                                   user_id INT NOT NULL,
+                                   code_hash CHAR(64) NOT NULL,
+                                   attempts INT NOT NULL DEFAULT 0,
+                                   created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                                   expires_at TIMESTAMP NOT NULL,
+                                   used_at TIMESTAMP NULL,
+                                   FOREIGN KEY (user_id) REFERENCES Users(id) ON UPDATE CASCADE ON DELETE CASCADE
+) COMMENT '找回密码验证码表';
+
+-- 两步验证恢复码表，只保存恢复码的摘要
+CREATE TABLE RecoveryCodes (
+                                   id INT AUTO_INCREMENT PRIMARY KEY,
+                                   user_id INT NOT NULL,
+                                   code_hash CHAR(64) NOT NULL,
+                                   used_at TIMESTAMP NULL,
+                                   FOREIGN KEY (user_id) REFERENCES Users(id) ON UPDATE CASCADE ON DELETE CASCADE
+) COMMENT '两步验证恢复码表';
+
+-- 两步验证角色策略表，required 为真时该角色的用户必须启用两步验证
+CREATE TABLE TwoFactorPolicies (
+                                   role VARCHAR(20) PRIMARY KEY,
+                                   required BOOLEAN NOT NULL DEFAULT FALSE
+) COMMENT '两步验证角色策略表';
+
+-- 外部账号关联表，记录 LDAP、OIDC 账号与本地用户的对应关系
+CREATE TABLE ExternalIdentities (
+                                    id INT AUTO_INCREMENT PRIMARY KEY,
+                                    user_id INT NOT NULL,
+                                    provider VARCHAR(20) NOT NULL,
+                                    subject VARCHAR(255) NOT NULL,
+                                    provisioned BOOLEAN NOT NULL DEFAULT FALSE,
+                                    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                                    last_login_at TIMESTAMP NULL,
+                                    UNIQUE KEY idx_externalidentities_provider_subject (provider, subject),
+                                    FOREIGN KEY (user_id) REFERENCES Users(id) ON UPDATE CASCADE ON DELETE CASCADE
+) COMMENT '外部账号关联表';
+
+-- 责任者表，记录作者、编者、译者和绘者，同名的责任者可以是不同的人，名称不唯一
+CREATE TABLE Contributors (
+                              id INT AUTO_INCREMENT PRIMARY KEY,
+                              name VARCHAR(100) NOT NULL
+) COMMENT '责任者表';
+
+-- 主题表
+CREATE TABLE Subjects (
+                          id INT AUTO_INCREMENT PRIMARY KEY,
+                          name VARCHAR(100) NOT NULL
+) COMMENT '主题表';
+
+-- 丛书表
+CREATE TABLE Series (
+                        id INT AUTO_INCREMENT PRIMARY KEY,
+                        name VARCHAR(100) NOT NULL
+) COMMENT '丛书表';
+
+-- 书籍类型与责任者关联表，同一责任者可以在一本书中承担多个角色
+CREATE TABLE BookTypeContributors (
+                                      ISBN VARCHAR(20) NOT NULL,
+                                      contributor_id INT NOT NULL,
+                                      role ENUM('author', 'editor', 'translator', 'illustrator') NOT NULL,
+                                      position INT NOT NULL DEFAULT 0,
+                                      PRIMARY KEY (ISBN, contributor_id, role),
+                                      FOREIGN KEY (ISBN) REFERENCES BookTypes(ISBN) ON UPDATE CASCADE ON DELETE CASCADE,
+                                      FOREIGN KEY (contributor_id) REFERENCES Contributors(id) ON UPDATE CASCADE ON DELETE RESTRICT
+) COMMENT '书籍类型与责任者关联表';
+
+-- 书籍类型与主题关联表
+CREATE TABLE BookTypeSubjects (
+                                  ISBN VARCHAR(20) NOT NULL,
+                                  subject_id INT NOT NULL,
+                                  PRIMARY KEY (ISBN, subject_id),
+                                  FOREIGN KEY (ISBN) REFERENCES BookTypes(ISBN) ON UPDATE CASCADE ON DELETE CASCADE,
+                                  FOREIGN KEY (subject_id) REFERENCES Subjects(id) ON UPDATE CASCADE ON DELETE RESTRICT
+) COMMENT '书籍类型与主题关联表';
+
+-- 书籍类型与丛书关联表
+CREATE TABLE BookTypeSeries (
+                                ISBN VARCHAR(20) NOT NULL,
+                                series_id INT NOT NULL,
+                                volume VARCHAR(20),
+                                PRIMARY KEY (ISBN, series_id),
+                                FOREIGN KEY (ISBN) REFERENCES BookTypes(ISBN) ON UPDATE CASCADE ON DELETE CASCADE,
+                                FOREIGN KEY (series_id) REFERENCES Series(id) ON UPDATE CASCADE ON DELETE RESTRICT
+) COMMENT '书籍类型与丛书关联表';
+
+-- 封面和附件表，内容保存在附件存储中，表中只记录存储 key
+CREATE TABLE Attachments (
+                             id INT AUTO_INCREMENT PRIMARY KEY,
+                             ISBN VARCHAR(20) NOT NULL,
+                             kind ENUM('cover', 'document') NOT NULL,
+                             filename VARCHAR(255) NOT NULL,
+                             content_type VARCHAR(100) NOT NULL,
+                             size BIGINT NOT NULL,
+                             storage_key VARCHAR(255) NOT NULL,
+                             thumbnail_key VARCHAR(255),
+                             uploaded_by INT,
+                             created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                             FOREIGN KEY (ISBN) REFERENCES BookTypes(ISBN) ON UPDATE CASCADE ON DELETE RESTRICT,
+                             FOREIGN KEY (uploaded_by) REFERENCES Users(id) ON UPDATE CASCADE ON DELETE SET NULL
+) COMMENT '封面和附件表';
 
 -- 创建索引
 CREATE INDEX idx_users_name ON Users(name);
 CREATE INDEX idx_booktypes_title ON BookTypes(title);
+CREATE INDEX idx_contributors_name ON Contributors(name);
+CREATE INDEX idx_subjects_name ON Subjects(name);
+CREATE INDEX idx_series_name ON Series(name);
 CREATE INDEX idx_booktypes_author ON BookTypes(author);
 CREATE INDEX idx_books_status ON Books(status);
 CREATE INDEX idx_borrowrecords_status ON BorrowRecords(status);
 CREATE INDEX idx_borrowrecords_user_book ON BorrowRecords(user_id, book_id);
+CREATE INDEX idx_borrowrecords_checkout_date ON BorrowRecords(checkout_date);
+CREATE INDEX idx_borrowrecords_book_checkout ON BorrowRecords(book_id, checkout_date);
+CREATE INDEX idx_reservations_user_status ON Reservations(user_id, status);
+CREATE INDEX idx_sessions_user_id ON Sessions(user_id);
+CREATE INDEX idx_sessions_expires_at ON Sessions(expires_at);
+CREATE INDEX idx_refreshtokens_session_id ON RefreshTokens(session_id);
+CREATE INDEX idx_auditlogs_action ON AuditLogs(action);
+CREATE INDEX idx_auditlogs_created_at ON AuditLogs(created_at);
+CREATE INDEX idx_passwordresetcodes_user_id ON PasswordResetCodes(user_id);
+CREATE INDEX idx_recoverycodes_user_id ON RecoveryCodes(user_id);
+CREATE INDEX idx_externalidentities_user_id ON ExternalIdentities(user_id);
+CREATE INDEX idx_borrowrecords_user_checkout ON BorrowRecords(user_id, checkout_date, id);
+CREATE INDEX idx_books_location ON Books(location);
+CREATE INDEX idx_books_purchase_date ON Books(purchase_date);
+CREATE INDEX idx_books_purchase_price ON Books(purchase_price);
+CREATE INDEX idx_books_last_checkout ON Books(last_checkout);
+CREATE INDEX idx_booktypecontributors_contributor ON BookTypeContributors(contributor_id);
+CREATE INDEX idx_booktypesubjects_subject ON BookTypeSubjects(subject_id);
+CREATE INDEX idx_booktypeseries_series ON BookTypeSeries(series_id);
+CREATE INDEX idx_categories_parent_id ON Categories(parent_id);
+CREATE INDEX idx_categories_path ON Categories(path);
+CREATE INDEX idx_booktypes_category ON BookTypes(category_id);
+CREATE INDEX idx_attachments_isbn_kind ON Attachments(ISBN, kind);
+CREATE UNIQUE INDEX idx_booktypes_isbn10 ON BookTypes(isbn10);
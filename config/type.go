@@ -14,13 +14,204 @@ type mySQL struct {
 	Password string `yaml:"password"` // 数据库密码
 	Charset  string `yaml:"charset"`  // 数据库字符集
 }
+
+// maxBorrowNum 用于存储借阅数量限制
 type maxBorrowNum struct {
-	Num int64 `yaml:"Num"`
+	Num int64 `yaml:"Num"` // 每位用户最多同时借阅的数量
+}
+
+// privacy 用于存储借阅历史隐私配置
+type privacy struct {
+	RetentionDays int64 `yaml:"retentionDays"` // 已归还借阅记录在匿名化前的保留天数
+	CheckInterval int64 `yaml:"checkInterval"` // 匿名化任务的执行间隔（分钟）
+}
+
+// tracing 用于存储链路追踪配置
+type tracing struct {
+	Exporter    string  `yaml:"exporter"`    // 导出方式：none、stdout 或 otlp
+	Endpoint    string  `yaml:"endpoint"`    // OTLP 接收端地址，如 127.0.0.1:4318
+	Insecure    bool    `yaml:"insecure"`    // 是否使用 HTTP 明文连接 OTLP 接收端
+	ServiceName string  `yaml:"serviceName"` // 上报的服务名称
+	SampleRatio float64 `yaml:"sampleRatio"` // 采样比例，取值 0 到 1
+}
+
+// jwtKey 用于存储单个JWT签名密钥
+// HS256 使用 secret 或 secretEnv 指定的环境变量作为密钥；
+// RS256 和 EdDSA 使用 PEM 格式的私钥和公钥，只配置公钥的密钥仅用于校验轮换前签发的Token。
+type jwtKey struct {
+	Kid            string `yaml:"kid"`            // 密钥ID，写入Token头部的 kid 字段
+	Algorithm      string `yaml:"algorithm"`      // 签名算法：HS256、RS256 或 EdDSA
+	Secret         string `yaml:"secret"`         // HMAC 密钥
+	SecretEnv      string `yaml:"secretEnv"`      // 存放 HMAC 密钥的环境变量名，优先于 secret
+	PrivateKeyFile string `yaml:"privateKeyFile"` // PEM 格式私钥文件路径
+	PublicKeyFile  string `yaml:"publicKeyFile"`  // PEM 格式公钥文件路径
+}
+
+// jwtConfig 用于存储JWT密钥配置
+type jwtConfig struct {
+	ActiveKid string   `yaml:"activeKid"` // 当前用于签发Token的密钥ID
+	Keys      []jwtKey `yaml:"keys"`      // 所有可用于校验的密钥
+}
+
+// loginProtection 用于存储登录防暴力破解配置
+type loginProtection struct {
+	MaxUserFailures int64  `yaml:"maxUserFailures"` // 同一用户名连续失败多少次后锁定，0 表示不锁定
+	MaxIPFailures   int64  `yaml:"maxIPFailures"`   // 同一 IP 连续失败多少次后锁定，0 表示不锁定
+	BaseDelay       int64  `yaml:"baseDelay"`       // 第一次失败后需要等待的时间（秒），之后每次失败翻倍
+	MaxDelay        int64  `yaml:"maxDelay"`        // 失败后等待时间的上限（秒）
+	LockoutDuration int64  `yaml:"lockoutDuration"` // 锁定时长（分钟）
+	Window          int64  `yaml:"window"`          // 失败次数的统计窗口（分钟）
+	Store           string `yaml:"store"`           // 失败记录的存储：memory 为进程内存储，mysql 为多实例共享存储
+}
+
+// passwordPolicy 用于存储密码强度策略
+type passwordPolicy struct {
+	MinLength  int `yaml:"minLength"`  // 密码最小长度
+	MaxLength  int `yaml:"maxLength"`  // 密码最大字节数，bcrypt 只使用前 72 字节
+	MinClasses int `yaml:"minClasses"` // 至少包含的字符种类数（小写字母、大写字母、数字、符号）
+}
+
+// sms 用于存储短信发送配置
+type sms struct {
+	Sender   string `yaml:"sender"`   // 发送器类型：log 只输出日志，webhook 转发到短信网关
+	Endpoint string `yaml:"endpoint"` // 短信网关地址，webhook 发送器使用
+	Token    string `yaml:"token"`    // 短信网关的访问令牌
+	TokenEnv string `yaml:"tokenEnv"` // 从该环境变量读取访问令牌，优先于 token
+	Timeout  int64  `yaml:"timeout"`  // 请求短信网关的超时时间（秒）
+}
+
+// passwordReset 用于存储找回密码验证码配置
+type passwordReset struct {
+	CodeLength     int   `yaml:"codeLength"`     // 验证码位数
+	CodeTTL        int64 `yaml:"codeTTL"`        // 验证码有效期（分钟）
+	ResendInterval int64 `yaml:"resendInterval"` // 同一用户两次发送验证码的最小间隔（秒）
+	MaxDailySends  int64 `yaml:"maxDailySends"`  // 同一用户 24 小时内最多发送的验证码数量
+	MaxAttempts    int64 `yaml:"maxAttempts"`    // 单个验证码最多允许输错的次数
+}
+
+// twoFactor 用于存储两步验证配置
+type twoFactor struct {
+	Issuer        string `yaml:"issuer"`        // 验证器应用中显示的签发方名称
+	Skew          int64  `yaml:"skew"`          // 允许的时钟偏差（时间步数，每步 30 秒）
+	RecoveryCodes int    `yaml:"recoveryCodes"` // 每次生成的恢复码数量
+}
+
+// ldapSSO 用于存储 LDAP 登录配置
+type ldapSSO struct {
+	Enabled            bool   `yaml:"enabled"`            // 是否启用 LDAP 登录
+	URL                string `yaml:"url"`                // LDAP 服务地址，如 ldaps://ldap.example.edu:636
+	StartTLS           bool   `yaml:"startTLS"`           // 使用 ldap:// 连接时是否升级为 TLS
+	InsecureSkipVerify bool   `yaml:"insecureSkipVerify"` // 是否跳过证书校验，仅用于本地测试
+	BindDN             string `yaml:"bindDN"`             // 用于搜索用户的服务账号 DN，为空时匿名搜索
+	BindPassword       string `yaml:"bindPassword"`       // 服务账号密码
+	BindPasswordEnv    string `yaml:"bindPasswordEnv"`    // 从该环境变量读取服务账号密码，优先于 bindPassword
+	BaseDN             string `yaml:"baseDN"`             // 搜索用户的起始 DN
+	UserFilter         string `yaml:"userFilter"`         // 搜索用户的过滤器，%s 替换为转义后的用户名
+	UsernameAttribute  string `yaml:"usernameAttribute"`  // 作为本地用户名的属性
+	SubjectAttribute   string `yaml:"subjectAttribute"`   // 作为外部账号唯一标识的属性，用户改名后保持不变
+	PhoneAttribute     string `yaml:"phoneAttribute"`     // 手机号属性
+	GroupAttribute     string `yaml:"groupAttribute"`     // 用户所属组的属性
+	Timeout            int64  `yaml:"timeout"`            // 连接和请求的超时时间（秒）
+}
+
+// oidcSSO 用于存储 OIDC 登录配置
+type oidcSSO struct {
+	Enabled         bool     `yaml:"enabled"`         // 是否启用 OIDC 登录
+	Issuer          string   `yaml:"issuer"`          // 身份提供方的 Issuer 地址
+	ClientID        string   `yaml:"clientID"`        // 客户端ID
+	ClientSecret    string   `yaml:"clientSecret"`    // 客户端密钥
+	ClientSecretEnv string   `yaml:"clientSecretEnv"` // 从该环境变量读取客户端密钥，优先于 clientSecret
+	RedirectURL     string   `yaml:"redirectURL"`     // 回调地址，需在身份提供方中登记
+	Scopes          []string `yaml:"scopes"`          // 额外申请的 scope，openid 会自动加入
+	UsernameClaim   string   `yaml:"usernameClaim"`   // 作为本地用户名的声明
+	PhoneClaim      string   `yaml:"phoneClaim"`      // 手机号声明
+	GroupsClaim     string   `yaml:"groupsClaim"`     // 用户所属组的声明
+}
+
+// groupMapping 用于存储外部组到本地权限的映射
+type groupMapping struct {
+	Group      string `yaml:"group"`      // 外部组名，LDAP 中一般为组的 DN
+	Permission string `yaml:"permission"` // 本地权限：admin、librarian 或 member
+}
+
+// sso 用于存储单点登录配置
+type sso struct {
+	LDAP              ldapSSO        `yaml:"ldap"`              // LDAP 登录配置
+	OIDC              oidcSSO        `yaml:"oidc"`              // OIDC 登录配置
+	GroupMappings     []groupMapping `yaml:"groupMappings"`     // 外部组到本地权限的映射，用户属于多个组时取最高权限
+	DefaultPermission string         `yaml:"defaultPermission"` // 没有匹配任何组时的权限
+}
+
+// patronType 用于存储一种读者类型的用户名规则
+type patronType struct {
+	Name            string `yaml:"name"`            // 读者类型，如 student、staff、visitor
+	UsernamePattern string `yaml:"usernamePattern"` // 用户名需匹配的正则表达式
+	Description     string `yaml:"description"`     // 用户名格式说明，校验失败时返回给客户端
+}
+
+// registration 用于存储注册及修改资料时的校验规则
+type registration struct {
+	PatronTypes       []patronType `yaml:"patronTypes"`       // 可注册的读者类型及其用户名规则
+	DefaultPatronType string       `yaml:"defaultPatronType"` // 注册时未指定读者类型时使用的类型
+	PhoneRegion       string       `yaml:"phoneRegion"`       // 手机号未带国际区号时默认的地区，如 CN
+	PhoneRequired     bool         `yaml:"phoneRequired"`     // 注册时是否必须填写手机号
+	EmailRequired     bool         `yaml:"emailRequired"`     // 注册时是否必须填写邮箱
+}
+
+// pagination 用于存储列表接口的分页配置
+type pagination struct {
+	DefaultPageSize int64 `yaml:"defaultPageSize"` // 请求未指定 page_size 时每页的数量
+	MaxPageSize     int64 `yaml:"maxPageSize"`     // 每页数量的上限，超过时按上限返回
+}
+
+// s3Attachment 用于存储 S3 兼容对象存储的配置
+type s3Attachment struct {
+	Endpoint           string `yaml:"endpoint"`           // 服务地址，如 https://s3.amazonaws.com 或 http://127.0.0.1:9000
+	Region             string `yaml:"region"`             // 区域，用于请求签名
+	Bucket             string `yaml:"bucket"`             // 存储桶名称
+	AccessKeyID        string `yaml:"accessKeyID"`        // 访问密钥ID
+	SecretAccessKey    string `yaml:"secretAccessKey"`    // 访问密钥
+	SecretAccessKeyEnv string `yaml:"secretAccessKeyEnv"` // 从该环境变量读取访问密钥，优先于 secretAccessKey
+	PathStyle          bool   `yaml:"pathStyle"`          // 是否使用 path-style 地址，MinIO 等自建服务一般需要
+	Timeout            int64  `yaml:"timeout"`            // 请求的超时时间（秒）
+}
+
+// attachment 用于存储封面和附件的配置
+type attachment struct {
+	Store         string       `yaml:"store"`         // 存储类型：local 保存在本地目录，s3 保存在 S3 兼容的对象存储
+	LocalDir      string       `yaml:"localDir"`      // local 存储的根目录
+	S3            s3Attachment `yaml:"s3"`            // s3 存储的配置
+	MaxSize       int64        `yaml:"maxSize"`       // 单个附件的最大字节数，修改后需重启服务才能调整请求体大小上限
+	ThumbnailSize int          `yaml:"thumbnailSize"` // 缩略图的最大边长（像素）
+	PublicBaseURL string       `yaml:"publicBaseURL"` // 附件可以直接公开访问时的地址前缀，如 CDN 地址，为空时通过下载接口访问
+}
+
+// metrics 用于存储 Prometheus 指标接口的访问配置
+// 指标中包含借阅量、逾期量、数据库连接池和各接口的访问量，不能公开访问：
+// 请求的来源地址在 allowedIPs 中，或携带 Authorization: Bearer <token> 时才能访问。
+type metrics struct {
+	ListenAddr string   `yaml:"listenAddr"` // 单独暴露指标的监听地址，如 127.0.0.1:9100；为空时在业务端口的 /metrics 暴露
+	Token      string   `yaml:"token"`      // 访问指标接口的 Bearer Token，为空时只按来源地址放行
+	TokenEnv   string   `yaml:"tokenEnv"`   // 从该环境变量读取 Token，优先于 token
+	AllowedIPs []string `yaml:"allowedIPs"` // 允许直接访问的来源 IP 或 CIDR，取连接的对端地址，不信任 X-Forwarded-For
 }
 
 // config 用于存储整个配置信息
 type config struct {
-	Server       server       `yaml:"server"` // 服务器配置
-	MySQL        mySQL        `yaml:"mysql"`  // MySQL数据库配置
-	maxBorrowNum maxBorrowNum `yaml:"maxBorrowNum"`
+	Server          server          `yaml:"server"`          // 服务器配置
+	MySQL           mySQL           `yaml:"mysql"`           // MySQL数据库配置
+	MaxBorrowNum    maxBorrowNum    `yaml:"maxBorrowNum"`    // 借阅数量限制
+	Privacy         privacy         `yaml:"privacy"`         // 借阅历史隐私配置
+	Tracing         tracing         `yaml:"tracing"`         // 链路追踪配置
+	JWT             jwtConfig       `yaml:"jwt"`             // JWT密钥配置
+	LoginProtection loginProtection `yaml:"loginProtection"` // 登录防暴力破解配置
+	PasswordPolicy  passwordPolicy  `yaml:"passwordPolicy"`  // 密码强度策略
+	SMS             sms             `yaml:"sms"`             // 短信发送配置
+	PasswordReset   passwordReset   `yaml:"passwordReset"`   // 找回密码验证码配置
+	TwoFactor       twoFactor       `yaml:"twoFactor"`       // 两步验证配置
+	SSO             sso             `yaml:"sso"`             // 单点登录配置
+	Registration    registration    `yaml:"registration"`    // 注册校验规则
+	Pagination      pagination      `yaml:"pagination"`      // 列表接口的分页配置
+	Attachment      attachment      `yaml:"attachment"`      // 封面和附件的配置
+	Metrics         metrics         `yaml:"metrics"`         // 指标接口的访问配置
 }
@@ -0,0 +1,43 @@
+namespace go attachment
+include "model.thrift"
+
+struct UploadAttachmentRequest{
+    1: required string ISBN,
+    2: required string kind,
+}
+struct UploadAttachmentResponse{
+    1: model.BaseResp base,
+    2: required model.Attachment data,
+}
+
+struct DeleteAttachmentRequest{
+    1: required i64 id,
+}
+struct DeleteAttachmentResponse{
+    1: model.BaseResp base,
+}
+
+struct GetAttachmentRequest{
+    1: required string ISBN,
+    2: optional string kind,
+}
+struct GetAttachmentResponse{
+    1: model.BaseResp base,
+    2: required list<model.Attachment> data,
+}
+
+struct DownloadAttachmentRequest{
+    1: required i64 id,
+    2: optional bool thumbnail,
+}
+struct DownloadAttachmentResponse{
+    1: model.BaseResp base,
+}
+
+
+service AttachmentService {
+    UploadAttachmentResponse uploadAttachment(1: UploadAttachmentRequest req)(api.post="/attachment/upload"),
+    DeleteAttachmentResponse deleteAttachment(1: DeleteAttachmentRequest req)(api.delete="/attachment/delete"),
+    GetAttachmentResponse getAttachment(1: GetAttachmentRequest req)(api.get="/attachment/get"),
+    DownloadAttachmentResponse downloadAttachment(1: DownloadAttachmentRequest req)(api.get="/attachment/download"),
+}
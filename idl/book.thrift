@@ -35,13 +35,35 @@ struct DeleteBookResponse{
 struct GetBookRequest{
     1: optional i64 book_id,
     2: optional string ISBN,
-    3: required i64 page_size,
-    4: required i64 page_num,
+    3: optional i64 page_size,
+    4: optional i64 page_num,
+    5: optional string cursor,
+    6: optional string sort,
+    7: optional bool with_total,
+    8: optional list<string> status,
+    9: optional string location_prefix,
+    10: optional i64 purchased_after,
+    11: optional i64 purchased_before,
+    12: optional double min_price,
+    13: optional double max_price,
+    14: optional i64 last_checkout_before,
+    15: optional bool with_book_type,
 }
 struct GetBookResponse{
     1: model.BaseResp base,
     2: required list<model.Book> data,
-    3: required i64 total_count,
+    3: optional i64 total_count,
+    4: optional string next_cursor,
+}
+
+struct WithdrawBookRequest{
+    1: required list<i64> book_ids,
+    2: optional string reason,
+}
+struct WithdrawBookResponse{
+    1: model.BaseResp base,
+    2: required list<i64> withdrawn,
+    3: required list<i64> skipped,
 }
 
 service BookService {
@@ -49,4 +71,5 @@ service BookService {
     UpdateBookResponse updateBook(1: UpdateBookRequest req)(api.put="/book/update"),
     DeleteBookResponse deleteBook(1: DeleteBookRequest req)(api.delete="/book/delete"),
     GetBookResponse getBook(1: GetBookRequest req)(api.get="/book/search"),
+    WithdrawBookResponse withdrawBook(1: WithdrawBookRequest req)(api.post="/book/withdraw"),
 }
@@ -1,14 +1,28 @@
 namespace go booktype
 include "model.thrift"
 
+struct ContributorLink{
+    1: required i64 contributor_id,
+    2: required string role,
+}
+
+struct SeriesLink{
+    1: required i64 series_id,
+    2: optional string volume,
+}
+
 struct AddBookTypeRequest{
     1: required string title,
-    2: required string author,
+    2: optional string author,
     3: required string category,
     4: required string ISBN,
     5: required string publisher,
     6: required i64 publish_year,
     7: required string description,
+    8: optional list<ContributorLink> contributors,
+    9: optional list<i64> subject_ids,
+    10: optional list<SeriesLink> series,
+    11: optional i64 category_id,
 }
 struct AddBookTypeResponse{
     1: model.BaseResp base,
@@ -23,6 +37,10 @@ struct UpdateBookTypeRequest{
     5: optional string publisher,
     6: optional i64 publish_year,
     7: optional string description,
+    8: optional list<ContributorLink> contributors,
+    9: optional list<i64> subject_ids,
+    10: optional list<SeriesLink> series,
+    11: optional i64 category_id,
 }
 struct UpdateBookTypeResponse{
     1: model.BaseResp base,
@@ -41,13 +59,22 @@ struct GetBookTypeRequest{
     2: optional string title,
     3: optional string author,
     4: optional string category,
-    5: required i64 page_size,
-    6: required i64 page_num,
+    5: optional i64 page_size,
+    6: optional i64 page_num,
+    7: optional string cursor,
+    8: optional string sort,
+    9: optional bool with_total,
+    10: optional i64 contributor_id,
+    11: optional string role,
+    12: optional i64 subject_id,
+    13: optional i64 series_id,
+    14: optional i64 category_id,
 }
 struct GetBookTypeResponse{
     1: model.BaseResp base,
     2: required list<model.BookType> data,
-    3: required i64 total,
+    3: optional i64 total,
+    4: optional string next_cursor,
 }
 
 
@@ -29,16 +29,30 @@ struct RenewResponse{
     2: required model.BorrowRecord data,
 }
 
+struct SettleFineRequest{
+    1: required i64 borrow_id,
+    2: required string action,
+    3: optional string note,
+}
+struct SettleFineResponse{
+    1: model.BaseResp base,
+    2: required model.BorrowRecord data,
+}
+
 struct GetBorrowRecordRequest{
     1: required i64 user_id,
-    2: required i64 page_size,
-    3: required i64 page_num,
+    2: optional i64 page_size,
+    3: optional i64 page_num,
     4: required i64 status,
+    5: optional string cursor,
+    6: optional string sort,
+    7: optional bool with_total,
 }
 struct GetBorrowRecordResponse{
     1: model.BaseResp base,
     2: required list<model.BorrowRecord> data,
-    3: required i64 total,
+    3: optional i64 total,
+    4: optional string next_cursor,
 }
 
 
@@ -46,6 +60,7 @@ service BorrowService {
     BorrowResponse borrow(1: BorrowRequest req)(api.post="/book/borrow"),
     ReturnResponse returnBook(1: ReturnRequest req)(api.post="/book/return"),
     RenewResponse renew(1: RenewRequest req)(api.post="/book/renew"),
+    SettleFineResponse settleFine(1: SettleFineRequest req)(api.post="/book/fine/settle"),
     GetBorrowRecordResponse getBorrowRecord(1: GetBorrowRecordRequest req)(api.get="/book/record"),
 }
 
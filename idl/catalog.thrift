@@ -0,0 +1,59 @@
+namespace go catalog
+include "model.thrift"
+
+struct AddCatalogEntryRequest{
+    1: required string name,
+}
+struct AddCatalogEntryResponse{
+    1: model.BaseResp base,
+    2: required model.CatalogEntry data,
+}
+
+struct UpdateCatalogEntryRequest{
+    1: required i64 id,
+    2: required string name,
+}
+struct UpdateCatalogEntryResponse{
+    1: model.BaseResp base,
+    2: required model.CatalogEntry data,
+}
+
+struct DeleteCatalogEntryRequest{
+    1: required i64 id,
+}
+struct DeleteCatalogEntryResponse{
+    1: model.BaseResp base,
+}
+
+struct GetCatalogEntryRequest{
+    1: optional string name,
+    2: optional i64 page_size,
+    3: optional i64 page_num,
+    4: optional string cursor,
+    5: optional string sort,
+    6: optional bool with_total,
+}
+struct GetCatalogEntryResponse{
+    1: model.BaseResp base,
+    2: required list<model.CatalogEntry> data,
+    3: optional i64 total,
+    4: optional string next_cursor,
+}
+
+
+service CatalogService {
+    AddCatalogEntryResponse addContributor(1: AddCatalogEntryRequest req)(api.post="/contributor/add"),
+    UpdateCatalogEntryResponse updateContributor(1: UpdateCatalogEntryRequest req)(api.put="/contributor/update"),
+    DeleteCatalogEntryResponse deleteContributor(1: DeleteCatalogEntryRequest req)(api.delete="/contributor/delete"),
+    GetCatalogEntryResponse getContributor(1: GetCatalogEntryRequest req)(api.get="/contributor/get"),
+
+    AddCatalogEntryResponse addSubject(1: AddCatalogEntryRequest req)(api.post="/subject/add"),
+    UpdateCatalogEntryResponse updateSubject(1: UpdateCatalogEntryRequest req)(api.put="/subject/update"),
+    DeleteCatalogEntryResponse deleteSubject(1: DeleteCatalogEntryRequest req)(api.delete="/subject/delete"),
+    GetCatalogEntryResponse getSubject(1: GetCatalogEntryRequest req)(api.get="/subject/get"),
+
+    AddCatalogEntryResponse addSeries(1: AddCatalogEntryRequest req)(api.post="/series/add"),
+    UpdateCatalogEntryResponse updateSeries(1: UpdateCatalogEntryRequest req)(api.put="/series/update"),
+    DeleteCatalogEntryResponse deleteSeries(1: DeleteCatalogEntryRequest req)(api.delete="/series/delete"),
+    GetCatalogEntryResponse getSeries(1: GetCatalogEntryRequest req)(api.get="/series/get"),
+}
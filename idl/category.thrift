@@ -0,0 +1,64 @@
+namespace go category
+include "model.thrift"
+
+struct AddCategoryRequest{
+    1: required string code,
+    2: required string name,
+    3: optional i64 parent_id,
+}
+struct AddCategoryResponse{
+    1: model.BaseResp base,
+    2: required model.Category data,
+}
+
+struct UpdateCategoryRequest{
+    1: required i64 id,
+    2: optional string code,
+    3: optional string name,
+    4: optional i64 parent_id,
+}
+struct UpdateCategoryResponse{
+    1: model.BaseResp base,
+    2: required model.Category data,
+}
+
+struct DeleteCategoryRequest{
+    1: required i64 id,
+}
+struct DeleteCategoryResponse{
+    1: model.BaseResp base,
+}
+
+struct GetCategoryRequest{
+    1: optional i64 parent_id,
+    2: optional string code,
+    3: optional string name,
+    4: optional i64 page_size,
+    5: optional i64 page_num,
+    6: optional string cursor,
+    7: optional string sort,
+    8: optional bool with_total,
+}
+struct GetCategoryResponse{
+    1: model.BaseResp base,
+    2: required list<model.Category> data,
+    3: optional i64 total,
+    4: optional string next_cursor,
+}
+
+struct GetCategoryPathRequest{
+    1: required i64 id,
+}
+struct GetCategoryPathResponse{
+    1: model.BaseResp base,
+    2: required list<model.Category> data,
+}
+
+
+service CategoryService {
+    AddCategoryResponse addCategory(1: AddCategoryRequest req)(api.post="/category/add"),
+    UpdateCategoryResponse updateCategory(1: UpdateCategoryRequest req)(api.put="/category/update"),
+    DeleteCategoryResponse deleteCategory(1: DeleteCategoryRequest req)(api.delete="/category/delete"),
+    GetCategoryResponse getCategory(1: GetCategoryRequest req)(api.get="/category/get"),
+    GetCategoryPathResponse getCategoryPath(1: GetCategoryPathRequest req)(api.get="/category/path"),
+}
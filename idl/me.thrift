@@ -0,0 +1,40 @@
+namespace go me
+include "model.thrift"
+
+struct GetSummaryRequest{
+    1: required i64 page_size,
+    2: required i64 page_num,
+}
+struct GetSummaryResponse{
+    1: model.BaseResp base,
+    2: required list<model.Loan> loans,
+    3: required i64 overdue_count,
+    4: required double fine_balance,
+    5: required list<model.Hold> holds,
+    6: required list<model.HistoryItem> history,
+    7: required i64 history_total,
+}
+
+struct UpdatePrivacyRequest{
+    1: required bool keep_history,
+}
+struct UpdatePrivacyResponse{
+    1: model.BaseResp base,
+    2: required model.User data,
+}
+
+struct ExportDataRequest{
+
+}
+struct ExportDataResponse{
+    1: model.BaseResp base,
+    2: required model.User user,
+    3: required list<model.BorrowRecord> borrow_records,
+    4: required list<model.Hold> holds,
+}
+
+service MeService {
+    GetSummaryResponse getSummary(1: GetSummaryRequest req)(api.get="/me/summary"),
+    UpdatePrivacyResponse updatePrivacy(1: UpdatePrivacyRequest req)(api.put="/me/privacy"),
+    ExportDataResponse exportData(1: ExportDataRequest req)(api.get="/me/export"),
+}
@@ -1,8 +1,18 @@
 namespace go model
 
+struct FieldError {
+    1: required string field,
+    2: required i64 code,
+    3: required string msg,
+    4: required string rule,
+    5: optional map<string, string> params,
+}
+
 struct BaseResp {
     1: required i64 code,
     2: required string msg,
+    3: optional list<FieldError> details,
+    4: optional string request_id,
 }
 
 struct ErrorResp {
@@ -12,11 +22,44 @@ struct ErrorResp {
 struct User {
     1: required i64 id
     2: required string username
-    3: required string password
     4: optional string phone
     5: required string status
     6: required string permissions
     7: required string register_date
+    8: required bool keep_history
+    9: required bool must_change_password
+    10: required bool two_factor_enabled
+    11: optional string email
+    12: required string patron_type
+    13: required i64 version
+}
+
+struct TwoFactorPolicy {
+    1: required string role
+    2: required bool required
+}
+
+struct PublicUser {
+    1: required i64 id
+    2: required string username
+    3: required string register_date
+}
+
+struct CatalogEntry {
+    1: required i64 id
+    2: required string name
+}
+
+struct BookTypeContributor {
+    1: required i64 contributor_id
+    2: required string name
+    3: required string role
+}
+
+struct BookTypeSeries {
+    1: required i64 series_id
+    2: required string name
+    3: optional string volume
 }
 
 struct BookType {
@@ -29,6 +72,33 @@ struct BookType {
     7: required string description
     8: required i64 total_copies
     9: required i64 available_copies
+    10: required i64 version
+    11: optional list<BookTypeContributor> contributors
+    12: optional list<CatalogEntry> subjects
+    13: optional list<BookTypeSeries> series
+    14: optional i64 category_id
+    15: optional string cover_url
+    16: optional string cover_thumbnail_url
+    17: optional string isbn10
+}
+
+struct Attachment {
+    1: required i64 id
+    2: required string ISBN
+    3: required string kind
+    4: required string filename
+    5: required string content_type
+    6: required i64 size
+    7: required string url
+    8: optional string thumbnail_url
+    9: required string created_at
+}
+
+struct Category {
+    1: required i64 id
+    2: required string code
+    3: required string name
+    4: optional i64 parent_id
 }
 
 struct Book {
@@ -39,7 +109,9 @@ struct Book {
     5: required string purchase_date
     6: required double purchase_price
     7: required string last_checkout
-
+    8: optional string title
+    9: optional string author
+    10: required i64 version
 }
 
 struct BorrowRecord {
@@ -53,6 +125,52 @@ struct BorrowRecord {
     8: required string status
     9: required i64 renewal_count
     10: required double late_fee
+    11: required string fee_status
+}
+
+
+struct Loan {
+    1: required i64 borrow_id
+    2: required i64 book_id
+    3: required string isbn
+    4: required string title
+    5: required string author
+    6: required string location
+    7: required string checkout_date
+    8: required string due_date
+    9: required i64 days_remaining
+    10: required i64 renewal_count
+    11: required bool overdue
+}
+
+struct Hold {
+    1: required i64 id
+    2: required string isbn
+    3: required string title
+    4: required string author
+    5: required string status
+    6: required string reserve_date
+    7: required string expire_date
 }
 
+struct HistoryItem {
+    1: required i64 borrow_id
+    2: required i64 book_id
+    3: required string isbn
+    4: required string title
+    5: required string author
+    6: required string checkout_date
+    7: required string return_date
+    8: required string status
+    9: required double late_fee
+}
 
+struct Session {
+    1: required i64 id
+    2: required string device
+    3: required string ip
+    4: required string created_at
+    5: required string last_used_at
+    6: required string expires_at
+    7: required bool current
+}
@@ -0,0 +1,181 @@
+namespace go report
+include "model.thrift"
+
+struct PeriodCount {
+    1: required string period
+    2: required i64 count
+}
+
+struct PeriodAmount {
+    1: required string period
+    2: required double amount
+}
+
+struct RankItem {
+    1: required string name
+    2: required string isbn
+    3: required i64 count
+}
+
+struct CategoryTurnover {
+    1: required string category
+    2: required i64 copies
+    3: required i64 loans
+    4: required double turnover
+    5: optional i64 category_id
+}
+
+struct IdleCopy {
+    1: required i64 book_id
+    2: required string isbn
+    3: required string title
+    4: required string category
+    5: required string location
+    6: required string purchase_date
+    7: optional i64 category_id
+}
+
+struct RoleOverdue {
+    1: required string role
+    2: required i64 loans
+    3: required i64 overdue
+    4: required double rate
+}
+
+struct CategoryValue {
+    1: required string category
+    2: required i64 copies
+    3: required double value
+    4: optional i64 category_id
+}
+
+struct WeedingGroup {
+    1: required string category
+    2: required string location
+    3: required i64 copies
+    4: optional i64 category_id
+}
+
+struct WeedingCandidate {
+    1: required i64 book_id
+    2: required string isbn
+    3: required string title
+    4: required string category
+    5: required string location
+    6: required string status
+    7: required string purchase_date
+    8: required string last_checkout
+    9: optional i64 category_id
+}
+
+struct LoanStatsRequest{
+    1: required string period,
+    2: optional i64 start_time,
+    3: optional i64 end_time,
+    4: optional string format,
+}
+struct LoanStatsResponse{
+    1: model.BaseResp base,
+    2: required list<PeriodCount> data,
+}
+
+struct TopBorrowedRequest{
+    1: required string by,
+    2: optional i64 limit,
+    3: optional i64 start_time,
+    4: optional i64 end_time,
+    5: optional string format,
+}
+struct TopBorrowedResponse{
+    1: model.BaseResp base,
+    2: required list<RankItem> data,
+}
+
+struct TurnoverRequest{
+    1: optional i64 start_time,
+    2: optional i64 end_time,
+    3: optional string format,
+    4: optional i64 category_id,
+}
+struct TurnoverResponse{
+    1: model.BaseResp base,
+    2: required list<CategoryTurnover> data,
+}
+
+struct IdleCopiesRequest{
+    1: optional string category,
+    2: optional string format,
+    3: optional i64 page_size,
+    4: optional i64 page_num,
+    5: optional string cursor,
+    6: optional string sort,
+    7: optional bool with_total,
+    8: optional i64 category_id,
+}
+struct IdleCopiesResponse{
+    1: model.BaseResp base,
+    2: required list<IdleCopy> data,
+    3: optional i64 total,
+    4: optional string next_cursor,
+}
+
+struct OverdueRateRequest{
+    1: optional i64 start_time,
+    2: optional i64 end_time,
+    3: optional string format,
+}
+struct OverdueRateResponse{
+    1: model.BaseResp base,
+    2: required list<RoleOverdue> data,
+}
+
+struct FineRevenueRequest{
+    1: required string period,
+    2: optional i64 start_time,
+    3: optional i64 end_time,
+    4: optional string format,
+}
+struct FineRevenueResponse{
+    1: model.BaseResp base,
+    2: required list<PeriodAmount> data,
+}
+
+struct CollectionValueRequest{
+    1: optional string format,
+    2: optional i64 category_id,
+}
+struct CollectionValueResponse{
+    1: model.BaseResp base,
+    2: required list<CategoryValue> data,
+}
+
+struct WeedingRequest{
+    1: required i64 months,
+    2: optional string category,
+    3: optional string location,
+    4: optional string format,
+    5: optional i64 page_size,
+    6: optional i64 page_num,
+    7: optional string cursor,
+    8: optional string sort,
+    9: optional bool with_total,
+    10: optional i64 category_id,
+}
+struct WeedingResponse{
+    1: model.BaseResp base,
+    2: required list<WeedingGroup> groups,
+    3: required list<WeedingCandidate> data,
+    4: optional i64 total,
+    5: optional string next_cursor,
+}
+
+service ReportService {
+    LoanStatsResponse loanStats(1: LoanStatsRequest req)(api.get="/report/loans"),
+    TopBorrowedResponse topBorrowed(1: TopBorrowedRequest req)(api.get="/report/top"),
+    TurnoverResponse turnover(1: TurnoverRequest req)(api.get="/report/turnover"),
+    IdleCopiesResponse idleCopies(1: IdleCopiesRequest req)(api.get="/report/idle"),
+    OverdueRateResponse overdueRate(1: OverdueRateRequest req)(api.get="/report/overdue"),
+    FineRevenueResponse fineRevenue(1: FineRevenueRequest req)(api.get="/report/fines"),
+    CollectionValueResponse collectionValue(1: CollectionValueRequest req)(api.get="/report/value"),
+    WeedingResponse weeding(1: WeedingRequest req)(api.get="/report/weeding"),
+}
@@ -4,7 +4,9 @@ include "model.thrift"
 struct RegisterRequest{
     1: required string username,
     2: required string password,
-    3: required string phone,
+    3: optional string phone,
+    4: optional string email,
+    5: optional string patron_type,
 }
 struct RegisterResponse{
     1: model.BaseResp base,
@@ -16,13 +18,23 @@ struct LoginRequest{
     2: required string password,
 }
 struct LoginResponse{
+    1: model.BaseResp base,
+    2: optional model.User data,
+    3: optional string challenge_token,
+}
+
+struct LoginTwoFactorRequest{
+    1: required string challenge_token,
+    2: required string code,
+}
+struct LoginTwoFactorResponse{
     1: model.BaseResp base,
     2: required model.User data,
 }
 
 struct UpdateUserRequest{
     1: optional string phone,
-    2: optional string password,
+    2: optional string email,
 }
 struct UpdateUserResponse{
     1: model.BaseResp base,
@@ -34,7 +46,8 @@ struct UserInfoRequest{
 }
 struct UserInfoResponse{
     1: model.BaseResp base,
-    2: required model.User data,
+    2: optional model.User data,
+    3: optional model.PublicUser profile,
 }
 
 struct DeleteUserRequest{
@@ -51,6 +64,8 @@ struct AdminUpdateUserRequest{
     4: optional string permission,
     5: optional string status,
     6: optional string password,
+    7: optional string email,
+    8: optional string patron_type,
 }
 struct AdminUpdateUserResponse{
     1: model.BaseResp base,
@@ -71,6 +86,137 @@ struct RefreshTokenResponse{
     1: model.BaseResp base,
 }
 
+struct LogoutRequest{
+
+}
+struct LogoutResponse{
+    1: model.BaseResp base,
+}
+
+struct LogoutAllRequest{
+
+}
+struct LogoutAllResponse{
+    1: model.BaseResp base,
+}
+
+struct ListSessionsRequest{
+
+}
+struct ListSessionsResponse{
+    1: model.BaseResp base,
+    2: required list<model.Session> data,
+}
+
+struct RevokeSessionRequest{
+    1: required i64 session_id,
+}
+struct RevokeSessionResponse{
+    1: model.BaseResp base,
+}
+
+struct ChangePasswordRequest{
+    1: required string old_password,
+    2: required string new_password,
+}
+struct ChangePasswordResponse{
+    1: model.BaseResp base,
+}
+
+struct SendResetCodeRequest{
+    1: required string username,
+}
+struct SendResetCodeResponse{
+    1: model.BaseResp base,
+}
+
+struct ResetPasswordRequest{
+    1: required string username,
+    2: required string code,
+    3: required string new_password,
+}
+struct ResetPasswordResponse{
+    1: model.BaseResp base,
+}
+
+struct SetupTwoFactorRequest{
+
+}
+struct SetupTwoFactorResponse{
+    1: model.BaseResp base,
+    2: required string secret,
+    3: required string provisioning_uri,
+}
+
+struct EnableTwoFactorRequest{
+    1: required string code,
+}
+struct EnableTwoFactorResponse{
+    1: model.BaseResp base,
+    2: required list<string> recovery_codes,
+}
+
+struct DisableTwoFactorRequest{
+    1: required string password,
+    2: required string code,
+}
+struct DisableTwoFactorResponse{
+    1: model.BaseResp base,
+}
+
+struct RegenerateRecoveryCodesRequest{
+    1: required string code,
+}
+struct RegenerateRecoveryCodesResponse{
+    1: model.BaseResp base,
+    2: required list<string> recovery_codes,
+}
+
+struct SetTwoFactorPolicyRequest{
+    1: required string role,
+    2: required bool required,
+}
+struct SetTwoFactorPolicyResponse{
+    1: model.BaseResp base,
+}
+
+struct ListTwoFactorPolicyRequest{
+
+}
+struct ListTwoFactorPolicyResponse{
+    1: model.BaseResp base,
+    2: required list<model.TwoFactorPolicy> data,
+}
+
+struct LDAPLoginRequest{
+    1: required string username,
+    2: required string password,
+}
+
+struct OIDCAuthorizeRequest{
+
+}
+struct OIDCAuthorizeResponse{
+    1: model.BaseResp base,
+    2: required string authorization_url,
+}
+
+struct OIDCCallbackRequest{
+    1: required string code,
+    2: required string state,
+}
+
+struct LinkLDAPRequest{
+    1: required string username,
+    2: required string password,
+}
+struct LinkLDAPResponse{
+    1: model.BaseResp base,
+}
+
+struct LinkOIDCRequest{
+
+}
 
 service UserService {
     RegisterResponse register(1: RegisterRequest req)(api.post="/user/register"),
@@ -79,9 +225,28 @@ service UserService {
     UserInfoResponse GetUserInfo(1: UserInfoRequest req)(api.get="/user/info"),
     DeleteUserResponse deleteUser(1: DeleteUserRequest req)(api.delete="/user/delete"),
     RefreshTokenResponse refreshToken(1: RefreshTokenRequest req)(api.post="/user/refresh"),
+    LogoutResponse logout(1: LogoutRequest req)(api.post="/user/logout"),
+    LogoutAllResponse logoutAll(1: LogoutAllRequest req)(api.post="/user/logout/all"),
+    ListSessionsResponse listSessions(1: ListSessionsRequest req)(api.get="/user/session/list"),
+    RevokeSessionResponse revokeSession(1: RevokeSessionRequest req)(api.delete="/user/session/revoke"),
+    ChangePasswordResponse changePassword(1: ChangePasswordRequest req)(api.put="/user/password"),
+    SendResetCodeResponse sendResetCode(1: SendResetCodeRequest req)(api.post="/user/password/reset/code"),
+    ResetPasswordResponse resetPassword(1: ResetPasswordRequest req)(api.post="/user/password/reset"),
+    LoginTwoFactorResponse loginTwoFactor(1: LoginTwoFactorRequest req)(api.post="/user/login/2fa"),
+    SetupTwoFactorResponse setupTwoFactor(1: SetupTwoFactorRequest req)(api.post="/user/2fa/setup"),
+    EnableTwoFactorResponse enableTwoFactor(1: EnableTwoFactorRequest req)(api.post="/user/2fa/enable"),
+    DisableTwoFactorResponse disableTwoFactor(1: DisableTwoFactorRequest req)(api.post="/user/2fa/disable"),
+    RegenerateRecoveryCodesResponse regenerateRecoveryCodes(1: RegenerateRecoveryCodesRequest req)(api.post="/user/2fa/recovery"),
+    LoginResponse ldapLogin(1: LDAPLoginRequest req)(api.post="/user/sso/ldap/login"),
+    OIDCAuthorizeResponse oidcAuthorize(1: OIDCAuthorizeRequest req)(api.get="/user/sso/oidc/authorize"),
+    LoginResponse oidcCallback(1: OIDCCallbackRequest req)(api.get="/user/sso/oidc/callback"),
+    LinkLDAPResponse linkLDAP(1: LinkLDAPRequest req)(api.post="/user/sso/ldap/link"),
+    OIDCAuthorizeResponse linkOIDC(1: LinkOIDCRequest req)(api.get="/user/sso/oidc/link"),
 }
 
 service AdminUserService {
     AdminUpdateUserResponse adminUpdateUser(1: AdminUpdateUserRequest req)(api.put="/user/admin/update"),
     AdminDeleteUserResponse adminDeleteUser(1: AdminDeleteUserRequest req)(api.delete="/user/admin/delete"),
+    SetTwoFactorPolicyResponse setTwoFactorPolicy(1: SetTwoFactorPolicyRequest req)(api.put="/user/admin/2fa/policy"),
+    ListTwoFactorPolicyResponse listTwoFactorPolicy(1: ListTwoFactorPolicyRequest req)(api.get="/user/admin/2fa/policy"),
 }
@@ -3,13 +3,20 @@
 package main
 
 import (
+	"context"
+
 	"github.com/cloudwego/hertz/pkg/app/server"
+	"github.com/cloudwego/hertz/pkg/app/server/binding"
 	"github.com/cloudwego/hertz/pkg/common/hlog"
 
+	"github.com/2451965602/LMS/pkg/constants"
+	"github.com/2451965602/LMS/pkg/tracing"
 	"github.com/2451965602/LMS/pkg/utils"
 
 	"github.com/2451965602/LMS/biz/dal"
 	mw "github.com/2451965602/LMS/biz/middleware"
+	"github.com/2451965602/LMS/biz/pack"
+	"github.com/2451965602/LMS/biz/service"
 	"github.com/2451965602/LMS/config"
 )
 
@@ -18,12 +25,21 @@ func init() {
 	config.Init() // 初始化配置模块
 	mw.Init()     // 初始化中间件模块
 
+	// 初始化链路追踪
+	if err := tracing.Init(); err != nil {
+		hlog.Errorf("tracing.Init: %v", err) // 记录初始化链路追踪的错误
+		panic(err)                           // 如果初始化失败，抛出错误并终止程序
+	}
+
 	// 初始化数据访问层
 	err := dal.Init()
 	if err != nil {
 		hlog.Errorf("dal.Init: %v", err) // 记录初始化数据访问层的错误
 		panic(err)                       // 如果初始化失败，抛出错误并终止程序
 	}
+
+	service.StartHistoryRetention() // 启动借阅历史匿名化任务
+	service.StartSessionCleanup()   // 启动过期会话清理任务
 }
 
 // main 是程序的入口点
@@ -35,11 +51,32 @@ func main() {
 		panic(err)                                  // 如果获取失败，抛出错误并终止程序
 	}
 
+	// vd 校验表达式的错误带上字段错误详情
+	validateConfig := binding.NewValidateConfig()
+	validateConfig.SetValidatorErrorFactory(pack.ValidateErrorFactory)
+
+	// 请求体上限需容纳上传的附件
+	maxRequestBody := max(constants.DefaultMaxRequestBody, int(config.Attachment.MaxSize)+constants.AttachmentFormOverhead)
+
 	// 创建Hertz服务器实例
-	h := server.Default(server.WithHostPorts(addr))
+	h := server.Default(server.WithHostPorts(addr), server.WithValidateConfig(validateConfig),
+		server.WithMaxRequestBodySize(maxRequestBody))
+	h.Use(mw.RequestID()) // 为每个请求分配请求ID
+	h.Use(mw.Tracing())   // 为每个请求创建追踪 span
+	h.Use(mw.Metrics())   // 记录请求次数和耗时
+
+	// 服务退出前导出剩余的追踪数据
+	h.OnShutdown = append(h.OnShutdown, func(ctx context.Context) {
+		ctx, cancel := context.WithTimeout(ctx, constants.TracingShutdownTimeout)
+		defer cancel()
+		if err := tracing.Shutdown(ctx); err != nil {
+			hlog.Errorf("tracing.Shutdown: %v", err)
+		}
+	})
 
 	// 注册路由和中间件
 	register(h)
+	startMetricsServer(h) // 配置了单独的监听地址时在该地址暴露指标
 
 	// 启动服务器
 	h.Spin()
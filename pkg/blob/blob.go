@@ -0,0 +1,66 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+const (
+	KindLocal = "local" // 保存在本地文件系统的存储
+	KindS3    = "s3"    // 保存在 S3 兼容对象存储的存储
+)
+
+// ErrNotFound 要读取的对象不存在
+var ErrNotFound = errors.New("blob: object not found")
+
+// Store 附件内容的存储接口，不同的存储后端实现该接口即可接入
+// key 由调用方生成，只包含字母、数字和 -_./ ，以 / 分隔层级。
+type Store interface {
+	// Put 写入一个对象，key 已存在时覆盖
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	// Get 读取一个对象，对象不存在时返回 ErrNotFound，调用方负责关闭返回的 ReadCloser
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete 删除一个对象，对象不存在时不返回错误
+	Delete(ctx context.Context, key string) error
+}
+
+// Options 创建存储所需的参数
+type Options struct {
+	LocalDir string // local 存储的根目录
+
+	Endpoint        string        // S3 兼容服务的地址，如 https://s3.amazonaws.com 或 http://127.0.0.1:9000
+	Region          string        // S3 区域，用于请求签名
+	Bucket          string        // 存储桶名称
+	AccessKeyID     string        // 访问密钥ID
+	SecretAccessKey string        // 访问密钥
+	PathStyle       bool          // 是否使用 path-style 地址（endpoint/bucket/key），MinIO 等自建服务一般需要
+	Timeout         time.Duration // 请求 S3 兼容服务的超时时间
+}
+
+// New 根据类型创建存储
+// 参数：
+//   - kind: 存储类型，local 保存在本地目录，s3 保存在 S3 兼容的对象存储
+//   - opts: 存储参数
+//
+// 返回值：
+//   - Store: 附件存储
+//   - error: 错误信息，类型未知或参数缺失时返回错误
+func New(kind string, opts Options) (Store, error) {
+	switch kind {
+	case KindLocal, "":
+		if opts.LocalDir == "" {
+			return nil, fmt.Errorf("blob.New: local store requires a directory")
+		}
+		return NewLocalStore(opts.LocalDir), nil
+	case KindS3:
+		if opts.Endpoint == "" || opts.Bucket == "" {
+			return nil, fmt.Errorf("blob.New: s3 store requires an endpoint and a bucket")
+		}
+		return NewS3Store(opts), nil
+	default:
+		return nil, fmt.Errorf("blob.New: unknown store %q", kind)
+	}
+}
@@ -0,0 +1,25 @@
+package constants
+
+// 附件的类型，与 Attachments 表 kind 列的枚举值一致
+const (
+	AttachmentKindCover    = "cover"    // 封面图片，每个 ISBN 只保留一张
+	AttachmentKindDocument = "document" // 目录等文档，可以是 PDF 或图片
+)
+
+const (
+	AttachmentFormField    = "file"                  // 上传附件时文件所在的表单字段
+	AttachmentMaxPixels    = 40_000_000              // 生成缩略图时允许解码的最大像素数
+	AttachmentFilenameMax  = 255                     // 附件原始文件名的最大长度
+	AttachmentThumbnailExt = "_thumb.jpg"            // 缩略图的存储 key 在原附件 key 之后追加的后缀
+	AttachmentKeyPrefix    = "attachments/"          // 附件存储 key 的前缀
+	AttachmentDownloadPath = "/attachment/download"  // 通过服务下载附件的接口地址
+	AttachmentCacheControl = "public, max-age=86400" // 下载附件时的缓存策略，附件内容上传后不再修改
+	AttachmentFormOverhead = 1 << 20                 // 请求体上限在附件大小上限之外为表单其他部分预留的空间
+	DefaultMaxRequestBody  = 4 << 20                 // Hertz 默认的请求体上限
+)
+
+// AttachmentContentTypes 各类附件允许的内容类型，按文件内容检测，不信任客户端声明的类型
+var AttachmentContentTypes = map[string][]string{
+	AttachmentKindCover:    {"image/jpeg", "image/png", "image/gif"},
+	AttachmentKindDocument: {"application/pdf", "image/jpeg", "image/png", "image/gif"},
+}
@@ -0,0 +1,32 @@
+package constants
+
+// 图书副本的状态，与 Books 表 status 列的枚举值一致
+const (
+	BookStatusAvailable  = "available"   // 在架可借
+	BookStatusCheckedOut = "checked_out" // 已借出
+	BookStatusLost       = "lost"        // 已遗失
+	BookStatusDamaged    = "damaged"     // 已损坏
+	BookStatusInRepair   = "in_repair"   // 修补中，暂不可借
+	BookStatusWithdrawn  = "withdrawn"   // 已剔除，不再计入馆藏副本数
+)
+
+// 责任者在书籍类型中承担的角色，与 BookTypeContributors 表 role 列的枚举值一致
+const (
+	ContributorRoleAuthor      = "author"      // 著者
+	ContributorRoleEditor      = "editor"      // 编者
+	ContributorRoleTranslator  = "translator"  // 译者
+	ContributorRoleIllustrator = "illustrator" // 绘者
+)
+
+const (
+	BookTypeAuthorMaxLength = 255 // 书籍类型著者说明的最大长度，如“[英] 乔治·奥威尔 著；董乐山 译”
+	CatalogNameMaxLength    = 100 // 责任者、主题和丛书名称的最大长度
+	SeriesVolumeMaxLength   = 20  // 丛书卷次的最大长度
+
+	CategoryCodeMaxLength = 20 // 分类号的最大长度
+	CategoryNameMaxLength = 50 // 分类名称的最大长度，与书籍类型的分类字段一致
+
+	AuditActionBookWithdraw = "book_withdraw" // 审计日志动作：批量剔除副本
+	WeedingMaxMonths        = 600             // 剔旧报表允许的最大未借出月数
+	WithdrawMaxBatch        = 500             // 一次批量剔除的最大副本数
+)
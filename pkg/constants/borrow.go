@@ -10,3 +10,25 @@ const (
 	Lost       = 4
 	All        = 0
 )
+
+const (
+	IdempotencyKeyHeader    = "Idempotency-Key" // 借书请求携带幂等键的请求头名称
+	IdempotencyKeyMaxLength = 64                // 幂等键的最大长度，与借阅记录表 idempotency_key 列的长度一致
+)
+
+// 借阅记录逾期费用的结算状态
+const (
+	FeeStatusNone   = "none"   // 没有逾期费用
+	FeeStatusUnpaid = "unpaid" // 尚未结清
+	FeeStatusPaid   = "paid"   // 已缴纳
+	FeeStatusWaived = "waived" // 已减免
+)
+
+const (
+	FineActionPay     = "pay"   // 结清逾期费用：读者已缴纳
+	FineActionWaive   = "waive" // 结清逾期费用：馆员减免
+	FineNoteMaxLength = 200     // 结清逾期费用时备注的最大长度
+
+	AuditActionFinePay   = "fine_pay"   // 审计日志动作：登记逾期费用已缴纳
+	AuditActionFineWaive = "fine_waive" // 审计日志动作：减免逾期费用
+)
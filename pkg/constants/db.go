@@ -8,10 +8,26 @@ const (
 	ConnMaxLifetime = 10 * time.Second // (DB) 最大可复用时间
 	ConnMaxIdleTime = 5 * time.Minute  // (DB) 最长保持空闲状态时间
 
-	UserTableName         = "Users"         // (DB) 用户表名
-	BookTypeTableName     = "BookTypes"     // (DB) 图书类型表名
-	BookTableName         = "Books"         // (DB) 图书表名
-	BorrowRecordTableName = "BorrowRecords" // (DB) 借阅记录表名
-	ReservationTableName  = "Reservations"  // (DB) 预约记录表名
+	UserTableName                = "Users"                // (DB) 用户表名
+	BookTypeTableName            = "BookTypes"            // (DB) 图书类型表名
+	BookTableName                = "Books"                // (DB) 图书表名
+	BorrowRecordTableName        = "BorrowRecords"        // (DB) 借阅记录表名
+	ReservationTableName         = "Reservations"         // (DB) 预约记录表名
+	SessionTableName             = "Sessions"             // (DB) 登录会话表名
+	RefreshTokenTableName        = "RefreshTokens"        // (DB) Refresh Token表名
+	LoginAttemptTableName        = "LoginAttempts"        // (DB) 登录失败记录表名
+	AuditLogTableName            = "AuditLogs"            // (DB) 审计日志表名
+	PasswordResetCodeTableName   = "PasswordResetCodes"   // (DB) 找回密码验证码表名
+	RecoveryCodeTableName        = "RecoveryCodes"        // (DB) 两步验证恢复码表名
+	TwoFactorPolicyTableName     = "TwoFactorPolicies"    // (DB) 两步验证角色策略表名
+	ExternalIdentityTableName    = "ExternalIdentities"   // (DB) 外部账号关联表名
+	ContributorTableName         = "Contributors"         // (DB) 责任者（作者、译者等）表名
+	SubjectTableName             = "Subjects"             // (DB) 主题表名
+	SeriesTableName              = "Series"               // (DB) 丛书表名
+	BookTypeContributorTableName = "BookTypeContributors" // (DB) 书籍类型与责任者关联表名
+	BookTypeSubjectTableName     = "BookTypeSubjects"     // (DB) 书籍类型与主题关联表名
+	BookTypeSeriesTableName      = "BookTypeSeries"       // (DB) 书籍类型与丛书关联表名
+	CategoryTableName            = "Categories"           // (DB) 分类法节点表名
+	AttachmentTableName          = "Attachments"          // (DB) 封面和附件表名
 
 )
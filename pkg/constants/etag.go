@@ -0,0 +1,6 @@
+package constants
+
+const (
+	ETagHeader    = "ETag"     // 读接口返回数据版本的响应头名称
+	IfMatchHeader = "If-Match" // 更新接口携带期望数据版本的请求头名称
+)
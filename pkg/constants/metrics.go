@@ -0,0 +1,12 @@
+package constants
+
+import "time"
+
+const (
+	MetricsNamespace    = "lms"           // Prometheus 指标的命名空间
+	MetricsPath         = "/metrics"      // Prometheus 拉取指标的路径
+	MetricsGaugeTimeout = 3 * time.Second // 计算业务指标时单次数据库查询的超时时间
+	ErrnoKey            = "errno"         // 在请求上下文中存储响应业务错误码的键名
+	ErrmsgKey           = "errmsg"        // 在请求上下文中存储响应错误信息的键名，内部错误为实际原因
+	ErrorKey            = "error"         // 在请求上下文中存储响应错误的键名，用于输出内部错误的调用栈
+)
@@ -0,0 +1,26 @@
+package constants
+
+const (
+	ReportPeriodDay   = "day"   // 按天统计
+	ReportPeriodWeek  = "week"  // 按周统计
+	ReportPeriodMonth = "month" // 按月统计
+
+	ReportRankByTitle  = "title"  // 按书名排行
+	ReportRankByAuthor = "author" // 按作者排行
+
+	ReportFormatJSON = "json" // JSON 格式输出
+	ReportFormatCSV  = "csv"  // CSV 格式输出
+
+	ReportDefaultTopLimit = 10  // 排行榜默认条数
+	ReportMaxTopLimit     = 100 // 排行榜最大条数
+
+	NextCursorHeader = "X-Next-Cursor" // CSV 报表返回下一页游标的响应头
+	TotalCountHeader = "X-Total-Count" // CSV 报表返回总数的响应头
+)
+
+// ReportPeriodFormats 统计周期对应的 MySQL DATE_FORMAT 格式
+var ReportPeriodFormats = map[string]string{
+	ReportPeriodDay:   "%Y-%m-%d",
+	ReportPeriodWeek:  "%x-W%v",
+	ReportPeriodMonth: "%Y-%m",
+}
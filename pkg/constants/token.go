@@ -6,4 +6,24 @@ const (
 	AccessTokenTTL  = time.Hour * 24     // 定义了Access Token的有效期
 	RefreshTokenTTL = time.Hour * 24 * 7 // 定义了Refresh Token的有效期
 	IdentityKey     = "user_id"          // 在JWT的Claims中，使用此键名存储用户ID
+
+	TokenTypeKey       = "token_type"     // 在JWT的Claims中，使用此键名存储Token类型
+	TokenIDKey         = "jti"            // 在JWT的Claims中，使用此键名存储Token的唯一ID，用于识别Refresh Token的重复使用
+	TokenVersionKey    = "ver"            // 在JWT的Claims中，使用此键名存储签发时用户的Token版本
+	SessionIDKey       = "sid"            // 在JWT的Claims中，使用此键名存储Token所属的登录会话ID
+	TokenTypeAccess    = "access"         // Access Token的类型
+	TokenTypeRefresh   = "refresh"        // Refresh Token的类型
+	TokenTypeChallenge = "2fa_challenge"  // 两步验证挑战Token的类型，只能用于 /user/login/2fa
+	ChallengeTokenTTL  = 5 * time.Minute  // 两步验证挑战Token的有效期
+	TokenTypeOIDCState = "oidc_state"     // OIDC 登录 state 参数的类型，jti 为写入 ID Token 的 nonce
+	OIDCStateTTL       = 10 * time.Minute // OIDC 登录 state 参数的有效期，需在此时间内完成身份提供方的登录
+	OIDCNonceCookie    = "lms_oidc_nonce" // 保存 state 中 nonce 的 Cookie，回调时校验，防止把他人发起的 state 用在当前浏览器
+	OIDCCookiePath     = "/user/sso/oidc" // OIDC 相关 Cookie 的路径，浏览器只在 OIDC 相关接口中发送
+
+	JWTDefaultAlgorithm    = "HS256"     // 未配置签名算法时使用的算法
+	JWTEphemeralKeyID      = "ephemeral" // 未配置密钥时临时生成的密钥ID
+	JWTSecretMinLength     = 32          // HMAC 密钥的最小字节数
+	TokenIDLength          = 16          // Token唯一ID的随机字节数
+	SessionCleanupInterval = time.Hour   // 清理已过期登录会话的间隔
+	SessionDeviceMaxLength = 255         // 登录会话中记录的设备信息的最大长度
 )
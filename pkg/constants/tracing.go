@@ -0,0 +1,18 @@
+package constants
+
+import "time"
+
+const (
+	TracerName             = "github.com/2451965602/LMS" // 创建 span 时使用的 tracer 名称
+	TraceIDHeader          = "Trace-Id"                  // 在响应中返回追踪ID的请求头名称
+	TracingShutdownTimeout = 5 * time.Second             // 服务退出时导出剩余 span 的超时时间
+	TracingExporterNone    = "none"                      // 不导出追踪数据
+	TracingExporterStdout  = "stdout"                    // 将追踪数据输出到标准输出
+	TracingExporterOTLP    = "otlp"                      // 通过 OTLP/HTTP 导出追踪数据
+	TracingDefaultService  = "LMS"                       // 未配置服务名称时使用的默认值
+
+	RequestIDHeader    = "X-Request-Id" // 传入和返回请求ID的请求头名称
+	RequestIDKey       = "request_id"   // 在请求上下文中存储请求ID的键名
+	RequestIDLength    = 16             // 生成请求ID的随机字节数
+	RequestIDMaxLength = 64             // 沿用客户端传入的请求ID时允许的最大长度
+)
@@ -1,5 +1,28 @@
 package constants
 
+import "time"
+
+const (
+	LoginDataKey              = "loginData"                 // 定义了上下文中存储用户登录数据的键名
+	MustChangePasswordKey     = "must_change_password"      // 上下文中存储用户是否必须修改密码的键名
+	TwoFactorSetupRequiredKey = "two_factor_setup_required" // 上下文中存储用户是否必须先启用两步验证的键名
+)
+
+const (
+	LoginAttemptUserPrefix = "user:" // 按用户名统计登录失败次数时使用的键前缀
+	LoginAttemptIPPrefix   = "ip:"   // 按 IP 统计登录失败次数时使用的键前缀
+
+	LoginAttemptStoreMemory = "memory" // 登录失败记录保存在进程内存中
+	LoginAttemptStoreMySQL  = "mysql"  // 登录失败记录保存在 MySQL 中，供多个实例共享
+
+	AuditActionLoginLockout = "login_lockout" // 审计日志动作：登录失败次数过多被锁定
+)
+
+const (
+	PasswordResetSendWindow  = 24 * time.Hour                          // 统计找回密码验证码发送次数的时间窗口，过期的验证码在窗口结束后删除
+	PasswordResetCodeMessage = "您的找回密码验证码为 %s，%d 分钟内有效。如非本人操作，请忽略本短信。" // 找回密码短信内容
+)
+
 const (
-	LoginDataKey = "loginData" // 定义了上下文中存储用户登录数据的键名
+	RecoveryCodeBytes = 5 // 恢复码的随机字节数，十六进制编码后为 10 个字符
 )
@@ -1,6 +1,11 @@
 package crypt
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"math/big"
+
 	"golang.org/x/crypto/bcrypt"
 
 	"github.com/2451965602/LMS/pkg/errno"
@@ -39,3 +44,43 @@ func PasswordVerify(pwd, hash string) bool {
 	// 如果没有错误，说明密码匹配
 	return err == nil
 }
+
+// RandomToken 生成指定字节数的随机值，并以十六进制字符串返回
+// 参数：
+//   - n: 随机字节数
+//
+// 返回值：
+//   - string: 十六进制编码的随机值，长度为 2n
+//   - error: 错误信息，如果读取随机数失败会返回错误
+func RandomToken(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", errno.Errorf(errno.InternalServiceErrorCode, "generate random token failed: %v", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// RandomDigits 生成指定位数的随机数字串，用于短信验证码
+// 参数：
+//   - n: 位数
+//
+// 返回值：
+//   - string: 随机数字串，可能以 0 开头
+//   - error: 错误信息，如果读取随机数失败会返回错误
+func RandomDigits(n int) (string, error) {
+	digits := make([]byte, n)
+	for i := range digits {
+		d, err := rand.Int(rand.Reader, big.NewInt(10))
+		if err != nil {
+			return "", errno.Errorf(errno.InternalServiceErrorCode, "generate random digits failed: %v", err)
+		}
+		digits[i] = byte('0' + d.Int64())
+	}
+	return string(digits), nil
+}
+
+// TokenHash 计算一次性凭证的 SHA-256 摘要，数据库中只保存摘要而不保存明文
+func TokenHash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -22,6 +22,7 @@ const (
 	AuthNoTokenCode                            // 没有 token
 	AuthNoOperatePermissionCode                // 没有操作权限
 	IllegalOperatorCode                        // 不合格的操作(比如传入 payment status时传入了一个不存在的 status)
+	AuthRefreshReusedCode                      // 刷新令牌被重复使用，所属会话已被吊销
 )
 
 // 500xx: 内部错误，Internal 打头
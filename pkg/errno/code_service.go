@@ -1,6 +1,7 @@
 package errno
 
 // 业务强相关, 范围是 1000-9999
+// 客户端按数值匹配错误码，新的错误码只能追加在末尾，不能插入到已有错误码之间
 const (
 	ServiceUserExist = 1000 + iota
 	ServiceUserNotExist
@@ -21,4 +22,45 @@ const (
 	ServiceBorrowRecordNotExist
 
 	ServiceActionNotAllowed
+
+	ServiceBorrowNumOver
+
+	ServiceSessionNotExist
+	ServiceLoginThrottled
+	ServiceLoginLocked
+	ServicePasswordChangeRequired
+	ServiceWeakPassword
+	ServicePasswordMismatch
+	ServiceResetCodeInvalid
+	ServiceResetCodeTooFrequent
+
+	ServiceTwoFactorInvalid
+	ServiceTwoFactorNotEnabled
+	ServiceTwoFactorAlreadyEnabled
+	ServiceTwoFactorRequired
+
+	ServiceSSODisabled
+	ServiceIdentityLinked
+
+	ServiceInvalidEmail
+	ServiceInvalidPatronType
+
+	ServiceIllegalStatusTransition
+	ServiceIdempotencyKeyReused
+	ServiceVersionConflict
+
+	ServiceContributorNotExist
+	ServiceSubjectNotExist
+	ServiceSeriesNotExist
+	ServiceCatalogEntryExist
+	ServiceCatalogEntryInUse
+
+	ServiceCategoryNotExist
+	ServiceCategoryExist
+	ServiceCategoryInUse
+	ServiceCategoryCycle
+
+	ServiceAttachmentNotExist
+	ServiceAttachmentTooLarge
+	ServiceAttachmentTypeNotAllowed
 )
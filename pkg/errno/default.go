@@ -13,3 +13,10 @@ var (
 
 	InternalServiceError = NewErrNo(InternalServiceErrorCode, "internal server error")
 )
+
+// publicMessages 内部错误码对外的固定说明，不包含任何内部细节
+var publicMessages = map[int64]string{
+	InternalServiceErrorCode:       "internal server error",
+	InternalDatabaseErrorCode:      "database error",
+	InternalPasswordCryptErrorCode: "password encryption failed",
+}
@@ -3,11 +3,31 @@ package errno
 import (
 	"errors"
 	"fmt"
+	"runtime"
+	"strings"
 )
 
+// stackDepth 内部错误记录的最大调用栈深度
+const stackDepth = 32
+
+// ErrNo 业务错误
+// 内部错误（500xx）的 ErrorMsg 只是固定的公开说明，实际原因保存在 cause 中，只写入日志，不返回给客户端。
 type ErrNo struct {
 	ErrorCode int64
 	ErrorMsg  string
+	Details   []FieldError // 逐个字段的错误说明，只在参数校验失败时设置
+
+	cause error     // 内部错误的实际原因
+	stack []uintptr // 生成内部错误时的调用栈
+}
+
+// FieldError 单个请求字段的校验错误
+type FieldError struct {
+	Field     string            // 请求中的字段名
+	Rule      string            // 未通过的规则，如 required、pattern、phone，客户端和本地化按此区分错误类型
+	ErrorCode int64             // 该字段的错误码
+	ErrorMsg  string            // 该字段的错误说明
+	Params    map[string]string // 规则的参数，如 pattern 规则的格式说明，用于生成本地化的错误说明
 }
 
 func NewErrNo(code int64, msg string) ErrNo {
@@ -19,30 +39,65 @@ func NewErrNo(code int64, msg string) ErrNo {
 
 func (e ErrNo) Error() string { return e.ErrorMsg }
 
+// NewErrNoWithStack 生成记录了调用栈的错误
+// 内部错误码的 msg 作为内部原因，对外的说明为该错误码的固定说明。
 func NewErrNoWithStack(code int64, msg string) ErrNo {
+	if IsInternal(code) {
+		return internal(code, errors.New(msg))
+	}
 	return ErrNo{
 		ErrorCode: code,
 		ErrorMsg:  msg,
+		stack:     callers(),
 	}
 }
 
+// Errorf 按模板生成错误
+// 内部错误码生成的说明可能包含 SQL、表名或数据，只作为内部原因记录，模板中可以使用 %w 包装原始错误。
 func Errorf(code int64, template string, args ...interface{}) ErrNo {
+	if IsInternal(code) {
+		return internal(code, fmt.Errorf(template, args...))
+	}
 	return ErrNo{
 		ErrorCode: code,
 		ErrorMsg:  fmt.Sprintf(template, args...),
 	}
 }
 
+// IsInternal 判断错误码是否为内部错误（500xx）
+func IsInternal(code int64) bool {
+	return code >= InternalServiceErrorCode && code < InternalServiceErrorCode+10000
+}
+
+// PublicMessage 返回内部错误码对外的固定说明，未知的内部错误码使用 InternalServiceError 的说明
+func PublicMessage(code int64) string {
+	if msg, ok := publicMessages[code]; ok {
+		return msg
+	}
+	return InternalServiceError.ErrorMsg
+}
+
 func (e ErrNo) WithMessage(message string) ErrNo {
 	e.ErrorMsg = message
 	return e
 }
 
+// WithError 以 err 作为错误说明；内部错误以 err 作为内部原因，对外的说明不变
 func (e ErrNo) WithError(err error) ErrNo {
+	if IsInternal(e.ErrorCode) {
+		e.cause = err
+		e.stack = callers()
+		return e
+	}
 	e.ErrorMsg = err.Error()
 	return e
 }
 
+func (e ErrNo) WithDetails(details ...FieldError) ErrNo {
+	e.Details = append(e.Details[:len(e.Details):len(e.Details)], details...)
+	return e
+}
+
 func ConvertErr(err error) ErrNo {
 	if err == nil {
 		return Success
@@ -51,7 +106,61 @@ func ConvertErr(err error) ErrNo {
 	if errors.As(err, &errno) {
 		return errno
 	}
-	s := InternalServiceError
-	s.ErrorMsg = err.Error()
-	return s
+	return internal(InternalServiceErrorCode, err)
+}
+
+// Cause 返回内部错误的实际原因，非内部错误返回 nil
+func (e ErrNo) Cause() error { return e.cause }
+
+// Unwrap 使 errors.Is 和 errors.As 可以检查内部原因
+func (e ErrNo) Unwrap() error { return e.cause }
+
+// InternalMessage 返回用于日志的错误说明，内部错误为实际原因，其他错误与 ErrorMsg 相同
+func (e ErrNo) InternalMessage() string {
+	if e.cause != nil {
+		return e.cause.Error()
+	}
+	return e.ErrorMsg
+}
+
+// Stack 返回生成错误时的调用栈，每行一个函数及其位置，没有记录时返回空字符串
+func (e ErrNo) Stack() string {
+	if len(e.stack) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	frames := runtime.CallersFrames(e.stack)
+	for {
+		frame, more := frames.Next()
+		fmt.Fprintf(&b, "%s\n\t%s:%d\n", frame.Function, frame.File, frame.Line)
+		if !more {
+			break
+		}
+	}
+	return b.String()
+}
+
+// internal 生成内部错误，对外说明为错误码的固定说明
+func internal(code int64, cause error) ErrNo {
+	return ErrNo{
+		ErrorCode: code,
+		ErrorMsg:  PublicMessage(code),
+		cause:     cause,
+		stack:     callers(),
+	}
+}
+
+// callers 记录调用栈，跳过 errno 包自身的函数
+func callers() []uintptr {
+	pcs := make([]uintptr, stackDepth)
+	n := runtime.Callers(2, pcs)
+	frames := pcs[:n]
+	for len(frames) > 0 {
+		fn := runtime.FuncForPC(frames[0] - 1)
+		if fn == nil || !strings.HasPrefix(fn.Name(), "github.com/2451965602/LMS/pkg/errno.") {
+			break
+		}
+		frames = frames[1:]
+	}
+	return frames
 }
@@ -0,0 +1,39 @@
+package etag
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/2451965602/LMS/pkg/constants"
+	"github.com/2451965602/LMS/pkg/errno"
+	"github.com/2451965602/LMS/pkg/validate"
+)
+
+// Format 由数据版本生成 ETag，如 "3"
+func Format(version int64) string {
+	return strconv.Quote(strconv.FormatInt(version, 10))
+}
+
+// ParseIfMatch 解析 If-Match 请求头中期望的数据版本
+// 同时接受弱 ETag，如 W/"3"；只接受一个 ETag，一次更新只针对一条数据。
+// 参数：
+//   - header: If-Match 请求头的值
+//
+// 返回值：
+//   - *int64: 期望的数据版本，未携带或为 * 时返回 nil，表示不检查版本
+//   - error: 格式不正确时返回 errno.ParamVerifyErrorCode
+func ParseIfMatch(header string) (*int64, error) {
+	header = strings.TrimSpace(header)
+	if header == "" || header == "*" {
+		return nil, nil
+	}
+	tag := strings.TrimPrefix(header, "W/")
+	if len(tag) >= 2 && tag[0] == '"' && tag[len(tag)-1] == '"' {
+		if version, err := strconv.ParseInt(tag[1:len(tag)-1], 10, 64); err == nil && version >= 0 {
+			return &version, nil
+		}
+	}
+	description := `a single ETag returned by the read endpoint, such as "3"`
+	return nil, validate.NewFieldError(constants.IfMatchHeader, validate.RulePattern, errno.ParamVerifyErrorCode,
+		map[string]string{"description": description}, "%s must be %s", constants.IfMatchHeader, description)
+}
@@ -0,0 +1,72 @@
+package i18n
+
+import (
+	"strings"
+
+	"golang.org/x/text/language"
+
+	"github.com/2451965602/LMS/pkg/errno"
+)
+
+// Lang 响应消息使用的语言
+type Lang string
+
+const (
+	LangEN   Lang = "en"    // 英文，错误信息的原始语言
+	LangZhCN Lang = "zh-CN" // 简体中文
+)
+
+// matcher 支持的语言，第一个为无法匹配时的默认语言
+var matcher = language.NewMatcher([]language.Tag{
+	language.English,
+	language.SimplifiedChinese,
+})
+
+// FromAcceptLanguage 按请求头 Accept-Language 选择响应语言
+// 请求头为空、无法解析或不包含支持的语言时返回英文。
+func FromAcceptLanguage(header string) Lang {
+	if header == "" {
+		return LangEN
+	}
+	tags, _, err := language.ParseAcceptLanguage(header)
+	if err != nil || len(tags) == 0 {
+		return LangEN
+	}
+	_, index, confidence := matcher.Match(tags...)
+	if confidence == language.No || index != 1 {
+		return LangEN
+	}
+	return LangZhCN
+}
+
+// Message 返回错误码在指定语言下的消息
+// 英文直接使用错误本身的消息；其他语言使用错误码对应的固定消息，没有对应消息时退回 fallback。
+func Message(lang Lang, code int64, fallback string) string {
+	if lang == LangEN {
+		return fallback
+	}
+	if msg, ok := zhCNMessages[code]; ok {
+		return msg
+	}
+	return fallback
+}
+
+// FieldMessage 返回字段错误在指定语言下的说明
+// 优先按规则生成，规则没有对应模板时使用错误码对应的消息。
+func FieldMessage(lang Lang, fe errno.FieldError) string {
+	if lang == LangEN {
+		return fe.ErrorMsg
+	}
+	tmpl, ok := zhCNRules[fe.Rule]
+	if !ok {
+		return Message(lang, fe.ErrorCode, fe.ErrorMsg)
+	}
+	if len(fe.Params) == 0 {
+		return tmpl
+	}
+	pairs := make([]string, 0, len(fe.Params)*2)
+	for k, v := range fe.Params {
+		pairs = append(pairs, "{"+k+"}", v)
+	}
+	return strings.NewReplacer(pairs...).Replace(tmpl)
+}
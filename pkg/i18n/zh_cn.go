@@ -0,0 +1,104 @@
+package i18n
+
+import (
+	"github.com/2451965602/LMS/pkg/errno"
+	"github.com/2451965602/LMS/pkg/validate"
+)
+
+// zhCNMessages 错误码对应的简体中文消息
+var zhCNMessages = map[int64]string{
+	errno.SuccessCode: "成功",
+
+	errno.ParamVerifyErrorCode:   "参数校验失败",
+	errno.ParamMissingErrorCode:  "缺少参数",
+	errno.ParamMissingHeaderCode: "缺少请求头",
+	errno.ParamInvalidHeaderCode: "请求头无效",
+
+	errno.AuthInvalidCode:             "身份验证失败",
+	errno.AuthAccessExpiredCode:       "访问令牌已过期",
+	errno.AuthRefreshExpiredCode:      "刷新令牌已过期",
+	errno.AuthNoTokenCode:             "缺少令牌",
+	errno.AuthNoOperatePermissionCode: "没有操作权限",
+	errno.IllegalOperatorCode:         "不允许的操作",
+	errno.AuthRefreshReusedCode:       "刷新令牌已被使用，该会话已失效，请重新登录",
+
+	errno.InternalServiceErrorCode:       "服务器内部错误",
+	errno.InternalDatabaseErrorCode:      "数据库错误",
+	errno.InternalPasswordCryptErrorCode: "密码加密失败",
+
+	errno.ServiceUserExist:        "用户已存在",
+	errno.ServiceUserNotExist:     "用户不存在或密码错误",
+	errno.ServiceInvalidUsername:  "用户名格式不正确",
+	errno.ServiceInvalidPhone:     "手机号格式不正确",
+	errno.ServicePermissionDenied: "权限不足",
+
+	errno.ServiceInvalidISBN:      "ISBN 格式不正确",
+	errno.ServiceInvalidAuthor:    "作者格式不正确",
+	errno.ServiceBookTypeNotFound: "图书种类不存在",
+	errno.ServiceBookTypeInUse:    "图书种类仍在使用中",
+	errno.ServiceBookTypeExist:    "图书种类已存在",
+	errno.ServiceBookTypeNotExist: "图书种类不存在",
+
+	errno.ServiceBookNotExist:     "图书不存在",
+	errno.ServiceBookNotAvailable: "图书当前不可借",
+
+	errno.ServiceBorrowRecordNotExist: "借阅记录不存在",
+	errno.ServiceBorrowNumOver:        "借阅数量已达上限",
+
+	errno.ServiceActionNotAllowed: "不允许执行该操作",
+
+	errno.ServiceSessionNotExist:        "登录会话不存在",
+	errno.ServiceLoginThrottled:         "登录失败次数过多，请稍后再试",
+	errno.ServiceLoginLocked:            "账号已被暂时锁定，请稍后再试",
+	errno.ServicePasswordChangeRequired: "请先修改密码",
+	errno.ServiceWeakPassword:           "密码强度不足",
+	errno.ServicePasswordMismatch:       "当前密码不正确",
+	errno.ServiceResetCodeInvalid:       "验证码错误或已过期",
+	errno.ServiceResetCodeTooFrequent:   "验证码发送过于频繁，请稍后再试",
+
+	errno.ServiceTwoFactorInvalid:        "两步验证码错误",
+	errno.ServiceTwoFactorNotEnabled:     "未启用两步验证",
+	errno.ServiceTwoFactorAlreadyEnabled: "已启用两步验证",
+	errno.ServiceTwoFactorRequired:       "当前角色必须启用两步验证",
+
+	errno.ServiceSSODisabled:    "未启用该登录方式",
+	errno.ServiceIdentityLinked: "该外部账号已关联其他用户",
+
+	errno.ServiceInvalidEmail:      "邮箱格式不正确",
+	errno.ServiceInvalidPatronType: "读者类型不正确",
+
+	errno.ServiceIllegalStatusTransition: "不允许将副本变更为该状态",
+	errno.ServiceIdempotencyKeyReused:    "该幂等键已用于其他请求",
+	errno.ServiceVersionConflict:         "数据已被他人修改，请基于最新数据重新提交",
+
+	errno.ServiceContributorNotExist: "责任者不存在",
+	errno.ServiceSubjectNotExist:     "主题不存在",
+	errno.ServiceSeriesNotExist:      "丛书不存在",
+	errno.ServiceCatalogEntryExist:   "名称已存在",
+	errno.ServiceCatalogEntryInUse:   "仍有书籍类型关联，不能删除",
+
+	errno.ServiceCategoryNotExist: "分类不存在",
+	errno.ServiceCategoryExist:    "分类号已存在",
+	errno.ServiceCategoryInUse:    "分类下仍有下级分类或书籍类型，不能删除",
+	errno.ServiceCategoryCycle:    "不能将分类移动到自身或其下级分类之下",
+
+	errno.ServiceAttachmentNotExist:       "附件不存在",
+	errno.ServiceAttachmentTooLarge:       "附件超过大小上限",
+	errno.ServiceAttachmentTypeNotAllowed: "不支持该类型的附件",
+}
+
+// zhCNRules 字段校验规则对应的简体中文说明，{name} 会被替换为规则的参数
+var zhCNRules = map[string]string{
+	validate.RuleRequired: "此项为必填项",
+	validate.RuleType:     "类型不正确",
+	validate.RuleValidate: "取值不符合要求",
+	validate.RulePattern:  "格式应为：{description}",
+	validate.RuleEnum:     "取值应为以下之一：{allowed}",
+	validate.RulePhone:    "电话号码无效，请使用国际格式，如 +8613800000000",
+	validate.RuleMobile:   "请填写手机号",
+	validate.RuleEmail:    "邮箱格式不正确",
+	validate.RuleRange:    "取值应在 {min} 到 {max} 之间",
+	validate.RuleMin:      "取值不能小于 {min}",
+	validate.RuleCursor:   "游标无效，请从第一页重新查询",
+	validate.RuleUnique:   "不能包含重复的值：{value}",
+}
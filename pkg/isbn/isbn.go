@@ -0,0 +1,95 @@
+package isbn
+
+import "strings"
+
+// Clean 去掉 ISBN 中的连字符和空格，校验位 x 转为大写
+func Clean(s string) string {
+	s = strings.NewReplacer("-", "", " ", "").Replace(strings.TrimSpace(s))
+	return strings.ToUpper(s)
+}
+
+// Normalize 校验 ISBN-10 或 ISBN-13，返回规范的 ISBN-13，同一版本的两种形式得到相同的结果
+// 参数：
+//   - s: ISBN，可以带连字符和空格
+//
+// 返回值：
+//   - string: 不带连字符的 ISBN-13
+//   - bool: ISBN 格式或校验位不正确时返回 false
+func Normalize(s string) (string, bool) {
+	s = Clean(s)
+	switch len(s) {
+	case 10:
+		if !valid10(s) {
+			return "", false
+		}
+		prefix := "978" + s[:9]
+		return prefix + string(checkDigit13(prefix)), true
+	case 13:
+		if !valid13(s) {
+			return "", false
+		}
+		return s, true
+	default:
+		return "", false
+	}
+}
+
+// To10 返回 ISBN-13 对应的 ISBN-10，只有 978 开头的 ISBN-13 有对应的 ISBN-10
+func To10(isbn13 string) (string, bool) {
+	if len(isbn13) != 13 || !strings.HasPrefix(isbn13, "978") || !valid13(isbn13) {
+		return "", false
+	}
+	body := isbn13[3:12]
+	sum := 0
+	for i := 0; i < 9; i++ {
+		sum += int(body[i]-'0') * (10 - i)
+	}
+	check := (11 - sum%11) % 11
+	if check == 10 {
+		return body + "X", true
+	}
+	return body + string(rune('0'+check)), true
+}
+
+// valid10 检查 ISBN-10，前 9 位必须是数字，校验位可以是数字或 X
+func valid10(s string) bool {
+	sum := 0
+	for i := 0; i < 10; i++ {
+		c := s[i]
+		var digit int
+		switch {
+		case c >= '0' && c <= '9':
+			digit = int(c - '0')
+		case c == 'X' && i == 9:
+			digit = 10
+		default:
+			return false
+		}
+		sum += digit * (10 - i)
+	}
+	return sum%11 == 0
+}
+
+// valid13 检查 ISBN-13，13 位都必须是数字
+func valid13(s string) bool {
+	for i := 0; i < 13; i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return checkDigit13(s[:12]) == s[12]
+}
+
+// checkDigit13 计算 ISBN-13 前 12 位对应的校验位
+func checkDigit13(first12 string) byte {
+	sum := 0
+	for i := 0; i < 12; i++ {
+		digit := int(first12[i] - '0')
+		if i%2 == 0 {
+			sum += digit
+		} else {
+			sum += digit * 3
+		}
+	}
+	return byte('0' + (10-sum%10)%10)
+}
@@ -0,0 +1,75 @@
+package loginguard
+
+import (
+	"context"
+	"time"
+)
+
+// Attempt 某个键（用户名或 IP）的登录失败记录
+type Attempt struct {
+	Failures    int64     // 统计窗口内连续失败的次数
+	LastFailure time.Time // 最近一次失败的时间
+	LockedUntil time.Time // 锁定的截止时间，零值表示未锁定
+}
+
+// Store 登录失败记录的存储
+// 单实例部署使用 MemoryStore；多实例部署需要使用共享存储，使各实例看到相同的失败次数。
+type Store interface {
+	// Get 获取键的失败记录，不存在时返回零值
+	Get(ctx context.Context, key string) (Attempt, error)
+	// Fail 记录一次失败并返回更新后的记录，上次失败早于 now-window 时重新计数
+	Fail(ctx context.Context, key string, now time.Time, window time.Duration) (Attempt, error)
+	// Lock 锁定键直到 until，并清空失败次数
+	Lock(ctx context.Context, key string, until time.Time) error
+	// Reset 删除键的失败记录
+	Reset(ctx context.Context, key string) error
+}
+
+// Limits 单类键的限制参数
+type Limits struct {
+	MaxFailures int64         // 达到该失败次数后锁定，0 表示不锁定
+	BaseDelay   time.Duration // 第一次失败后需要等待的时间，之后每次失败翻倍
+	MaxDelay    time.Duration // 等待时间的上限
+	Lockout     time.Duration // 锁定时长
+	Window      time.Duration // 失败次数的统计窗口
+}
+
+// Wait 计算在 now 时刻还需要等待多久才能再次尝试登录
+// 返回值：
+//   - time.Duration: 需要等待的时间，0 表示可以立即尝试
+//   - bool: 是否处于锁定状态
+func (l Limits) Wait(a Attempt, now time.Time) (time.Duration, bool) {
+	if now.Before(a.LockedUntil) {
+		return a.LockedUntil.Sub(now), true
+	}
+	if a.Failures == 0 || now.Sub(a.LastFailure) > l.Window {
+		return 0, false
+	}
+	if next := a.LastFailure.Add(l.Backoff(a.Failures)); now.Before(next) {
+		return next.Sub(now), false
+	}
+	return 0, false
+}
+
+// Backoff 计算失败 failures 次后的等待时间，按 BaseDelay 指数增长并不超过 MaxDelay
+func (l Limits) Backoff(failures int64) time.Duration {
+	if failures <= 0 || l.BaseDelay <= 0 {
+		return 0
+	}
+	delay := l.BaseDelay
+	for i := int64(1); i < failures; i++ {
+		delay *= 2
+		if l.MaxDelay > 0 && delay >= l.MaxDelay {
+			return l.MaxDelay
+		}
+	}
+	if l.MaxDelay > 0 && delay > l.MaxDelay {
+		return l.MaxDelay
+	}
+	return delay
+}
+
+// ShouldLock 判断失败记录是否达到锁定条件
+func (l Limits) ShouldLock(a Attempt) bool {
+	return l.MaxFailures > 0 && a.Failures >= l.MaxFailures
+}
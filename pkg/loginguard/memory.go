@@ -0,0 +1,73 @@
+package loginguard
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// maxMemoryKeys 内存中保留的键数量上限，超过后清理已过期的记录，避免大量不同 IP 占满内存
+const maxMemoryKeys = 100000
+
+// MemoryStore 进程内的失败记录存储，只适用于单实例部署
+type MemoryStore struct {
+	mu       sync.Mutex
+	attempts map[string]Attempt
+}
+
+// NewMemoryStore 创建一个空的进程内存储
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{attempts: make(map[string]Attempt)}
+}
+
+func (s *MemoryStore) Get(_ context.Context, key string) (Attempt, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.attempts[key], nil
+}
+
+func (s *MemoryStore) Fail(_ context.Context, key string, now time.Time, window time.Duration) (Attempt, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.attempts) >= maxMemoryKeys {
+		s.prune(now, window)
+	}
+
+	a := s.attempts[key]
+	if now.Sub(a.LastFailure) > window {
+		a.Failures = 0
+	}
+	a.Failures++
+	a.LastFailure = now
+	s.attempts[key] = a
+	return a, nil
+}
+
+func (s *MemoryStore) Lock(_ context.Context, key string, until time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a := s.attempts[key]
+	a.Failures = 0
+	a.LockedUntil = until
+	s.attempts[key] = a
+	return nil
+}
+
+func (s *MemoryStore) Reset(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.attempts, key)
+	return nil
+}
+
+// prune 删除已解除锁定且超出统计窗口的记录，调用方需持有锁
+func (s *MemoryStore) prune(now time.Time, window time.Duration) {
+	for key, a := range s.attempts {
+		if now.After(a.LockedUntil) && now.Sub(a.LastFailure) > window {
+			delete(s.attempts, key)
+		}
+	}
+}
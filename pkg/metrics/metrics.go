@@ -0,0 +1,95 @@
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/2451965602/LMS/pkg/constants"
+)
+
+// Registry 服务自身的指标注册表，不使用 prometheus 的全局默认注册表
+var Registry = prometheus.NewRegistry()
+
+var (
+	// requestTotal HTTP 请求总数，按方法、路由和业务错误码区分
+	requestTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: constants.MetricsNamespace,
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "Total number of HTTP requests by method, route and errno code.",
+	}, []string{"method", "route", "code"})
+
+	// requestDuration HTTP 请求耗时分布，按方法、路由和业务错误码区分
+	requestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: constants.MetricsNamespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "HTTP request latency by method, route and errno code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "code"})
+
+	// dbQueryDuration 数据库操作耗时分布，按 DAL 函数和操作类型区分
+	dbQueryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: constants.MetricsNamespace,
+		Subsystem: "db",
+		Name:      "query_duration_seconds",
+		Help:      "Database statement latency by DAL function and operation.",
+		Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"function", "operation"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		requestTotal,
+		requestDuration,
+		dbQueryDuration,
+	)
+}
+
+// ObserveRequest 记录一次 HTTP 请求
+// 参数：
+//   - method: 请求方法
+//   - route: 注册的路由模板，未匹配到路由时为空
+//   - code: 响应中的业务错误码
+//   - duration: 请求耗时
+func ObserveRequest(method, route string, code int64, duration time.Duration) {
+	codeLabel := strconv.FormatInt(code, 10)
+	requestTotal.WithLabelValues(method, route, codeLabel).Inc()
+	requestDuration.WithLabelValues(method, route, codeLabel).Observe(duration.Seconds())
+}
+
+// ObserveDBQuery 记录一次数据库操作
+// 参数：
+//   - function: 发起操作的 DAL 函数名
+//   - operation: 操作类型，如 query、create、update
+//   - duration: 操作耗时
+func ObserveDBQuery(function, operation string, duration time.Duration) {
+	dbQueryDuration.WithLabelValues(function, operation).Observe(duration.Seconds())
+}
+
+// Handler 返回暴露 Registry 中所有指标的 HTTP 处理器
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
+
+// RegisterDBStats 注册数据库连接池统计指标
+func RegisterDBStats(db *sql.DB, dbName string) {
+	Registry.MustRegister(collectors.NewDBStatsCollector(db, dbName))
+}
+
+// RegisterGauge 注册一个在每次拉取时通过 fn 计算数值的业务指标
+func RegisterGauge(name, help string, fn func() float64) {
+	Registry.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: constants.MetricsNamespace,
+		Name:      name,
+		Help:      help,
+	}, fn))
+}
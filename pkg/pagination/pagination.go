@@ -0,0 +1,280 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/2451965602/LMS/config"
+	"github.com/2451965602/LMS/pkg/errno"
+	"github.com/2451965602/LMS/pkg/validate"
+)
+
+// MaxOffset 页码分页允许跳过的最大行数，更靠后的数据需使用游标翻页
+// 页码过大时 OFFSET 既慢又可能溢出，按每页条数换算为页码上限。
+const MaxOffset = 100000
+
+// Kind 排序字段的值类型，决定游标中的值如何还原为查询参数
+type Kind int
+
+const (
+	KindInt Kind = iota
+	KindString
+	KindTime
+)
+
+// Field 可以用于排序的字段
+type Field struct {
+	Column string // 数据库列名，需与 GORM 的列名一致，用于从查询结果中取值
+	Kind   Kind   // 值类型
+}
+
+// Spec 一个列表接口的分页规则
+type Spec struct {
+	Fields      map[string]Field // 允许客户端选择的排序字段，键为请求中使用的字段名
+	DefaultSort string           // 未指定排序时使用的排序，格式同请求中的 sort，如 -id
+	Key         Field            // 唯一键，排序值相同时按此列排序，保证翻页时不重复也不遗漏
+}
+
+// Request 请求中的分页参数
+type Request struct {
+	Cursor    *string // 上一页返回的 next_cursor
+	PageSize  *int64  // 每页数量
+	PageNum   *int64  // 页码，只为兼容旧客户端保留，传入 cursor 时忽略
+	Sort      *string // 排序字段，前缀 - 表示降序，如 -checkout_date
+	WithTotal *bool   // 是否返回总数，总数需要额外的 COUNT 查询
+}
+
+// Page 解析后的分页参数
+type Page struct {
+	Size      int       // 每页数量
+	Offset    int       // 按页码分页时跳过的记录数
+	Sort      string    // 规范化后的排序，写入游标，翻页时需保持一致
+	Column    string    // 排序列
+	Desc      bool      // 是否降序
+	Key       Field     // 唯一键
+	After     *Position // 游标指向的记录，为空时从第一条开始
+	WithTotal bool      // 是否查询总数
+
+	kind Kind // 排序列的值类型
+}
+
+// Position 游标指向的记录，下一页从这条记录之后开始
+type Position struct {
+	Value interface{} // 排序列的值
+	Key   interface{} // 唯一键的值
+}
+
+// Result 一页查询结果的分页信息
+type Result struct {
+	NextCursor string // 下一页的游标，没有下一页时为空
+	Total      *int64 // 符合条件的总数，只在请求需要时查询
+}
+
+// token 游标编码前的内容，对客户端不透明
+type token struct {
+	Sort  string `json:"s"`
+	Value string `json:"v"`
+	Key   string `json:"k"`
+}
+
+// Parse 按接口的分页规则解析请求中的分页参数
+// 参数：
+//   - spec: 接口的分页规则
+//   - req: 请求中的分页参数
+//
+// 返回值：
+//   - *Page: 解析后的分页参数
+//   - error: 排序字段不在白名单中、每页数量或页码小于 1、游标无效时返回 errno.ParamVerifyErrorCode
+func Parse(spec Spec, req Request) (*Page, error) {
+	size, err := parseSize(req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	page := &Page{
+		Size: size,
+		Key:  spec.Key,
+	}
+
+	var cursor *token
+	if req.Cursor != nil && *req.Cursor != "" {
+		t, err := decode(*req.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		cursor = t
+	}
+
+	// 翻页时可以只传游标，沿用生成游标时的排序
+	sortBy := spec.DefaultSort
+	switch {
+	case req.Sort != nil && *req.Sort != "":
+		sortBy = *req.Sort
+	case cursor != nil:
+		sortBy = cursor.Sort
+	}
+	field, desc, err := spec.lookup(sortBy)
+	if err != nil {
+		return nil, err
+	}
+	page.Column, page.Desc, page.kind = field.Column, desc, field.Kind
+	page.Sort = strings.TrimPrefix(sortBy, "-")
+	if desc {
+		page.Sort = "-" + page.Sort
+	}
+
+	if cursor != nil {
+		if cursor.Sort != page.Sort {
+			return nil, invalidCursor("cursor was issued for sort %s, not %s", cursor.Sort, page.Sort)
+		}
+		value, err := parseValue(cursor.Value, page.kind)
+		if err != nil {
+			return nil, invalidCursor("invalid cursor")
+		}
+		key, err := parseValue(cursor.Key, spec.Key.Kind)
+		if err != nil {
+			return nil, invalidCursor("invalid cursor")
+		}
+		page.After = &Position{Value: value, Key: key}
+	} else if req.PageNum != nil {
+		if page.Offset, err = parseOffset(*req.PageNum, page.Size); err != nil {
+			return nil, err
+		}
+	}
+
+	// 按页码分页的旧客户端依赖总数计算页数，继续返回总数
+	page.WithTotal = (req.WithTotal != nil && *req.WithTotal) || (cursor == nil && req.PageNum != nil)
+	return page, nil
+}
+
+// ParseOffset 解析只支持按页码分页的接口的分页参数
+// 参数：
+//   - pageSize: 每页数量，为 nil 时使用默认值，超过上限时按上限返回
+//   - pageNum: 页码，从 1 开始
+//
+// 返回值：
+//   - int: 每页数量
+//   - int: 跳过的记录数
+//   - error: 每页数量或页码小于 1 时返回 errno.ParamVerifyErrorCode
+func ParseOffset(pageSize *int64, pageNum int64) (int, int, error) {
+	size, err := parseSize(pageSize)
+	if err != nil {
+		return 0, 0, err
+	}
+	offset, err := parseOffset(pageNum, size)
+	if err != nil {
+		return 0, 0, err
+	}
+	return size, offset, nil
+}
+
+// parseSize 解析每页数量，未指定时使用默认值，超过上限时按上限返回
+func parseSize(pageSize *int64) (int, error) {
+	size := int(config.Pagination.DefaultPageSize)
+	if pageSize != nil {
+		if *pageSize < 1 {
+			return 0, validate.NewFieldError("page_size", validate.RuleRange, errno.ParamVerifyErrorCode,
+				map[string]string{"min": "1", "max": strconv.FormatInt(config.Pagination.MaxPageSize, 10)},
+				"page_size must be between 1 and %d", config.Pagination.MaxPageSize)
+		}
+		size = int(*pageSize)
+	}
+	if max := int(config.Pagination.MaxPageSize); max > 0 && size > max {
+		size = max
+	}
+	if size < 1 {
+		size = 1
+	}
+	return size, nil
+}
+
+// parseOffset 将页码转换为跳过的记录数，页码小于 1 或跳过的记录数超过 MaxOffset 时返回错误
+func parseOffset(pageNum int64, size int) (int, error) {
+	maxPage := int64(MaxOffset/size) + 1
+	if pageNum < 1 || pageNum > maxPage {
+		return 0, validate.NewFieldError("page_num", validate.RuleRange, errno.ParamVerifyErrorCode,
+			map[string]string{"min": "1", "max": strconv.FormatInt(maxPage, 10)},
+			"page_num must be between 1 and %d, use cursor for deeper pages", maxPage)
+	}
+	return int(pageNum-1) * size, nil
+}
+
+// Cursor 生成指向指定记录的游标，下一页从这条记录之后开始
+// 参数：
+//   - value: 该记录排序列的值
+//   - key: 该记录唯一键的值
+func (p *Page) Cursor(value, key interface{}) string {
+	b, _ := json.Marshal(token{
+		Sort:  p.Sort,
+		Value: formatValue(value),
+		Key:   formatValue(key),
+	})
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// lookup 查找排序字段，返回字段和是否降序
+func (s Spec) lookup(sortBy string) (Field, bool, error) {
+	desc := strings.HasPrefix(sortBy, "-")
+	name := strings.TrimPrefix(sortBy, "-")
+	if field, ok := s.Fields[name]; ok {
+		return field, desc, nil
+	}
+
+	names := make([]string, 0, len(s.Fields))
+	for n := range s.Fields {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	allowed := strings.Join(names, ", ")
+	return Field{}, false, validate.NewFieldError("sort", validate.RuleEnum, errno.ParamVerifyErrorCode,
+		map[string]string{"allowed": allowed}, "sort must be one of %s, prefixed with - for descending order", allowed)
+}
+
+// decode 解码游标
+func decode(cursor string) (*token, error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, invalidCursor("invalid cursor")
+	}
+	var t token
+	if err = json.Unmarshal(b, &t); err != nil || t.Sort == "" {
+		return nil, invalidCursor("invalid cursor")
+	}
+	return &t, nil
+}
+
+// formatValue 将排序值转换为游标中的字符串，时间统一使用 UTC
+func formatValue(v interface{}) string {
+	switch v := v.(type) {
+	case time.Time:
+		return v.UTC().Format(time.RFC3339Nano)
+	case *time.Time:
+		if v == nil {
+			return ""
+		}
+		return v.UTC().Format(time.RFC3339Nano)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// parseValue 将游标中的字符串还原为查询参数
+func parseValue(s string, kind Kind) (interface{}, error) {
+	switch kind {
+	case KindInt:
+		return strconv.ParseInt(s, 10, 64)
+	case KindTime:
+		return time.Parse(time.RFC3339Nano, s)
+	default:
+		return s, nil
+	}
+}
+
+// invalidCursor 生成游标无效的错误
+func invalidCursor(format string, args ...interface{}) error {
+	return validate.NewFieldError("cursor", validate.RuleCursor, errno.ParamVerifyErrorCode, nil, format, args...)
+}
@@ -0,0 +1,100 @@
+package password
+
+import (
+	"bufio"
+	_ "embed"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/2451965602/LMS/pkg/errno"
+)
+
+// commonPasswordList 常见及已泄露的弱密码，每行一个，比较时忽略大小写
+//
+//go:embed common_passwords.txt
+var commonPasswordList string
+
+var (
+	commonOnce      sync.Once
+	commonPasswords map[string]struct{}
+)
+
+// Policy 密码强度策略
+type Policy struct {
+	MinLength  int // 最小长度（字符数）
+	MaxLength  int // 最大长度（字节数），bcrypt 只使用前 72 字节，超出部分不会参与校验
+	MinClasses int // 至少包含的字符种类数：小写字母、大写字母、数字、其他符号
+}
+
+// Check 检查密码是否符合策略
+// 参数：
+//   - password: 明文密码
+//   - username: 用户名，密码不能与用户名相同或包含用户名
+//
+// 返回值：
+//   - error: 不符合策略时返回 errno.ServiceWeakPassword，错误信息说明具体原因
+func (p Policy) Check(password, username string) error {
+	if n := utf8.RuneCountInString(password); n < p.MinLength {
+		return errno.Errorf(errno.ServiceWeakPassword, "password must be at least %d characters", p.MinLength)
+	}
+	if p.MaxLength > 0 && len(password) > p.MaxLength {
+		return errno.Errorf(errno.ServiceWeakPassword, "password must be at most %d bytes", p.MaxLength)
+	}
+	if classes := characterClasses(password); classes < p.MinClasses {
+		return errno.Errorf(errno.ServiceWeakPassword,
+			"password must contain at least %d of lowercase letters, uppercase letters, digits and symbols", p.MinClasses)
+	}
+
+	lower := strings.ToLower(password)
+	if username != "" && strings.Contains(lower, strings.ToLower(username)) {
+		return errno.Errorf(errno.ServiceWeakPassword, "password must not contain the username")
+	}
+	if IsCommon(password) {
+		return errno.Errorf(errno.ServiceWeakPassword, "password is too common")
+	}
+	return nil
+}
+
+// IsCommon 判断密码是否在内置的常见密码列表中，比较时忽略大小写
+func IsCommon(password string) bool {
+	commonOnce.Do(loadCommonPasswords)
+	_, ok := commonPasswords[strings.ToLower(password)]
+	return ok
+}
+
+// loadCommonPasswords 解析内置的常见密码列表
+func loadCommonPasswords() {
+	commonPasswords = make(map[string]struct{})
+	scanner := bufio.NewScanner(strings.NewReader(commonPasswordList))
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			commonPasswords[strings.ToLower(line)] = struct{}{}
+		}
+	}
+}
+
+// characterClasses 统计密码包含的字符种类数
+func characterClasses(password string) int {
+	var lower, upper, digit, other bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			other = true
+		}
+	}
+	count := 0
+	for _, ok := range []bool{lower, upper, digit, other} {
+		if ok {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,15 @@
+package sms
+
+import (
+	"context"
+
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+)
+
+// LogSender 将短信内容输出到日志而不真正发送，只应在开发和测试环境使用
+type LogSender struct{}
+
+func (LogSender) Send(ctx context.Context, phone, message string) error {
+	hlog.CtxWarnf(ctx, "sms.LogSender: to=%s message=%q", phone, message)
+	return nil
+}
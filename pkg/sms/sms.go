@@ -0,0 +1,47 @@
+package sms
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const (
+	KindLog     = "log"     // 只输出日志的发送器
+	KindWebhook = "webhook" // 通过 HTTP 转发到短信网关的发送器
+)
+
+// Sender 短信发送接口，不同的短信服务商实现该接口即可接入
+type Sender interface {
+	// Send 向手机号发送一条短信
+	Send(ctx context.Context, phone, message string) error
+}
+
+// Options 创建短信发送器所需的参数
+type Options struct {
+	Endpoint string        // webhook 发送器的地址
+	Token    string        // webhook 发送器在 Authorization 头中携带的令牌
+	Timeout  time.Duration // webhook 请求的超时时间
+}
+
+// New 根据类型创建短信发送器
+// 参数：
+//   - kind: 发送器类型，log 只输出日志（用于开发和测试），webhook 将短信内容转发到短信网关
+//   - opts: 发送器参数
+//
+// 返回值：
+//   - Sender: 短信发送器
+//   - error: 错误信息，类型未知或参数缺失时返回错误
+func New(kind string, opts Options) (Sender, error) {
+	switch kind {
+	case KindLog, "":
+		return LogSender{}, nil
+	case KindWebhook:
+		if opts.Endpoint == "" {
+			return nil, fmt.Errorf("sms.New: webhook sender requires an endpoint")
+		}
+		return NewWebhookSender(opts.Endpoint, opts.Token, opts.Timeout), nil
+	default:
+		return nil, fmt.Errorf("sms.New: unknown sender %q", kind)
+	}
+}
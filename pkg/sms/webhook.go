@@ -0,0 +1,52 @@
+package sms
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// WebhookSender 将短信以 JSON 形式 POST 到短信网关，由网关对接具体的短信服务商
+// 请求体为 {"phone": "...", "message": "..."}，网关返回 2xx 视为发送成功。
+type WebhookSender struct {
+	endpoint string
+	token    string
+	client   *http.Client
+}
+
+// NewWebhookSender 创建 webhook 发送器，token 非空时以 Bearer 方式放入 Authorization 头
+func NewWebhookSender(endpoint, token string, timeout time.Duration) *WebhookSender {
+	return &WebhookSender{
+		endpoint: endpoint,
+		token:    token,
+		client:   &http.Client{Timeout: timeout},
+	}
+}
+
+func (s *WebhookSender) Send(ctx context.Context, phone, message string) error {
+	body, err := json.Marshal(map[string]string{"phone": phone, "message": message})
+	if err != nil {
+		return fmt.Errorf("sms.WebhookSender: marshal request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("sms.WebhookSender: create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("sms.WebhookSender: send request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("sms.WebhookSender: unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
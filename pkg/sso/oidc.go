@@ -0,0 +1,155 @@
+package sso
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+)
+
+// OIDCOptions OIDC 授权码登录的参数
+type OIDCOptions struct {
+	Issuer        string   // 身份提供方的 Issuer 地址，用于获取 /.well-known/openid-configuration
+	ClientID      string   // 客户端ID
+	ClientSecret  string   // 客户端密钥
+	RedirectURL   string   // 回调地址，需在身份提供方中登记
+	Scopes        []string // 额外申请的 scope，openid 会自动加入
+	UsernameClaim string   // 作为本地用户名的声明，如 preferred_username
+	PhoneClaim    string   // 手机号声明，如 phone_number
+	GroupsClaim   string   // 用户所属组的声明，如 groups
+}
+
+// OIDCProvider 使用授权码流程登录的身份提供方
+// 身份提供方的元数据在第一次使用时获取，获取失败时下次使用会重试。
+type OIDCProvider struct {
+	opts OIDCOptions
+
+	mu       sync.Mutex
+	provider *oidc.Provider
+}
+
+// NewOIDCProvider 创建 OIDC 身份提供方
+func NewOIDCProvider(opts OIDCOptions) *OIDCProvider {
+	return &OIDCProvider{opts: opts}
+}
+
+// AuthCodeURL 生成跳转到身份提供方登录页的地址
+// 参数：
+//   - ctx: 上下文
+//   - state: 回调时原样返回的防伪参数
+//   - nonce: 写入 ID Token 的随机值，回调时校验以防止重放
+//
+// 返回值：
+//   - string: 登录页地址
+//   - error: 错误信息，如果获取身份提供方元数据失败会返回错误
+func (p *OIDCProvider) AuthCodeURL(ctx context.Context, state, nonce string) (string, error) {
+	cfg, _, err := p.config(ctx)
+	if err != nil {
+		return "", err
+	}
+	return cfg.AuthCodeURL(state, oidc.Nonce(nonce)), nil
+}
+
+// Exchange 使用回调中的授权码换取 ID Token 并解析用户信息
+// 参数：
+//   - ctx: 上下文
+//   - code: 回调中的授权码
+//   - nonce: 生成登录页地址时使用的 nonce
+//
+// 返回值：
+//   - *Identity: 用户信息
+//   - error: 错误信息，如果授权码无效、ID Token 校验失败或 nonce 不一致会返回错误
+func (p *OIDCProvider) Exchange(ctx context.Context, code, nonce string) (*Identity, error) {
+	cfg, provider, err := p.config(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := cfg.Exchange(ctx, code)
+	if err != nil {
+		return nil, fmt.Errorf("sso.OIDCProvider: exchange code: %w", err)
+	}
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		return nil, errors.New("sso.OIDCProvider: token response has no id_token")
+	}
+	idToken, err := provider.Verifier(&oidc.Config{ClientID: p.opts.ClientID}).Verify(ctx, rawIDToken)
+	if err != nil {
+		return nil, fmt.Errorf("sso.OIDCProvider: verify id token: %w", err)
+	}
+	if idToken.Nonce != nonce {
+		return nil, errors.New("sso.OIDCProvider: nonce mismatch")
+	}
+
+	var claims map[string]interface{}
+	if err = idToken.Claims(&claims); err != nil {
+		return nil, fmt.Errorf("sso.OIDCProvider: parse claims: %w", err)
+	}
+	identity := &Identity{
+		Provider: ProviderOIDC,
+		Subject:  idToken.Subject,
+		Username: stringClaim(claims, p.opts.UsernameClaim),
+		Phone:    stringClaim(claims, p.opts.PhoneClaim),
+		Groups:   stringsClaim(claims, p.opts.GroupsClaim),
+	}
+	if identity.Username == "" {
+		identity.Username = idToken.Subject
+	}
+	return identity, nil
+}
+
+// config 获取身份提供方元数据并生成 OAuth2 配置
+func (p *OIDCProvider) config(ctx context.Context) (*oauth2.Config, *oidc.Provider, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.provider == nil {
+		provider, err := oidc.NewProvider(ctx, p.opts.Issuer)
+		if err != nil {
+			return nil, nil, fmt.Errorf("sso.OIDCProvider: discover %s: %w", p.opts.Issuer, err)
+		}
+		p.provider = provider
+	}
+
+	scopes := append([]string{oidc.ScopeOpenID}, p.opts.Scopes...)
+	return &oauth2.Config{
+		ClientID:     p.opts.ClientID,
+		ClientSecret: p.opts.ClientSecret,
+		RedirectURL:  p.opts.RedirectURL,
+		Endpoint:     p.provider.Endpoint(),
+		Scopes:       scopes,
+	}, p.provider, nil
+}
+
+// stringClaim 读取字符串类型的声明，不存在或类型不符时返回空字符串
+func stringClaim(claims map[string]interface{}, name string) string {
+	if name == "" {
+		return ""
+	}
+	v, _ := claims[name].(string)
+	return v
+}
+
+// stringsClaim 读取字符串数组类型的声明，单个字符串视为只有一个元素的数组
+func stringsClaim(claims map[string]interface{}, name string) []string {
+	if name == "" {
+		return nil
+	}
+	switch v := claims[name].(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,29 @@
+package sso
+
+import (
+	"context"
+	"errors"
+)
+
+const (
+	ProviderLDAP = "ldap" // LDAP 绑定认证
+	ProviderOIDC = "oidc" // OIDC 授权码登录
+)
+
+// ErrInvalidCredentials 用户名或密码错误，或身份提供方拒绝了本次认证
+var ErrInvalidCredentials = errors.New("sso: invalid credentials")
+
+// Identity 身份提供方认证通过后返回的用户信息
+type Identity struct {
+	Provider string   // 身份提供方，ProviderLDAP 或 ProviderOIDC
+	Subject  string   // 用户在身份提供方中不变的唯一标识，用于关联本地账号
+	Username string   // 建议使用的本地用户名
+	Phone    string   // 手机号，身份提供方未提供时为空
+	Groups   []string // 用户所属的组，用于映射本地权限
+}
+
+// PasswordAuthenticator 使用用户名和密码认证的身份提供方
+type PasswordAuthenticator interface {
+	// Authenticate 校验用户名和密码，密码错误时返回 ErrInvalidCredentials
+	Authenticate(ctx context.Context, username, password string) (*Identity, error)
+}
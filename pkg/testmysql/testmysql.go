@@ -0,0 +1,78 @@
+// Package testmysql 为需要 MySQL 的测试准备临时数据库
+package testmysql
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+
+	"github.com/2451965602/LMS/config"
+)
+
+// DSNEnv 测试用 MySQL 的 DSN，如 root:root@tcp(127.0.0.1:3306)/，未设置时跳过需要数据库的测试
+const DSNEnv = "LMS_TEST_MYSQL_DSN"
+
+// ErrNotConfigured 未设置 DSNEnv，需要数据库的测试应当跳过
+var ErrNotConfigured = errors.New(DSNEnv + " not set")
+
+// Open 新建临时数据库，并以默认配置初始化 config，数据库配置指向该临时数据库
+// 一个测试进程只调用一次，一般在 TestMain 中调用，之后由调用方初始化数据访问层。
+// 返回值：
+//   - func(): 删除临时数据库和生成的配置文件，在全部测试结束后调用
+//   - error: 未设置 DSNEnv 时返回 ErrNotConfigured，连接或建库失败时返回错误
+func Open() (func(), error) {
+	dsn := os.Getenv(DSNEnv)
+	if dsn == "" {
+		return nil, ErrNotConfigured
+	}
+	cfg, err := mysql.ParseDSN(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", DSNEnv, err)
+	}
+	sqlDB, err := sql.Open("mysql", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("open test mysql: %w", err)
+	}
+	database := fmt.Sprintf("lms_test_%d", time.Now().UnixNano())
+	if _, err = sqlDB.Exec("CREATE DATABASE " + database + " CHARACTER SET utf8mb4"); err != nil {
+		sqlDB.Close()
+		return nil, fmt.Errorf("create database %s: %w", database, err)
+	}
+	dir, err := os.MkdirTemp("", "lms-test")
+	if err != nil {
+		sqlDB.Close()
+		return nil, err
+	}
+	cleanup := func() {
+		if _, err := sqlDB.Exec("DROP DATABASE " + database); err != nil {
+			fmt.Fprintf(os.Stderr, "testmysql: drop database %s: %v\n", database, err)
+		}
+		sqlDB.Close()
+		os.RemoveAll(dir)
+	}
+
+	// 配置文件不存在时 config.Init 会在工作目录下生成默认配置
+	wd, err := os.Getwd()
+	if err != nil {
+		cleanup()
+		return nil, err
+	}
+	if err = os.Chdir(dir); err != nil {
+		cleanup()
+		return nil, err
+	}
+	config.Init()
+	if err = os.Chdir(wd); err != nil {
+		cleanup()
+		return nil, err
+	}
+	config.Mysql.Addr = cfg.Addr
+	config.Mysql.Username = cfg.User
+	config.Mysql.Password = cfg.Passwd
+	config.Mysql.Database = database
+	return cleanup, nil
+}
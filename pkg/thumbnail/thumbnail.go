@@ -0,0 +1,99 @@
+package thumbnail
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif" // 注册 GIF 解码器
+	"image/jpeg"
+	_ "image/png" // 注册 PNG 解码器
+)
+
+// ErrTooLarge 图片的像素数超过上限，避免解码体积很小但尺寸极大的图片时耗尽内存
+var ErrTooLarge = errors.New("thumbnail: image too large")
+
+// Quality 缩略图的 JPEG 质量
+const Quality = 85
+
+// DecodeConfig 只读取图片头部，返回格式和尺寸
+func DecodeConfig(data []byte) (format string, width, height int, err error) {
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return "", 0, 0, fmt.Errorf("thumbnail: decode config: %w", err)
+	}
+	return format, cfg.Width, cfg.Height, nil
+}
+
+// Make 只使用标准库生成缩略图，支持 JPEG、PNG 和 GIF，按比例缩小到宽高都不超过 maxSize，不放大较小的图片，结果编码为 JPEG
+// 参数：
+//   - data: 原图内容
+//   - maxSize: 缩略图的最大边长
+//   - maxPixels: 允许解码的最大像素数，超过时返回 ErrTooLarge
+//
+// 返回值：
+//   - []byte: JPEG 格式的缩略图
+//   - error: 错误信息，图片无法解码或超过像素上限时返回错误
+func Make(data []byte, maxSize, maxPixels int) ([]byte, error) {
+	_, width, height, err := DecodeConfig(data)
+	if err != nil {
+		return nil, err
+	}
+	if width <= 0 || height <= 0 || width*height > maxPixels {
+		return nil, ErrTooLarge
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("thumbnail: decode: %w", err)
+	}
+	dst := resize(src, maxSize)
+
+	var buf bytes.Buffer
+	if err = jpeg.Encode(&buf, dst, &jpeg.Options{Quality: Quality}); err != nil {
+		return nil, fmt.Errorf("thumbnail: encode: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// resize 以区域平均的方式缩小图片，透明部分以白色填充
+// 目标图中的每个像素取原图中对应矩形区域内所有像素的平均值，缩小倍数较大时也不会出现明显的锯齿。
+func resize(src image.Image, maxSize int) *image.RGBA {
+	b := src.Bounds()
+	sw, sh := b.Dx(), b.Dy()
+	dw, dh := sw, sh
+	if sw > maxSize || sh > maxSize {
+		if sw >= sh {
+			dw, dh = maxSize, max(1, sh*maxSize/sw)
+		} else {
+			dw, dh = max(1, sw*maxSize/sh), maxSize
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < dh; y++ {
+		y0, y1 := b.Min.Y+y*sh/dh, b.Min.Y+max((y+1)*sh/dh, y*sh/dh+1)
+		for x := 0; x < dw; x++ {
+			x0, x1 := b.Min.X+x*sw/dw, b.Min.X+max((x+1)*sw/dw, x*sw/dw+1)
+			var r, g, bl, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					// 预乘 alpha 的颜色叠加到白色背景上
+					r += uint64(cr + 0xffff - ca)
+					g += uint64(cg + 0xffff - ca)
+					bl += uint64(cb + 0xffff - ca)
+					n++
+				}
+			}
+			dst.SetRGBA(x, y, color.RGBA{
+				R: uint8(r / n >> 8),
+				G: uint8(g / n >> 8),
+				B: uint8(bl / n >> 8),
+				A: 0xff,
+			})
+		}
+	}
+	return dst
+}
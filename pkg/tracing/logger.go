@@ -0,0 +1,65 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// logger 在 hlog 的 Ctx 系列方法输出前附加追踪ID和 span ID，其余方法沿用原日志实现
+type logger struct {
+	hlog.FullLogger
+}
+
+// NewLogger 包装已有的日志实现，使 hlog.CtxXxx 输出的日志带有 trace_id 和 span_id
+func NewLogger(l hlog.FullLogger) hlog.FullLogger {
+	return &logger{FullLogger: l}
+}
+
+// withTrace 在格式串前附加追踪信息，ctx 中没有 span 时原样返回
+func withTrace(ctx context.Context, format string, v []interface{}) (string, []interface{}) {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return format, v
+	}
+	args := make([]interface{}, 0, len(v)+2)
+	args = append(args, sc.TraceID().String(), sc.SpanID().String())
+	args = append(args, v...)
+	return "trace_id=%s span_id=%s " + format, args
+}
+
+func (l *logger) CtxTracef(ctx context.Context, format string, v ...interface{}) {
+	format, v = withTrace(ctx, format, v)
+	l.FullLogger.CtxTracef(ctx, format, v...)
+}
+
+func (l *logger) CtxDebugf(ctx context.Context, format string, v ...interface{}) {
+	format, v = withTrace(ctx, format, v)
+	l.FullLogger.CtxDebugf(ctx, format, v...)
+}
+
+func (l *logger) CtxInfof(ctx context.Context, format string, v ...interface{}) {
+	format, v = withTrace(ctx, format, v)
+	l.FullLogger.CtxInfof(ctx, format, v...)
+}
+
+func (l *logger) CtxNoticef(ctx context.Context, format string, v ...interface{}) {
+	format, v = withTrace(ctx, format, v)
+	l.FullLogger.CtxNoticef(ctx, format, v...)
+}
+
+func (l *logger) CtxWarnf(ctx context.Context, format string, v ...interface{}) {
+	format, v = withTrace(ctx, format, v)
+	l.FullLogger.CtxWarnf(ctx, format, v...)
+}
+
+func (l *logger) CtxErrorf(ctx context.Context, format string, v ...interface{}) {
+	format, v = withTrace(ctx, format, v)
+	l.FullLogger.CtxErrorf(ctx, format, v...)
+}
+
+func (l *logger) CtxFatalf(ctx context.Context, format string, v ...interface{}) {
+	format, v = withTrace(ctx, format, v)
+	l.FullLogger.CtxFatalf(ctx, format, v...)
+}
@@ -0,0 +1,137 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/2451965602/LMS/config"
+	"github.com/2451965602/LMS/pkg/constants"
+)
+
+// provider 当前生效的 TracerProvider，用于服务退出时导出剩余的 span
+var provider *sdktrace.TracerProvider
+
+// Init 根据配置初始化全局 TracerProvider 和上下文传播方式
+// 未配置导出方式时仍会生成 span，追踪ID可用于日志和响应头，只是不会导出
+// 返回值：
+//   - error: 错误信息，如果创建导出器失败会返回错误
+func Init() error {
+	cfg := config.Tracing
+
+	// 1. 创建导出器
+	exporter, err := newExporter(cfg.Exporter, cfg.Endpoint, cfg.Insecure)
+	if err != nil {
+		return err
+	}
+
+	// 2. 设置服务名称
+	serviceName := cfg.ServiceName
+	if serviceName == "" {
+		serviceName = constants.TracingDefaultService
+	}
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(serviceName),
+	))
+	if err != nil {
+		return fmt.Errorf("tracing.Init: create resource error: %w", err)
+	}
+
+	// 3. 创建 TracerProvider，上游请求已采样时沿用上游的决定
+	opts := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	}
+	if exporter != nil {
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	}
+	provider = sdktrace.NewTracerProvider(opts...)
+
+	// 4. 注册为全局实现，并使用 W3C Trace Context 在服务间传播
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	// 5. 让 hlog.CtxXxx 输出的日志带上追踪ID
+	hlog.SetLogger(NewLogger(hlog.DefaultLogger()))
+	return nil
+}
+
+// newExporter 根据导出方式创建对应的导出器，none 或空值时返回 nil
+func newExporter(kind, endpoint string, insecure bool) (sdktrace.SpanExporter, error) {
+	switch kind {
+	case constants.TracingExporterNone, "":
+		return nil, nil
+	case constants.TracingExporterStdout:
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+		if err != nil {
+			return nil, fmt.Errorf("tracing.newExporter: create stdout exporter error: %w", err)
+		}
+		return exporter, nil
+	case constants.TracingExporterOTLP:
+		opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(endpoint)}
+		if insecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		exporter, err := otlptracehttp.New(context.Background(), opts...)
+		if err != nil {
+			return nil, fmt.Errorf("tracing.newExporter: create otlp exporter error: %w", err)
+		}
+		return exporter, nil
+	default:
+		return nil, fmt.Errorf("tracing.newExporter: unknown exporter %q", kind)
+	}
+}
+
+// Shutdown 导出剩余的 span 并关闭 TracerProvider
+func Shutdown(ctx context.Context) error {
+	if provider == nil {
+		return nil
+	}
+	return provider.Shutdown(ctx)
+}
+
+// Start 以 ctx 中的 span 为父节点创建新的 span
+// 参数：
+//   - ctx: 上下文
+//   - name: span 名称，服务方法使用 "XxxService.Method" 的形式
+//   - opts: span 的创建选项
+//
+// 返回值：
+//   - context.Context: 包含新 span 的上下文
+//   - trace.Span: 新创建的 span，调用方需负责调用 End
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return otel.Tracer(constants.TracerName).Start(ctx, name, opts...)
+}
+
+// RecordError 在 span 上记录错误并将状态标记为失败，err 为 nil 时不做处理
+func RecordError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+// TraceID 返回 ctx 中 span 的追踪ID，不存在时返回空字符串
+func TraceID(ctx context.Context) string {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.HasTraceID() {
+		return ""
+	}
+	return sc.TraceID().String()
+}
@@ -0,0 +1,181 @@
+package validate
+
+import (
+	"fmt"
+	"net/mail"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/nyaruka/phonenumbers"
+
+	"github.com/2451965602/LMS/pkg/errno"
+)
+
+// EmailMaxLength 邮箱地址的最大长度（RFC 5321）
+const EmailMaxLength = 254
+
+// 字段校验规则的名称，写入 errno.FieldError 的 Rule
+const (
+	RuleRequired = "required" // 必填字段未填写
+	RuleType     = "type"     // 字段值无法转换为需要的类型
+	RuleValidate = "validate" // 未通过请求结构体上的 vd 校验表达式
+	RulePattern  = "pattern"  // 不符合格式要求，参数 description 为格式说明
+	RuleEnum     = "enum"     // 不是允许的取值之一，参数 allowed 为允许的取值
+	RulePhone    = "phone"    // 不是有效的电话号码
+	RuleMobile   = "mobile"   // 不是手机号
+	RuleEmail    = "email"    // 不是有效的邮箱地址
+	RuleRange    = "range"    // 超出取值范围，参数 min、max 为允许的范围
+	RuleMin      = "min"      // 小于允许的最小值，参数 min 为最小值
+	RuleCursor   = "cursor"   // 分页游标无效，或与本次请求的排序方式不一致
+	RuleUnique   = "unique"   // 列表中有重复的值，参数 value 为重复的值
+)
+
+// patterns 已编译的用户名规则，规则来自配置，按正则表达式原文缓存
+var patterns sync.Map
+
+// Errors 收集多个字段的校验错误，全部检查完后一次返回
+type Errors []errno.FieldError
+
+// Add 记录一个字段的校验错误
+func (e *Errors) Add(field, rule string, code int64, format string, args ...interface{}) {
+	*e = append(*e, errno.FieldError{
+		Field:     field,
+		Rule:      rule,
+		ErrorCode: code,
+		ErrorMsg:  fmt.Sprintf(format, args...),
+	})
+}
+
+// AddErr 记录一个字段的校验错误
+// err 带有字段错误详情时沿用其中的规则和参数，否则以 rule 作为规则，错误码和说明取自 err。
+func (e *Errors) AddErr(field, rule string, err error) {
+	if err == nil {
+		return
+	}
+	no := errno.ConvertErr(err)
+	if len(no.Details) == 0 {
+		e.Add(field, rule, no.ErrorCode, "%s", no.ErrorMsg)
+		return
+	}
+	for _, d := range no.Details {
+		d.Field = field
+		*e = append(*e, d)
+	}
+}
+
+// Err 没有错误时返回 nil，否则返回带有全部字段错误的 errno.ErrNo
+// 只有一个字段出错时沿用该字段的错误码和说明；多个字段出错时错误码为 ParamVerifyErrorCode。
+func (e Errors) Err() error {
+	switch len(e) {
+	case 0:
+		return nil
+	case 1:
+		return errno.NewErrNo(e[0].ErrorCode, e[0].ErrorMsg).WithDetails(e...)
+	default:
+		fields := make([]string, 0, len(e))
+		for _, fe := range e {
+			fields = append(fields, fe.Field)
+		}
+		return errno.Errorf(errno.ParamVerifyErrorCode, "invalid fields: %s", strings.Join(fields, ", ")).WithDetails(e...)
+	}
+}
+
+// UsernameRule 一种读者类型的用户名规则
+type UsernameRule struct {
+	PatronType  string // 读者类型
+	Pattern     string // 用户名需匹配的正则表达式
+	Description string // 用户名格式说明
+}
+
+// Check 检查用户名是否符合规则
+// 参数：
+//   - username: 用户名
+//
+// 返回值：
+//   - error: 不符合规则时返回 errno.ServiceInvalidUsername，规则本身无法编译时返回 errno.InternalServiceErrorCode
+func (r UsernameRule) Check(username string) error {
+	re, err := compile(r.Pattern)
+	if err != nil {
+		return errno.Errorf(errno.InternalServiceErrorCode, "invalid username pattern for patron type %s: %v", r.PatronType, err)
+	}
+	if re.MatchString(username) {
+		return nil
+	}
+	description := r.Description
+	if description == "" {
+		description = "matched by " + r.Pattern
+	}
+	return NewFieldError("username", RulePattern, errno.ServiceInvalidUsername, map[string]string{"description": description},
+		"username for %s must be %s", r.PatronType, description)
+}
+
+// Phone 校验手机号并转换为 E.164 格式
+// 无法区分固定电话和手机的地区（如美国）按手机号处理。
+// 参数：
+//   - phone: 手机号，可以带 + 和国际区号，也可以是本地格式
+//   - region: 不带国际区号时按此地区解析，如 CN
+//
+// 返回值：
+//   - string: E.164 格式的手机号，如 +8613800000000
+//   - error: 无法解析或不是有效号码时返回 errno.ServiceInvalidPhone
+func Phone(phone, region string) (string, error) {
+	num, err := phonenumbers.Parse(strings.TrimSpace(phone), strings.ToUpper(region))
+	if err != nil || !phonenumbers.IsValidNumber(num) {
+		return "", NewFieldError("phone", RulePhone, errno.ServiceInvalidPhone, nil,
+			"invalid phone number, use the international format such as +8613800000000")
+	}
+	// 手机号用于接收找回密码的短信，不接受只能是固定电话的号码
+	switch phonenumbers.GetNumberType(num) {
+	case phonenumbers.MOBILE, phonenumbers.FIXED_LINE_OR_MOBILE:
+	default:
+		return "", NewFieldError("phone", RuleMobile, errno.ServiceInvalidPhone, nil, "phone number must be a mobile number")
+	}
+	return phonenumbers.Format(num, phonenumbers.E164), nil
+}
+
+// Email 校验邮箱地址
+// 只接受不带显示名称的地址，域名部分转换为小写。
+// 参数：
+//   - email: 邮箱地址
+//
+// 返回值：
+//   - string: 规范化后的邮箱地址
+//   - error: 格式不正确时返回 errno.ServiceInvalidEmail
+func Email(email string) (string, error) {
+	email = strings.TrimSpace(email)
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Address != email || len(email) > EmailMaxLength {
+		return "", NewFieldError("email", RuleEmail, errno.ServiceInvalidEmail, nil, "invalid email address")
+	}
+	at := strings.LastIndex(email, "@")
+	if !strings.Contains(email[at+1:], ".") {
+		return "", NewFieldError("email", RuleEmail, errno.ServiceInvalidEmail, nil, "invalid email address")
+	}
+	return email[:at] + strings.ToLower(email[at:]), nil
+}
+
+// NewFieldError 生成带有单个字段错误详情的错误，调用方通过 Errors.AddErr 收集时会替换为实际的字段名
+func NewFieldError(field, rule string, code int64, params map[string]string, format string, args ...interface{}) error {
+	msg := fmt.Sprintf(format, args...)
+	return errno.NewErrNo(code, msg).WithDetails(errno.FieldError{
+		Field:     field,
+		Rule:      rule,
+		ErrorCode: code,
+		ErrorMsg:  msg,
+		Params:    params,
+	})
+}
+
+// compile 编译并缓存正则表达式
+func compile(pattern string) (*regexp.Regexp, error) {
+	if re, ok := patterns.Load(pattern); ok {
+		return re.(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	patterns.Store(pattern, re)
+	return re, nil
+}
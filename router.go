@@ -3,14 +3,44 @@
 package main
 
 import (
+	"context"
+
 	"github.com/cloudwego/hertz/pkg/app/server"
+	"github.com/cloudwego/hertz/pkg/common/hlog"
 
 	"github.com/2451965602/LMS/biz/handler"
+	"github.com/2451965602/LMS/biz/router/auth"
+	"github.com/2451965602/LMS/config"
+	"github.com/2451965602/LMS/pkg/constants"
 )
 
 // customizeRegister registers customize routers.
 func customizedRegister(r *server.Hertz) {
 	r.GET("/ping", handler.Ping)
+	// 未单独配置指标的监听地址时在业务端口暴露指标，同样需要通过访问限制
+	if config.Metrics.ListenAddr == "" {
+		r.GET(constants.MetricsPath, auth.MetricsAuth(), handler.Metrics)
+	}
 
 	// your code ...
 }
+
+// startMetricsServer 在单独的地址上暴露指标，业务端口对外开放时指标仍只在内网可达
+func startMetricsServer(h *server.Hertz) {
+	addr := config.Metrics.ListenAddr
+	if addr == "" {
+		return
+	}
+	ms := server.New(server.WithHostPorts(addr))
+	ms.GET(constants.MetricsPath, auth.MetricsAuth(), handler.Metrics)
+	go func() {
+		if err := ms.Run(); err != nil {
+			hlog.Errorf("metrics server: %v", err)
+		}
+	}()
+	h.OnShutdown = append(h.OnShutdown, func(ctx context.Context) {
+		if err := ms.Shutdown(ctx); err != nil {
+			hlog.Errorf("metrics server shutdown: %v", err)
+		}
+	})
+}